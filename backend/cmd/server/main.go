@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log"
 	"os"
@@ -16,18 +17,35 @@ import (
 
 	"skillr-mvp-v1/backend/internal/ai"
 	"skillr-mvp-v1/backend/internal/config"
+	"skillr-mvp-v1/backend/internal/domain/artifact"
+	"skillr-mvp-v1/backend/internal/domain/credential"
+	"skillr-mvp-v1/backend/internal/domain/endorsement"
+	"skillr-mvp-v1/backend/internal/domain/engagement"
+	"skillr-mvp-v1/backend/internal/domain/evidence"
+	"skillr-mvp-v1/backend/internal/domain/job"
+	"skillr-mvp-v1/backend/internal/domain/journal"
 	"skillr-mvp-v1/backend/internal/domain/lernreise"
 	"skillr-mvp-v1/backend/internal/domain/portfolio"
+	"skillr-mvp-v1/backend/internal/domain/profile"
+	"skillr-mvp-v1/backend/internal/domain/qr"
+	"skillr-mvp-v1/backend/internal/domain/reflection"
 	"skillr-mvp-v1/backend/internal/domain/session"
+	"skillr-mvp-v1/backend/internal/domain/share"
+	"skillr-mvp-v1/backend/internal/domain/taxonomy"
 	"skillr-mvp-v1/backend/internal/firebase"
 	"skillr-mvp-v1/backend/internal/gateway"
 	"skillr-mvp-v1/backend/internal/honeycomb"
+	"skillr-mvp-v1/backend/internal/mail"
 	"skillr-mvp-v1/backend/internal/memory"
 	"skillr-mvp-v1/backend/internal/middleware"
 	"skillr-mvp-v1/backend/internal/postgres"
+	"skillr-mvp-v1/backend/internal/preview"
 	"skillr-mvp-v1/backend/internal/redis"
+	"skillr-mvp-v1/backend/internal/scan"
 	"skillr-mvp-v1/backend/internal/server"
+	"skillr-mvp-v1/backend/internal/signing"
 	"skillr-mvp-v1/backend/internal/solid"
+	"skillr-mvp-v1/backend/internal/storage"
 )
 
 // version is set at build time via ldflags: -X main.version=<git-sha>
@@ -75,12 +93,158 @@ func run() error {
 	portfolioSvc := portfolio.NewService(nil)
 	portfolioH := portfolio.NewHandler(portfolioSvc)
 
+	// Skill taxonomy registry: built-in until the published version is
+	// loaded (DB connected later via SetRepo)
+	taxonomySvc := taxonomy.NewService(nil)
+	taxonomySvc.SetImportDir(cfg.TaxonomyImportDir)
+
+	// Profile computation service created early with nil repo (DB connected later via SetRepo).
+	// The category table follows the taxonomy unless a file overrides it.
+	profileSvc := profile.NewService(nil)
+	portfolioSvc.SetProfiles(profileSvc)
+	tableFromFile := false
+	if cfg.ProfileCategoryTablePath != "" {
+		table, err := profile.LoadCategoryTable(cfg.ProfileCategoryTablePath)
+		if err != nil {
+			log.Printf("warning: %v (using the taxonomy category table)", err)
+		} else {
+			profileSvc.SetCategoryTable(table)
+			tableFromFile = true
+		}
+	}
+	if !tableFromFile {
+		taxonomySvc.OnChange(func(reg *taxonomy.Registry) {
+			profileSvc.SetCategoryTable(reg.CategoryTable(taxonomy.DefaultLocale))
+		})
+	}
+
+	// Reflection service created early with nil repo; scores heuristically
+	// until the AI client is available (DB connected later via SetRepo)
+	reflectionSvc := reflection.NewService(nil)
+
+	// Interaction journal; dimension lookups follow the taxonomy (DB
+	// connected later via SetRepo)
+	journalSvc := journal.NewService(nil)
+	journalSvc.SetTaxonomy(taxonomySvc)
+
+	// Evidence service created early with nil repo (DB connected later via SetRepo)
+	evidenceSvc := evidence.NewService(nil)
+	evidenceSvc.SetTaxonomy(taxonomySvc)
+
+	// Issuer key ring (Ed25519) signing evidence and Open Badges 3.0 credentials
+	var issuerKeys *signing.KeyRing
+	if cfg.CredentialSigningKey != "" {
+		issuerKeys, err = signing.ParseKeyRing(cfg.CredentialSigningKey, cfg.CredentialRetiredKeys)
+		if err != nil {
+			return fmt.Errorf("credential signing key: %w", err)
+		}
+	} else {
+		key, err := signing.GenerateKey()
+		if err != nil {
+			return err
+		}
+		issuerKeys = signing.NewKeyRing(key)
+		log.Println("warning: CREDENTIAL_SIGNING_KEY not set — using an ephemeral issuer key (development only)")
+	}
+	evidenceSvc.SetKeys(issuerKeys)
+	// Ended sessions are mined for evidence suggestions (needs the AI client)
+	sessionSvc.SetEndListener(evidenceSvc)
+	issuer, err := credential.NewIssuer(cfg.CredentialIssuerURL, cfg.CredentialIssuerName, issuerKeys)
+	if err != nil {
+		return fmt.Errorf("credential issuer: %w", err)
+	}
+	credentialSvc := credential.NewService(nil, issuer)
+
+	// Job matching service created early with nil repo (DB connected later via SetRepo)
+	jobSvc := job.NewService(nil)
+	jobSvc.SetTaxonomy(taxonomySvc)
+	jobSvc.SetFeedDir(cfg.JobFeedDir)
+
+	// Share link service created early with nil repo (DB connected later via SetRepo)
+	shareSvc := share.NewService(nil)
+
+	// Mail outbox: SMTP if configured (MailHog in docker-compose), otherwise
+	// mails are only logged. Queued mails are delivered once the DB is up.
+	renderer, err := mail.NewRenderer()
+	if err != nil {
+		return fmt.Errorf("mail templates: %w", err)
+	}
+	var mailProvider mail.Provider = mail.LogProvider{}
+	if cfg.SMTPHost != "" {
+		mailProvider = mail.NewSMTPProvider(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	}
+	outbox := mail.NewOutbox(nil, mailProvider, renderer, cfg.MailFrom)
+	authH.SetMailer(outbox, cfg.AppBaseURL)
+
+	// Endorsement and artifact services created early with nil repo (DB connected later via SetRepo)
+	endorsementSvc := endorsement.NewService(nil)
+	endorsementSvc.SetTaxonomy(taxonomySvc)
+	endorsementSvc.SetMailer(outbox, cfg.AppBaseURL)
+	endorsementSvc.SetReminderAfter(time.Duration(cfg.EndorsementReminderDays) * 24 * time.Hour)
+	artifactSvc := artifact.NewService(nil)
+	artifactSvc.SetTaxonomy(taxonomySvc)
+
+	// File storage for artifact uploads (local files or S3-compatible bucket)
+	fileStore, localFiles, err := openFileStore(cfg)
+	if err != nil {
+		return fmt.Errorf("file storage: %w", err)
+	}
+	artifactSvc.SetStore(fileStore)
+	artifactSvc.SetMailer(outbox, cfg.AppBaseURL)
+	authH.SetFileEraser(artifactSvc)
+
+	// Thumbnails and metadata of clean uploads; PDFs need pdftoppm
+	previewer := preview.NewGenerator()
+	if previewer.PDFRenderer == "" {
+		log.Println("warning: pdftoppm not found, PDF artifacts get no page preview")
+	}
+	artifactSvc.SetPreviewer(previewer)
+	portfolioSvc.SetArtifacts(artifactSvc)
+
+	// Malware scanning of uploads (clamd in docker-compose)
+	if cfg.ClamdAddr != "" {
+		scanner := scan.NewClamdScanner(cfg.ClamdAddr)
+		artifactSvc.SetScanner(scanner)
+		pingCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := scanner.Ping(pingCtx); err != nil {
+			log.Printf("warning: clamd at %s not reachable yet, uploads stay quarantined until it is: %v", cfg.ClamdAddr, err)
+		}
+		cancel()
+	} else {
+		log.Println("warning: CLAMD_ADDR not set, uploaded files stay quarantined until a scanner is configured")
+	}
+
+	// XP ledger and engagement state (DB connected later via SetRepo)
+	engagementSvc := engagement.NewService(nil)
+
+	// QR codes for invites and evidence (DB connected later via SetRepo)
+	qrSvc := qr.NewService(nil)
+	qrSvc.SetTargets(endorsementSvc, evidenceSvc)
+	qrSvc.SetLogoBaseURL(cfg.AppBaseURL)
+
 	deps := &server.Dependencies{
 		Health:           healthH,
 		ConfigH:          configH,
 		Auth:             authH,
 		Session:          sessionH,
 		PortfolioEntries: portfolioH,
+		Profile:          profile.NewHandler(profileSvc, cfg.AppBaseURL),
+		Reflection:       reflection.NewHandler(reflectionSvc),
+		Journal:          journal.NewHandler(journalSvc),
+		Evidence:         evidence.NewHandler(evidenceSvc),
+		Credential:       credential.NewHandler(credentialSvc),
+		Taxonomy:         taxonomy.NewHandler(taxonomySvc),
+		Job:              job.NewHandler(jobSvc),
+		Share:            share.NewHandler(shareSvc),
+		Endorsement:      endorsement.NewHandler(endorsementSvc),
+		QR:               qr.NewHandler(qrSvc, cfg.AppBaseURL),
+		Artifact:         artifact.NewHandler(artifactSvc),
+		Engagement:       engagement.NewHandler(engagementSvc),
+		Mail:             mail.NewHandler(outbox),
+	}
+
+	if localFiles != nil {
+		deps.Files = storage.NewHandler(localFiles)
 	}
 
 	// Initialize AI handler if GCP project is configured
@@ -91,6 +255,8 @@ func run() error {
 		} else {
 			orch := ai.NewPassthroughOrchestrator()
 			deps.AI = ai.NewHandler(aiClient, orch)
+			reflectionSvc.SetScorer(reflection.NewAIScorer(aiClient, orch))
+			evidenceSvc.SetExtractor(evidence.NewAIExtractor(aiClient, orch))
 			healthH.SetAI(true)
 			log.Printf("AI service initialized (project=%s, region=%s, ttsRegion=%s)", cfg.GCPProject, cfg.GCPRegion, cfg.GCPTTSRegion)
 			// Close AI client on shutdown
@@ -108,6 +274,7 @@ func run() error {
 		// Lernreise service uses a nil repo until DB is connected (same pattern as auth)
 		lrSvc := lernreise.NewService(nil, hcClient, memClient)
 		deps.Lernreise = lernreise.NewHandler(lrSvc)
+		jobSvc.SetCourses(hcClient)
 		healthH.SetHoneycomb(true)
 		healthH.SetMemoryService(true)
 		log.Printf("Honeycomb integration initialized (url=%s)", cfg.HoneycombURL)
@@ -153,6 +320,9 @@ func run() error {
 	rl := redis.NewRateLimiter(nil)
 	deps.AIRateLimit = middleware.RateLimit(rl, "ai", 30, time.Minute)
 	deps.EndorsementRateLimit = middleware.RateLimit(rl, "endorsement", 10, time.Minute)
+	deps.ShareRateLimit = middleware.RateLimit(rl, "share", 30, time.Minute)
+	deps.QRRateLimit = middleware.RateLimit(rl, "qr", 30, time.Minute)
+	deps.PasswordResetRateLimit = middleware.RateLimit(rl, "password-reset", 5, 15*time.Minute)
 	log.Println("rate limiters initialized (in-memory fallback)")
 
 	// Initialize gateway handlers (created early with nil DB, SetDB called after pool connects)
@@ -240,6 +410,46 @@ func run() error {
 		portfolioSvc.SetRepo(postgres.NewPortfolioRepository(pool))
 		portfolioSvc.SetDB(pool)
 
+		// Inject DB into taxonomy service and load the published registry
+		taxonomySvc.SetRepo(postgres.NewTaxonomyRepository(pool))
+		if reg, err := taxonomySvc.Load(ctx); err != nil {
+			log.Printf("warning: %v (using built-in taxonomy)", err)
+		} else {
+			log.Printf("skill taxonomy version %d loaded (%d dimensions)", reg.Version, len(reg.Dimensions))
+		}
+
+		// Inject DB into profile service (created earlier with nil repo)
+		profileSvc.SetRepo(postgres.NewProfileRepository(pool))
+		journalSvc.SetRepo(postgres.NewJournalRepository(pool))
+
+		// Inject DB into evidence service (created earlier with nil repo) and
+		// keep signing entries that are unsigned or signed with a retired key.
+		// Never with an ephemeral key: those signatures die with the process.
+		evidenceSvc.SetRepo(postgres.NewEvidenceRepository(pool))
+		if cfg.CredentialSigningKey != "" {
+			go evidenceSvc.Run(ctx, time.Hour)
+		} else {
+			log.Println("warning: evidence re-signing disabled without CREDENTIAL_SIGNING_KEY")
+		}
+		credentialSvc.SetRepo(postgres.NewCredentialRepository(pool))
+		jobSvc.SetRepo(postgres.NewJobRepository(pool))
+		shareSvc.SetRepo(postgres.NewShareRepository(pool))
+		endorsementSvc.SetRepo(postgres.NewEndorsementRepository(pool))
+		go endorsementSvc.Run(ctx, time.Hour)
+		artifactSvc.SetRepo(postgres.NewArtifactRepository(pool))
+		go artifactSvc.Run(ctx, 5*time.Minute)
+		qrSvc.SetRepo(postgres.NewQRRepository(pool))
+		engagementSvc.SetRepo(postgres.NewEngagementRepository(pool))
+		go qrSvc.Run(ctx, time.Hour)
+
+		// Inject DB into the mail outbox and start delivering queued mail
+		outbox.SetRepo(postgres.NewMailRepository(pool))
+		go outbox.Run(ctx, 30*time.Second)
+
+		// Inject DB into reflection service and keep scoring pending reflections
+		reflectionSvc.SetRepo(postgres.NewReflectionRepository(pool))
+		go reflectionSvc.Run(ctx, time.Minute)
+
 		// Inject DB into gateway handlers (created earlier with nil DB)
 		gwAnalytics.SetDB(pool)
 		gwLegal.SetDB(pool)
@@ -294,3 +504,34 @@ func runMigrations(cfg *config.Config) error {
 	}
 	return nil
 }
+
+// openFileStore creates the configured file storage. The local store is
+// also returned so its downloads can be served.
+func openFileStore(cfg *config.Config) (storage.Store, *storage.LocalStore, error) {
+	switch cfg.StorageBackend {
+	case "s3":
+		store, err := storage.NewS3Store(cfg.StorageS3Endpoint, cfg.StorageS3Region, cfg.StorageS3Bucket,
+			cfg.StorageS3AccessKey, cfg.StorageS3SecretKey, cfg.StorageS3PathStyle)
+		if err != nil {
+			return nil, nil, err
+		}
+		log.Printf("file storage: bucket %s at %s", cfg.StorageS3Bucket, cfg.StorageS3Endpoint)
+		return store, nil, nil
+	case "local":
+		secret := []byte(cfg.StorageSigningSecret)
+		if len(secret) == 0 {
+			secret = make([]byte, 32)
+			if _, err := rand.Read(secret); err != nil {
+				return nil, nil, err
+			}
+			log.Println("warning: STORAGE_SIGNING_SECRET not set — download links will not survive a restart")
+		}
+		store, err := storage.NewLocalStore(cfg.StorageLocalDir, cfg.StoragePublicURL, secret)
+		if err != nil {
+			return nil, nil, err
+		}
+		log.Printf("file storage: local directory %s", cfg.StorageLocalDir)
+		return store, store, nil
+	}
+	return nil, nil, fmt.Errorf("unknown STORAGE_BACKEND %q (local or s3)", cfg.StorageBackend)
+}
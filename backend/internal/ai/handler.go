@@ -121,12 +121,20 @@ Regeln:
 
 	"station-result": `Du bist ein Bewertungs-Tool. Analysiere das Gespraech und vergib Scores fuer die gezeigten Dimensionen.
 Antworte NUR mit validem JSON in diesem Format:
-{"dimensionScores": {"dimension_name": 80, ...}, "summary": "Kurze Zusammenfassung"}
+{"dimensionScores": {"dimension_name": 80, ...}, "confidence": {"dimension_name": 0.7, ...}, "summary": "Kurze Zusammenfassung"}
 Regeln:
 - dimensionScores: Scores von 0-100 pro erkannter Dimension
+- confidence: 0.0-1.0 pro Dimension, wie gut der Score durch das Gespraech belegt ist
 - summary: 1-2 Saetze auf Deutsch`,
 }
 
+// ExtractPrompt returns the built-in system instruction for an extract type,
+// for server-side extraction outside the HTTP handler.
+func ExtractPrompt(extractType string) (string, bool) {
+	p, ok := builtinExtractPrompts[extractType]
+	return p, ok
+}
+
 var builtinGeneratePrompts = map[string]string{
 	"curriculum": `Du bist ein Curriculum-Generator fuer die VUCA-Reise. Erstelle strukturierte Lehrplaene mit 12 Modulen (3 pro VUCA-Dimension).
 Antworte NUR mit validem JSON in diesem Format:
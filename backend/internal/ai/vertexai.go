@@ -3,6 +3,7 @@ package ai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -307,6 +308,16 @@ func (c *VertexAIClient) Chat(ctx context.Context, req ChatRequest) (*ChatRespon
 	}, nil
 }
 
+// IsTransient reports whether a Generate error is worth retrying: a
+// transport failure or timeout, rate limiting, or a server error.
+func IsTransient(err error) bool {
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusTooManyRequests || apiErr.Code >= http.StatusInternalServerError
+	}
+	return err != nil
+}
+
 func (c *VertexAIClient) Generate(ctx context.Context, req ChatRequest) (*ChatResponse, error) {
 	start := time.Now()
 	modelName := req.Model
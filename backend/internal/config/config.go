@@ -27,6 +27,15 @@ func (c *Config) LogStatus() {
 	log.Printf("  Admin Email:    %s", c.AdminSeedEmail)
 	log.Printf("  Admin Password: %s", c.AdminSeedPassword)
 	log.Printf("  LFS Proxy:      %s (enabled=%v)", configured(c.LFSProxyURL), c.LFSProxyEnabled)
+	log.Printf("  Category Table: %s", configured(c.ProfileCategoryTablePath))
+	log.Printf("  Taxonomy Dir:   %s", configured(c.TaxonomyImportDir))
+	log.Printf("  Job Feed Dir:   %s", configured(c.JobFeedDir))
+	log.Printf("  VC Issuer:      %s (signing key %s)", c.CredentialIssuerURL, configured(c.CredentialSigningKey))
+	log.Printf("  SMTP:           %s (from %s)", configured(c.SMTPHost), c.MailFrom)
+	log.Printf("  App Base URL:   %s", c.AppBaseURL)
+	log.Printf("  Reminders:      %d days", c.EndorsementReminderDays)
+	log.Printf("  File Storage:   %s", c.StorageBackend)
+	log.Printf("  Virus Scanner:  %s", configured(c.ClamdAddr))
 	log.Println("============================")
 }
 
@@ -84,6 +93,52 @@ type Config struct {
 	// LFS Proxy integration (FR-131)
 	LFSProxyURL     string
 	LFSProxyEnabled bool
+	// Profile computation: optional JSON file overriding the dimension-to-category table
+	ProfileCategoryTablePath string
+	// Skill taxonomy: directory ESCO CSV dumps are imported from (admin API)
+	TaxonomyImportDir string
+	// Job postings: directory partner feeds are imported from (admin API)
+	JobFeedDir string
+	// Verifiable Credentials: public base URL (defines the did:web issuer),
+	// display name and base64 Ed25519 seed of the issuer key. Retired keys
+	// (comma-separated seeds) stay published for verification after rotation.
+	CredentialIssuerURL   string
+	CredentialIssuerName  string
+	CredentialSigningKey  string
+	CredentialRetiredKeys string
+	// Mail: SMTP relay for the outbox (without SMTP_HOST mails are only
+	// logged), sender address, and the public frontend URL mail links point to
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string
+	AppBaseURL   string
+	// EndorsementReminderDays: days without an answer before an invite is
+	// reminded (0 disables reminders)
+	EndorsementReminderDays int
+	// File storage for artifact uploads: "local" (files below StorageLocalDir,
+	// downloads served by this server at StoragePublicURL) or "s3" (any
+	// S3-compatible service, e.g. GCS at https://storage.googleapis.com with
+	// HMAC keys). StorageSigningSecret signs local download URLs; without it
+	// an ephemeral one is generated and URLs do not survive a restart.
+	StorageBackend       string
+	StorageLocalDir      string
+	StoragePublicURL     string
+	StorageSigningSecret string
+	StorageS3Endpoint    string
+	StorageS3Region      string
+	StorageS3Bucket      string
+	StorageS3AccessKey   string
+	StorageS3SecretKey   string
+	StorageS3PathStyle   bool
+
+	// ClamdAddr is the host:port of the ClamAV daemon that scans uploaded
+	// files. Without it uploads are not scanned.
+	ClamdAddr string
+	// Production is set on Cloud Run (K_SERVICE or CLOUD_RUN). Settings
+	// with a development fallback are required there.
+	Production bool
 }
 
 func Load() (*Config, error) {
@@ -129,10 +184,53 @@ func Load() (*Config, error) {
 		// LFS Proxy (FR-131) — defaults to localhost:8080 in dev mode
 		LFSProxyURL:     getEnv("LFS_PROXY_URL", "http://localhost:8080"),
 		LFSProxyEnabled: getEnvBool("LFS_PROXY_ENABLED", true),
+		// Profile computation — empty uses the built-in category table
+		ProfileCategoryTablePath: os.Getenv("PROFILE_CATEGORY_TABLE"),
+		// Skill taxonomy — empty disables the ESCO import endpoint
+		TaxonomyImportDir: os.Getenv("TAXONOMY_IMPORT_DIR"),
+		JobFeedDir:        os.Getenv("JOB_FEED_DIR"),
+		// Verifiable Credentials — the key is required in production; in
+		// development an ephemeral one is generated
+		CredentialIssuerURL:   getEnv("CREDENTIAL_ISSUER_URL", "http://localhost:8080"),
+		CredentialIssuerName:  getEnv("CREDENTIAL_ISSUER_NAME", "maindset.ACADEMY"),
+		CredentialSigningKey:  os.Getenv("CREDENTIAL_SIGNING_KEY"),
+		CredentialRetiredKeys: os.Getenv("CREDENTIAL_RETIRED_KEYS"),
+		// Mail — MailHog in docker-compose listens on port 1025
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     getEnv("SMTP_PORT", "587"),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		MailFrom:     getEnv("MAIL_FROM", "SkillR <noreply@skillr.local>"),
+		AppBaseURL:   getEnv("APP_BASE_URL", "http://localhost:3000"),
+		// Endorsement invites — reminder after a week without an answer
+		EndorsementReminderDays: getEnvInt("ENDORSEMENT_REMINDER_DAYS", 7),
+		// File storage — local files by default
+		StorageBackend:       getEnv("STORAGE_BACKEND", "local"),
+		StorageLocalDir:      getEnv("STORAGE_LOCAL_DIR", "data/files"),
+		StoragePublicURL:     getEnv("STORAGE_PUBLIC_URL", "http://localhost:8080"),
+		StorageSigningSecret: os.Getenv("STORAGE_SIGNING_SECRET"),
+		StorageS3Endpoint:    getEnv("STORAGE_S3_ENDPOINT", "https://storage.googleapis.com"),
+		StorageS3Region:      getEnv("STORAGE_S3_REGION", "auto"),
+		StorageS3Bucket:      os.Getenv("STORAGE_S3_BUCKET"),
+		StorageS3AccessKey:   os.Getenv("STORAGE_S3_ACCESS_KEY"),
+		StorageS3SecretKey:   os.Getenv("STORAGE_S3_SECRET_KEY"),
+		StorageS3PathStyle:   getEnvBool("STORAGE_S3_PATH_STYLE", false),
+		ClamdAddr:            os.Getenv("CLAMD_ADDR"),
+		Production:           os.Getenv("K_SERVICE") != "" || os.Getenv("CLOUD_RUN") != "",
+	}
+	// Credentials signed with an ephemeral key stop verifying after a
+	// restart, and did:web would publish a new key on every boot.
+	if cfg.Production && cfg.CredentialSigningKey == "" {
+		return nil, fmt.Errorf("CREDENTIAL_SIGNING_KEY is required in production")
+	}
+	// The container disk is not shared between instances and is lost on
+	// restart, so uploads must go to a bucket.
+	if cfg.Production && cfg.StorageBackend != "s3" {
+		return nil, fmt.Errorf("STORAGE_BACKEND=s3 is required in production")
 	}
 	// M12: Warn about ALLOWED_ORIGINS in production
 	if os.Getenv("ALLOWED_ORIGINS") == "" {
-		if os.Getenv("K_SERVICE") != "" || os.Getenv("CLOUD_RUN") != "" {
+		if cfg.Production {
 			log.Println("WARNING: ALLOWED_ORIGINS not set on Cloud Run — using localhost defaults. Set ALLOWED_ORIGINS for cross-origin requests.")
 		} else {
 			log.Println("WARNING: ALLOWED_ORIGINS not set — using localhost defaults. Set ALLOWED_ORIGINS for production.")
@@ -159,6 +257,16 @@ func getEnvBool(key string, fallback bool) bool {
 	return fallback
 }
 
+func getEnvInt(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		n, err := strconv.Atoi(v)
+		if err == nil && n >= 0 {
+			return n
+		}
+	}
+	return fallback
+}
+
 func parseOrigins(s string) []string {
 	var origins []string
 	for _, o := range splitAndTrim(s, ",") {
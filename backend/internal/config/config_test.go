@@ -12,6 +12,29 @@ func TestLoad_RequiresDatabaseURL(t *testing.T) {
 	}
 }
 
+func TestLoad_ProductionRequiresSigningKey(t *testing.T) {
+	t.Setenv("DATABASE_URL", "postgres://localhost/test")
+	t.Setenv("K_SERVICE", "skillr-backend")
+	t.Setenv("CREDENTIAL_SIGNING_KEY", "")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error when CREDENTIAL_SIGNING_KEY is not set in production")
+	}
+
+	t.Setenv("CREDENTIAL_SIGNING_KEY", "test-key")
+	if _, err := Load(); err == nil {
+		t.Fatal("expected error for local file storage in production")
+	}
+
+	t.Setenv("STORAGE_BACKEND", "s3")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.Production {
+		t.Error("expected production on Cloud Run")
+	}
+}
+
 func TestLoad_Defaults(t *testing.T) {
 	t.Setenv("DATABASE_URL", "postgres://localhost/test")
 
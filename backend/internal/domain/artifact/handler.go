@@ -2,8 +2,12 @@ package artifact
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -38,6 +42,8 @@ func (h *Handler) List(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]interface{}{"artifacts": artifacts, "total": total})
 }
 
+// Upload adds an artifact from a form. Multipart uploads are streamed: the
+// "file" part must come after the other fields.
 func (h *Handler) Upload(c echo.Context) error {
 	userInfo := middleware.GetUserInfo(c)
 	if userInfo == nil {
@@ -45,27 +51,119 @@ func (h *Handler) Upload(c echo.Context) error {
 	}
 	userID := deriveUUID(userInfo.UID)
 
-	artifactType := c.FormValue("artifact_type")
-	description := c.FormValue("description")
-	url := c.FormValue("url")
+	var (
+		artifact *ExternalArtifact
+		err      error
+	)
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		artifact, err = h.uploadMultipart(c, userID)
+	} else {
+		artifact, err = h.svc.Upload(c.Request().Context(), userID, uploadRequest(c.FormValue), nil)
+	}
+	switch {
+	case errors.Is(err, ErrFileTooLarge):
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, ErrUnsupportedFile):
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, err.Error())
+	case err != nil:
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusCreated, artifact)
+}
 
-	var storageRef *string
-	if url != "" {
-		storageRef = &url
+// uploadMultipart reads the form fields up to the "file" part and streams
+// that part to the service.
+func (h *Handler) uploadMultipart(c echo.Context, userID uuid.UUID) (*ExternalArtifact, error) {
+	mr, err := c.Request().MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("invalid multipart body")
+	}
+	fields := map[string]string{}
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return h.svc.Upload(c.Request().Context(), userID, uploadRequest(mapValue(fields)), nil)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid multipart body")
+		}
+		if part.FormName() == "file" {
+			file := &File{Name: part.FileName(), Body: part}
+			return h.svc.Upload(c.Request().Context(), userID, uploadRequest(mapValue(fields)), file)
+		}
+		value, err := io.ReadAll(io.LimitReader(part, maxFieldSize+1))
+		if err != nil || len(value) > maxFieldSize {
+			return nil, fmt.Errorf("form field %s is too large", part.FormName())
+		}
+		fields[part.FormName()] = string(value)
 	}
+}
+
+// maxFieldSize bounds a single form field of an upload.
+const maxFieldSize = 64 << 10
 
-	var skillDimensions map[string]float64
-	if sd := c.FormValue("skill_dimensions"); sd != "" {
-		_ = json.Unmarshal([]byte(sd), &skillDimensions)
+func uploadRequest(value func(string) string) UploadRequest {
+	req := UploadRequest{
+		ArtifactType: value("artifact_type"),
+		Description:  value("description"),
+		URL:          value("url"),
 	}
+	if sd := value("skill_dimensions"); sd != "" {
+		_ = json.Unmarshal([]byte(sd), &req.SkillDimensions)
+	}
+	return req
+}
+
+func mapValue(m map[string]string) func(string) string {
+	return func(k string) string { return m[k] }
+}
 
-	// TODO: Handle file upload to Firebase Storage in Phase 5
+// Download returns a signed, time-limited URL for the artifact's file.
+func (h *Handler) Download(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	userID := deriveUUID(userInfo.UID)
 
-	artifact, err := h.svc.Upload(c.Request().Context(), userID, artifactType, description, skillDimensions, storageRef)
+	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid artifact ID")
 	}
-	return c.JSON(http.StatusCreated, artifact)
+
+	download, err := h.svc.Download(c.Request().Context(), id, userID)
+	if errors.Is(err, ErrNoFile) || errors.Is(err, ErrQuarantined) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "artifact not found")
+	}
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, download)
+}
+
+// Preview returns a signed, time-limited URL for the artifact's thumbnail.
+func (h *Handler) Preview(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	userID := deriveUUID(userInfo.UID)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid artifact ID")
+	}
+
+	preview, err := h.svc.Preview(c.Request().Context(), id, userID)
+	if errors.Is(err, ErrNoPreview) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "artifact not found")
+	}
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, preview)
 }
 
 func (h *Handler) Get(c echo.Context) error {
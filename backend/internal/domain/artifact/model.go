@@ -1,6 +1,7 @@
 package artifact
 
 import (
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,9 +13,63 @@ type ExternalArtifact struct {
 	ArtifactType    string             `json:"artifact_type"`
 	Description     string             `json:"description"`
 	SkillDimensions map[string]float64 `json:"skill_dimensions,omitempty"`
-	StorageRef      *string            `json:"storage_ref,omitempty"`
-	EndorsementIDs  []uuid.UUID        `json:"endorsement_ids,omitempty"`
-	UploadedAt      time.Time          `json:"uploaded_at"`
+	// StorageRef is the external URL of link artifacts.
+	StorageRef     *string     `json:"storage_ref,omitempty"`
+	EndorsementIDs []uuid.UUID `json:"endorsement_ids,omitempty"`
+	// File metadata of uploaded files. ContentType is sniffed from the
+	// content, not taken from the client. The object key stays internal;
+	// downloads go through signed URLs.
+	FileName    string `json:"file_name,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	SizeBytes   int64  `json:"size_bytes,omitempty"`
+	Checksum    string `json:"checksum_sha256,omitempty"`
+	ObjectKey   string `json:"-"`
+	// ScanStatus is the malware scan state of the file (empty for links).
+	// Only clean files can be downloaded; ScanSignature names
+	// what was found in infected files.
+	ScanStatus    string     `json:"scan_status,omitempty"`
+	ScanSignature string     `json:"scan_signature,omitempty"`
+	ScannedAt     *time.Time `json:"scanned_at,omitempty"`
+	// Metadata read by the preview worker once the file is clean.
+	Width           int     `json:"width,omitempty"`
+	Height          int     `json:"height,omitempty"`
+	PageCount       int     `json:"page_count,omitempty"`
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+	// PreviewStatus is pending until the worker has looked at the file;
+	// HasPreview says whether it made a thumbnail (images and PDFs).
+	PreviewStatus string    `json:"preview_status,omitempty"`
+	HasPreview    bool      `json:"has_preview,omitempty"`
+	PreviewKey    string    `json:"-"`
+	UploadedAt    time.Time `json:"uploaded_at"`
+}
+
+// Artifact types.
+const (
+	TypePhoto    = "photo"
+	TypeDocument = "document"
+	TypeVideo    = "video"
+	TypeLink     = "link"
+)
+
+// Scan states of uploaded files.
+const (
+	ScanPending   = "pending"   // quarantined until the scanner has seen it
+	ScanClean     = "clean"     // no malware found
+	ScanInfected  = "infected"  // malware found, file removed from storage
+	ScanFailed    = "failed"    // the scanner could not check the file
+	ScanUnscanned = "unscanned" // no scanner configured at upload time
+)
+
+// Preview states of uploaded files.
+const (
+	PreviewPending = "pending"
+	PreviewDone    = "done"
+	PreviewFailed  = "failed"
+)
+
+// Downloadable reports whether the file may be handed out.
+func (a *ExternalArtifact) Downloadable() bool {
+	return a.ObjectKey != "" && a.ScanStatus == ScanClean
 }
 
 type ExternalArtifactDetailed struct {
@@ -22,6 +77,33 @@ type ExternalArtifactDetailed struct {
 	Endorsements []interface{} `json:"endorsements,omitempty"`
 }
 
+// UploadRequest holds the form fields of an upload.
+type UploadRequest struct {
+	ArtifactType    string
+	Description     string
+	URL             string
+	SkillDimensions map[string]float64
+}
+
+// File is an uploaded file as it is streamed from the request.
+type File struct {
+	Name string
+	Body io.Reader
+}
+
+// ExportItem is an artifact for the portfolio export with its thumbnail
+// (JPEG, nil without preview).
+type ExportItem struct {
+	ExternalArtifact
+	Thumbnail []byte
+}
+
+// Download is a signed, time-limited URL for an artifact's file.
+type Download struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 type LinkEndorsementRequest struct {
 	EndorsementID uuid.UUID `json:"endorsement_id"`
 }
@@ -0,0 +1,205 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/preview"
+	"skillr-mvp-v1/backend/internal/storage"
+)
+
+// ErrNoPreview is returned when an artifact has no thumbnail.
+var ErrNoPreview = errors.New("artifact has no preview")
+
+// previewTimeout bounds making one preview, including the download of the
+// file from storage.
+const previewTimeout = 5 * time.Minute
+
+// maxExportArtifacts bounds the artifacts in a portfolio export.
+const maxExportArtifacts = 100
+
+// Previewer extracts metadata and makes a thumbnail from a file on disk
+// (satisfied by *preview.Generator).
+type Previewer interface {
+	Generate(ctx context.Context, path, contentType string) (*preview.Result, error)
+}
+
+// SetPreviewer makes thumbnails and reads metadata of uploaded files once
+// they are clean.
+func (s *Service) SetPreviewer(p Previewer) {
+	s.preview = p
+}
+
+// PreviewPending schedules previews for up to limit clean files that have
+// none yet.
+func (s *Service) PreviewPending(ctx context.Context, limit int) (int, error) {
+	if s.repo == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+	if s.preview == nil || s.store == nil {
+		return 0, nil
+	}
+	queued, err := s.repo.ListPreviewQueue(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("list preview queue: %w", err)
+	}
+	n := 0
+	for _, a := range queued {
+		if s.previewAsync(a) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *Service) previewAsync(a ExternalArtifact) bool {
+	return s.goAsync(a, previewTimeout, s.makePreview)
+}
+
+// makePreview reads a's metadata and stores its thumbnail next to the
+// file as <object key>.preview.jpg. Storage errors leave the preview
+// pending for the next run; files the previewer cannot read are marked
+// failed.
+func (s *Service) makePreview(ctx context.Context, a *ExternalArtifact) {
+	if s.preview == nil {
+		return
+	}
+	file, err := s.fetch(ctx, a.ObjectKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		s.setPreview(ctx, a, PreviewFailed)
+		return
+	}
+	if err != nil {
+		log.Printf("[artifact] fetch %s for preview: %v", a.ID, err)
+		return
+	}
+	defer os.Remove(file)
+
+	res, err := s.preview.Generate(ctx, file, a.ContentType)
+	if err != nil {
+		log.Printf("[artifact] preview %s: %v", a.ID, err)
+		s.setPreview(ctx, a, PreviewFailed)
+		return
+	}
+	if res.Thumbnail != nil {
+		key := a.ObjectKey + ".preview.jpg"
+		if err := s.store.Put(ctx, key, bytes.NewReader(res.Thumbnail), int64(len(res.Thumbnail)), "image/jpeg"); err != nil {
+			log.Printf("[artifact] store preview of %s: %v", a.ID, err)
+			return
+		}
+		a.PreviewKey = key
+	}
+	a.Width, a.Height, a.PageCount = res.Width, res.Height, res.Pages
+	a.DurationSeconds = res.Duration.Seconds()
+	s.setPreview(ctx, a, PreviewDone)
+}
+
+func (s *Service) setPreview(ctx context.Context, a *ExternalArtifact, status string) {
+	a.PreviewStatus = status
+	if err := s.repo.SetPreview(ctx, a); err != nil {
+		log.Printf("[artifact] store preview of %s: %v", a.ID, err)
+	}
+}
+
+// fetch copies a stored object to a temporary file and returns its path.
+func (s *Service) fetch(ctx context.Context, key string) (string, error) {
+	rc, err := s.store.Open(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	f, err := os.CreateTemp(s.spoolDir, "preview-*")
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(f, rc)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// Preview returns a signed URL for the artifact's thumbnail.
+func (s *Service) Preview(ctx context.Context, id, learnerID uuid.UUID) (*Download, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	if s.store == nil {
+		return nil, fmt.Errorf("file storage not available")
+	}
+	a, err := s.repo.GetByID(ctx, id, learnerID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.Downloadable() || a.PreviewKey == "" {
+		return nil, ErrNoPreview
+	}
+	name := strings.TrimSuffix(a.FileName, path.Ext(a.FileName)) + "-preview.jpg"
+	expires := time.Now().UTC().Add(DownloadTTL)
+	url, err := s.store.SignedURL(ctx, a.PreviewKey, name, DownloadTTL)
+	if err != nil {
+		return nil, fmt.Errorf("sign preview: %w", err)
+	}
+	return &Download{URL: url, ExpiresAt: expires}, nil
+}
+
+// ExportItems returns the learner's artifacts for the portfolio export,
+// newest first, with the thumbnails of files that passed the scan.
+// Rejected files are left out.
+func (s *Service) ExportItems(ctx context.Context, learnerID uuid.UUID) ([]ExportItem, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	artifacts, _, err := s.repo.List(ctx, learnerID, nil, maxExportArtifacts, 0)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]ExportItem, 0, len(artifacts))
+	for _, a := range artifacts {
+		if a.ScanStatus == ScanInfected {
+			continue
+		}
+		item := ExportItem{ExternalArtifact: a}
+		if a.Downloadable() && a.PreviewKey != "" && s.store != nil {
+			item.Thumbnail, err = s.readPreview(ctx, a.PreviewKey)
+			if err != nil {
+				log.Printf("[artifact] read preview of %s: %v", a.ID, err)
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// maxPreviewSize bounds a thumbnail read into memory for the export.
+const maxPreviewSize = 1 << 20
+
+func (s *Service) readPreview(ctx context.Context, key string) ([]byte, error) {
+	rc, err := s.store.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxPreviewSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxPreviewSize {
+		return nil, fmt.Errorf("preview exceeds %d bytes", maxPreviewSize)
+	}
+	return data, nil
+}
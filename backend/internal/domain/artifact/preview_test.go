@@ -0,0 +1,122 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/preview"
+	"skillr-mvp-v1/backend/internal/storage"
+)
+
+func pngImage(t *testing.T, w, h int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUpload_MakesPreviewNextToFile(t *testing.T) {
+	svc, repo, store := newTestService(t)
+	svc.SetScanner(&fakeScanner{})
+	svc.SetPreviewer(&preview.Generator{})
+	ctx := context.Background()
+	learner := uuid.New()
+
+	a, err := svc.Upload(ctx, learner, UploadRequest{ArtifactType: TypePhoto, Description: "Werkstueck"},
+		&File{Name: "werkstueck.png", Body: bytes.NewReader(pngImage(t, 1600, 1200))})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if a.PreviewStatus != PreviewPending {
+		t.Errorf("preview status after upload = %q", a.PreviewStatus)
+	}
+	svc.Wait()
+
+	got := repo.get(a.ID)
+	if got.PreviewStatus != PreviewDone || got.PreviewKey != a.ObjectKey+".preview.jpg" || got.Width != 1600 || got.Height != 1200 {
+		t.Fatalf("preview = %q %q %dx%d", got.PreviewStatus, got.PreviewKey, got.Width, got.Height)
+	}
+	rc, err := store.Open(ctx, got.PreviewKey)
+	if err != nil {
+		t.Fatalf("stored preview: %v", err)
+	}
+	cfg, err := jpeg.DecodeConfig(rc)
+	rc.Close()
+	if err != nil || cfg.Width != preview.ThumbnailSize || cfg.Height != 360 {
+		t.Errorf("thumbnail = %+v, %v", cfg, err)
+	}
+
+	d, err := svc.Preview(ctx, a.ID, learner)
+	if err != nil || !strings.Contains(d.URL, "werkstueck-preview.jpg") {
+		t.Errorf("Preview = %+v, %v", d, err)
+	}
+	items, err := svc.ExportItems(ctx, learner)
+	if err != nil || len(items) != 1 || len(items[0].Thumbnail) == 0 {
+		t.Errorf("ExportItems = %d items, %v", len(items), err)
+	}
+
+	if err := svc.Delete(ctx, a.ID, learner); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Open(ctx, got.PreviewKey); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("preview after delete: %v", err)
+	}
+}
+
+func TestPreview_OnlyAfterCleanScan(t *testing.T) {
+	svc, repo, _ := newTestService(t)
+	svc.SetPreviewer(&preview.Generator{})
+	scanner := &fakeScanner{err: errors.New("clamd down")}
+	svc.SetScanner(scanner)
+	ctx := context.Background()
+	learner := uuid.New()
+
+	a, err := svc.Upload(ctx, learner, UploadRequest{ArtifactType: TypePhoto, Description: "Foto"},
+		&File{Name: "foto.png", Body: bytes.NewReader(pngImage(t, 100, 100))})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	svc.Wait()
+	if n, _ := svc.PreviewPending(ctx, 10); n != 0 {
+		t.Errorf("%d previews scheduled for a quarantined file", n)
+	}
+	if _, err := svc.Preview(ctx, a.ID, learner); !errors.Is(err, ErrNoPreview) {
+		t.Errorf("Preview while quarantined = %v", err)
+	}
+
+	scanner.mu.Lock()
+	scanner.err = nil
+	scanner.mu.Unlock()
+	if _, err := svc.ScanPending(ctx, 10); err != nil {
+		t.Fatal(err)
+	}
+	svc.Wait()
+	if got := repo.get(a.ID); got.ScanStatus != ScanClean || got.PreviewStatus != PreviewDone || !got.HasPreview {
+		t.Errorf("after clean scan: scan %q, preview %q", got.ScanStatus, got.PreviewStatus)
+	}
+}
+
+func TestExportItems_LeavesOutRejectedFiles(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	svc.SetScanner(&fakeScanner{})
+	ctx := context.Background()
+	learner := uuid.New()
+
+	uploadText(t, svc, learner, "ein virus")
+	uploadText(t, svc, learner, "sauber")
+	svc.Wait()
+	items, err := svc.ExportItems(ctx, learner)
+	if err != nil || len(items) != 1 || items[0].ScanStatus != ScanClean || items[0].Thumbnail != nil {
+		t.Errorf("ExportItems = %+v, %v", items, err)
+	}
+}
@@ -2,6 +2,7 @@ package artifact
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,4 +14,14 @@ type Repository interface {
 	List(ctx context.Context, learnerID uuid.UUID, artifactType *string, limit, offset int) ([]ExternalArtifact, int, error)
 	Delete(ctx context.Context, id uuid.UUID, learnerID uuid.UUID) error
 	LinkEndorsement(ctx context.Context, id uuid.UUID, learnerID uuid.UUID, endorsementID uuid.UUID) (*ExternalArtifactDetailed, error)
+	// ListScanQueue returns up to limit files that are pending or unscanned,
+	// oldest first.
+	ListScanQueue(ctx context.Context, limit int) ([]ExternalArtifact, error)
+	SetScanResult(ctx context.Context, id uuid.UUID, status, signature string, at time.Time) error
+	LearnerEmail(ctx context.Context, learnerID uuid.UUID) (string, error)
+	// ListPreviewQueue returns up to limit files scanned clean whose preview
+	// is pending, oldest first.
+	ListPreviewQueue(ctx context.Context, limit int) ([]ExternalArtifact, error)
+	// SetPreview stores a's preview status, preview key and metadata.
+	SetPreview(ctx context.Context, a *ExternalArtifact) error
 }
@@ -0,0 +1,194 @@
+package artifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/mail"
+	"skillr-mvp-v1/backend/internal/scan"
+	"skillr-mvp-v1/backend/internal/storage"
+)
+
+// ErrQuarantined is returned when downloading a file that has not passed
+// the malware scan.
+var ErrQuarantined = errors.New("file has not passed the malware scan")
+
+// scanTimeout bounds one scan, including reading the file from storage.
+const scanTimeout = 10 * time.Minute
+
+// Scanner checks a file for malware (satisfied by *scan.ClamdScanner).
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (scan.Result, error)
+}
+
+// Mailer queues templated mail (satisfied by *mail.Outbox).
+type Mailer interface {
+	Enqueue(ctx context.Context, to, template, locale string, data map[string]interface{}) (uuid.UUID, error)
+}
+
+// SetScanner quarantines new uploads until scanner reports them clean.
+// Without a scanner files are stored as unscanned and stay quarantined
+// until a scanner is configured and Run has scanned them.
+func (s *Service) SetScanner(scanner Scanner) {
+	s.scanner = scanner
+}
+
+// SetMailer notifies learners about rejected files; baseURL is the public
+// frontend URL the mail links to.
+func (s *Service) SetMailer(m Mailer, baseURL string) {
+	s.mailer = m
+	s.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// Wait blocks until all scans started so far have finished.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+// Run rescans quarantined and unscanned files and makes missing previews
+// every interval until ctx is cancelled; scans that failed because the
+// scanner was unreachable are retried this way.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.ScanPending(ctx, 100); err != nil {
+			log.Printf("[artifact] scan queue: %v", err)
+		} else if n > 0 {
+			log.Printf("[artifact] scheduled %d file scans", n)
+		}
+		if n, err := s.PreviewPending(ctx, 100); err != nil {
+			log.Printf("[artifact] preview queue: %v", err)
+		} else if n > 0 {
+			log.Printf("[artifact] scheduled %d previews", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ScanPending schedules scans for up to limit files that are pending or
+// were stored without a scanner. Files whose scan is still running are
+// skipped.
+func (s *Service) ScanPending(ctx context.Context, limit int) (int, error) {
+	if s.repo == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+	if s.scanner == nil || s.store == nil {
+		return 0, nil
+	}
+	queued, err := s.repo.ListScanQueue(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("list scan queue: %w", err)
+	}
+	n := 0
+	for _, a := range queued {
+		if s.scanAsync(a) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// scanAsync scans a in the background unless work on it is running.
+func (s *Service) scanAsync(a ExternalArtifact) bool {
+	return s.goAsync(a, scanTimeout, s.scan)
+}
+
+// goAsync runs fn for a in the background unless work on a is running.
+func (s *Service) goAsync(a ExternalArtifact, timeout time.Duration, fn func(context.Context, *ExternalArtifact)) bool {
+	s.mu.Lock()
+	if s.busy[a.ID] {
+		s.mu.Unlock()
+		return false
+	}
+	s.busy[a.ID] = true
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.busy, a.ID)
+			s.mu.Unlock()
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		fn(ctx, &a)
+	}()
+	return true
+}
+
+// scan checks a's file and records the verdict. Infected files are removed
+// from storage and the learner is told; when the scanner cannot be reached
+// the file stays pending for the next run.
+func (s *Service) scan(ctx context.Context, a *ExternalArtifact) {
+	rc, err := s.store.Open(ctx, a.ObjectKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		s.setScanResult(ctx, a, ScanFailed, "")
+		return
+	}
+	if err != nil {
+		log.Printf("[artifact] open %s for scanning: %v", a.ID, err)
+		return
+	}
+	res, err := s.scanner.Scan(ctx, rc)
+	rc.Close()
+	switch {
+	case errors.Is(err, scan.ErrTooLarge):
+		log.Printf("[artifact] %s (%d bytes) exceeds the scanner's size limit", a.ID, a.SizeBytes)
+		s.setScanResult(ctx, a, ScanFailed, "")
+	case err != nil:
+		log.Printf("[artifact] scan %s: %v", a.ID, err)
+	case res.Infected:
+		log.Printf("[artifact] %s is infected (%s), removing file", a.ID, res.Signature)
+		s.deleteObject(a.ObjectKey)
+		if a.PreviewKey != "" {
+			s.deleteObject(a.PreviewKey)
+		}
+		s.setScanResult(ctx, a, ScanInfected, res.Signature)
+		s.notifyRejected(ctx, a)
+	default:
+		s.setScanResult(ctx, a, ScanClean, "")
+		if a.PreviewStatus == PreviewPending {
+			s.makePreview(ctx, a)
+		}
+	}
+}
+
+func (s *Service) setScanResult(ctx context.Context, a *ExternalArtifact, status, signature string) {
+	if err := s.repo.SetScanResult(ctx, a.ID, status, signature, time.Now().UTC()); err != nil {
+		log.Printf("[artifact] store scan result for %s: %v", a.ID, err)
+	}
+}
+
+// notifyRejected mails the learner that an upload was rejected.
+func (s *Service) notifyRejected(ctx context.Context, a *ExternalArtifact) {
+	if s.mailer == nil {
+		return
+	}
+	email, err := s.repo.LearnerEmail(ctx, a.LearnerID)
+	if err != nil || email == "" {
+		log.Printf("[artifact] no address to report rejected file %s: %v", a.ID, err)
+		return
+	}
+	data := map[string]interface{}{
+		"FileName":    a.FileName,
+		"Description": a.Description,
+		"AppURL":      s.baseURL,
+	}
+	if _, err := s.mailer.Enqueue(ctx, email, mail.TemplateArtifactRejected, mail.DefaultLocale, data); err != nil {
+		log.Printf("[artifact] queue rejection mail for %s: %v", a.ID, err)
+	}
+}
@@ -2,53 +2,310 @@ package artifact
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/storage"
 )
 
+// DownloadTTL is how long a signed download URL stays valid.
+const DownloadTTL = 15 * time.Minute
+
+// Store keeps uploaded files (satisfied by storage.Store).
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key, filename string, ttl time.Duration) (string, error)
+}
+
+// Taxonomy resolves dimension keys against the skill registry (satisfied
+// by *taxonomy.Service).
+type Taxonomy interface {
+	Normalize(dims map[string]float64) (map[string]float64, error)
+}
+
 type Service struct {
-	repo Repository
+	repo     Repository
+	taxonomy Taxonomy
+	store    Store
+	scanner  Scanner
+	preview  Previewer
+	mailer   Mailer
+	baseURL  string
+	// spoolDir holds uploads while they are checked (os.TempDir if empty).
+	spoolDir string
+
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	busy map[uuid.UUID]bool // artifacts with a scan or preview in progress
 }
 
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, busy: map[uuid.UUID]bool{}}
+}
+
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
+}
+
+// SetTaxonomy makes uploads map dimension keys to canonical keys and reject
+// unknown ones.
+func (s *Service) SetTaxonomy(t Taxonomy) {
+	s.taxonomy = t
+}
+
+// SetStore enables file uploads; without a store only links can be added.
+func (s *Service) SetStore(store Store) {
+	s.store = store
 }
 
 func (s *Service) List(ctx context.Context, learnerID uuid.UUID, artifactType *string, limit, offset int) ([]ExternalArtifact, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
 	return s.repo.List(ctx, learnerID, artifactType, limit, offset)
 }
 
-func (s *Service) Upload(ctx context.Context, learnerID uuid.UUID, artifactType, description string, skillDimensions map[string]float64, storageRef *string) (*ExternalArtifact, error) {
-	if description == "" {
+// Upload adds an artifact. Photos, documents and videos need a file, links
+// a URL. The file is spooled to disk while its size, content type and
+// checksum are determined, then stored under artifacts/<learner>/<id> and
+// quarantined until the malware scan passes.
+func (s *Service) Upload(ctx context.Context, learnerID uuid.UUID, req UploadRequest, file *File) (*ExternalArtifact, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	if req.Description == "" {
 		return nil, fmt.Errorf("description is required")
 	}
+	switch req.ArtifactType {
+	case TypeLink:
+		if req.URL == "" || file != nil {
+			return nil, fmt.Errorf("link artifacts need a url and no file")
+		}
+	case TypePhoto, TypeDocument, TypeVideo:
+		if file == nil {
+			return nil, fmt.Errorf("%s artifacts need a file", req.ArtifactType)
+		}
+		if s.store == nil {
+			return nil, fmt.Errorf("file storage not available")
+		}
+	default:
+		return nil, fmt.Errorf("artifact_type must be photo, document, video or link")
+	}
+	skillDimensions := req.SkillDimensions
+	if s.taxonomy != nil {
+		dims, err := s.taxonomy.Normalize(skillDimensions)
+		if err != nil {
+			return nil, err
+		}
+		skillDimensions = dims
+	}
 
 	a := &ExternalArtifact{
 		ID:              uuid.New(),
 		LearnerID:       learnerID,
-		ArtifactType:    artifactType,
-		Description:     description,
+		ArtifactType:    req.ArtifactType,
+		Description:     req.Description,
 		SkillDimensions: skillDimensions,
-		StorageRef:      storageRef,
 		UploadedAt:      time.Now().UTC(),
 	}
+	if file == nil {
+		a.StorageRef = &req.URL
+	} else if err := s.storeFile(ctx, a, file); err != nil {
+		return nil, err
+	}
 
 	if err := s.repo.Create(ctx, a); err != nil {
+		if a.ObjectKey != "" {
+			s.deleteObject(a.ObjectKey)
+		}
 		return nil, fmt.Errorf("create artifact: %w", err)
 	}
+	// The preview is made once the scan finds the file clean, so the
+	// previewer never parses a file the scanner has not seen.
+	if a.ScanStatus == ScanPending {
+		s.scanAsync(*a)
+	}
 	return a, nil
 }
 
+// storeFile checks the file against its type's limits, stores it and fills
+// in a's file metadata.
+func (s *Service) storeFile(ctx context.Context, a *ExternalArtifact, file *File) error {
+	limit := MaxFileSize[a.ArtifactType]
+	spool, err := os.CreateTemp(s.spoolDir, "artifact-*")
+	if err != nil {
+		return fmt.Errorf("spool upload: %w", err)
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file.Body, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("read upload: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("file is empty")
+	}
+	head = head[:n]
+	name := storage.SafeFilename(file.Name)
+	contentType := sniffContentType(head, name)
+	if !allowedContentType(a.ArtifactType, contentType) {
+		return fmt.Errorf("%w: %s is not allowed for %s artifacts", ErrUnsupportedFile, contentType, a.ArtifactType)
+	}
+
+	hash := sha256.New()
+	w := io.MultiWriter(spool, hash)
+	if _, err := w.Write(head); err != nil {
+		return fmt.Errorf("spool upload: %w", err)
+	}
+	// Read one byte past the limit to detect oversized files without
+	// consuming them completely.
+	rest, err := io.Copy(w, io.LimitReader(file.Body, limit-int64(n)+1))
+	if err != nil {
+		return fmt.Errorf("read upload: %w", err)
+	}
+	size := int64(n) + rest
+	if size > limit {
+		return fmt.Errorf("%w: %s artifacts may be at most %d MB", ErrFileTooLarge, a.ArtifactType, limit>>20)
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("spool upload: %w", err)
+	}
+
+	key := "artifacts/" + a.LearnerID.String() + "/" + a.ID.String()
+	if err := s.store.Put(ctx, key, spool, size, contentType); err != nil {
+		return fmt.Errorf("store file: %w", err)
+	}
+	a.ObjectKey = key
+	a.FileName = name
+	a.ContentType = contentType
+	a.SizeBytes = size
+	a.Checksum = hex.EncodeToString(hash.Sum(nil))
+	a.ScanStatus = ScanUnscanned
+	if s.scanner != nil {
+		a.ScanStatus = ScanPending
+	}
+	if s.preview != nil {
+		a.PreviewStatus = PreviewPending
+	}
+	return nil
+}
+
+// deleteObject removes a stored file; failures leave an orphaned object
+// and are only logged.
+func (s *Service) deleteObject(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.store.Delete(ctx, key); err != nil {
+		log.Printf("[artifact] delete object %s: %v", key, err)
+	}
+}
+
 func (s *Service) Get(ctx context.Context, id uuid.UUID, learnerID uuid.UUID) (*ExternalArtifactDetailed, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 	return s.repo.GetDetailedByID(ctx, id, learnerID)
 }
 
 func (s *Service) Delete(ctx context.Context, id uuid.UUID, learnerID uuid.UUID) error {
-	return s.repo.Delete(ctx, id, learnerID)
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	a, err := s.repo.GetByID(ctx, id, learnerID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id, learnerID); err != nil {
+		return err
+	}
+	if s.store != nil {
+		for _, key := range []string{a.ObjectKey, a.PreviewKey} {
+			if key != "" {
+				s.deleteObject(key)
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteLearnerFiles removes the stored files and previews of all the
+// learner's artifacts. Account deletion calls it before the rows go with
+// the user, so that no file is left without a reference.
+func (s *Service) DeleteLearnerFiles(ctx context.Context, learnerID uuid.UUID) error {
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	if s.store == nil {
+		return nil
+	}
+	const page = 100
+	for offset := 0; ; offset += page {
+		items, total, err := s.repo.List(ctx, learnerID, nil, page, offset)
+		if err != nil {
+			return fmt.Errorf("list artifacts: %w", err)
+		}
+		for _, a := range items {
+			for _, key := range []string{a.ObjectKey, a.PreviewKey} {
+				if key == "" {
+					continue
+				}
+				if err := s.store.Delete(ctx, key); err != nil {
+					return fmt.Errorf("delete object %s: %w", key, err)
+				}
+			}
+		}
+		if len(items) == 0 || offset+len(items) >= total {
+			return nil
+		}
+	}
+}
+
+// Download returns a signed URL for the artifact's file; links have none
+// and quarantined or infected files are refused.
+func (s *Service) Download(ctx context.Context, id, learnerID uuid.UUID) (*Download, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	if s.store == nil {
+		return nil, fmt.Errorf("file storage not available")
+	}
+	a, err := s.repo.GetByID(ctx, id, learnerID)
+	if err != nil {
+		return nil, err
+	}
+	if a.ObjectKey == "" {
+		return nil, ErrNoFile
+	}
+	if !a.Downloadable() {
+		return nil, ErrQuarantined
+	}
+	expires := time.Now().UTC().Add(DownloadTTL)
+	url, err := s.store.SignedURL(ctx, a.ObjectKey, a.FileName, DownloadTTL)
+	if err != nil {
+		return nil, fmt.Errorf("sign download: %w", err)
+	}
+	return &Download{URL: url, ExpiresAt: expires}, nil
 }
 
 func (s *Service) LinkEndorsement(ctx context.Context, id uuid.UUID, learnerID uuid.UUID, endorsementID uuid.UUID) (*ExternalArtifactDetailed, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 	return s.repo.LinkEndorsement(ctx, id, learnerID, endorsementID)
 }
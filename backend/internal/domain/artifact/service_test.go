@@ -0,0 +1,536 @@
+package artifact
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/mail"
+	"skillr-mvp-v1/backend/internal/preview"
+	"skillr-mvp-v1/backend/internal/scan"
+	"skillr-mvp-v1/backend/internal/storage"
+)
+
+type memRepo struct {
+	mu        sync.Mutex
+	artifacts map[uuid.UUID]*ExternalArtifact
+	createErr error
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{artifacts: map[uuid.UUID]*ExternalArtifact{}}
+}
+
+func (r *memRepo) Create(_ context.Context, a *ExternalArtifact) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.createErr != nil {
+		return r.createErr
+	}
+	cp := *a
+	r.artifacts[a.ID] = &cp
+	return nil
+}
+
+func (r *memRepo) GetByID(_ context.Context, id, learnerID uuid.UUID) (*ExternalArtifact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.artifacts[id]
+	if !ok || a.LearnerID != learnerID {
+		return nil, fmt.Errorf("get artifact: no rows")
+	}
+	cp := *a
+	return &cp, nil
+}
+
+func (r *memRepo) GetDetailedByID(ctx context.Context, id, learnerID uuid.UUID) (*ExternalArtifactDetailed, error) {
+	a, err := r.GetByID(ctx, id, learnerID)
+	if err != nil {
+		return nil, err
+	}
+	return &ExternalArtifactDetailed{ExternalArtifact: *a}, nil
+}
+
+func (r *memRepo) List(_ context.Context, learnerID uuid.UUID, _ *string, limit, _ int) ([]ExternalArtifact, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var list []ExternalArtifact
+	for _, a := range r.artifacts {
+		if a.LearnerID == learnerID && len(list) < limit {
+			list = append(list, *a)
+		}
+	}
+	return list, len(list), nil
+}
+
+func (r *memRepo) Delete(_ context.Context, id, learnerID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.artifacts[id]
+	if !ok || a.LearnerID != learnerID {
+		return fmt.Errorf("artifact not found")
+	}
+	delete(r.artifacts, id)
+	return nil
+}
+
+func (r *memRepo) LinkEndorsement(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) (*ExternalArtifactDetailed, error) {
+	return nil, nil
+}
+
+func (r *memRepo) ListScanQueue(_ context.Context, limit int) ([]ExternalArtifact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var queued []ExternalArtifact
+	for _, a := range r.artifacts {
+		if (a.ScanStatus == ScanPending || a.ScanStatus == ScanUnscanned) && len(queued) < limit {
+			queued = append(queued, *a)
+		}
+	}
+	return queued, nil
+}
+
+func (r *memRepo) SetScanResult(_ context.Context, id uuid.UUID, status, signature string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.artifacts[id]; ok {
+		a.ScanStatus, a.ScanSignature, a.ScannedAt = status, signature, &at
+		if status == ScanInfected {
+			a.ObjectKey = ""
+		}
+	}
+	return nil
+}
+
+func (r *memRepo) LearnerEmail(context.Context, uuid.UUID) (string, error) {
+	return "lea@example.org", nil
+}
+
+func (r *memRepo) ListPreviewQueue(_ context.Context, limit int) ([]ExternalArtifact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var queued []ExternalArtifact
+	for _, a := range r.artifacts {
+		if a.PreviewStatus == PreviewPending && a.ScanStatus == ScanClean && len(queued) < limit {
+			queued = append(queued, *a)
+		}
+	}
+	return queued, nil
+}
+
+func (r *memRepo) SetPreview(_ context.Context, a *ExternalArtifact) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.artifacts[a.ID]; ok {
+		stored.PreviewStatus, stored.PreviewKey = a.PreviewStatus, a.PreviewKey
+		stored.Width, stored.Height, stored.PageCount, stored.DurationSeconds = a.Width, a.Height, a.PageCount, a.DurationSeconds
+		stored.HasPreview = a.PreviewKey != ""
+	}
+	return nil
+}
+
+func (r *memRepo) get(id uuid.UUID) ExternalArtifact {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.artifacts[id]
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newTestService(t *testing.T) (*Service, *memRepo, *storage.LocalStore) {
+	t.Helper()
+	store, err := storage.NewLocalStore(t.TempDir(), "https://api.example", []byte("secret"))
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	repo := newMemRepo()
+	svc := NewService(repo)
+	svc.SetStore(store)
+	svc.spoolDir = t.TempDir()
+	return svc, repo, store
+}
+
+func TestUpload_StoresFileWithSniffedTypeAndChecksum(t *testing.T) {
+	svc, repo, store := newTestService(t)
+	learner := uuid.New()
+	content := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{1}, 2000)...)
+
+	a, err := svc.Upload(context.Background(), learner,
+		UploadRequest{ArtifactType: TypePhoto, Description: "Robotik-Projekt"},
+		&File{Name: `C:\fotos\roboter.jpg`, Body: bytes.NewReader(content)})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	sum := sha256.Sum256(content)
+	if a.ContentType != "image/png" || a.SizeBytes != int64(len(content)) || a.Checksum != hex.EncodeToString(sum[:]) || a.FileName != "roboter.jpg" {
+		t.Errorf("metadata = %q %d %q %q", a.ContentType, a.SizeBytes, a.Checksum, a.FileName)
+	}
+	if want := "artifacts/" + learner.String() + "/" + a.ID.String(); a.ObjectKey != want || repo.get(a.ID).ObjectKey != want {
+		t.Errorf("object key = %q, want %q", a.ObjectKey, want)
+	}
+	rc, err := store.Open(context.Background(), a.ObjectKey)
+	if err != nil {
+		t.Fatalf("stored object: %v", err)
+	}
+	stored, _ := io.ReadAll(rc)
+	rc.Close()
+	if !bytes.Equal(stored, content) {
+		t.Error("stored content differs")
+	}
+}
+
+func TestUpload_RejectsOversizedFile(t *testing.T) {
+	svc, repo, _ := newTestService(t)
+	body := io.MultiReader(bytes.NewReader(pngHeader), io.LimitReader(zeros{}, MaxFileSize[TypePhoto]))
+
+	_, err := svc.Upload(context.Background(), uuid.New(),
+		UploadRequest{ArtifactType: TypePhoto, Description: "zu gross"}, &File{Name: "big.png", Body: body})
+	if !errors.Is(err, ErrFileTooLarge) {
+		t.Fatalf("err = %v, want ErrFileTooLarge", err)
+	}
+	if len(repo.artifacts) != 0 {
+		t.Error("oversized artifact was created")
+	}
+}
+
+func TestUpload_RejectsContentNotMatchingType(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	cases := []struct {
+		artifactType string
+		name         string
+		content      []byte
+	}{
+		{TypePhoto, "foto.png", []byte("#!/bin/sh\necho hi\n")},
+		{TypeDocument, "bericht.pdf", []byte("MZ\x90\x00\x03\x00\x00\x00")},
+		{TypeVideo, "clip.mp4", pngHeader},
+		{TypeDocument, "archiv.docx", zipWith(t, "payload.exe")},
+	}
+	for _, tc := range cases {
+		_, err := svc.Upload(context.Background(), uuid.New(),
+			UploadRequest{ArtifactType: tc.artifactType, Description: "x"}, &File{Name: tc.name, Body: bytes.NewReader(tc.content)})
+		if !errors.Is(err, ErrUnsupportedFile) {
+			t.Errorf("%s as %s: err = %v, want ErrUnsupportedFile", tc.name, tc.artifactType, err)
+		}
+	}
+}
+
+func TestUpload_RecognizesOfficeDocuments(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	a, err := svc.Upload(context.Background(), uuid.New(),
+		UploadRequest{ArtifactType: TypeDocument, Description: "Praktikumsbericht"},
+		&File{Name: "Bericht.DOCX", Body: bytes.NewReader(zipWith(t, "[Content_Types].xml"))})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if a.ContentType != docxType {
+		t.Errorf("content type = %q", a.ContentType)
+	}
+}
+
+func TestUpload_LinksNeedURLAndFilesNeedFile(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	ctx := context.Background()
+	if _, err := svc.Upload(ctx, uuid.New(), UploadRequest{ArtifactType: TypeLink, Description: "x"}, nil); err == nil {
+		t.Error("link without url accepted")
+	}
+	if _, err := svc.Upload(ctx, uuid.New(), UploadRequest{ArtifactType: TypePhoto, Description: "x", URL: "https://x"}, nil); err == nil {
+		t.Error("photo without file accepted")
+	}
+	if _, err := svc.Upload(ctx, uuid.New(), UploadRequest{ArtifactType: "audio", Description: "x"}, nil); err == nil {
+		t.Error("unknown type accepted")
+	}
+	a, err := svc.Upload(ctx, uuid.New(), UploadRequest{ArtifactType: TypeLink, Description: "x", URL: "https://github.com/me/robot"}, nil)
+	if err != nil || a.StorageRef == nil || *a.StorageRef != "https://github.com/me/robot" || a.ObjectKey != "" {
+		t.Errorf("link upload = %+v, %v", a, err)
+	}
+	if _, err := svc.Download(ctx, a.ID, a.LearnerID); !errors.Is(err, ErrNoFile) {
+		t.Errorf("download of link = %v, want ErrNoFile", err)
+	}
+}
+
+func TestUpload_RemovesObjectWhenRecordFails(t *testing.T) {
+	svc, repo, store := newTestService(t)
+	rec := &recordingStore{Store: store}
+	svc.SetStore(rec)
+	repo.createErr = errors.New("db down")
+
+	_, err := svc.Upload(context.Background(), uuid.New(),
+		UploadRequest{ArtifactType: TypePhoto, Description: "x"}, &File{Name: "a.png", Body: bytes.NewReader(pngHeader)})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if len(rec.put) != 1 || len(rec.deleted) != 1 || rec.put[0] != rec.deleted[0] {
+		t.Errorf("put %v, deleted %v", rec.put, rec.deleted)
+	}
+}
+
+type recordingStore struct {
+	Store
+	put, deleted []string
+}
+
+func (r *recordingStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	r.put = append(r.put, key)
+	return r.Store.Put(ctx, key, body, size, contentType)
+}
+
+func (r *recordingStore) Delete(ctx context.Context, key string) error {
+	r.deleted = append(r.deleted, key)
+	return r.Store.Delete(ctx, key)
+}
+
+func TestDelete_RemovesStoredObject(t *testing.T) {
+	svc, _, store := newTestService(t)
+	svc.SetScanner(&fakeScanner{})
+	ctx := context.Background()
+	learner := uuid.New()
+	a, err := svc.Upload(ctx, learner, UploadRequest{ArtifactType: TypePhoto, Description: "x"},
+		&File{Name: "a.png", Body: bytes.NewReader(pngHeader)})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	svc.Wait()
+
+	d, err := svc.Download(ctx, a.ID, learner)
+	if err != nil || !strings.Contains(d.URL, storage.FilePath+a.ObjectKey+"?") {
+		t.Fatalf("Download = %+v, %v", d, err)
+	}
+	if _, err := svc.Download(ctx, a.ID, uuid.New()); err == nil {
+		t.Error("other learner got a download URL")
+	}
+
+	if err := svc.Delete(ctx, a.ID, learner); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Open(ctx, a.ObjectKey); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("object after delete: %v", err)
+	}
+}
+
+// fakeScanner reports files containing "virus" as infected and fails while
+// err is set.
+type fakeScanner struct {
+	mu    sync.Mutex
+	err   error
+	calls int
+}
+
+func (f *fakeScanner) Scan(_ context.Context, r io.Reader) (scan.Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return scan.Result{}, f.err
+	}
+	data, _ := io.ReadAll(r)
+	if bytes.Contains(data, []byte("virus")) {
+		return scan.Result{Infected: true, Signature: "Test.Virus"}, nil
+	}
+	return scan.Result{}, nil
+}
+
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent []string
+}
+
+func (m *fakeMailer) Enqueue(_ context.Context, to, template, _ string, _ map[string]interface{}) (uuid.UUID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, to+" "+template)
+	return uuid.New(), nil
+}
+
+func uploadText(t *testing.T, svc *Service, learner uuid.UUID, content string) *ExternalArtifact {
+	t.Helper()
+	a, err := svc.Upload(context.Background(), learner, UploadRequest{ArtifactType: TypeDocument, Description: "Bericht"},
+		&File{Name: "bericht.txt", Body: strings.NewReader(content)})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	return a
+}
+
+func TestUpload_QuarantinedUntilScannedClean(t *testing.T) {
+	svc, repo, _ := newTestService(t)
+	svc.SetScanner(&fakeScanner{})
+	ctx := context.Background()
+	learner := uuid.New()
+
+	a := uploadText(t, svc, learner, "harmloser Bericht")
+	if a.ScanStatus != ScanPending {
+		t.Errorf("status after upload = %q, want pending", a.ScanStatus)
+	}
+	svc.Wait()
+	if got := repo.get(a.ID); got.ScanStatus != ScanClean || got.ScannedAt == nil {
+		t.Errorf("status after scan = %q at %v", got.ScanStatus, got.ScannedAt)
+	}
+	if _, err := svc.Download(ctx, a.ID, learner); err != nil {
+		t.Errorf("Download of clean file: %v", err)
+	}
+}
+
+func TestUpload_InfectedFileRemovedAndLearnerNotified(t *testing.T) {
+	svc, repo, store := newTestService(t)
+	svc.SetScanner(&fakeScanner{})
+	mailer := &fakeMailer{}
+	svc.SetMailer(mailer, "https://app.example/")
+	ctx := context.Background()
+	learner := uuid.New()
+
+	a := uploadText(t, svc, learner, "ein virus im text")
+	if _, err := svc.Download(ctx, a.ID, learner); err != nil && !errors.Is(err, ErrQuarantined) {
+		t.Errorf("Download while pending = %v", err)
+	}
+	svc.Wait()
+
+	got := repo.get(a.ID)
+	if got.ScanStatus != ScanInfected || got.ScanSignature != "Test.Virus" {
+		t.Errorf("scan result = %q %q", got.ScanStatus, got.ScanSignature)
+	}
+	if _, err := store.Open(ctx, a.ObjectKey); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("infected object still stored: %v", err)
+	}
+	if _, err := svc.Download(ctx, a.ID, learner); err == nil {
+		t.Error("infected file can be downloaded")
+	}
+	if len(mailer.sent) != 1 || mailer.sent[0] != "lea@example.org "+mail.TemplateArtifactRejected {
+		t.Errorf("mails = %v", mailer.sent)
+	}
+}
+
+func TestScanPending_RetriesWhenScannerUnavailable(t *testing.T) {
+	svc, repo, _ := newTestService(t)
+	scanner := &fakeScanner{err: errors.New("connect clamd: connection refused")}
+	svc.SetScanner(scanner)
+	ctx := context.Background()
+	learner := uuid.New()
+
+	a := uploadText(t, svc, learner, "Bericht")
+	svc.Wait()
+	if got := repo.get(a.ID); got.ScanStatus != ScanPending {
+		t.Fatalf("status after failed scan = %q, want pending", got.ScanStatus)
+	}
+	if _, err := svc.Download(ctx, a.ID, learner); !errors.Is(err, ErrQuarantined) {
+		t.Errorf("Download = %v, want ErrQuarantined", err)
+	}
+
+	scanner.mu.Lock()
+	scanner.err = nil
+	scanner.mu.Unlock()
+	if n, err := svc.ScanPending(ctx, 10); err != nil || n != 1 {
+		t.Fatalf("ScanPending = %d, %v", n, err)
+	}
+	svc.Wait()
+	if got := repo.get(a.ID); got.ScanStatus != ScanClean {
+		t.Errorf("status after retry = %q, want clean", got.ScanStatus)
+	}
+}
+
+func TestScan_TooLargeForScannerStaysBlocked(t *testing.T) {
+	svc, repo, _ := newTestService(t)
+	svc.SetScanner(&fakeScanner{err: fmt.Errorf("clamd: %w", scan.ErrTooLarge)})
+	a := uploadText(t, svc, uuid.New(), "Bericht")
+	svc.Wait()
+	if got := repo.get(a.ID); got.ScanStatus != ScanFailed {
+		t.Errorf("status = %q, want failed", got.ScanStatus)
+	}
+	if _, err := svc.Download(context.Background(), a.ID, a.LearnerID); !errors.Is(err, ErrQuarantined) {
+		t.Errorf("Download = %v, want ErrQuarantined", err)
+	}
+}
+
+func TestUpload_WithoutScannerStaysQuarantined(t *testing.T) {
+	svc, _, _ := newTestService(t)
+	a := uploadText(t, svc, uuid.New(), "Bericht")
+	if a.ScanStatus != ScanUnscanned {
+		t.Errorf("status = %q, want unscanned", a.ScanStatus)
+	}
+	if _, err := svc.Download(context.Background(), a.ID, a.LearnerID); !errors.Is(err, ErrQuarantined) {
+		t.Errorf("Download = %v, want ErrQuarantined", err)
+	}
+}
+
+func TestUpload_WithoutScannerMakesNoPreview(t *testing.T) {
+	svc, repo, _ := newTestService(t)
+	svc.SetPreviewer(&preview.Generator{})
+	ctx := context.Background()
+	a, err := svc.Upload(ctx, uuid.New(), UploadRequest{ArtifactType: TypePhoto, Description: "Werkstueck"},
+		&File{Name: "werkstueck.png", Body: bytes.NewReader(pngImage(t, 40, 30))})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if n, _ := svc.PreviewPending(ctx, 10); n != 0 {
+		t.Errorf("scheduled %d previews of an unscanned file", n)
+	}
+	svc.Wait()
+	if got := repo.get(a.ID); got.PreviewStatus != PreviewPending || got.HasPreview {
+		t.Errorf("preview status = %q, want pending", got.PreviewStatus)
+	}
+}
+
+func TestDeleteLearnerFiles(t *testing.T) {
+	svc, _, store := newTestService(t)
+	svc.SetScanner(&fakeScanner{})
+	svc.SetPreviewer(&preview.Generator{})
+	ctx := context.Background()
+	learner, other := uuid.New(), uuid.New()
+	a, err := svc.Upload(ctx, learner, UploadRequest{ArtifactType: TypePhoto, Description: "Werkstueck"},
+		&File{Name: "werkstueck.png", Body: bytes.NewReader(pngImage(t, 40, 30))})
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	kept := uploadText(t, svc, other, "Bericht")
+	svc.Wait()
+	got, _ := svc.repo.GetByID(ctx, a.ID, learner)
+	if got.PreviewKey == "" {
+		t.Fatal("upload has no preview")
+	}
+
+	if err := svc.DeleteLearnerFiles(ctx, learner); err != nil {
+		t.Fatalf("DeleteLearnerFiles: %v", err)
+	}
+	for _, key := range []string{got.ObjectKey, got.PreviewKey} {
+		if _, err := store.Open(ctx, key); !errors.Is(err, storage.ErrNotFound) {
+			t.Errorf("object %s still stored: %v", key, err)
+		}
+	}
+	rc, err := store.Open(ctx, kept.ObjectKey)
+	if err != nil {
+		t.Fatalf("file of another learner was removed: %v", err)
+	}
+	rc.Close()
+}
+
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func zipWith(t *testing.T, name string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte("<Types/>"))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,87 @@
+package artifact
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrFileTooLarge is returned when a file exceeds its type's limit.
+	ErrFileTooLarge = errors.New("file is too large")
+	// ErrUnsupportedFile is returned when the sniffed content type is not
+	// allowed for the artifact type.
+	ErrUnsupportedFile = errors.New("file type is not allowed")
+	// ErrNoFile is returned when downloading a link artifact.
+	ErrNoFile = errors.New("artifact has no file")
+)
+
+// MaxFileSize is the upload limit per artifact type in bytes.
+var MaxFileSize = map[string]int64{
+	TypePhoto:    10 << 20,
+	TypeDocument: 25 << 20,
+	TypeVideo:    200 << 20,
+}
+
+// MaxUploadSize bounds the whole upload request: the largest file plus
+// room for the form fields.
+const MaxUploadSize = 201 << 20
+
+// allowedContentTypes lists the sniffed content types per artifact type.
+var allowedContentTypes = map[string][]string{
+	TypePhoto:    {"image/jpeg", "image/png", "image/gif", "image/webp"},
+	TypeDocument: {"application/pdf", "text/plain", docxType, xlsxType, pptxType},
+	TypeVideo:    {"video/mp4", "video/webm"},
+}
+
+const (
+	docxType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	xlsxType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	pptxType = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+)
+
+// officeTypes maps Office Open XML extensions to their content types. The
+// content only shows that a ZIP is an Office document; the extension says
+// which one.
+var officeTypes = map[string]string{".docx": docxType, ".xlsx": xlsxType, ".pptx": pptxType}
+
+// sniffContentType determines the content type from the first bytes of a
+// file (at most 512 are considered). Parameters such as charset are
+// dropped.
+func sniffContentType(head []byte, filename string) string {
+	ct, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	if ct == "application/zip" && isOfficeZip(head) {
+		if t, ok := officeTypes[strings.ToLower(path.Ext(filename))]; ok {
+			return t
+		}
+	}
+	return ct
+}
+
+// isOfficeZip reports whether the first ZIP entry is "[Content_Types].xml"
+// (or the "docProps"/"_rels" parts some writers put first), as in Office
+// Open XML files.
+func isOfficeZip(head []byte) bool {
+	const nameOffset = 30 // size of the local file header before the name
+	if len(head) <= nameOffset {
+		return false
+	}
+	name := head[nameOffset:]
+	for _, prefix := range []string{"[Content_Types].xml", "_rels/", "docProps/"} {
+		if bytes.HasPrefix(name, []byte(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+func allowedContentType(artifactType, contentType string) bool {
+	for _, t := range allowedContentTypes[artifactType] {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
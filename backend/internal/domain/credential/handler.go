@@ -0,0 +1,161 @@
+package credential
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"skillr-mvp-v1/backend/internal/middleware"
+)
+
+// mediaTypeVC is the media type of a secured VC 2.0 credential in JSON-LD.
+const mediaTypeVC = "application/vc+ld+json"
+
+// maxVerifyBody limits the size of credentials posted for verification.
+const maxVerifyBody = 256 << 10
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+func (h *Handler) List(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	params := ListParams{
+		UserID: deriveUUID(userInfo.UID),
+		Limit:  intQuery(c, "limit", 20),
+		Offset: intQuery(c, "offset", 0),
+	}
+	creds, total, err := h.svc.List(c.Request().Context(), params)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list credentials")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"credentials": creds, "total": total})
+}
+
+func (h *Handler) Issue(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	var req IssueRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	cred, err := h.svc.Issue(c.Request().Context(), deriveUUID(userInfo.UID), req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusCreated, cred)
+}
+
+func (h *Handler) Get(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid credential ID")
+	}
+	cred, err := h.svc.Get(c.Request().Context(), deriveUUID(userInfo.UID), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "credential not found")
+	}
+	return c.JSON(http.StatusOK, cred)
+}
+
+// Download returns the signed credential document for import into a wallet.
+func (h *Handler) Download(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid credential ID")
+	}
+	cred, err := h.svc.Get(c.Request().Context(), deriveUUID(userInfo.UID), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "credential not found")
+	}
+	c.Response().Header().Set("Content-Disposition", `attachment; filename="credential-`+cred.ID.String()+`.json"`)
+	return c.Blob(http.StatusOK, mediaTypeVC, cred.Document)
+}
+
+func (h *Handler) Revoke(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid credential ID")
+	}
+	var req RevokeRequest
+	_ = c.Bind(&req)
+	cred, err := h.svc.Revoke(c.Request().Context(), deriveUUID(userInfo.UID), id, req.Reason)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "credential not found")
+	}
+	return c.JSON(http.StatusOK, cred)
+}
+
+// DIDDocument serves the issuer's did:web document (public).
+func (h *Handler) DIDDocument(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, h.svc.Issuer().DIDDocument())
+}
+
+// JWKS publishes the active and retired issuer keys (public).
+func (h *Handler) JWKS(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, h.svc.Issuer().Keys().JWKS())
+}
+
+// Verify checks a credential posted in the request body (public).
+func (h *Handler) Verify(c echo.Context) error {
+	raw, err := io.ReadAll(io.LimitReader(c.Request().Body, maxVerifyBody+1))
+	if err != nil || len(raw) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "credential required in request body")
+	}
+	if len(raw) > maxVerifyBody {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "credential too large")
+	}
+	return c.JSON(http.StatusOK, h.svc.Verify(c.Request().Context(), raw))
+}
+
+// VerifyByID checks a stored credential by ID (public).
+func (h *Handler) VerifyByID(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid credential ID")
+	}
+	res, err := h.svc.VerifyByID(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "credential not found")
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func deriveUUID(firebaseUID string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceDNS, []byte(firebaseUID))
+}
+
+func intQuery(c echo.Context, key string, def int) int {
+	if v := c.QueryParam(key); v != "" {
+		if i, err := strconv.Atoi(v); err == nil && i >= 0 {
+			return i
+		}
+	}
+	return def
+}
@@ -0,0 +1,316 @@
+package credential
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/signing"
+	"skillr-mvp-v1/backend/internal/skillscore"
+)
+
+// JSON-LD contexts and proof parameters for Open Badges 3.0 on VC 2.0.
+const (
+	contextVC       = "https://www.w3.org/ns/credentials/v2"
+	contextOB3      = "https://purl.imsglobal.org/spec/ob/v3p0/context-3.0.3.json"
+	contextMultikey = "https://w3id.org/security/multikey/v1"
+	contextDID      = "https://www.w3.org/ns/did/v1"
+	proofType       = "DataIntegrityProof"
+	cryptosuite     = "eddsa-jcs-2022"
+	proofPurpose    = "assertionMethod"
+)
+
+// defaultValidity follows default_credential_expiry in specs/skill-wallet.allium.
+const defaultValidity = 1825 * 24 * time.Hour
+
+// Issuer signs credentials as the platform's did:web identity.
+type Issuer struct {
+	DID  string
+	URL  string
+	Name string
+	keys *signing.KeyRing
+}
+
+// NewIssuer creates an issuer for the given public base URL. The DID is the
+// did:web identifier of that URL, so the DID document must be served at
+// <baseURL>/.well-known/did.json (or <baseURL>/did.json for a path).
+func NewIssuer(baseURL, name string, keys *signing.KeyRing) (*Issuer, error) {
+	did, err := DIDWeb(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Issuer{DID: did, URL: strings.TrimRight(baseURL, "/"), Name: name, keys: keys}, nil
+}
+
+// DIDWeb converts an https URL into its did:web identifier.
+func DIDWeb(baseURL string) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid issuer URL %q", baseURL)
+	}
+	did := "did:web:" + strings.ReplaceAll(u.Host, ":", "%3A")
+	for _, seg := range strings.Split(strings.Trim(u.Path, "/"), "/") {
+		if seg != "" {
+			did += ":" + url.PathEscape(seg)
+		}
+	}
+	return did, nil
+}
+
+// KeyID returns the ID of the active signing key.
+func (i *Issuer) KeyID() string {
+	return i.keys.Active().ID
+}
+
+// Keys returns the issuer's key ring.
+func (i *Issuer) Keys() *signing.KeyRing {
+	return i.keys
+}
+
+func (i *Issuer) verificationMethod(kid string) string {
+	return i.DID + "#" + kid
+}
+
+// DIDDocument returns the did:web document listing the issuer's public keys.
+// Retired keys stay listed so credentials signed before a rotation verify.
+func (i *Issuer) DIDDocument() map[string]interface{} {
+	var methods []map[string]interface{}
+	var refs []string
+	for _, k := range i.keys.Keys() {
+		vm := i.verificationMethod(k.ID)
+		methods = append(methods, map[string]interface{}{
+			"id":                 vm,
+			"type":               "Multikey",
+			"controller":         i.DID,
+			"publicKeyMultibase": k.PublicKeyMultibase(),
+		})
+		refs = append(refs, vm)
+	}
+	return map[string]interface{}{
+		"@context":           []string{contextDID, contextMultikey},
+		"id":                 i.DID,
+		"verificationMethod": methods,
+		"assertionMethod":    refs,
+		"authentication":     []string{i.verificationMethod(i.KeyID())},
+	}
+}
+
+// identityObject identifies the holder by a salted SHA-256 hash of their
+// account ID rather than their name, which a verifier could guess and
+// confirm. The salt is new for every credential, so the hashes of one
+// holder's credentials cannot be linked.
+func identityObject(userID uuid.UUID) map[string]interface{} {
+	b := make([]byte, 16)
+	rand.Read(b) // never fails since Go 1.24
+	salt := hex.EncodeToString(b)
+	sum := sha256.Sum256([]byte(userID.String() + salt))
+	return map[string]interface{}{
+		"type":         "IdentityObject",
+		"identityHash": "sha256$" + hex.EncodeToString(sum[:]),
+		"identityType": "systemId",
+		"hashed":       true,
+		"salt":         salt,
+	}
+}
+
+// Build creates the unsigned Open Badges 3.0 credential for a source record.
+func (i *Issuer) Build(id uuid.UUID, src *Source, issuedAt time.Time) map[string]interface{} {
+	achievement := map[string]interface{}{
+		"id":              "urn:uuid:" + src.ID.String(),
+		"type":            []string{"Achievement"},
+		"achievementType": achievementType(src.Type),
+		"name":            src.Name,
+		"description":     src.Description,
+		"criteria":        map[string]interface{}{"narrative": criteriaNarrative(src)},
+	}
+
+	// The subject has no id: the account ID is public (profile URLs), so
+	// the holder is only named by the salted identifier.
+	subject := map[string]interface{}{
+		"type":       []string{"AchievementSubject"},
+		"identifier": []map[string]interface{}{identityObject(src.UserID)},
+	}
+
+	if len(src.Dimensions) > 0 {
+		keys := make([]string, 0, len(src.Dimensions))
+		for k := range src.Dimensions {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		descriptions := make([]map[string]interface{}, 0, len(keys))
+		results := make([]map[string]interface{}, 0, len(keys))
+		for _, k := range keys {
+			rid := "urn:skillr:dimension:" + url.PathEscape(k)
+			descriptions = append(descriptions, map[string]interface{}{
+				"id":         rid,
+				"type":       []string{"ResultDescription"},
+				"name":       k,
+				"resultType": "Percent",
+			})
+			results = append(results, map[string]interface{}{
+				"type":              []string{"Result"},
+				"resultDescription": rid,
+				"value":             strconv.FormatFloat(skillscore.Clamp(src.Dimensions[k]), 'f', 0, 64),
+			})
+		}
+		achievement["resultDescription"] = descriptions
+		subject["result"] = results
+	}
+	subject["achievement"] = achievement
+
+	doc := map[string]interface{}{
+		"@context": []string{contextVC, contextOB3},
+		"id":       "urn:uuid:" + id.String(),
+		"type":     []string{"VerifiableCredential", "OpenBadgeCredential"},
+		"issuer": map[string]interface{}{
+			"id":   i.DID,
+			"type": []string{"Profile"},
+			"name": i.Name,
+			"url":  i.URL,
+		},
+		"name":              src.Name,
+		"validFrom":         issuedAt.UTC().Format(time.RFC3339),
+		"validUntil":        issuedAt.Add(defaultValidity).UTC().Format(time.RFC3339),
+		"credentialSubject": subject,
+	}
+	if src.Description != "" {
+		doc["evidence"] = []map[string]interface{}{{
+			"type":      []string{"Evidence"},
+			"name":      src.Name,
+			"narrative": src.Description,
+		}}
+	}
+	return doc
+}
+
+// Sign adds an eddsa-jcs-2022 Data Integrity proof to doc and returns the
+// signed document as JSON.
+func (i *Issuer) Sign(doc map[string]interface{}, at time.Time) ([]byte, error) {
+	proof := map[string]interface{}{
+		"type":               proofType,
+		"cryptosuite":        cryptosuite,
+		"created":            at.UTC().Format(time.RFC3339),
+		"verificationMethod": i.verificationMethod(i.KeyID()),
+		"proofPurpose":       proofPurpose,
+	}
+	hash, err := proofHash(doc, proof)
+	if err != nil {
+		return nil, err
+	}
+	proof["proofValue"] = signing.EncodeMultibase(i.keys.Active().Sign(hash))
+
+	signed := make(map[string]interface{}, len(doc)+1)
+	for k, v := range doc {
+		signed[k] = v
+	}
+	signed["proof"] = proof
+	return json.Marshal(signed)
+}
+
+// VerifyProof checks the Data Integrity proof of a signed credential. It
+// returns the key ID of the verification method on success.
+func (i *Issuer) VerifyProof(raw []byte) (string, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return "", fmt.Errorf("credential is not valid JSON")
+	}
+	proof, ok := doc["proof"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("credential has no proof")
+	}
+	delete(doc, "proof")
+
+	if proof["type"] != proofType || proof["cryptosuite"] != cryptosuite {
+		return "", fmt.Errorf("unsupported proof type")
+	}
+	if proof["proofPurpose"] != proofPurpose {
+		return "", fmt.Errorf("unexpected proof purpose")
+	}
+	vm, _ := proof["verificationMethod"].(string)
+	did, kid, found := strings.Cut(vm, "#")
+	if !found || did != i.DID {
+		return "", fmt.Errorf("credential was not issued by %s", i.DID)
+	}
+	key, ok := i.keys.Lookup(kid)
+	if !ok {
+		return kid, fmt.Errorf("unknown key %s", kid)
+	}
+	value, _ := proof["proofValue"].(string)
+	sig, err := signing.DecodeMultibase(value)
+	if err != nil {
+		return kid, fmt.Errorf("malformed proof value")
+	}
+
+	config := make(map[string]interface{}, len(proof))
+	for k, v := range proof {
+		if k != "proofValue" {
+			config[k] = v
+		}
+	}
+	hash, err := proofHash(doc, config)
+	if err != nil {
+		return kid, err
+	}
+	if !key.Verify(hash, sig) {
+		return kid, fmt.Errorf("signature does not match")
+	}
+	return kid, nil
+}
+
+// proofHash computes the eddsa-jcs-2022 hash data: the SHA-256 of the
+// canonical proof configuration followed by the SHA-256 of the canonical
+// unsecured document. The proof configuration carries the document context.
+func proofHash(doc, proofConfig map[string]interface{}) ([]byte, error) {
+	config := make(map[string]interface{}, len(proofConfig)+1)
+	for k, v := range proofConfig {
+		config[k] = v
+	}
+	config["@context"] = doc["@context"]
+
+	canonConfig, err := signing.Canonicalize(config)
+	if err != nil {
+		return nil, fmt.Errorf("canonicalize proof: %w", err)
+	}
+	canonDoc, err := signing.Canonicalize(doc)
+	if err != nil {
+		return nil, fmt.Errorf("canonicalize credential: %w", err)
+	}
+	configHash := sha256.Sum256(canonConfig)
+	docHash := sha256.Sum256(canonDoc)
+	return append(configHash[:], docHash[:]...), nil
+}
+
+func achievementType(t SubjectType) string {
+	switch t {
+	case SubjectLernreise:
+		return "LearningProgram"
+	case SubjectEndorsement:
+		return "Badge"
+	default:
+		return "Competency"
+	}
+}
+
+func criteriaNarrative(src *Source) string {
+	switch src.Type {
+	case SubjectLernreise:
+		return "Lernreise vollständig abgeschlossen."
+	case SubjectEndorsement:
+		who := src.EndorserName
+		if src.EndorserRole != "" {
+			who += " (" + src.EndorserRole + ")"
+		}
+		return "Kompetenzen bestätigt durch " + who + "."
+	default:
+		return "Nachweis aus einer dokumentierten Lernaktivität."
+	}
+}
@@ -0,0 +1,93 @@
+package credential
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubjectType is the kind of record a credential is issued for.
+type SubjectType string
+
+const (
+	SubjectEvidence    SubjectType = "evidence"
+	SubjectLernreise   SubjectType = "lernreise"
+	SubjectEndorsement SubjectType = "endorsement"
+)
+
+// Credential types as defined in specs/skill-wallet.allium.
+const (
+	TypeSkillAttestation  = "skill_attestation"
+	TypeJourneyCompletion = "journey_completion"
+	TypeEndorsementBadge  = "endorsement_badge"
+)
+
+// Credential statuses.
+const (
+	StatusActive  = "active"
+	StatusRevoked = "revoked"
+)
+
+// Credential is an issued, signed Open Badges 3.0 credential.
+type Credential struct {
+	ID               uuid.UUID       `json:"id"`
+	UserID           uuid.UUID       `json:"user_id"`
+	SubjectType      SubjectType     `json:"subject_type"`
+	SubjectID        uuid.UUID       `json:"subject_id"`
+	CredentialType   string          `json:"credential_type"`
+	KeyID            string          `json:"key_id"`
+	Status           string          `json:"status"`
+	Document         json.RawMessage `json:"document"`
+	IssuedAt         time.Time       `json:"issued_at"`
+	ExpiresAt        *time.Time      `json:"expires_at,omitempty"`
+	RevokedAt        *time.Time      `json:"revoked_at,omitempty"`
+	RevocationReason *string         `json:"revocation_reason,omitempty"`
+}
+
+// Source is the record a credential is built from, loaded by the repository
+// for evidence entries, completed Lernreisen and published endorsements.
+type Source struct {
+	Type        SubjectType
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	Dimensions  map[string]float64
+	// EndorserName and EndorserRole are set for endorsements.
+	EndorserName string
+	EndorserRole string
+	OccurredAt   time.Time
+}
+
+// IssueRequest asks for a credential for one of the learner's records.
+type IssueRequest struct {
+	SubjectType SubjectType `json:"subject_type"`
+	SubjectID   uuid.UUID   `json:"subject_id"`
+}
+
+// RevokeRequest carries the optional revocation reason.
+type RevokeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// VerificationResult is returned by the public verify endpoint.
+type VerificationResult struct {
+	Verified       bool       `json:"verified"`
+	SignatureValid bool       `json:"signature_valid"`
+	Revoked        bool       `json:"revoked"`
+	Expired        bool       `json:"expired"`
+	CredentialID   string     `json:"credential_id,omitempty"`
+	Issuer         string     `json:"issuer,omitempty"`
+	KeyID          string     `json:"key_id,omitempty"`
+	IssuedAt       *time.Time `json:"issued_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	Errors         []string   `json:"errors,omitempty"`
+}
+
+// ListParams filters the learner's credential list.
+type ListParams struct {
+	UserID uuid.UUID
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,21 @@
+package credential
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// LoadSource returns the learner's record for a credential subject.
+	// Lernreisen must be completed and endorsements published (accepted,
+	// visible and not moderated).
+	LoadSource(ctx context.Context, userID uuid.UUID, subjectType SubjectType, subjectID uuid.UUID) (*Source, error)
+	Create(ctx context.Context, c *Credential) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Credential, error)
+	// GetActiveBySubject returns the active credential for a subject, or nil.
+	GetActiveBySubject(ctx context.Context, userID uuid.UUID, subjectType SubjectType, subjectID uuid.UUID) (*Credential, error)
+	List(ctx context.Context, params ListParams) ([]Credential, int, error)
+	Revoke(ctx context.Context, id, userID uuid.UUID, reason string, at time.Time) error
+}
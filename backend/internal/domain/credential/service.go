@@ -0,0 +1,193 @@
+package credential
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	repo   Repository
+	issuer *Issuer
+}
+
+func NewService(repo Repository, issuer *Issuer) *Service {
+	return &Service{repo: repo, issuer: issuer}
+}
+
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
+}
+
+// Issuer returns the signing identity.
+func (s *Service) Issuer() *Issuer {
+	return s.issuer
+}
+
+// Issue signs a credential for one of the learner's records. If an active
+// credential already exists for the record, it is returned unchanged.
+func (s *Service) Issue(ctx context.Context, userID uuid.UUID, req IssueRequest) (*Credential, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	credType, ok := credentialTypes[req.SubjectType]
+	if !ok {
+		return nil, fmt.Errorf("subject_type must be one of evidence, lernreise, endorsement")
+	}
+	if req.SubjectID == uuid.Nil {
+		return nil, fmt.Errorf("subject_id is required")
+	}
+
+	existing, err := s.repo.GetActiveBySubject(ctx, userID, req.SubjectType, req.SubjectID)
+	if err != nil {
+		return nil, fmt.Errorf("lookup credential: %w", err)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	src, err := s.repo.LoadSource(ctx, userID, req.SubjectType, req.SubjectID)
+	if err != nil {
+		return nil, fmt.Errorf("load %s: %w", req.SubjectType, err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	id := uuid.New()
+	doc, err := s.issuer.Sign(s.issuer.Build(id, src, now), now)
+	if err != nil {
+		return nil, fmt.Errorf("sign credential: %w", err)
+	}
+	expires := now.Add(defaultValidity)
+	c := &Credential{
+		ID:             id,
+		UserID:         userID,
+		SubjectType:    req.SubjectType,
+		SubjectID:      req.SubjectID,
+		CredentialType: credType,
+		KeyID:          s.issuer.KeyID(),
+		Status:         StatusActive,
+		Document:       doc,
+		IssuedAt:       now,
+		ExpiresAt:      &expires,
+	}
+	if err := s.repo.Create(ctx, c); err != nil {
+		return nil, fmt.Errorf("store credential: %w", err)
+	}
+	return c, nil
+}
+
+var credentialTypes = map[SubjectType]string{
+	SubjectEvidence:    TypeSkillAttestation,
+	SubjectLernreise:   TypeJourneyCompletion,
+	SubjectEndorsement: TypeEndorsementBadge,
+}
+
+func (s *Service) List(ctx context.Context, params ListParams) ([]Credential, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
+	return s.repo.List(ctx, params)
+}
+
+// Get returns one of the learner's credentials.
+func (s *Service) Get(ctx context.Context, userID, id uuid.UUID) (*Credential, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	c, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserID != userID {
+		return nil, fmt.Errorf("credential not found")
+	}
+	return c, nil
+}
+
+// Revoke marks one of the learner's credentials as revoked.
+func (s *Service) Revoke(ctx context.Context, userID, id uuid.UUID, reason string) (*Credential, error) {
+	c, err := s.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.Status == StatusRevoked {
+		return c, nil
+	}
+	if reason == "" {
+		reason = "holder_request"
+	}
+	now := time.Now().UTC()
+	if err := s.repo.Revoke(ctx, id, userID, reason, now); err != nil {
+		return nil, fmt.Errorf("revoke credential: %w", err)
+	}
+	c.Status = StatusRevoked
+	c.RevokedAt = &now
+	c.RevocationReason = &reason
+	return c, nil
+}
+
+// Verify checks the signature of a presented credential and looks up its
+// revocation status.
+func (s *Service) Verify(ctx context.Context, raw []byte) *VerificationResult {
+	res := &VerificationResult{Issuer: s.issuer.DID}
+
+	kid, err := s.issuer.VerifyProof(raw)
+	res.KeyID = kid
+	if err != nil {
+		res.Errors = append(res.Errors, err.Error())
+	} else {
+		res.SignatureValid = true
+	}
+
+	var doc struct {
+		ID         string `json:"id"`
+		ValidUntil string `json:"validUntil"`
+	}
+	_ = json.Unmarshal(raw, &doc)
+	res.CredentialID = doc.ID
+	if until, err := time.Parse(time.RFC3339, doc.ValidUntil); err == nil && time.Now().After(until) {
+		res.Expired = true
+		res.Errors = append(res.Errors, "credential has expired")
+	}
+
+	id, err := uuid.Parse(strings.TrimPrefix(doc.ID, "urn:uuid:"))
+	switch {
+	case err != nil:
+		res.Errors = append(res.Errors, "credential id is not a urn:uuid")
+	case s.repo == nil:
+		res.Errors = append(res.Errors, "revocation status unavailable")
+	default:
+		stored, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			res.Errors = append(res.Errors, "credential not found in issuer registry")
+			break
+		}
+		res.IssuedAt = &stored.IssuedAt
+		if stored.Status == StatusRevoked {
+			res.Revoked = true
+			res.RevokedAt = stored.RevokedAt
+			res.Errors = append(res.Errors, "credential has been revoked")
+		}
+	}
+
+	res.Verified = len(res.Errors) == 0
+	return res
+}
+
+// VerifyByID verifies a stored credential, used by links and QR codes that
+// only carry the credential ID.
+func (s *Service) VerifyByID(ctx context.Context, id uuid.UUID) (*VerificationResult, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	c, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.Verify(ctx, c.Document), nil
+}
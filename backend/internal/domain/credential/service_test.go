@@ -0,0 +1,249 @@
+package credential
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/signing"
+)
+
+type mockRepo struct {
+	sources     map[uuid.UUID]*Source
+	credentials map[uuid.UUID]*Credential
+}
+
+func newMockRepo() *mockRepo {
+	return &mockRepo{sources: map[uuid.UUID]*Source{}, credentials: map[uuid.UUID]*Credential{}}
+}
+
+func (m *mockRepo) LoadSource(_ context.Context, userID uuid.UUID, t SubjectType, id uuid.UUID) (*Source, error) {
+	src, ok := m.sources[id]
+	if !ok || src.UserID != userID || src.Type != t {
+		return nil, fmt.Errorf("not found")
+	}
+	return src, nil
+}
+
+func (m *mockRepo) Create(_ context.Context, c *Credential) error {
+	m.credentials[c.ID] = c
+	return nil
+}
+
+func (m *mockRepo) GetByID(_ context.Context, id uuid.UUID) (*Credential, error) {
+	c, ok := m.credentials[id]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	cp := *c
+	return &cp, nil
+}
+
+func (m *mockRepo) GetActiveBySubject(_ context.Context, userID uuid.UUID, t SubjectType, id uuid.UUID) (*Credential, error) {
+	for _, c := range m.credentials {
+		if c.UserID == userID && c.SubjectType == t && c.SubjectID == id && c.Status == StatusActive {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepo) List(_ context.Context, params ListParams) ([]Credential, int, error) {
+	var out []Credential
+	for _, c := range m.credentials {
+		if c.UserID == params.UserID {
+			out = append(out, *c)
+		}
+	}
+	return out, len(out), nil
+}
+
+func (m *mockRepo) Revoke(_ context.Context, id, userID uuid.UUID, reason string, at time.Time) error {
+	c, ok := m.credentials[id]
+	if !ok || c.UserID != userID {
+		return fmt.Errorf("not found")
+	}
+	c.Status = StatusRevoked
+	c.RevokedAt = &at
+	c.RevocationReason = &reason
+	return nil
+}
+
+func testIssuer(t *testing.T) *Issuer {
+	t.Helper()
+	key, err := signing.NewKey(bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := NewIssuer("https://skillr.example", "maindset.ACADEMY", signing.NewKeyRing(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return issuer
+}
+
+func seedEvidence(repo *mockRepo, userID uuid.UUID) uuid.UUID {
+	id := uuid.New()
+	repo.sources[id] = &Source{
+		Type:        SubjectEvidence,
+		ID:          id,
+		UserID:      userID,
+		Name:        "Kompetenznachweis",
+		Description: "Hat ein Teamprojekt geleitet.",
+		Dimensions:  map[string]float64{"teamwork": 0.8, "creativity": 65},
+		OccurredAt:  time.Now(),
+	}
+	return id
+}
+
+func TestDIDWeb(t *testing.T) {
+	cases := map[string]string{
+		"https://skillr.de":               "did:web:skillr.de",
+		"http://localhost:8080/":          "did:web:localhost%3A8080",
+		"https://example.com/issuers/sk1": "did:web:example.com:issuers:sk1",
+	}
+	for in, want := range cases {
+		got, err := DIDWeb(in)
+		if err != nil || got != want {
+			t.Errorf("DIDWeb(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := DIDWeb("not a url"); err == nil {
+		t.Error("expected error for invalid URL")
+	}
+}
+
+func TestService_IssueAndVerify(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo, testIssuer(t))
+	userID := uuid.New()
+	evID := seedEvidence(repo, userID)
+
+	cred, err := svc.Issue(context.Background(), userID, IssueRequest{SubjectType: SubjectEvidence, SubjectID: evID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cred.CredentialType != TypeSkillAttestation || cred.Status != StatusActive {
+		t.Errorf("unexpected credential %+v", cred)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(cred.Document, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["id"] != "urn:uuid:"+cred.ID.String() {
+		t.Errorf("unexpected credential id %v", doc["id"])
+	}
+	subject := doc["credentialSubject"].(map[string]interface{})
+	if results := subject["result"].([]interface{}); len(results) != 2 {
+		t.Errorf("expected 2 results, got %d", len(results))
+	}
+	if _, ok := subject["id"]; ok || strings.Contains(string(cred.Document), userID.String()) {
+		t.Errorf("credential must not contain the account ID: %s", cred.Document)
+	}
+	ident := subject["identifier"].([]interface{})[0].(map[string]interface{})
+	sum := sha256.Sum256([]byte(userID.String() + ident["salt"].(string)))
+	if ident["hashed"] != true || ident["identityType"] != "systemId" || ident["identityHash"] != "sha256$"+hex.EncodeToString(sum[:]) {
+		t.Errorf("unexpected identifier %v", ident)
+	}
+
+	res := svc.Verify(context.Background(), cred.Document)
+	if !res.Verified || !res.SignatureValid || res.Revoked {
+		t.Fatalf("expected verified credential, got %+v", res)
+	}
+	if res.KeyID != svc.Issuer().KeyID() {
+		t.Errorf("expected key %s, got %s", svc.Issuer().KeyID(), res.KeyID)
+	}
+
+	again, err := svc.Issue(context.Background(), userID, IssueRequest{SubjectType: SubjectEvidence, SubjectID: evID})
+	if err != nil || again.ID != cred.ID {
+		t.Errorf("expected existing active credential to be returned")
+	}
+}
+
+func TestService_VerifyDetectsTampering(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo, testIssuer(t))
+	userID := uuid.New()
+	cred, err := svc.Issue(context.Background(), userID, IssueRequest{SubjectType: SubjectEvidence, SubjectID: seedEvidence(repo, userID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered := strings.Replace(string(cred.Document), "Teamprojekt", "Weltkonzern", 1)
+	res := svc.Verify(context.Background(), []byte(tampered))
+	if res.Verified || res.SignatureValid {
+		t.Errorf("expected tampered credential to fail, got %+v", res)
+	}
+
+	// A credential signed by a different key for the same DID is rejected.
+	otherKey, _ := signing.NewKey(bytes.Repeat([]byte{2}, 32))
+	other, _ := NewIssuer("https://skillr.example", "maindset.ACADEMY", signing.NewKeyRing(otherKey))
+	forged, _ := other.Sign(other.Build(cred.ID, repo.sources[cred.SubjectID], time.Now()), time.Now())
+	if res := svc.Verify(context.Background(), forged); res.SignatureValid {
+		t.Error("expected forged credential to fail")
+	}
+}
+
+func TestService_RevokeFailsVerification(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo, testIssuer(t))
+	userID := uuid.New()
+	cred, err := svc.Issue(context.Background(), userID, IssueRequest{SubjectType: SubjectEvidence, SubjectID: seedEvidence(repo, userID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.Revoke(context.Background(), uuid.New(), cred.ID, ""); err == nil {
+		t.Error("expected error when revoking another user's credential")
+	}
+	revoked, err := svc.Revoke(context.Background(), userID, cred.ID, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if revoked.Status != StatusRevoked || *revoked.RevocationReason != "holder_request" {
+		t.Errorf("unexpected revoked credential %+v", revoked)
+	}
+
+	res, err := svc.VerifyByID(context.Background(), cred.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Verified || !res.SignatureValid || !res.Revoked {
+		t.Errorf("expected valid signature but revoked, got %+v", res)
+	}
+}
+
+func TestService_IssueValidation(t *testing.T) {
+	svc := NewService(newMockRepo(), testIssuer(t))
+	if _, err := svc.Issue(context.Background(), uuid.New(), IssueRequest{SubjectType: "badge", SubjectID: uuid.New()}); err == nil {
+		t.Error("expected error for unknown subject type")
+	}
+	if _, err := svc.Issue(context.Background(), uuid.New(), IssueRequest{SubjectType: SubjectLernreise, SubjectID: uuid.New()}); err == nil {
+		t.Error("expected error for missing source")
+	}
+	if _, err := NewService(nil, testIssuer(t)).Issue(context.Background(), uuid.New(), IssueRequest{}); err == nil {
+		t.Error("expected error without database")
+	}
+}
+
+func TestIssuer_DIDDocument(t *testing.T) {
+	issuer := testIssuer(t)
+	doc := issuer.DIDDocument()
+	if doc["id"] != "did:web:skillr.example" {
+		t.Errorf("unexpected DID %v", doc["id"])
+	}
+	vm := doc["verificationMethod"].([]map[string]interface{})[0]
+	pub, err := signing.DecodeMultibase(vm["publicKeyMultibase"].(string))
+	if err != nil || len(pub) != 34 || pub[0] != 0xed || pub[1] != 0x01 {
+		t.Errorf("expected multicodec ed25519 public key, got %x (%v)", pub, err)
+	}
+}
@@ -1,6 +1,8 @@
 package endorsement
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -42,12 +44,107 @@ func (h *Handler) Submit(c echo.Context) error {
 	}
 
 	endorsement, err := h.svc.Submit(c.Request().Context(), req)
+	if errors.Is(err, ErrEndorserBlocked) {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 	return c.JSON(http.StatusCreated, endorsement)
 }
 
+// Rubric returns the rubric of an invite for the endorsement form. The
+// token is sent in the body to keep it out of URLs and logs.
+func (h *Handler) Rubric(c echo.Context) error {
+	var req RubricLookupRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	rubric, err := h.svc.Rubric(c.Request().Context(), req.InvitationToken)
+	if errors.Is(err, ErrRubricNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "invite has no rubric")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusOK, rubric)
+}
+
+// AdminListRubrics lists rubric versions (?role=&active=true).
+func (h *Handler) AdminListRubrics(c echo.Context) error {
+	rubrics, err := h.svc.ListRubrics(c.Request().Context(), c.QueryParam("role"), c.QueryParam("active") == "true")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list rubrics")
+	}
+	if rubrics == nil {
+		rubrics = []Rubric{}
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"rubrics": rubrics, "total": len(rubrics)})
+}
+
+// AdminCreateRubric publishes a new rubric version for a role.
+func (h *Handler) AdminCreateRubric(c echo.Context) error {
+	var req RubricRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	rubric, err := h.svc.CreateRubric(c.Request().Context(), req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusCreated, rubric)
+}
+
+// AdminDeactivateRubric retires a rubric version.
+func (h *Handler) AdminDeactivateRubric(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid rubric ID")
+	}
+
+	err = h.svc.DeactivateRubric(c.Request().Context(), id)
+	if errors.Is(err, ErrRubricNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "rubric not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to deactivate rubric")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RequestVerification mails a one-time code to the invited endorser.
+func (h *Handler) RequestVerification(c echo.Context) error {
+	var req VerificationCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	err := h.svc.RequestVerification(c.Request().Context(), req)
+	if errors.Is(err, ErrMailUnavailable) {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusAccepted, map[string]interface{}{"ok": true})
+}
+
+// Verify confirms the one-time code of an invite.
+func (h *Handler) Verify(c echo.Context) error {
+	var req VerifyRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	invite, err := h.svc.Verify(c.Request().Context(), req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"verified": true, "email_verified_at": invite.EmailVerifiedAt})
+}
+
 func (h *Handler) Invite(c echo.Context) error {
 	userInfo := middleware.GetUserInfo(c)
 	if userInfo == nil {
@@ -61,6 +158,9 @@ func (h *Handler) Invite(c echo.Context) error {
 	}
 
 	invite, err := h.svc.Invite(c.Request().Context(), userID, req)
+	if errors.Is(err, ErrEndorserBlocked) {
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
@@ -81,6 +181,55 @@ func (h *Handler) Pending(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]interface{}{"invitations": invites, "total": total})
 }
 
+// Revoke invalidates a pending invite.
+func (h *Handler) Revoke(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	userID := deriveUUID(userInfo.UID)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid invite ID")
+	}
+	if err := h.svc.Revoke(c.Request().Context(), userID, id); err != nil {
+		return inviteError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Resend mails a pending or expired invite again.
+func (h *Handler) Resend(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	userID := deriveUUID(userInfo.UID)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid invite ID")
+	}
+	invite, err := h.svc.Resend(c.Request().Context(), userID, id)
+	if err != nil {
+		return inviteError(err)
+	}
+	return c.JSON(http.StatusOK, invite)
+}
+
+func inviteError(err error) error {
+	switch {
+	case errors.Is(err, ErrInviteNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrInviteClosed):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, ErrResendTooSoon):
+		return echo.NewHTTPError(http.StatusTooManyRequests, err.Error())
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, "failed to update invite")
+}
+
 func (h *Handler) Visibility(c echo.Context) error {
 	userInfo := middleware.GetUserInfo(c)
 	if userInfo == nil {
@@ -100,11 +249,206 @@ func (h *Handler) Visibility(c echo.Context) error {
 
 	endorsement, err := h.svc.Visibility(c.Request().Context(), id, userID, req.Visible)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, "endorsement not found")
+		return endorsementError(err)
+	}
+	return c.JSON(http.StatusOK, endorsement)
+}
+
+// Accept publishes a held endorsement (body {"visible": false} keeps it
+// private).
+func (h *Handler) Accept(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	userID := deriveUUID(userInfo.UID)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid endorsement ID")
+	}
+	var req DecisionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	visible := req.Visible == nil || *req.Visible
+
+	endorsement, err := h.svc.Accept(c.Request().Context(), userID, id, visible)
+	if err != nil {
+		return endorsementError(err)
+	}
+	return c.JSON(http.StatusOK, endorsement)
+}
+
+// Decline rejects a held or accepted endorsement.
+func (h *Handler) Decline(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	userID := deriveUUID(userInfo.UID)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid endorsement ID")
+	}
+	endorsement, err := h.svc.Decline(c.Request().Context(), userID, id)
+	if err != nil {
+		return endorsementError(err)
+	}
+	return c.JSON(http.StatusOK, endorsement)
+}
+
+// Report files an abuse report on one of the learner's endorsements.
+func (h *Handler) Report(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	userID := deriveUUID(userInfo.UID)
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid endorsement ID")
+	}
+	var req ReportRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	report, err := h.svc.Report(c.Request().Context(), userID, id, req)
+	if err != nil {
+		return endorsementError(err)
+	}
+	return c.JSON(http.StatusCreated, report)
+}
+
+// AdminModerationQueue lists endorsements awaiting or after moderation
+// (?status=flagged|hidden|deleted).
+func (h *Handler) AdminModerationQueue(c echo.Context) error {
+	limit := intQuery(c, "limit", 50)
+	if limit > 200 {
+		limit = 200
+	}
+	cases, total, err := h.svc.ModerationQueue(c.Request().Context(), c.QueryParam("status"), limit, intQuery(c, "offset", 0))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if cases == nil {
+		cases = []ModerationCase{}
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"endorsements": cases, "total": total})
+}
+
+// AdminHide withdraws an endorsement from the public profile.
+func (h *Handler) AdminHide(c echo.Context) error {
+	return h.moderate(c, h.svc.Hide)
+}
+
+// AdminDelete removes an endorsement for the learner too.
+func (h *Handler) AdminDelete(c echo.Context) error {
+	return h.moderate(c, h.svc.Delete)
+}
+
+// AdminRestore clears a flagged, hidden or deleted endorsement.
+func (h *Handler) AdminRestore(c echo.Context) error {
+	return h.moderate(c, h.svc.Restore)
+}
+
+func (h *Handler) moderate(c echo.Context, action func(ctx context.Context, adminID, id uuid.UUID, reason string) (*Endorsement, error)) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid endorsement ID")
+	}
+	var req ModerationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	endorsement, err := action(c.Request().Context(), deriveUUID(userInfo.UID), id, req.Reason)
+	if err != nil {
+		return endorsementError(err)
 	}
 	return c.JSON(http.StatusOK, endorsement)
 }
 
+// AdminModerationLog returns the decisions on an endorsement.
+func (h *Handler) AdminModerationLog(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid endorsement ID")
+	}
+	audit, err := h.svc.ModerationLog(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to load moderation log")
+	}
+	if audit == nil {
+		audit = []ModerationEntry{}
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"audit": audit, "total": len(audit)})
+}
+
+func (h *Handler) AdminListBlockedEmails(c echo.Context) error {
+	blocked, err := h.svc.BlockedEmails(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list blocked addresses")
+	}
+	if blocked == nil {
+		blocked = []BlockedEmail{}
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"blocked": blocked, "total": len(blocked)})
+}
+
+// AdminBlockEmail blocks an endorser address.
+func (h *Handler) AdminBlockEmail(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	var req BlockEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	blocked, err := h.svc.BlockEmail(c.Request().Context(), deriveUUID(userInfo.UID), req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusCreated, blocked)
+}
+
+// AdminUnblockEmail lifts a block.
+func (h *Handler) AdminUnblockEmail(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	err := h.svc.UnblockEmail(c.Request().Context(), deriveUUID(userInfo.UID), c.Param("email"))
+	if errors.Is(err, ErrNotBlocked) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to unblock address")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func endorsementError(err error) error {
+	switch {
+	case errors.Is(err, ErrEndorsementNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrUnderReview), errors.Is(err, ErrNotAccepted):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, ErrInvalidReport):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, "failed to update endorsement")
+}
+
 func deriveUUID(firebaseUID string) uuid.UUID {
 	return uuid.NewSHA1(uuid.NameSpaceDNS, []byte(firebaseUID))
 }
@@ -0,0 +1,177 @@
+package endorsement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/mail"
+)
+
+var (
+	ErrInviteNotFound = errors.New("invite not found")
+	ErrInviteClosed   = errors.New("invite is no longer pending")
+	ErrResendTooSoon  = errors.New("invite was sent moments ago")
+)
+
+const (
+	inviteTTL = 30 * 24 * time.Hour
+	// resendCooldown keeps learners from flooding an endorser's inbox.
+	resendCooldown = 10 * time.Minute
+	// maxReminders is the number of automatic reminders per delivery.
+	maxReminders  = 2
+	reminderBatch = 100
+)
+
+// SetReminderAfter enables automatic reminders for invites that have not
+// been answered this long after the last mail; zero disables them.
+func (s *Service) SetReminderAfter(d time.Duration) {
+	s.reminderAfter = d
+}
+
+// Revoke invalidates a pending invite; its link stops working.
+func (s *Service) Revoke(ctx context.Context, learnerID, inviteID uuid.UUID) error {
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	invite, err := s.repo.GetInvite(ctx, inviteID, learnerID)
+	if err != nil {
+		return fmt.Errorf("get invite: %w", err)
+	}
+	if invite == nil {
+		return ErrInviteNotFound
+	}
+	ok, err := s.repo.RevokeInvite(ctx, inviteID, learnerID)
+	if err != nil {
+		return fmt.Errorf("revoke invite: %w", err)
+	}
+	if !ok {
+		return ErrInviteClosed
+	}
+	s.recordEvent(ctx, inviteID, EventRevoked, nil, time.Now().UTC())
+	return nil
+}
+
+// Resend mails a pending or expired invite again with the same link and a
+// fresh expiry. Reminders start over.
+func (s *Service) Resend(ctx context.Context, learnerID, inviteID uuid.UUID) (*EndorsementInvite, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	invite, err := s.repo.GetInvite(ctx, inviteID, learnerID)
+	if err != nil {
+		return nil, fmt.Errorf("get invite: %w", err)
+	}
+	if invite == nil {
+		return nil, ErrInviteNotFound
+	}
+	if invite.Status != InvitePending && invite.Status != InviteExpired {
+		return nil, ErrInviteClosed
+	}
+	now := time.Now().UTC()
+	if invite.Status == InvitePending && invite.LastSentAt != nil && now.Sub(*invite.LastSentAt) < resendCooldown {
+		return nil, ErrResendTooSoon
+	}
+
+	invite.Status = InvitePending
+	invite.ExpiresAt = now.Add(inviteTTL)
+	invite.LastSentAt = &now
+	invite.ReminderCount = 0
+	invite.InviteURL = inviteURL(invite.Token)
+	invite.QRCodeURL = qrCodeURL(invite.ID)
+	if err := s.repo.RenewInvite(ctx, invite.ID, invite.ExpiresAt, now); err != nil {
+		return nil, fmt.Errorf("renew invite: %w", err)
+	}
+	mailID := s.sendInvite(ctx, invite, mail.TemplateEndorsementInvite)
+	s.recordEvent(ctx, invite.ID, EventResent, mailID, now)
+	return invite, nil
+}
+
+// InviteLink returns the absolute endorsement link of the learner's
+// pending invite, e.g. for rendering it as a QR code.
+func (s *Service) InviteLink(ctx context.Context, learnerID, inviteID uuid.UUID) (string, error) {
+	if s.repo == nil {
+		return "", fmt.Errorf("database not available")
+	}
+	invite, err := s.repo.GetInvite(ctx, inviteID, learnerID)
+	if err != nil {
+		return "", fmt.Errorf("get invite: %w", err)
+	}
+	if invite == nil {
+		return "", ErrInviteNotFound
+	}
+	if invite.Status != InvitePending || !time.Now().Before(invite.ExpiresAt) {
+		return "", ErrInviteClosed
+	}
+	return s.baseURL + inviteURL(invite.Token), nil
+}
+
+// Run expires overdue invites and sends due reminders every interval until
+// ctx is cancelled.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.ExpireInvites(ctx); err != nil {
+			log.Printf("[endorsement] expire invites: %v", err)
+		} else if n > 0 {
+			log.Printf("[endorsement] expired %d invites", n)
+		}
+		if n, err := s.SendReminders(ctx); err != nil {
+			log.Printf("[endorsement] send reminders: %v", err)
+		} else if n > 0 {
+			log.Printf("[endorsement] sent %d invite reminders", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExpireInvites marks pending invites past their expiry as expired.
+func (s *Service) ExpireInvites(ctx context.Context) (int, error) {
+	if s.repo == nil {
+		return 0, nil
+	}
+	now := time.Now().UTC()
+	ids, err := s.repo.ExpireInvites(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		s.recordEvent(ctx, id, EventExpired, nil, now)
+	}
+	return len(ids), nil
+}
+
+// SendReminders mails a reminder for invites unanswered since the last
+// delivery, at most maxReminders times per delivery. Invites are claimed
+// before the mail is queued so that several instances never remind the same
+// endorser twice; a reminder whose mail fails to queue is not retried.
+func (s *Service) SendReminders(ctx context.Context) (int, error) {
+	if s.repo == nil || s.mailer == nil || s.reminderAfter <= 0 {
+		return 0, nil
+	}
+	now := time.Now().UTC()
+	due, err := s.repo.ClaimReminders(ctx, now.Add(-s.reminderAfter), now, maxReminders, reminderBatch)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for i := range due {
+		invite := &due[i]
+		mailID := s.sendInvite(ctx, invite, mail.TemplateReminder)
+		if mailID == nil {
+			continue
+		}
+		s.recordEvent(ctx, invite.ID, EventReminder, mailID, now)
+		sent++
+	}
+	return sent, nil
+}
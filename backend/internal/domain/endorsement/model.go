@@ -7,39 +7,126 @@ import (
 )
 
 type Endorsement struct {
-	ID               uuid.UUID          `json:"id"`
-	LearnerID        uuid.UUID          `json:"learner_id"`
-	EndorserID       *uuid.UUID         `json:"endorser_id,omitempty"`
-	EndorserName     string             `json:"endorser_name"`
-	EndorserRole     string             `json:"endorser_role"`
-	EndorserVerified bool               `json:"endorser_verified"`
-	SkillDimensions  map[string]float64 `json:"skill_dimensions,omitempty"`
-	Statement        string             `json:"statement"`
-	Context          *string            `json:"context,omitempty"`
-	ArtifactRefs     []uuid.UUID        `json:"artifact_refs,omitempty"`
-	Visible          bool               `json:"visible"`
-	CreatedAt        time.Time          `json:"created_at"`
+	ID               uuid.UUID  `json:"id"`
+	LearnerID        uuid.UUID  `json:"learner_id"`
+	EndorserID       *uuid.UUID `json:"endorser_id,omitempty"`
+	EndorserName     string     `json:"endorser_name"`
+	EndorserRole     string     `json:"endorser_role"`
+	EndorserVerified bool       `json:"endorser_verified"`
+	// VerificationMethod is set for verified endorsers: "email" after a
+	// confirmed one-time code, "organization" if the address is also on a
+	// verified organisation domain.
+	VerificationMethod   string             `json:"verification_method,omitempty"`
+	EndorserOrganization *string            `json:"endorser_organization,omitempty"`
+	SkillDimensions      map[string]float64 `json:"skill_dimensions,omitempty"`
+	// RubricID is the rubric the endorsement was given on; Ratings holds
+	// the raw scale values behind SkillDimensions (mapped onto 0-100) and
+	// Answers the responses to the rubric's prompts.
+	RubricID     *uuid.UUID        `json:"rubric_id,omitempty"`
+	Ratings      map[string]int    `json:"ratings,omitempty"`
+	Answers      map[string]string `json:"answers,omitempty"`
+	Statement    string            `json:"statement"`
+	Context      *string           `json:"context,omitempty"`
+	ArtifactRefs []uuid.UUID       `json:"artifact_refs,omitempty"`
+	// Visible is the learner's choice; an endorsement is only public if it
+	// is also accepted and its moderation status is clear.
+	Visible bool `json:"visible"`
+	// EndorserEmail is the invited address (empty for older endorsements).
+	EndorserEmail    string     `json:"endorser_email,omitempty"`
+	Status           string     `json:"status"`
+	ModerationStatus string     `json:"moderation_status"`
+	ScreeningFlags   []string   `json:"screening_flags,omitempty"`
+	DecidedAt        *time.Time `json:"decided_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
 }
 
+// Endorsement statuses: the learner's decision on a submitted endorsement.
+const (
+	StatusPending  = "pending"
+	StatusAccepted = "accepted"
+	StatusDeclined = "declined"
+)
+
+// Moderation statuses. Flagged endorsements (by screening or a report)
+// wait for an admin; flagged and deleted ones are not shown to the learner.
+const (
+	ModerationClear   = "clear"
+	ModerationFlagged = "flagged"
+	ModerationHidden  = "hidden"
+	ModerationDeleted = "deleted"
+)
+
+// Verification methods.
+const (
+	VerifiedByEmail        = "email"
+	VerifiedByOrganization = "organization"
+)
+
 type EndorsementInvite struct {
 	ID            uuid.UUID `json:"id"`
 	LearnerID     uuid.UUID `json:"learner_id"`
 	EndorserEmail string    `json:"endorser_email"`
 	EndorserRole  string    `json:"endorser_role"`
 	Status        string    `json:"status"`
+	Message       *string   `json:"message,omitempty"`
+	Locale        string    `json:"locale,omitempty"`
+	Token         string    `json:"-"`
 	InviteURL     string    `json:"invite_url,omitempty"`
 	QRCodeURL     string    `json:"qr_code_url,omitempty"`
 	CreatedAt     time.Time `json:"created_at"`
 	ExpiresAt     time.Time `json:"expires_at"`
+	// EmailVerifiedAt is set once the endorser confirmed the one-time code.
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// LastSentAt is the last delivery (initial mail, resend or reminder);
+	// ReminderCount counts automatic reminders since the last resend.
+	LastSentAt    *time.Time    `json:"last_sent_at,omitempty"`
+	ReminderCount int           `json:"reminder_count"`
+	History       []InviteEvent `json:"history,omitempty"`
+	// RubricID locks the rubric that was active for the role when the
+	// invite was created; nil for roles without a rubric.
+	RubricID *uuid.UUID `json:"rubric_id,omitempty"`
+	Rubric   *Rubric    `json:"rubric,omitempty"`
+}
+
+// Invite statuses.
+const (
+	InvitePending   = "pending"
+	InviteCompleted = "completed"
+	InviteExpired   = "expired"
+	InviteRevoked   = "revoked"
+)
+
+// Invite event kinds.
+const (
+	EventCreated  = "created"
+	EventResent   = "resent"
+	EventReminder = "reminder"
+	EventRevoked  = "revoked"
+	EventExpired  = "expired"
+)
+
+// InviteEvent is one entry of an invite's history. MailStatus is the outbox
+// status of the mail sent with the event (pending, sent, failed, bounced,
+// suppressed).
+type InviteEvent struct {
+	Kind       string     `json:"kind"`
+	MailID     *uuid.UUID `json:"mail_id,omitempty"`
+	MailStatus string     `json:"mail_status,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
 }
 
 type SubmitEndorsementRequest struct {
-	InvitationToken string             `json:"invitation_token"`
-	EndorserName    string             `json:"endorser_name"`
-	EndorserRole    string             `json:"endorser_role"`
+	InvitationToken string `json:"invitation_token"`
+	EndorserName    string `json:"endorser_name"`
+	EndorserRole    string `json:"endorser_role"`
+	// SkillDimensions are free-form scores for invites without a rubric.
 	SkillDimensions map[string]float64 `json:"skill_dimensions"`
-	Statement       string             `json:"statement"`
-	Context         *string            `json:"context,omitempty"`
+	// Ratings (dimension key to scale value) and Answers (prompt key to
+	// text) are required for invites with a rubric.
+	Ratings   map[string]int    `json:"ratings,omitempty"`
+	Answers   map[string]string `json:"answers,omitempty"`
+	Statement string            `json:"statement"`
+	Context   *string           `json:"context,omitempty"`
 }
 
 type EndorsementInviteRequest struct {
@@ -47,6 +134,159 @@ type EndorsementInviteRequest struct {
 	EndorserRole    string   `json:"endorser_role"`
 	Message         *string  `json:"message,omitempty"`
 	SkillDimensions []string `json:"skill_dimensions,omitempty"`
+	// Locale of the invite mail (default "de").
+	Locale string `json:"locale,omitempty"`
+}
+
+// VerificationCodeRequest asks for a one-time code mailed to the invited
+// address.
+type VerificationCodeRequest struct {
+	InvitationToken string `json:"invitation_token"`
+	Locale          string `json:"locale,omitempty"`
+}
+
+// VerifyRequest confirms the one-time code.
+type VerifyRequest struct {
+	InvitationToken string `json:"invitation_token"`
+	Code            string `json:"code"`
+}
+
+// Rubric is a versioned rating template for an endorser role. Texts are
+// keyed by locale.
+type Rubric struct {
+	ID         uuid.UUID         `json:"id"`
+	Role       string            `json:"role"`
+	Version    int               `json:"version"`
+	Name       map[string]string `json:"name"`
+	Scale      RatingScale       `json:"scale"`
+	Dimensions []RubricDimension `json:"dimensions"`
+	Prompts    []RubricPrompt    `json:"prompts,omitempty"`
+	Active     bool              `json:"active"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// RatingScale lists the levels in ascending order of value.
+type RatingScale struct {
+	Levels []ScaleLevel `json:"levels"`
+}
+
+type ScaleLevel struct {
+	Value      int               `json:"value"`
+	Label      map[string]string `json:"label"`
+	Descriptor map[string]string `json:"descriptor,omitempty"`
+}
+
+// RubricDimension is a rated taxonomy dimension. Optional dimensions may be
+// left out if the endorser could not observe them.
+type RubricDimension struct {
+	Key         string            `json:"key"`
+	Required    bool              `json:"required"`
+	Description map[string]string `json:"description,omitempty"`
+}
+
+// RubricPrompt is a free-text question.
+type RubricPrompt struct {
+	Key       string            `json:"key"`
+	Required  bool              `json:"required"`
+	MaxLength int               `json:"max_length,omitempty"`
+	Question  map[string]string `json:"question"`
+}
+
+// RubricRequest creates a new rubric version for a role.
+type RubricRequest struct {
+	Role       string            `json:"role"`
+	Name       map[string]string `json:"name"`
+	Scale      RatingScale       `json:"scale"`
+	Dimensions []RubricDimension `json:"dimensions"`
+	Prompts    []RubricPrompt    `json:"prompts,omitempty"`
+}
+
+// RubricLookupRequest asks for the rubric of an invite.
+type RubricLookupRequest struct {
+	InvitationToken string `json:"invitation_token"`
+}
+
+// Report reasons.
+var ReportReasons = []string{"harassment", "inappropriate", "false_information", "spam", "other"}
+
+// Report is a learner's abuse report on an endorsement.
+type Report struct {
+	ID            uuid.UUID  `json:"id"`
+	EndorsementID uuid.UUID  `json:"endorsement_id"`
+	ReporterID    uuid.UUID  `json:"reporter_id"`
+	Reason        string     `json:"reason"`
+	Details       string     `json:"details,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ModerationCase is an endorsement in the admin queue with its reports.
+type ModerationCase struct {
+	Endorsement
+	Reports []Report `json:"reports"`
+}
+
+// Moderation log actions.
+const (
+	ActionSubmit  = "submit"
+	ActionAccept  = "accept"
+	ActionDecline = "decline"
+	ActionReport  = "report"
+	ActionHide    = "hide"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+	ActionBlock   = "block"
+	ActionUnblock = "unblock"
+)
+
+// Moderation log actor roles.
+const (
+	ActorLearner = "learner"
+	ActorAdmin   = "admin"
+	ActorSystem  = "system"
+)
+
+// ModerationEntry records one moderation decision. The log is append-only;
+// block entries carry an email instead of an endorsement.
+type ModerationEntry struct {
+	ID            uuid.UUID  `json:"id"`
+	EndorsementID *uuid.UUID `json:"endorsement_id,omitempty"`
+	// EmailHash identifies the address of a block or unblock; see hashEmail.
+	EmailHash string                 `json:"email_hash,omitempty"`
+	ActorID   *uuid.UUID             `json:"actor_id,omitempty"`
+	ActorRole string                 `json:"actor_role"`
+	Action    string                 `json:"action"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// BlockedEmail is an endorser address that can no longer be invited or
+// submit endorsements.
+type BlockedEmail struct {
+	Email     string     `json:"email"`
+	Reason    string     `json:"reason,omitempty"`
+	BlockedBy *uuid.UUID `json:"blocked_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// DecisionRequest accepts an endorsement; Visible defaults to true.
+type DecisionRequest struct {
+	Visible *bool `json:"visible"`
+}
+
+type ReportRequest struct {
+	Reason  string `json:"reason"`
+	Details string `json:"details"`
+}
+
+// ModerationRequest is an admin decision on an endorsement.
+type ModerationRequest struct {
+	Reason string `json:"reason"`
+}
+
+type BlockEmailRequest struct {
+	Email  string `json:"email"`
+	Reason string `json:"reason"`
 }
 
 type VisibilityRequest struct {
@@ -0,0 +1,289 @@
+package endorsement
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEndorsementNotFound = errors.New("endorsement not found")
+	ErrUnderReview         = errors.New("endorsement is under review")
+	ErrNotAccepted         = errors.New("endorsement has not been accepted")
+	ErrEndorserBlocked     = errors.New("this endorser address is blocked")
+	ErrNotBlocked          = errors.New("address is not blocked")
+	ErrInvalidReport       = errors.New("invalid report")
+)
+
+const maxReportDetails = 2000
+
+var blockEmailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// SetScreener replaces the content screener; nil disables screening.
+func (s *Service) SetScreener(sc Screener) {
+	s.screener = sc
+}
+
+// screen returns the screening flags for the endorsement's texts.
+func (s *Service) screen(e *Endorsement) []string {
+	if s.screener == nil {
+		return nil
+	}
+	texts := []string{e.EndorserName, e.Statement}
+	if e.Context != nil {
+		texts = append(texts, *e.Context)
+	}
+	for _, a := range e.Answers {
+		texts = append(texts, a)
+	}
+	return s.screener.Screen(texts...)
+}
+
+// checkBlocked rejects blocked endorser addresses.
+func (s *Service) checkBlocked(ctx context.Context, email string) error {
+	blocked, err := s.repo.IsEmailBlocked(ctx, normalizeEmail(email))
+	if err != nil {
+		return fmt.Errorf("check blocked address: %w", err)
+	}
+	if blocked {
+		return ErrEndorserBlocked
+	}
+	return nil
+}
+
+// Accept publishes a pending or declined endorsement on the learner's
+// profile (unless visible is false).
+func (s *Service) Accept(ctx context.Context, learnerID, id uuid.UUID, visible bool) (*Endorsement, error) {
+	return s.decide(ctx, learnerID, id, StatusAccepted, visible)
+}
+
+// Decline rejects an endorsement; it stays private and can still be
+// accepted later.
+func (s *Service) Decline(ctx context.Context, learnerID, id uuid.UUID) (*Endorsement, error) {
+	return s.decide(ctx, learnerID, id, StatusDeclined, false)
+}
+
+func (s *Service) decide(ctx context.Context, learnerID, id uuid.UUID, status string, visible bool) (*Endorsement, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	e, err := s.learnerEndorsement(ctx, learnerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.ModerationStatus != ModerationClear {
+		return nil, ErrUnderReview
+	}
+	action := ActionAccept
+	if status == StatusDeclined {
+		action = ActionDecline
+	}
+	now := time.Now().UTC()
+	audit := newModerationEntry(&id, &learnerID, ActorLearner, action, map[string]interface{}{"previous_status": e.Status})
+	if err := s.repo.Decide(ctx, id, learnerID, status, visible, now, credentialRevocation(status), audit); err != nil {
+		return nil, fmt.Errorf("decide endorsement: %w", err)
+	}
+	e.Status, e.Visible, e.DecidedAt = status, visible, &now
+	return e, nil
+}
+
+// Report files an abuse report. The endorsement is flagged and withdrawn
+// from the profile until an admin decides.
+func (s *Service) Report(ctx context.Context, learnerID, id uuid.UUID, req ReportRequest) (*Report, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	if !contains(ReportReasons, req.Reason) {
+		return nil, fmt.Errorf("%w: reason must be one of %s", ErrInvalidReport, strings.Join(ReportReasons, ", "))
+	}
+	details := strings.TrimSpace(req.Details)
+	if len([]rune(details)) > maxReportDetails {
+		return nil, fmt.Errorf("%w: details must be at most %d characters", ErrInvalidReport, maxReportDetails)
+	}
+	if _, err := s.learnerEndorsement(ctx, learnerID, id); err != nil {
+		return nil, err
+	}
+	r := &Report{
+		ID:            uuid.New(),
+		EndorsementID: id,
+		ReporterID:    learnerID,
+		Reason:        req.Reason,
+		Details:       details,
+		CreatedAt:     time.Now().UTC(),
+	}
+	audit := newModerationEntry(&id, &learnerID, ActorLearner, ActionReport, map[string]interface{}{"report_id": r.ID, "reason": r.Reason})
+	if err := s.repo.CreateReport(ctx, r, audit); err != nil {
+		return nil, fmt.Errorf("create report: %w", err)
+	}
+	return r, nil
+}
+
+// learnerEndorsement returns the learner's endorsement; flagged and
+// deleted ones are not visible to the learner.
+func (s *Service) learnerEndorsement(ctx context.Context, learnerID, id uuid.UUID) (*Endorsement, error) {
+	e, err := s.repo.GetByID(ctx, id, learnerID)
+	if err != nil {
+		return nil, fmt.Errorf("get endorsement: %w", err)
+	}
+	if e == nil || e.ModerationStatus == ModerationDeleted {
+		return nil, ErrEndorsementNotFound
+	}
+	if e.ModerationStatus == ModerationFlagged {
+		return nil, ErrUnderReview
+	}
+	return e, nil
+}
+
+// ModerationQueue lists endorsements with the given moderation status
+// (default flagged), oldest first.
+func (s *Service) ModerationQueue(ctx context.Context, status string, limit, offset int) ([]ModerationCase, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
+	switch status {
+	case "":
+		status = ModerationFlagged
+	case ModerationFlagged, ModerationHidden, ModerationDeleted:
+	default:
+		return nil, 0, fmt.Errorf("status must be flagged, hidden or deleted")
+	}
+	return s.repo.ListModeration(ctx, status, limit, offset)
+}
+
+// Hide withdraws an endorsement from the public profile; the learner still
+// sees it.
+func (s *Service) Hide(ctx context.Context, adminID, id uuid.UUID, reason string) (*Endorsement, error) {
+	return s.moderate(ctx, adminID, id, ModerationHidden, ActionHide, reason)
+}
+
+// Delete removes an endorsement from the profile and the learner's list.
+// The content is kept for the audit trail, so Restore can undo it.
+func (s *Service) Delete(ctx context.Context, adminID, id uuid.UUID, reason string) (*Endorsement, error) {
+	return s.moderate(ctx, adminID, id, ModerationDeleted, ActionDelete, reason)
+}
+
+// Restore clears a flagged, hidden or deleted endorsement. It returns to
+// the learner's decision: pending ones still need to be accepted.
+func (s *Service) Restore(ctx context.Context, adminID, id uuid.UUID, reason string) (*Endorsement, error) {
+	return s.moderate(ctx, adminID, id, ModerationClear, ActionRestore, reason)
+}
+
+// credentialRevocation returns the reason credentials issued for an
+// endorsement are revoked with when it gets status, or "" if they stay
+// valid. Credentials stay revoked when a hidden endorsement is restored.
+func credentialRevocation(status string) string {
+	switch status {
+	case StatusDeclined, ModerationHidden, ModerationDeleted:
+		return "endorsement_" + status
+	}
+	return ""
+}
+
+func (s *Service) moderate(ctx context.Context, adminID, id uuid.UUID, status, action, reason string) (*Endorsement, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	e, err := s.repo.GetEndorsement(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get endorsement: %w", err)
+	}
+	if e == nil {
+		return nil, ErrEndorsementNotFound
+	}
+	audit := newModerationEntry(&id, &adminID, ActorAdmin, action, map[string]interface{}{
+		"previous_status": e.ModerationStatus,
+		"reason":          strings.TrimSpace(reason),
+	})
+	if err := s.repo.SetModeration(ctx, id, status, time.Now().UTC(), credentialRevocation(status), audit); err != nil {
+		return nil, fmt.Errorf("moderate endorsement: %w", err)
+	}
+	e.ModerationStatus = status
+	return e, nil
+}
+
+// ModerationLog returns the decisions on an endorsement, oldest first.
+func (s *Service) ModerationLog(ctx context.Context, id uuid.UUID) ([]ModerationEntry, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	return s.repo.ListModerationLog(ctx, id)
+}
+
+// BlockEmail blocks an endorser address: it can no longer be invited or
+// submit, and its pending invites are revoked.
+func (s *Service) BlockEmail(ctx context.Context, adminID uuid.UUID, req BlockEmailRequest) (*BlockedEmail, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	email := normalizeEmail(req.Email)
+	if !blockEmailPattern.MatchString(email) {
+		return nil, fmt.Errorf("invalid email address")
+	}
+	b := &BlockedEmail{
+		Email:     email,
+		Reason:    strings.TrimSpace(req.Reason),
+		BlockedBy: &adminID,
+		CreatedAt: time.Now().UTC(),
+	}
+	audit := newModerationEntry(nil, &adminID, ActorAdmin, ActionBlock, map[string]interface{}{"reason": b.Reason})
+	audit.EmailHash = hashEmail(email)
+	if err := s.repo.BlockEmail(ctx, b, audit); err != nil {
+		return nil, fmt.Errorf("block email: %w", err)
+	}
+	return b, nil
+}
+
+func (s *Service) UnblockEmail(ctx context.Context, adminID uuid.UUID, email string) error {
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	email = normalizeEmail(email)
+	audit := newModerationEntry(nil, &adminID, ActorAdmin, ActionUnblock, nil)
+	audit.EmailHash = hashEmail(email)
+	ok, err := s.repo.UnblockEmail(ctx, email, audit)
+	if err != nil {
+		return fmt.Errorf("unblock email: %w", err)
+	}
+	if !ok {
+		return ErrNotBlocked
+	}
+	return nil
+}
+
+func (s *Service) BlockedEmails(ctx context.Context) ([]BlockedEmail, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	return s.repo.ListBlockedEmails(ctx)
+}
+
+func newModerationEntry(endorsementID, actorID *uuid.UUID, role, action string, details map[string]interface{}) *ModerationEntry {
+	return &ModerationEntry{
+		ID:            uuid.New(),
+		EndorsementID: endorsementID,
+		ActorID:       actorID,
+		ActorRole:     role,
+		Action:        action,
+		Details:       details,
+		CreatedAt:     time.Now().UTC(),
+	}
+}
+
+// hashEmail returns the hex SHA-256 of a normalized address. The moderation
+// log keeps it instead of the address, so a block can still be traced to a
+// known address without storing it.
+func hashEmail(email string) string {
+	sum := sha256.Sum256([]byte(email))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -2,17 +2,86 @@ package endorsement
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Repository interface {
-	Create(ctx context.Context, e *Endorsement) error
+	// Mutating moderation methods take the log entry describing the
+	// decision and append it in the same transaction.
+	Create(ctx context.Context, e *Endorsement, audit *ModerationEntry) error
+	// List returns the learner's endorsements except flagged and deleted
+	// ones.
 	List(ctx context.Context, learnerID uuid.UUID, limit, offset int) ([]Endorsement, int, error)
+	// GetByID returns the learner's endorsement, or nil if it does not exist.
 	GetByID(ctx context.Context, id uuid.UUID, learnerID uuid.UUID) (*Endorsement, error)
 	UpdateVisibility(ctx context.Context, id uuid.UUID, learnerID uuid.UUID, visible bool) (*Endorsement, error)
 	CreateInvite(ctx context.Context, inv *EndorsementInvite) error
 	GetInviteByToken(ctx context.Context, token string) (*EndorsementInvite, error)
+	// ListPendingInvites returns the learner's pending invites with their
+	// history, oldest event first.
 	ListPendingInvites(ctx context.Context, learnerID uuid.UUID) ([]EndorsementInvite, int, error)
 	MarkInviteCompleted(ctx context.Context, token string) error
+	// GetInvite returns the learner's invite including its token, or nil if
+	// it does not exist.
+	GetInvite(ctx context.Context, id, learnerID uuid.UUID) (*EndorsementInvite, error)
+	// RevokeInvite revokes a pending invite and reports whether it was pending.
+	RevokeInvite(ctx context.Context, id, learnerID uuid.UUID) (bool, error)
+	// RenewInvite makes a pending or expired invite pending again until
+	// expiresAt and records sentAt as its last delivery.
+	RenewInvite(ctx context.Context, id uuid.UUID, expiresAt, sentAt time.Time) error
+	// ExpireInvites marks pending invites past their expiry as expired and
+	// returns their IDs.
+	ExpireInvites(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+	// ClaimReminders atomically claims up to limit pending, unexpired invites
+	// last sent before sentBefore with fewer than maxReminders reminders: it
+	// counts the reminder and records at as their last delivery before
+	// returning them, including tokens, so concurrent callers never claim the
+	// same invite.
+	ClaimReminders(ctx context.Context, sentBefore, at time.Time, maxReminders, limit int) ([]EndorsementInvite, error)
+	AddInviteEvent(ctx context.Context, inviteID uuid.UUID, kind string, mailID *uuid.UUID, at time.Time) error
+	// SetVerificationCode stores the hash of a new one-time code for the
+	// invite and resets the attempt counter.
+	SetVerificationCode(ctx context.Context, inviteID uuid.UUID, codeHash string, expiresAt time.Time) error
+	// ConfirmVerificationCode marks the invite's address as verified if the
+	// hash matches an unexpired code with fewer than maxAttempts failed
+	// attempts; a mismatch counts as a failed attempt.
+	ConfirmVerificationCode(ctx context.Context, inviteID uuid.UUID, codeHash string, maxAttempts int) (bool, error)
+	// OrganizationForDomain returns the organisation of the most specific
+	// verified domain among domains (from active brand configs), or "".
+	OrganizationForDomain(ctx context.Context, domains []string) (string, error)
+	// ActiveRubric returns the active rubric of the role, or nil.
+	ActiveRubric(ctx context.Context, role string) (*Rubric, error)
+	// GetRubric returns the rubric (active or not), or nil.
+	GetRubric(ctx context.Context, id uuid.UUID) (*Rubric, error)
+	ListRubrics(ctx context.Context, role string, activeOnly bool) ([]Rubric, error)
+	// CreateRubric stores r as the next version of its role (setting
+	// r.Version and r.CreatedAt) and deactivates the previous one.
+	CreateRubric(ctx context.Context, r *Rubric) error
+	DeactivateRubric(ctx context.Context, id uuid.UUID) (bool, error)
+	// Decide records the learner's decision and visibility. A non-empty
+	// revoke reason revokes the active credentials issued for the
+	// endorsement in the same transaction.
+	Decide(ctx context.Context, id, learnerID uuid.UUID, status string, visible bool, at time.Time, revoke string, audit *ModerationEntry) error
+	// CreateReport stores the report and flags the endorsement if it is
+	// clear.
+	CreateReport(ctx context.Context, r *Report, audit *ModerationEntry) error
+	// ListModeration returns endorsements of any learner with the given
+	// moderation status and their reports, oldest first.
+	ListModeration(ctx context.Context, status string, limit, offset int) ([]ModerationCase, int, error)
+	// GetEndorsement returns any learner's endorsement, or nil.
+	GetEndorsement(ctx context.Context, id uuid.UUID) (*Endorsement, error)
+	// SetModeration changes the moderation status and resolves open
+	// reports; revoke works as for Decide.
+	SetModeration(ctx context.Context, id uuid.UUID, status string, at time.Time, revoke string, audit *ModerationEntry) error
+	ListModerationLog(ctx context.Context, endorsementID uuid.UUID) ([]ModerationEntry, error)
+	// BlockEmail blocks the address and revokes its pending invites.
+	BlockEmail(ctx context.Context, b *BlockedEmail, audit *ModerationEntry) error
+	// UnblockEmail reports whether the address was blocked.
+	UnblockEmail(ctx context.Context, email string, audit *ModerationEntry) (bool, error)
+	ListBlockedEmails(ctx context.Context) ([]BlockedEmail, error)
+	IsEmailBlocked(ctx context.Context, email string) (bool, error)
+	// LearnerName returns the learner's display name ("" if unset).
+	LearnerName(ctx context.Context, learnerID uuid.UUID) (string, error)
 }
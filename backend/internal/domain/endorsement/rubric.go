@@ -0,0 +1,240 @@
+package endorsement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/skillscore"
+)
+
+var (
+	ErrRubricNotFound = errors.New("rubric not found")
+	// ErrRubricInvalid wraps submissions that do not match the invite's
+	// rubric.
+	ErrRubricInvalid = errors.New("submission does not match the rubric")
+)
+
+const (
+	defaultAnswerLength = 2000
+	maxRubricLevels     = 10
+)
+
+// RubricRoles are the endorser roles a rubric can be defined for.
+var RubricRoles = []string{"teacher", "trainer", "mentor", "employer", "peer", "parent", "other"}
+
+var rubricKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Rubric returns the rubric locked by an open invite, for rendering the
+// endorsement form. Invites without a rubric return ErrRubricNotFound.
+func (s *Service) Rubric(ctx context.Context, token string) (*Rubric, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	invite, err := s.openInvite(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.inviteRubric(ctx, invite)
+}
+
+func (s *Service) inviteRubric(ctx context.Context, invite *EndorsementInvite) (*Rubric, error) {
+	if invite.RubricID == nil {
+		return nil, ErrRubricNotFound
+	}
+	r, err := s.repo.GetRubric(ctx, *invite.RubricID)
+	if err != nil {
+		return nil, fmt.Errorf("get rubric: %w", err)
+	}
+	if r == nil {
+		return nil, ErrRubricNotFound
+	}
+	return r, nil
+}
+
+// ListRubrics lists rubrics, optionally of one role and only active ones.
+func (s *Service) ListRubrics(ctx context.Context, role string, activeOnly bool) ([]Rubric, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	return s.repo.ListRubrics(ctx, role, activeOnly)
+}
+
+// CreateRubric stores req as the next version for its role and makes it
+// active. Invites created before keep their rubric.
+func (s *Service) CreateRubric(ctx context.Context, req RubricRequest) (*Rubric, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	r := &Rubric{
+		ID:         uuid.New(),
+		Role:       req.Role,
+		Name:       req.Name,
+		Scale:      req.Scale,
+		Dimensions: req.Dimensions,
+		Prompts:    req.Prompts,
+		Active:     true,
+	}
+	if err := s.validateRubric(r); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateRubric(ctx, r); err != nil {
+		return nil, fmt.Errorf("create rubric: %w", err)
+	}
+	return r, nil
+}
+
+// DeactivateRubric retires a rubric; new invites for its role get none.
+func (s *Service) DeactivateRubric(ctx context.Context, id uuid.UUID) error {
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	ok, err := s.repo.DeactivateRubric(ctx, id)
+	if err != nil {
+		return fmt.Errorf("deactivate rubric: %w", err)
+	}
+	if !ok {
+		return ErrRubricNotFound
+	}
+	return nil
+}
+
+func (s *Service) validateRubric(r *Rubric) error {
+	if !contains(RubricRoles, r.Role) {
+		return fmt.Errorf("role must be one of %s", strings.Join(RubricRoles, ", "))
+	}
+	if len(r.Name) == 0 {
+		return fmt.Errorf("name is required")
+	}
+	levels := r.Scale.Levels
+	if len(levels) < 2 || len(levels) > maxRubricLevels {
+		return fmt.Errorf("scale must have between 2 and %d levels", maxRubricLevels)
+	}
+	for i, l := range levels {
+		if len(l.Label) == 0 {
+			return fmt.Errorf("scale level %d needs a label", l.Value)
+		}
+		if i > 0 && l.Value <= levels[i-1].Value {
+			return fmt.Errorf("scale levels must be in ascending order of value")
+		}
+	}
+
+	if len(r.Dimensions) == 0 {
+		return fmt.Errorf("at least one dimension is required")
+	}
+	seen := map[string]bool{}
+	for i, d := range r.Dimensions {
+		if s.taxonomy != nil {
+			dims, err := s.taxonomy.Normalize(map[string]float64{d.Key: 0})
+			if err != nil {
+				return err
+			}
+			for key := range dims {
+				r.Dimensions[i].Key = key
+			}
+		}
+		key := r.Dimensions[i].Key
+		if !rubricKeyPattern.MatchString(key) {
+			return fmt.Errorf("invalid dimension key %q", key)
+		}
+		if seen[key] {
+			return fmt.Errorf("duplicate dimension %q", key)
+		}
+		seen[key] = true
+	}
+
+	prompts := map[string]bool{}
+	for _, p := range r.Prompts {
+		if !rubricKeyPattern.MatchString(p.Key) {
+			return fmt.Errorf("invalid prompt key %q", p.Key)
+		}
+		if prompts[p.Key] {
+			return fmt.Errorf("duplicate prompt %q", p.Key)
+		}
+		prompts[p.Key] = true
+		if len(p.Question) == 0 {
+			return fmt.Errorf("prompt %q needs a question", p.Key)
+		}
+		if p.MaxLength < 0 {
+			return fmt.Errorf("prompt %q: max_length must not be negative", p.Key)
+		}
+	}
+	return nil
+}
+
+// score validates the ratings and answers against the rubric and returns
+// the ratings mapped onto 0-100 (lowest level 0, highest 100) together with
+// the trimmed answers.
+func (r *Rubric) score(ratings map[string]int, answers map[string]string) (map[string]float64, map[string]string, error) {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrRubricInvalid, fmt.Sprintf(format, args...))
+	}
+
+	levels := map[int]bool{}
+	for _, l := range r.Scale.Levels {
+		levels[l.Value] = true
+	}
+	lo, hi := r.Scale.Levels[0].Value, r.Scale.Levels[len(r.Scale.Levels)-1].Value
+
+	dims := make(map[string]float64, len(ratings))
+	known := map[string]bool{}
+	for _, d := range r.Dimensions {
+		known[d.Key] = true
+		v, ok := ratings[d.Key]
+		if !ok {
+			if d.Required {
+				return nil, nil, invalid("rating for %q is required", d.Key)
+			}
+			continue
+		}
+		if !levels[v] {
+			return nil, nil, invalid("rating for %q must be a scale value between %d and %d", d.Key, lo, hi)
+		}
+		dims[d.Key] = skillscore.Max * float64(v-lo) / float64(hi-lo)
+	}
+	for key := range ratings {
+		if !known[key] {
+			return nil, nil, invalid("%q is not rated in this rubric", key)
+		}
+	}
+
+	out := make(map[string]string, len(answers))
+	prompts := map[string]bool{}
+	for _, p := range r.Prompts {
+		prompts[p.Key] = true
+		text := strings.TrimSpace(answers[p.Key])
+		if text == "" {
+			if p.Required {
+				return nil, nil, invalid("answer to %q is required", p.Key)
+			}
+			continue
+		}
+		limit := p.MaxLength
+		if limit == 0 {
+			limit = defaultAnswerLength
+		}
+		if len([]rune(text)) > limit {
+			return nil, nil, invalid("answer to %q must be at most %d characters", p.Key, limit)
+		}
+		out[p.Key] = text
+	}
+	for key := range answers {
+		if !prompts[key] {
+			return nil, nil, invalid("%q is not a prompt of this rubric", key)
+		}
+	}
+	return dims, out, nil
+}
+
+func contains(list []string, v string) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package endorsement
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Screener checks the texts of a submitted endorsement before the learner
+// sees it and returns the reasons to hold it for review (none if clean).
+type Screener interface {
+	Screen(texts ...string) []string
+}
+
+// Screening flags.
+const (
+	FlagAbusiveLanguage = "abusive_language"
+	FlagSexualContent   = "sexual_content"
+	FlagThreat          = "threat"
+	FlagContactDetails  = "contact_details"
+	FlagLink            = "link"
+)
+
+// screeningTerms map flags to words; a trailing "*" also matches compounds
+// and inflections starting with the word ("idiot*" matches "idioten").
+var screeningTerms = map[string][]string{
+	FlagAbusiveLanguage: {
+		"arschloch*", "arschgeige*", "hurensohn*", "wichser*", "fotze*", "schlampe*", "missgeburt*", "spast*",
+		"behindert", "vollidiot*", "idiot*", "depp", "penner*", "opfer", "fick*", "scheiss*", "verpiss*",
+		"fuck*", "shit*", "bitch*", "cunt*", "asshole*", "bastard*", "retard*", "moron*", "whore*", "slut*", "loser*",
+	},
+	FlagSexualContent: {
+		"sex", "sexy", "nackt*", "porn*", "titten", "muschi", "blowjob*",
+		"nude*", "naked", "horny", "boobs", "pussy",
+	},
+	FlagThreat: {
+		"umbringen", "abstechen", "kys", "suicide",
+	},
+	FlagContactDetails: {
+		"whatsapp", "snapchat", "telegram", "instagram", "tiktok", "discord",
+	},
+}
+
+// screeningPhrases are matched against the folded text with word
+// boundaries.
+var screeningPhrases = map[string][]string{
+	FlagThreat:         {"bring dich um", "ich bring dich", "kill yourself", "kill you", "ich finde dich", "i will find you"},
+	FlagContactDetails: {"adde mich", "dm me", "add me", "text me"},
+}
+
+var (
+	emailPattern  = regexp.MustCompile(`[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}`)
+	phonePattern  = regexp.MustCompile(`(?:\+\d{2}|\b0\d{2,4})[ /-]?\d{2,}(?:[ /-]?\d{2,})+`)
+	handlePattern = regexp.MustCompile(`(?:^|\s)@[a-z0-9_.]{3,}`)
+	linkPattern   = regexp.MustCompile(`https?://|www\.|\b[a-z0-9-]+\.(?:com|de|net|org|io|me|ly|gg|at|ch)\b`)
+)
+
+// leetReplacer undoes common obfuscations before matching words.
+var leetReplacer = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "@", "a", "$", "s",
+	"ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss")
+
+type keywordScreener struct {
+	exact    map[string]string
+	prefixes map[string]string
+	phrases  map[string]*regexp.Regexp
+}
+
+// NewKeywordScreener returns the built-in screener. It flags abusive and
+// sexual language, threats, contact details and links in German and
+// English; its findings are reviewed by an admin, so it errs on the side of
+// flagging.
+func NewKeywordScreener() Screener {
+	k := &keywordScreener{exact: map[string]string{}, prefixes: map[string]string{}, phrases: map[string]*regexp.Regexp{}}
+	for flag, words := range screeningTerms {
+		for _, w := range words {
+			w = leetReplacer.Replace(w)
+			if prefix, ok := strings.CutSuffix(w, "*"); ok {
+				k.prefixes[prefix] = flag
+			} else {
+				k.exact[w] = flag
+			}
+		}
+	}
+	for flag, phrases := range screeningPhrases {
+		quoted := make([]string, len(phrases))
+		for i, p := range phrases {
+			quoted[i] = regexp.QuoteMeta(leetReplacer.Replace(p))
+		}
+		k.phrases[flag] = regexp.MustCompile(`\b(?:` + strings.Join(quoted, "|") + `)\b`)
+	}
+	return k
+}
+
+func (k *keywordScreener) Screen(texts ...string) []string {
+	found := map[string]bool{}
+	for _, text := range texts {
+		lower := strings.ToLower(text)
+		// Contact details and links are matched before folding digits.
+		if emailPattern.MatchString(lower) || phonePattern.MatchString(lower) || handlePattern.MatchString(lower) {
+			found[FlagContactDetails] = true
+		}
+		if linkPattern.MatchString(lower) {
+			found[FlagLink] = true
+		}
+
+		folded := leetReplacer.Replace(lower)
+		for flag, re := range k.phrases {
+			if re.MatchString(folded) {
+				found[flag] = true
+			}
+		}
+		for _, word := range strings.FieldsFunc(folded, func(r rune) bool { return !unicode.IsLetter(r) }) {
+			if flag, ok := k.exact[word]; ok {
+				found[flag] = true
+				continue
+			}
+			for prefix, flag := range k.prefixes {
+				if strings.HasPrefix(word, prefix) {
+					found[flag] = true
+					break
+				}
+			}
+		}
+	}
+	flags := make([]string, 0, len(found))
+	for flag := range found {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+	return flags
+}
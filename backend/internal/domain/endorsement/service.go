@@ -5,54 +5,130 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/mail"
 )
 
+// Taxonomy resolves dimension keys against the skill registry (satisfied
+// by *taxonomy.Service).
+type Taxonomy interface {
+	Normalize(dims map[string]float64) (map[string]float64, error)
+}
+
+// Mailer queues templated mail (satisfied by *mail.Outbox).
+type Mailer interface {
+	Enqueue(ctx context.Context, to, template, locale string, data map[string]interface{}) (uuid.UUID, error)
+}
+
 type Service struct {
-	repo Repository
+	repo     Repository
+	taxonomy Taxonomy
+	mailer   Mailer
+	screener Screener
+	baseURL  string
+	// reminderAfter is the delay before an unanswered invite is reminded
+	// (zero disables reminders).
+	reminderAfter time.Duration
 }
 
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, screener: NewKeywordScreener()}
+}
+
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
+}
+
+// SetTaxonomy makes submissions map dimension keys to canonical keys and reject
+// unknown ones.
+func (s *Service) SetTaxonomy(t Taxonomy) {
+	s.taxonomy = t
+}
+
+// SetMailer sends invites by mail; baseURL is the public frontend URL the
+// invite links point to.
+func (s *Service) SetMailer(m Mailer, baseURL string) {
+	s.mailer = m
+	s.baseURL = strings.TrimRight(baseURL, "/")
 }
 
 func (s *Service) List(ctx context.Context, learnerID uuid.UUID, limit, offset int) ([]Endorsement, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
 	return s.repo.List(ctx, learnerID, limit, offset)
 }
 
 func (s *Service) Submit(ctx context.Context, req SubmitEndorsementRequest) (*Endorsement, error) {
-	if req.InvitationToken == "" {
-		return nil, fmt.Errorf("invitation_token is required")
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
 	}
-
-	invite, err := s.repo.GetInviteByToken(ctx, req.InvitationToken)
+	invite, err := s.openInvite(ctx, req.InvitationToken)
 	if err != nil {
-		return nil, fmt.Errorf("invalid invitation token")
-	}
-
-	if invite.Status != "pending" {
-		return nil, fmt.Errorf("invitation already used or expired")
+		return nil, err
 	}
-	if time.Now().After(invite.ExpiresAt) {
-		return nil, fmt.Errorf("invitation has expired")
+	if err := s.checkBlocked(ctx, invite.EndorserEmail); err != nil {
+		return nil, err
 	}
 
+	// New endorsements are held until the learner accepts them.
 	endorsement := &Endorsement{
 		ID:               uuid.New(),
 		LearnerID:        invite.LearnerID,
 		EndorserName:     req.EndorserName,
 		EndorserRole:     req.EndorserRole,
-		EndorserVerified: false,
-		SkillDimensions:  req.SkillDimensions,
+		EndorserEmail:    normalizeEmail(invite.EndorserEmail),
 		Statement:        req.Statement,
 		Context:          req.Context,
-		Visible:          true,
+		Status:           StatusPending,
+		ModerationStatus: ModerationClear,
 		CreatedAt:        time.Now().UTC(),
 	}
+	if invite.RubricID != nil {
+		// The rubric defines the dimensions; its role is the invited one.
+		if len(req.SkillDimensions) > 0 {
+			return nil, fmt.Errorf("%w: rate the rubric dimensions in ratings instead of skill_dimensions", ErrRubricInvalid)
+		}
+		rubric, err := s.inviteRubric(ctx, invite)
+		if err != nil {
+			return nil, err
+		}
+		dims, answers, err := rubric.score(req.Ratings, req.Answers)
+		if err != nil {
+			return nil, err
+		}
+		endorsement.EndorserRole = invite.EndorserRole
+		endorsement.RubricID = &rubric.ID
+		endorsement.Ratings = req.Ratings
+		endorsement.Answers = answers
+		req.SkillDimensions = dims
+	}
+	if s.taxonomy != nil {
+		dims, err := s.taxonomy.Normalize(req.SkillDimensions)
+		if err != nil {
+			return nil, err
+		}
+		req.SkillDimensions = dims
+	}
+	endorsement.SkillDimensions = req.SkillDimensions
+	s.applyVerification(ctx, endorsement, invite)
 
-	if err := s.repo.Create(ctx, endorsement); err != nil {
+	// Flagged endorsements wait for an admin before the learner sees them.
+	endorsement.ScreeningFlags = s.screen(endorsement)
+	if len(endorsement.ScreeningFlags) > 0 {
+		endorsement.ModerationStatus = ModerationFlagged
+	}
+	audit := newModerationEntry(&endorsement.ID, nil, ActorSystem, ActionSubmit, map[string]interface{}{
+		"screening_flags":   endorsement.ScreeningFlags,
+		"moderation_status": endorsement.ModerationStatus,
+	})
+	if err := s.repo.Create(ctx, endorsement, audit); err != nil {
 		return nil, fmt.Errorf("create endorsement: %w", err)
 	}
 
@@ -61,33 +137,162 @@ func (s *Service) Submit(ctx context.Context, req SubmitEndorsementRequest) (*En
 }
 
 func (s *Service) Invite(ctx context.Context, learnerID uuid.UUID, req EndorsementInviteRequest) (*EndorsementInvite, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 	if req.EndorserEmail == "" {
 		return nil, fmt.Errorf("endorser_email is required")
 	}
+	if err := s.checkBlocked(ctx, req.EndorserEmail); err != nil {
+		return nil, err
+	}
 
+	locale := req.Locale
+	if locale == "" {
+		locale = mail.DefaultLocale
+	}
 	token := generateToken()
+	now := time.Now().UTC()
 	invite := &EndorsementInvite{
 		ID:            uuid.New(),
 		LearnerID:     learnerID,
 		EndorserEmail: req.EndorserEmail,
 		EndorserRole:  req.EndorserRole,
-		Status:        "pending",
-		InviteURL:     fmt.Sprintf("/endorse?token=%s", token),
-		CreatedAt:     time.Now().UTC(),
-		ExpiresAt:     time.Now().Add(30 * 24 * time.Hour).UTC(),
+		Status:        InvitePending,
+		Message:       req.Message,
+		Locale:        locale,
+		Token:         token,
+		InviteURL:     inviteURL(token),
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(inviteTTL),
+		LastSentAt:    &now,
+	}
+	rubric, err := s.repo.ActiveRubric(ctx, req.EndorserRole)
+	if err != nil {
+		return nil, fmt.Errorf("load rubric: %w", err)
+	}
+	if rubric != nil {
+		invite.RubricID = &rubric.ID
+		invite.Rubric = rubric
 	}
 
 	if err := s.repo.CreateInvite(ctx, invite); err != nil {
 		return nil, fmt.Errorf("create invite: %w", err)
 	}
+	invite.QRCodeURL = qrCodeURL(invite.ID)
+	mailID := s.sendInvite(ctx, invite, mail.TemplateEndorsementInvite)
+	s.recordEvent(ctx, invite.ID, EventCreated, mailID, now)
 	return invite, nil
 }
 
+func inviteURL(token string) string {
+	return fmt.Sprintf("/endorse?token=%s", token)
+}
+
+// qrCodeURL is the authenticated endpoint rendering the invite as a QR
+// code; the code encodes a short-lived link instead of the token.
+func qrCodeURL(id uuid.UUID) string {
+	return "/api/v1/portfolio/endorsements/invites/" + id.String() + "/qr"
+}
+
+// sendInvite queues the invite or reminder mail and returns its outbox ID.
+// The invite stays valid if queueing fails; the learner can still share the
+// link.
+func (s *Service) sendInvite(ctx context.Context, invite *EndorsementInvite, template string) *uuid.UUID {
+	if s.mailer == nil {
+		return nil
+	}
+	locale := invite.Locale
+	if locale == "" {
+		locale = mail.DefaultLocale
+	}
+	name, err := s.repo.LearnerName(ctx, invite.LearnerID)
+	if err != nil || name == "" {
+		name = learnerFallbackName(locale)
+	}
+	data := map[string]interface{}{
+		"LearnerName": name,
+		"Role":        roleLabel(invite.EndorserRole, locale),
+		"InviteURL":   s.baseURL + inviteURL(invite.Token),
+		"ExpiresAt":   formatDate(invite.ExpiresAt, locale),
+		"Message":     "",
+	}
+	if invite.Message != nil {
+		data["Message"] = strings.TrimSpace(*invite.Message)
+	}
+	id, err := s.mailer.Enqueue(ctx, invite.EndorserEmail, template, locale, data)
+	if err != nil {
+		log.Printf("[endorsement] queue %s mail for invite %s: %v", template, invite.ID, err)
+		return nil
+	}
+	return &id
+}
+
+// recordEvent appends to the invite history; failures only lose history.
+func (s *Service) recordEvent(ctx context.Context, inviteID uuid.UUID, kind string, mailID *uuid.UUID, at time.Time) {
+	if err := s.repo.AddInviteEvent(ctx, inviteID, kind, mailID, at); err != nil {
+		log.Printf("[endorsement] record %s event for invite %s: %v", kind, inviteID, err)
+	}
+}
+
+// roleLabels names endorser roles in invite mails; "other" has no label.
+var roleLabels = map[string]map[string]string{
+	"de": {"teacher": "Lehrkraft", "trainer": "Ausbilder:in", "mentor": "Mentor:in", "employer": "Arbeitgeber:in", "peer": "Mitlernende:r", "parent": "Elternteil"},
+	"en": {"teacher": "teacher", "trainer": "trainer", "mentor": "mentor", "employer": "employer", "peer": "peer", "parent": "parent"},
+}
+
+func roleLabel(role, locale string) string {
+	if labels, ok := roleLabels[language(locale)]; ok {
+		return labels[role]
+	}
+	return roleLabels[mail.DefaultLocale][role]
+}
+
+func learnerFallbackName(locale string) string {
+	if language(locale) == "en" {
+		return "A SkillR learner"
+	}
+	return "Ein:e SkillR-Lernende:r"
+}
+
+func formatDate(t time.Time, locale string) string {
+	if language(locale) == "en" {
+		return t.Format("2 January 2006")
+	}
+	return t.Format("02.01.2006")
+}
+
+func language(locale string) string {
+	lang, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	return lang
+}
+
 func (s *Service) Pending(ctx context.Context, learnerID uuid.UUID) ([]EndorsementInvite, int, error) {
-	return s.repo.ListPendingInvites(ctx, learnerID)
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
+	invites, total, err := s.repo.ListPendingInvites(ctx, learnerID)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range invites {
+		invites[i].QRCodeURL = qrCodeURL(invites[i].ID)
+	}
+	return invites, total, nil
 }
 
+// Visibility shows or hides an accepted endorsement on the profile.
 func (s *Service) Visibility(ctx context.Context, id uuid.UUID, learnerID uuid.UUID, visible bool) (*Endorsement, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	e, err := s.learnerEndorsement(ctx, learnerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if e.Status != StatusAccepted {
+		return nil, ErrNotAccepted
+	}
 	return s.repo.UpdateVisibility(ctx, id, learnerID, visible)
 }
 
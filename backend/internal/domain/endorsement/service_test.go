@@ -0,0 +1,943 @@
+package endorsement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/mail"
+)
+
+type mockRepo struct {
+	endorsements []Endorsement
+	invites      map[string]*EndorsementInvite
+	codes        map[uuid.UUID]string
+	codeExpiry   map[uuid.UUID]time.Time
+	attempts     map[uuid.UUID]int
+	orgDomains   map[string]string
+	events       []InviteEvent
+	rubrics      []*Rubric
+	reports      []Report
+	blocked      map[string]bool
+	audit        []ModerationEntry
+	// revoked holds the reason credentials of an endorsement were revoked.
+	revoked map[uuid.UUID]string
+}
+
+func newMockRepo() *mockRepo {
+	return &mockRepo{
+		invites:    map[string]*EndorsementInvite{},
+		codes:      map[uuid.UUID]string{},
+		codeExpiry: map[uuid.UUID]time.Time{},
+		attempts:   map[uuid.UUID]int{},
+		orgDomains: map[string]string{},
+		blocked:    map[string]bool{},
+		revoked:    map[uuid.UUID]string{},
+	}
+}
+
+func (m *mockRepo) Create(_ context.Context, e *Endorsement, audit *ModerationEntry) error {
+	m.endorsements = append(m.endorsements, *e)
+	m.audit = append(m.audit, *audit)
+	return nil
+}
+
+func (m *mockRepo) List(context.Context, uuid.UUID, int, int) ([]Endorsement, int, error) {
+	return m.endorsements, len(m.endorsements), nil
+}
+
+func (m *mockRepo) endorsement(id uuid.UUID) *Endorsement {
+	for i := range m.endorsements {
+		if m.endorsements[i].ID == id {
+			return &m.endorsements[i]
+		}
+	}
+	return nil
+}
+
+func (m *mockRepo) GetByID(_ context.Context, id, learnerID uuid.UUID) (*Endorsement, error) {
+	if e := m.endorsement(id); e != nil && e.LearnerID == learnerID {
+		cp := *e
+		return &cp, nil
+	}
+	return nil, nil
+}
+
+func (m *mockRepo) UpdateVisibility(_ context.Context, id, _ uuid.UUID, visible bool) (*Endorsement, error) {
+	e := m.endorsement(id)
+	e.Visible = visible
+	cp := *e
+	return &cp, nil
+}
+
+func (m *mockRepo) CreateInvite(_ context.Context, inv *EndorsementInvite) error {
+	cp := *inv
+	m.invites[inv.Token] = &cp
+	return nil
+}
+
+func (m *mockRepo) GetInviteByToken(_ context.Context, token string) (*EndorsementInvite, error) {
+	inv, ok := m.invites[token]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	cp := *inv
+	return &cp, nil
+}
+
+func (m *mockRepo) ListPendingInvites(context.Context, uuid.UUID) ([]EndorsementInvite, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockRepo) MarkInviteCompleted(_ context.Context, token string) error {
+	m.invites[token].Status = InviteCompleted
+	return nil
+}
+
+func (m *mockRepo) byID(id uuid.UUID) *EndorsementInvite {
+	for _, inv := range m.invites {
+		if inv.ID == id {
+			return inv
+		}
+	}
+	return nil
+}
+
+func (m *mockRepo) GetInvite(_ context.Context, id, learnerID uuid.UUID) (*EndorsementInvite, error) {
+	inv := m.byID(id)
+	if inv == nil || inv.LearnerID != learnerID {
+		return nil, nil
+	}
+	cp := *inv
+	return &cp, nil
+}
+
+func (m *mockRepo) RevokeInvite(_ context.Context, id, learnerID uuid.UUID) (bool, error) {
+	inv := m.byID(id)
+	if inv == nil || inv.LearnerID != learnerID || inv.Status != InvitePending {
+		return false, nil
+	}
+	inv.Status = InviteRevoked
+	return true, nil
+}
+
+func (m *mockRepo) RenewInvite(_ context.Context, id uuid.UUID, expiresAt, sentAt time.Time) error {
+	inv := m.byID(id)
+	inv.Status, inv.ExpiresAt, inv.LastSentAt, inv.ReminderCount = InvitePending, expiresAt, &sentAt, 0
+	return nil
+}
+
+func (m *mockRepo) ExpireInvites(_ context.Context, now time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, inv := range m.invites {
+		if inv.Status == InvitePending && !inv.ExpiresAt.After(now) {
+			inv.Status = InviteExpired
+			ids = append(ids, inv.ID)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockRepo) ClaimReminders(_ context.Context, sentBefore, at time.Time, maxReminders, limit int) ([]EndorsementInvite, error) {
+	var out []EndorsementInvite
+	for _, inv := range m.invites {
+		if inv.Status == InvitePending && inv.ExpiresAt.After(time.Now()) && inv.LastSentAt.Before(sentBefore) && inv.ReminderCount < maxReminders && len(out) < limit {
+			inv.ReminderCount++
+			inv.LastSentAt = &at
+			out = append(out, *inv)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRepo) AddInviteEvent(_ context.Context, _ uuid.UUID, kind string, mailID *uuid.UUID, at time.Time) error {
+	m.events = append(m.events, InviteEvent{Kind: kind, MailID: mailID, CreatedAt: at})
+	return nil
+}
+
+func (m *mockRepo) SetVerificationCode(_ context.Context, inviteID uuid.UUID, codeHash string, expiresAt time.Time) error {
+	m.codes[inviteID], m.codeExpiry[inviteID], m.attempts[inviteID] = codeHash, expiresAt, 0
+	return nil
+}
+
+func (m *mockRepo) ConfirmVerificationCode(_ context.Context, inviteID uuid.UUID, codeHash string, maxAttempts int) (bool, error) {
+	if m.codes[inviteID] == codeHash && time.Now().Before(m.codeExpiry[inviteID]) && m.attempts[inviteID] < maxAttempts {
+		now := time.Now()
+		for _, inv := range m.invites {
+			if inv.ID == inviteID {
+				inv.EmailVerifiedAt = &now
+			}
+		}
+		delete(m.codes, inviteID)
+		return true, nil
+	}
+	m.attempts[inviteID]++
+	return false, nil
+}
+
+func (m *mockRepo) OrganizationForDomain(_ context.Context, domains []string) (string, error) {
+	for _, d := range domains {
+		if org, ok := m.orgDomains[d]; ok {
+			return org, nil
+		}
+	}
+	return "", nil
+}
+
+func (m *mockRepo) ActiveRubric(_ context.Context, role string) (*Rubric, error) {
+	for _, r := range m.rubrics {
+		if r.Role == role && r.Active {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepo) GetRubric(_ context.Context, id uuid.UUID) (*Rubric, error) {
+	for _, r := range m.rubrics {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepo) ListRubrics(_ context.Context, role string, activeOnly bool) ([]Rubric, error) {
+	var out []Rubric
+	for _, r := range m.rubrics {
+		if (role == "" || r.Role == role) && (!activeOnly || r.Active) {
+			out = append(out, *r)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRepo) CreateRubric(_ context.Context, rb *Rubric) error {
+	rb.Version = 1
+	for _, r := range m.rubrics {
+		if r.Role == rb.Role {
+			r.Active = false
+			rb.Version = max(rb.Version, r.Version+1)
+		}
+	}
+	rb.CreatedAt = time.Now().UTC()
+	m.rubrics = append(m.rubrics, rb)
+	return nil
+}
+
+func (m *mockRepo) DeactivateRubric(_ context.Context, id uuid.UUID) (bool, error) {
+	for _, r := range m.rubrics {
+		if r.ID == id {
+			r.Active = false
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockRepo) Decide(_ context.Context, id, _ uuid.UUID, status string, visible bool, at time.Time, revoke string, audit *ModerationEntry) error {
+	e := m.endorsement(id)
+	e.Status, e.Visible, e.DecidedAt = status, visible, &at
+	m.revoke(id, revoke)
+	m.audit = append(m.audit, *audit)
+	return nil
+}
+
+func (m *mockRepo) CreateReport(_ context.Context, r *Report, audit *ModerationEntry) error {
+	m.reports = append(m.reports, *r)
+	if e := m.endorsement(r.EndorsementID); e.ModerationStatus == ModerationClear {
+		e.ModerationStatus = ModerationFlagged
+	}
+	m.audit = append(m.audit, *audit)
+	return nil
+}
+
+func (m *mockRepo) ListModeration(_ context.Context, status string, _, _ int) ([]ModerationCase, int, error) {
+	var cases []ModerationCase
+	for _, e := range m.endorsements {
+		if e.ModerationStatus == status {
+			c := ModerationCase{Endorsement: e}
+			for _, r := range m.reports {
+				if r.EndorsementID == e.ID {
+					c.Reports = append(c.Reports, r)
+				}
+			}
+			cases = append(cases, c)
+		}
+	}
+	return cases, len(cases), nil
+}
+
+func (m *mockRepo) GetEndorsement(_ context.Context, id uuid.UUID) (*Endorsement, error) {
+	if e := m.endorsement(id); e != nil {
+		cp := *e
+		return &cp, nil
+	}
+	return nil, nil
+}
+
+func (m *mockRepo) SetModeration(_ context.Context, id uuid.UUID, status string, at time.Time, revoke string, audit *ModerationEntry) error {
+	m.endorsement(id).ModerationStatus = status
+	m.revoke(id, revoke)
+	for i := range m.reports {
+		if m.reports[i].EndorsementID == id && m.reports[i].ResolvedAt == nil {
+			m.reports[i].ResolvedAt = &at
+		}
+	}
+	m.audit = append(m.audit, *audit)
+	return nil
+}
+
+func (m *mockRepo) revoke(id uuid.UUID, reason string) {
+	if reason != "" {
+		if _, ok := m.revoked[id]; !ok {
+			m.revoked[id] = reason
+		}
+	}
+}
+
+func (m *mockRepo) ListModerationLog(_ context.Context, id uuid.UUID) ([]ModerationEntry, error) {
+	var out []ModerationEntry
+	for _, a := range m.audit {
+		if a.EndorsementID != nil && *a.EndorsementID == id {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRepo) BlockEmail(_ context.Context, b *BlockedEmail, audit *ModerationEntry) error {
+	m.blocked[b.Email] = true
+	for _, inv := range m.invites {
+		if strings.EqualFold(inv.EndorserEmail, b.Email) && inv.Status == InvitePending {
+			inv.Status = InviteRevoked
+		}
+	}
+	m.audit = append(m.audit, *audit)
+	return nil
+}
+
+func (m *mockRepo) UnblockEmail(_ context.Context, email string, audit *ModerationEntry) (bool, error) {
+	if !m.blocked[email] {
+		return false, nil
+	}
+	delete(m.blocked, email)
+	m.audit = append(m.audit, *audit)
+	return true, nil
+}
+
+func (m *mockRepo) ListBlockedEmails(context.Context) ([]BlockedEmail, error) {
+	var out []BlockedEmail
+	for email := range m.blocked {
+		out = append(out, BlockedEmail{Email: email})
+	}
+	return out, nil
+}
+
+func (m *mockRepo) IsEmailBlocked(_ context.Context, email string) (bool, error) {
+	return m.blocked[email], nil
+}
+
+func (m *mockRepo) LearnerName(context.Context, uuid.UUID) (string, error) {
+	return "Ada", nil
+}
+
+type sentMail struct {
+	to, template, locale string
+	data                 map[string]interface{}
+}
+
+type mockMailer struct{ sent []sentMail }
+
+func (m *mockMailer) Enqueue(_ context.Context, to, template, locale string, data map[string]interface{}) (uuid.UUID, error) {
+	m.sent = append(m.sent, sentMail{to, template, locale, data})
+	return uuid.New(), nil
+}
+
+func newTestService() (*Service, *mockRepo, *mockMailer) {
+	repo, mailer := newMockRepo(), &mockMailer{}
+	svc := NewService(repo)
+	svc.SetMailer(mailer, "https://app.example/")
+	return svc, repo, mailer
+}
+
+func TestInvite_SendsMail(t *testing.T) {
+	svc, repo, mailer := newTestService()
+	inv, err := svc.Invite(context.Background(), uuid.New(), EndorsementInviteRequest{
+		EndorserEmail: "lehrer@schule.de", EndorserRole: "teacher", Locale: "en",
+	})
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	if _, ok := repo.invites[inv.Token]; !ok {
+		t.Fatal("invite must be stored under its raw token")
+	}
+	if len(mailer.sent) != 1 {
+		t.Fatalf("expected one mail, got %d", len(mailer.sent))
+	}
+	m := mailer.sent[0]
+	if m.to != "lehrer@schule.de" || m.locale != "en" || m.data["InviteURL"] != "https://app.example"+inv.InviteURL || m.data["Role"] != "teacher" {
+		t.Errorf("unexpected invite mail: %+v", m)
+	}
+}
+
+func TestVerification_MarksEndorsementVerified(t *testing.T) {
+	svc, repo, mailer := newTestService()
+	ctx := context.Background()
+	repo.orgDomains["schule.de"] = "Gymnasium Musterstadt"
+	inv, _ := svc.Invite(ctx, uuid.New(), EndorsementInviteRequest{EndorserEmail: "frau.x@lehrer.schule.de", EndorserRole: "teacher"})
+
+	if err := svc.RequestVerification(ctx, VerificationCodeRequest{InvitationToken: inv.Token}); err != nil {
+		t.Fatalf("RequestVerification: %v", err)
+	}
+	last := mailer.sent[len(mailer.sent)-1]
+	code, _ := last.data["Code"].(string)
+	if last.to != "frau.x@lehrer.schule.de" || len(code) != 6 {
+		t.Fatalf("expected six-digit code mailed to the invited address, got %+v", last)
+	}
+
+	if _, err := svc.Verify(ctx, VerifyRequest{InvitationToken: inv.Token, Code: "000000x"}); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("wrong code: got %v, want ErrInvalidCode", err)
+	}
+	verified, err := svc.Verify(ctx, VerifyRequest{InvitationToken: inv.Token, Code: code})
+	if err != nil || verified.EmailVerifiedAt == nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	e, err := svc.Submit(ctx, SubmitEndorsementRequest{InvitationToken: inv.Token, EndorserName: "Frau X", EndorserRole: "teacher", Statement: "Sehr engagiert."})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if !e.EndorserVerified || e.VerificationMethod != VerifiedByOrganization || e.EndorserOrganization == nil || *e.EndorserOrganization != "Gymnasium Musterstadt" {
+		t.Errorf("expected organisation-verified endorsement, got %+v", e)
+	}
+}
+
+func TestVerification_AttemptLimit(t *testing.T) {
+	svc, _, mailer := newTestService()
+	ctx := context.Background()
+	inv, _ := svc.Invite(ctx, uuid.New(), EndorsementInviteRequest{EndorserEmail: "a@example.com", EndorserRole: "mentor"})
+	if err := svc.RequestVerification(ctx, VerificationCodeRequest{InvitationToken: inv.Token}); err != nil {
+		t.Fatalf("RequestVerification: %v", err)
+	}
+	code := mailer.sent[len(mailer.sent)-1].data["Code"].(string)
+
+	for i := 0; i < verifyCodeAttempts; i++ {
+		if _, err := svc.Verify(ctx, VerifyRequest{InvitationToken: inv.Token, Code: "wrong"}); !errors.Is(err, ErrInvalidCode) {
+			t.Fatalf("attempt %d: got %v", i+1, err)
+		}
+	}
+	if _, err := svc.Verify(ctx, VerifyRequest{InvitationToken: inv.Token, Code: code}); !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("correct code after %d failed attempts must be rejected, got %v", verifyCodeAttempts, err)
+	}
+}
+
+func TestSubmit_UnverifiedWithoutCode(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+	repo.orgDomains["schule.de"] = "Gymnasium Musterstadt"
+	inv, _ := svc.Invite(ctx, uuid.New(), EndorsementInviteRequest{EndorserEmail: "lehrer@schule.de", EndorserRole: "teacher"})
+
+	e, err := svc.Submit(ctx, SubmitEndorsementRequest{InvitationToken: inv.Token, EndorserName: "X", EndorserRole: "teacher", Statement: "Gut."})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	if e.EndorserVerified || e.VerificationMethod != "" || e.EndorserOrganization != nil {
+		t.Errorf("a domain alone must not verify the endorser, got %+v", e)
+	}
+	if _, err := svc.Submit(ctx, SubmitEndorsementRequest{InvitationToken: inv.Token, Statement: "again"}); err == nil {
+		t.Error("expected error for a completed invite")
+	}
+}
+
+func TestRequestVerification_NoMailer(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	inv, _ := svc.Invite(context.Background(), uuid.New(), EndorsementInviteRequest{EndorserEmail: "a@example.com", EndorserRole: "peer"})
+	if err := svc.RequestVerification(context.Background(), VerificationCodeRequest{InvitationToken: inv.Token}); !errors.Is(err, ErrMailUnavailable) {
+		t.Errorf("got %v, want ErrMailUnavailable", err)
+	}
+}
+
+func TestDomainCandidates(t *testing.T) {
+	cases := map[string][]string{
+		"a@lehrer.schule.de": {"lehrer.schule.de", "schule.de"},
+		"A@Firma.DE":         {"firma.de"},
+		"a@localhost":        nil,
+		"invalid":            nil,
+	}
+	for in, want := range cases {
+		if got := domainCandidates(in); !reflect.DeepEqual(got, want) {
+			t.Errorf("domainCandidates(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+	learner := uuid.New()
+	inv, _ := svc.Invite(ctx, learner, EndorsementInviteRequest{EndorserEmail: "a@example.com", EndorserRole: "mentor"})
+
+	if err := svc.Revoke(ctx, uuid.New(), inv.ID); !errors.Is(err, ErrInviteNotFound) {
+		t.Errorf("foreign learner: got %v, want ErrInviteNotFound", err)
+	}
+	if err := svc.Revoke(ctx, learner, inv.ID); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if err := svc.Revoke(ctx, learner, inv.ID); !errors.Is(err, ErrInviteClosed) {
+		t.Errorf("second revoke: got %v, want ErrInviteClosed", err)
+	}
+	if _, err := svc.Submit(ctx, SubmitEndorsementRequest{InvitationToken: inv.Token, EndorserName: "X", EndorserRole: "mentor", Statement: "Gut."}); err == nil {
+		t.Error("a revoked invite must not accept submissions")
+	}
+	if _, err := svc.Resend(ctx, learner, inv.ID); !errors.Is(err, ErrInviteClosed) {
+		t.Errorf("resend revoked: got %v, want ErrInviteClosed", err)
+	}
+	if last := repo.events[len(repo.events)-1]; last.Kind != EventRevoked {
+		t.Errorf("expected revoked event, got %+v", last)
+	}
+}
+
+func TestResend(t *testing.T) {
+	svc, repo, mailer := newTestService()
+	ctx := context.Background()
+	learner := uuid.New()
+	inv, _ := svc.Invite(ctx, learner, EndorsementInviteRequest{EndorserEmail: "a@example.com", EndorserRole: "mentor"})
+
+	if _, err := svc.Resend(ctx, learner, inv.ID); !errors.Is(err, ErrResendTooSoon) {
+		t.Errorf("immediate resend: got %v, want ErrResendTooSoon", err)
+	}
+
+	// An expired invite can be reactivated with the same link.
+	stored := repo.invites[inv.Token]
+	stored.Status = InviteExpired
+	stored.ExpiresAt = time.Now().Add(-time.Hour)
+	stored.ReminderCount = 2
+	resent, err := svc.Resend(ctx, learner, inv.ID)
+	if err != nil {
+		t.Fatalf("Resend: %v", err)
+	}
+	if stored.Status != InvitePending || !stored.ExpiresAt.After(time.Now()) || stored.ReminderCount != 0 {
+		t.Errorf("invite not renewed: %+v", stored)
+	}
+	if resent.InviteURL != inv.InviteURL || len(mailer.sent) != 2 {
+		t.Errorf("expected the same link mailed again, got %q and %d mails", resent.InviteURL, len(mailer.sent))
+	}
+	if last := repo.events[len(repo.events)-1]; last.Kind != EventResent || last.MailID == nil {
+		t.Errorf("expected resent event with mail, got %+v", last)
+	}
+}
+
+func TestExpireInvites(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+	old, _ := svc.Invite(ctx, uuid.New(), EndorsementInviteRequest{EndorserEmail: "a@example.com", EndorserRole: "peer"})
+	fresh, _ := svc.Invite(ctx, uuid.New(), EndorsementInviteRequest{EndorserEmail: "b@example.com", EndorserRole: "peer"})
+	repo.invites[old.Token].ExpiresAt = time.Now().Add(-time.Minute)
+
+	n, err := svc.ExpireInvites(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("ExpireInvites = %d, %v; want 1", n, err)
+	}
+	if repo.invites[old.Token].Status != InviteExpired || repo.invites[fresh.Token].Status != InvitePending {
+		t.Error("only the overdue invite must expire")
+	}
+	if last := repo.events[len(repo.events)-1]; last.Kind != EventExpired {
+		t.Errorf("expected expired event, got %+v", last)
+	}
+}
+
+func TestSendReminders(t *testing.T) {
+	svc, repo, mailer := newTestService()
+	ctx := context.Background()
+	inv, _ := svc.Invite(ctx, uuid.New(), EndorsementInviteRequest{EndorserEmail: "a@example.com", EndorserRole: "teacher", Locale: "en"})
+
+	if n, _ := svc.SendReminders(ctx); n != 0 {
+		t.Fatalf("reminders are disabled by default, sent %d", n)
+	}
+	svc.SetReminderAfter(7 * 24 * time.Hour)
+	if n, _ := svc.SendReminders(ctx); n != 0 {
+		t.Fatalf("fresh invite must not be reminded, sent %d", n)
+	}
+
+	for i := 0; i < maxReminders+1; i++ {
+		past := time.Now().Add(-8 * 24 * time.Hour)
+		repo.invites[inv.Token].LastSentAt = &past
+		n, err := svc.SendReminders(ctx)
+		if err != nil {
+			t.Fatalf("SendReminders: %v", err)
+		}
+		want := 1
+		if i == maxReminders {
+			want = 0
+		}
+		if n != want {
+			t.Errorf("round %d: sent %d, want %d", i+1, n, want)
+		}
+		// A second run, as on another instance, finds the invite claimed.
+		if n, _ := svc.SendReminders(ctx); n != 0 {
+			t.Errorf("round %d: claimed invite reminded again", i+1)
+		}
+	}
+	last := mailer.sent[len(mailer.sent)-1]
+	if last.template != mail.TemplateReminder || last.locale != "en" {
+		t.Errorf("unexpected reminder mail: %+v", last)
+	}
+	if repo.invites[inv.Token].ReminderCount != maxReminders {
+		t.Errorf("reminder count = %d, want %d", repo.invites[inv.Token].ReminderCount, maxReminders)
+	}
+}
+
+func TestInviteLink(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+	learner := uuid.New()
+	inv, _ := svc.Invite(ctx, learner, EndorsementInviteRequest{EndorserEmail: "a@example.com", EndorserRole: "peer"})
+	if inv.QRCodeURL != "/api/v1/portfolio/endorsements/invites/"+inv.ID.String()+"/qr" {
+		t.Errorf("unexpected qr_code_url %q", inv.QRCodeURL)
+	}
+
+	link, err := svc.InviteLink(ctx, learner, inv.ID)
+	if err != nil || link != "https://app.example"+inv.InviteURL {
+		t.Errorf("InviteLink = %q, %v", link, err)
+	}
+	if _, err := svc.InviteLink(ctx, uuid.New(), inv.ID); !errors.Is(err, ErrInviteNotFound) {
+		t.Errorf("foreign learner: got %v", err)
+	}
+	repo.invites[inv.Token].ExpiresAt = time.Now().Add(-time.Minute)
+	if _, err := svc.InviteLink(ctx, learner, inv.ID); !errors.Is(err, ErrInviteClosed) {
+		t.Errorf("expired invite: got %v", err)
+	}
+}
+
+func teacherRubric() RubricRequest {
+	return RubricRequest{
+		Role: "teacher",
+		Name: map[string]string{"de": "Lehrkraft", "en": "Teacher"},
+		Scale: RatingScale{Levels: []ScaleLevel{
+			{Value: 1, Label: map[string]string{"de": "selten"}},
+			{Value: 2, Label: map[string]string{"de": "manchmal"}},
+			{Value: 3, Label: map[string]string{"de": "oft"}},
+			{Value: 4, Label: map[string]string{"de": "immer"}},
+		}},
+		Dimensions: []RubricDimension{{Key: "teamwork", Required: true}, {Key: "creativity"}},
+		Prompts:    []RubricPrompt{{Key: "example", Required: true, MaxLength: 20, Question: map[string]string{"de": "Ein Beispiel?"}}},
+	}
+}
+
+func TestRubric_SubmitIsValidatedAndNormalized(t *testing.T) {
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+	rubric, err := svc.CreateRubric(ctx, teacherRubric())
+	if err != nil {
+		t.Fatalf("CreateRubric: %v", err)
+	}
+	inv, _ := svc.Invite(ctx, uuid.New(), EndorsementInviteRequest{EndorserEmail: "l@schule.de", EndorserRole: "teacher"})
+	if inv.RubricID == nil || *inv.RubricID != rubric.ID {
+		t.Fatalf("invite must lock the active rubric, got %v", inv.RubricID)
+	}
+
+	// A newer version does not change the locked rubric.
+	if _, err := svc.CreateRubric(ctx, teacherRubric()); err != nil {
+		t.Fatalf("CreateRubric v2: %v", err)
+	}
+	if got, err := svc.Rubric(ctx, inv.Token); err != nil || got.ID != rubric.ID {
+		t.Fatalf("Rubric = %v, %v", got, err)
+	}
+
+	valid := map[string]string{"example": " Projektwoche "}
+	for name, req := range map[string]SubmitEndorsementRequest{
+		"missing rating":   {Ratings: map[string]int{"creativity": 2}, Answers: valid},
+		"off scale":        {Ratings: map[string]int{"teamwork": 5}, Answers: valid},
+		"unknown rating":   {Ratings: map[string]int{"teamwork": 2, "math": 3}, Answers: valid},
+		"missing answer":   {Ratings: map[string]int{"teamwork": 2}},
+		"answer too long":  {Ratings: map[string]int{"teamwork": 2}, Answers: map[string]string{"example": "viel zu lange Antwort hier"}},
+		"unknown prompt":   {Ratings: map[string]int{"teamwork": 2}, Answers: map[string]string{"example": "ok", "other": "x"}},
+		"skill_dimensions": {Ratings: map[string]int{"teamwork": 2}, Answers: valid, SkillDimensions: map[string]float64{"teamwork": 0.5}},
+	} {
+		req.InvitationToken = inv.Token
+		if _, err := svc.Submit(ctx, req); !errors.Is(err, ErrRubricInvalid) {
+			t.Errorf("%s: got %v, want ErrRubricInvalid", name, err)
+		}
+	}
+
+	e, err := svc.Submit(ctx, SubmitEndorsementRequest{
+		InvitationToken: inv.Token, EndorserName: "Herr Y", EndorserRole: "peer", Statement: "Zuverlaessig.",
+		Ratings: map[string]int{"teamwork": 4, "creativity": 1}, Answers: valid,
+	})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	want := map[string]float64{"teamwork": 100, "creativity": 0}
+	if !reflect.DeepEqual(e.SkillDimensions, want) || e.EndorserRole != "teacher" || e.Answers["example"] != "Projektwoche" {
+		t.Errorf("unexpected endorsement: dims=%v role=%s answers=%v", e.SkillDimensions, e.EndorserRole, e.Answers)
+	}
+	if e.RubricID == nil || *e.RubricID != rubric.ID {
+		t.Errorf("endorsement must reference the locked rubric")
+	}
+}
+
+func TestRubric_InvitesWithoutRubric(t *testing.T) {
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+	inv, _ := svc.Invite(ctx, uuid.New(), EndorsementInviteRequest{EndorserEmail: "a@example.com", EndorserRole: "mentor"})
+	if _, err := svc.Rubric(ctx, inv.Token); !errors.Is(err, ErrRubricNotFound) {
+		t.Errorf("Rubric: got %v, want ErrRubricNotFound", err)
+	}
+	e, err := svc.Submit(ctx, SubmitEndorsementRequest{InvitationToken: inv.Token, EndorserName: "A", EndorserRole: "mentor", Statement: "Gut.",
+		SkillDimensions: map[string]float64{"teamwork": 0.7}})
+	if err != nil || e.RubricID != nil || e.SkillDimensions["teamwork"] != 0.7 {
+		t.Errorf("legacy submit: %+v, %v", e, err)
+	}
+}
+
+func TestCreateRubric_Validation(t *testing.T) {
+	svc, _, _ := newTestService()
+	for name, mutate := range map[string]func(*RubricRequest){
+		"role":           func(r *RubricRequest) { r.Role = "boss" },
+		"name":           func(r *RubricRequest) { r.Name = nil },
+		"one level":      func(r *RubricRequest) { r.Scale.Levels = r.Scale.Levels[:1] },
+		"unordered":      func(r *RubricRequest) { r.Scale.Levels[1].Value = 1 },
+		"no dimensions":  func(r *RubricRequest) { r.Dimensions = nil },
+		"duplicate":      func(r *RubricRequest) { r.Dimensions[1].Key = "teamwork" },
+		"invalid key":    func(r *RubricRequest) { r.Prompts[0].Key = "Bad Key" },
+		"empty question": func(r *RubricRequest) { r.Prompts[0].Question = nil },
+	} {
+		req := teacherRubric()
+		mutate(&req)
+		if _, err := svc.CreateRubric(context.Background(), req); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+// submitted invites an endorser and submits statement as them.
+func submitted(t *testing.T, svc *Service, learnerID uuid.UUID, statement string) *Endorsement {
+	t.Helper()
+	ctx := context.Background()
+	inv, err := svc.Invite(ctx, learnerID, EndorsementInviteRequest{EndorserEmail: "Mentor@Example.com", EndorserRole: "mentor"})
+	if err != nil {
+		t.Fatalf("Invite: %v", err)
+	}
+	e, err := svc.Submit(ctx, SubmitEndorsementRequest{InvitationToken: inv.Token, EndorserName: "M", EndorserRole: "mentor", Statement: statement})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	return e
+}
+
+func TestSubmit_HeldUntilAccepted(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+	learner := uuid.New()
+	e := submitted(t, svc, learner, "Hat im Praktikum vom 01.09.2024 bis 15.12.2024 sehr zuverlaessig gearbeitet.")
+
+	if e.Status != StatusPending || e.Visible || e.ModerationStatus != ModerationClear || e.EndorserEmail != "mentor@example.com" {
+		t.Fatalf("new endorsement must be held and clear, got %+v", e)
+	}
+	if _, err := svc.Visibility(ctx, e.ID, learner, true); !errors.Is(err, ErrNotAccepted) {
+		t.Errorf("Visibility before accepting: got %v, want ErrNotAccepted", err)
+	}
+	if _, err := svc.Accept(ctx, uuid.New(), e.ID, true); !errors.Is(err, ErrEndorsementNotFound) {
+		t.Errorf("Accept by another learner: got %v", err)
+	}
+
+	accepted, err := svc.Accept(ctx, learner, e.ID, true)
+	if err != nil || accepted.Status != StatusAccepted || !accepted.Visible || accepted.DecidedAt == nil {
+		t.Fatalf("Accept = %+v, %v", accepted, err)
+	}
+	declined, err := svc.Decline(ctx, learner, e.ID)
+	if err != nil || declined.Status != StatusDeclined || declined.Visible {
+		t.Fatalf("Decline = %+v, %v", declined, err)
+	}
+
+	var actions []string
+	for _, a := range repo.audit {
+		actions = append(actions, a.ActorRole+":"+a.Action)
+	}
+	if want := []string{"system:submit", "learner:accept", "learner:decline"}; !reflect.DeepEqual(actions, want) {
+		t.Errorf("audit = %v, want %v", actions, want)
+	}
+}
+
+func TestSubmit_ScreeningHoldsForReview(t *testing.T) {
+	svc, _, _ := newTestService()
+	ctx := context.Background()
+	learner, admin := uuid.New(), uuid.New()
+	e := submitted(t, svc, learner, "Du bist ein Vollidiot, schreib mir auf WhatsApp: 0151 23456789")
+
+	if e.ModerationStatus != ModerationFlagged || !reflect.DeepEqual(e.ScreeningFlags, []string{FlagAbusiveLanguage, FlagContactDetails}) {
+		t.Fatalf("expected flagged endorsement, got %s %v", e.ModerationStatus, e.ScreeningFlags)
+	}
+	if _, err := svc.Accept(ctx, learner, e.ID, true); !errors.Is(err, ErrUnderReview) {
+		t.Errorf("Accept while flagged: got %v, want ErrUnderReview", err)
+	}
+	queue, total, err := svc.ModerationQueue(ctx, "", 50, 0)
+	if err != nil || total != 1 || queue[0].ID != e.ID {
+		t.Fatalf("ModerationQueue = %v, %d, %v", queue, total, err)
+	}
+
+	if _, err := svc.Restore(ctx, admin, e.ID, "false positive"); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if _, err := svc.Accept(ctx, learner, e.ID, false); err != nil {
+		t.Errorf("Accept after restore: %v", err)
+	}
+	log, _ := svc.ModerationLog(ctx, e.ID)
+	if len(log) != 3 || log[1].Action != ActionRestore || *log[1].ActorID != admin || log[1].Details["reason"] != "false positive" {
+		t.Errorf("unexpected moderation log: %+v", log)
+	}
+}
+
+func TestReport_AdminHidesAndDeletes(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+	learner, admin := uuid.New(), uuid.New()
+	e := submitted(t, svc, learner, "Zuverlaessig.")
+	if _, err := svc.Accept(ctx, learner, e.ID, true); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+
+	if _, err := svc.Report(ctx, learner, e.ID, ReportRequest{Reason: "rude"}); !errors.Is(err, ErrInvalidReport) {
+		t.Errorf("unknown reason: got %v", err)
+	}
+	if _, err := svc.Report(ctx, learner, e.ID, ReportRequest{Reason: "harassment", Details: " Er kennt mich gar nicht. "}); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+	if _, err := svc.Visibility(ctx, e.ID, learner, true); !errors.Is(err, ErrUnderReview) {
+		t.Errorf("reported endorsement must be under review, got %v", err)
+	}
+	queue, _, _ := svc.ModerationQueue(ctx, ModerationFlagged, 50, 0)
+	if len(queue) != 1 || len(queue[0].Reports) != 1 || queue[0].Reports[0].Details != "Er kennt mich gar nicht." {
+		t.Fatalf("queue must carry the report, got %+v", queue)
+	}
+
+	if _, err := svc.Hide(ctx, admin, e.ID, "harassment"); err != nil {
+		t.Fatalf("Hide: %v", err)
+	}
+	if repo.reports[0].ResolvedAt == nil {
+		t.Error("a decision must resolve open reports")
+	}
+	if _, err := svc.Accept(ctx, learner, e.ID, true); !errors.Is(err, ErrUnderReview) {
+		t.Errorf("Accept while hidden: got %v", err)
+	}
+	if _, err := svc.Delete(ctx, admin, e.ID, ""); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := svc.Report(ctx, learner, e.ID, ReportRequest{Reason: "spam"}); !errors.Is(err, ErrEndorsementNotFound) {
+		t.Errorf("deleted endorsement must be gone for the learner, got %v", err)
+	}
+	if _, err := svc.Hide(ctx, admin, uuid.New(), ""); !errors.Is(err, ErrEndorsementNotFound) {
+		t.Errorf("unknown endorsement: got %v", err)
+	}
+	if _, _, err := svc.ModerationQueue(ctx, "clear", 50, 0); err == nil {
+		t.Error("the queue only lists moderated endorsements")
+	}
+}
+
+func TestModeration_RevokesCredentials(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+	learner, admin := uuid.New(), uuid.New()
+
+	declined := submitted(t, svc, learner, "Hilfsbereit.")
+	if _, err := svc.Decline(ctx, learner, declined.ID); err != nil {
+		t.Fatalf("Decline: %v", err)
+	}
+	hidden := submitted(t, svc, learner, "Zuverlaessig.")
+	if _, err := svc.Accept(ctx, learner, hidden.ID, true); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if got := repo.revoked[hidden.ID]; got != "" {
+		t.Errorf("accepting must not revoke credentials, got %q", got)
+	}
+	if _, err := svc.Hide(ctx, admin, hidden.ID, "spam"); err != nil {
+		t.Fatalf("Hide: %v", err)
+	}
+	deleted := submitted(t, svc, learner, "Kreativ.")
+	if _, err := svc.Accept(ctx, learner, deleted.ID, true); err != nil {
+		t.Fatalf("Accept: %v", err)
+	}
+	if _, err := svc.Delete(ctx, admin, deleted.ID, "harassment"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	for id, want := range map[uuid.UUID]string{
+		declined.ID: "endorsement_declined",
+		hidden.ID:   "endorsement_hidden",
+		deleted.ID:  "endorsement_deleted",
+	} {
+		if got := repo.revoked[id]; got != want {
+			t.Errorf("revocation reason = %q, want %q", got, want)
+		}
+	}
+
+	delete(repo.revoked, hidden.ID)
+	if _, err := svc.Restore(ctx, admin, hidden.ID, ""); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if got := repo.revoked[hidden.ID]; got != "" {
+		t.Errorf("restoring must not revoke credentials, got %q", got)
+	}
+}
+
+func TestBlockEmail(t *testing.T) {
+	svc, repo, _ := newTestService()
+	ctx := context.Background()
+	learner, admin := uuid.New(), uuid.New()
+	inv, _ := svc.Invite(ctx, learner, EndorsementInviteRequest{EndorserEmail: "troll@example.com", EndorserRole: "peer"})
+
+	if _, err := svc.BlockEmail(ctx, admin, BlockEmailRequest{Email: "not-an-address"}); err == nil {
+		t.Error("expected error for an invalid address")
+	}
+	if _, err := svc.BlockEmail(ctx, admin, BlockEmailRequest{Email: " Troll@Example.com ", Reason: "abuse"}); err != nil {
+		t.Fatalf("BlockEmail: %v", err)
+	}
+	if repo.invites[inv.Token].Status != InviteRevoked {
+		t.Error("pending invites of a blocked address must be revoked")
+	}
+	if _, err := svc.Invite(ctx, learner, EndorsementInviteRequest{EndorserEmail: "TROLL@example.com", EndorserRole: "peer"}); !errors.Is(err, ErrEndorserBlocked) {
+		t.Errorf("Invite to blocked address: got %v", err)
+	}
+
+	if err := svc.UnblockEmail(ctx, admin, "troll@example.com"); err != nil {
+		t.Fatalf("UnblockEmail: %v", err)
+	}
+	if err := svc.UnblockEmail(ctx, admin, "troll@example.com"); !errors.Is(err, ErrNotBlocked) {
+		t.Errorf("second unblock: got %v, want ErrNotBlocked", err)
+	}
+	inv, _ = svc.Invite(ctx, learner, EndorsementInviteRequest{EndorserEmail: "troll@example.com", EndorserRole: "peer"})
+	repo.blocked["troll@example.com"] = true
+	if _, err := svc.Submit(ctx, SubmitEndorsementRequest{InvitationToken: inv.Token, Statement: "x"}); !errors.Is(err, ErrEndorserBlocked) {
+		t.Errorf("Submit from blocked address: got %v", err)
+	}
+	if n := len(repo.audit); n != 2 || repo.audit[0].EmailHash != hashEmail("troll@example.com") || repo.audit[1].Action != ActionUnblock {
+		t.Errorf("unexpected audit: %+v", repo.audit)
+	}
+}
+
+func TestKeywordScreener(t *testing.T) {
+	sc := NewKeywordScreener()
+	for text, want := range map[string][]string{
+		"Sie hat die Projektwoche vom 01.09.2024 bis 15.12.2024 souveraen geleitet.": {},
+		"Sehr hilfsbereit im Team, 3 Jahre dabei, Note 1,3.":                         {},
+		"Was fuer ein 1d10t":          {FlagAbusiveLanguage},
+		"Schick mir Nacktbilder":      {FlagSexualContent},
+		"Ich bring dich um":           {FlagThreat},
+		"Meld dich: lea@example.org":  {FlagContactDetails, FlagLink},
+		"Ruf an unter +49 30 1234567": {FlagContactDetails},
+		"Folg mir @lea.macht.sachen":  {FlagContactDetails},
+		"Mehr auf www.example.de":     {FlagLink},
+	} {
+		if got := sc.Screen(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("Screen(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
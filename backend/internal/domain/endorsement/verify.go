@@ -0,0 +1,158 @@
+package endorsement
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"skillr-mvp-v1/backend/internal/mail"
+)
+
+var (
+	ErrInvalidCode     = errors.New("invalid or expired verification code")
+	ErrMailUnavailable = errors.New("verification mail not available")
+)
+
+const (
+	verifyCodeTTL      = 15 * time.Minute
+	verifyCodeAttempts = 5
+)
+
+// RequestVerification mails a one-time code to the invited address. The code
+// is never returned to the caller: whoever holds the invite link (including
+// the learner) can only verify by reading the endorser's inbox.
+func (s *Service) RequestVerification(ctx context.Context, req VerificationCodeRequest) error {
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	if s.mailer == nil {
+		return ErrMailUnavailable
+	}
+	invite, err := s.openInvite(ctx, req.InvitationToken)
+	if err != nil {
+		return err
+	}
+	if invite.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	code, err := generateCode()
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetVerificationCode(ctx, invite.ID, hashCode(req.InvitationToken, code), time.Now().Add(verifyCodeTTL).UTC()); err != nil {
+		return fmt.Errorf("store verification code: %w", err)
+	}
+	locale := req.Locale
+	if locale == "" {
+		locale = mail.DefaultLocale
+	}
+	_, err = s.mailer.Enqueue(ctx, invite.EndorserEmail, mail.TemplateVerification, locale, map[string]interface{}{
+		"Code":      code,
+		"ExpiresIn": int(verifyCodeTTL.Minutes()),
+	})
+	if err != nil {
+		return fmt.Errorf("queue verification mail: %w", err)
+	}
+	return nil
+}
+
+// Verify confirms the one-time code for the invite. Endorsements submitted
+// afterwards are marked as verified.
+func (s *Service) Verify(ctx context.Context, req VerifyRequest) (*EndorsementInvite, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	invite, err := s.openInvite(ctx, req.InvitationToken)
+	if err != nil {
+		return nil, err
+	}
+	if invite.EmailVerifiedAt != nil {
+		return invite, nil
+	}
+	code := strings.TrimSpace(req.Code)
+	if code == "" {
+		return nil, ErrInvalidCode
+	}
+	ok, err := s.repo.ConfirmVerificationCode(ctx, invite.ID, hashCode(req.InvitationToken, code), verifyCodeAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("confirm verification code: %w", err)
+	}
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+	now := time.Now().UTC()
+	invite.EmailVerifiedAt = &now
+	return invite, nil
+}
+
+// openInvite returns the pending, unexpired invite for token.
+func (s *Service) openInvite(ctx context.Context, token string) (*EndorsementInvite, error) {
+	if token == "" {
+		return nil, fmt.Errorf("invitation_token is required")
+	}
+	invite, err := s.repo.GetInviteByToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invitation token")
+	}
+	if invite.Status != InvitePending {
+		return nil, fmt.Errorf("invitation already used, revoked or expired")
+	}
+	if time.Now().After(invite.ExpiresAt) {
+		return nil, fmt.Errorf("invitation has expired")
+	}
+	return invite, nil
+}
+
+// applyVerification marks e as verified if the invite's address was
+// confirmed, and attaches the organisation of a verified domain.
+func (s *Service) applyVerification(ctx context.Context, e *Endorsement, invite *EndorsementInvite) {
+	if invite.EmailVerifiedAt == nil {
+		return
+	}
+	e.EndorserVerified = true
+	e.VerificationMethod = VerifiedByEmail
+	org, err := s.repo.OrganizationForDomain(ctx, domainCandidates(invite.EndorserEmail))
+	if err == nil && org != "" {
+		e.VerificationMethod = VerifiedByOrganization
+		e.EndorserOrganization = &org
+	}
+}
+
+// domainCandidates returns the address's domain and its parent domains, so
+// that "lehrer.schule.de" matches a verified "schule.de". Top-level domains
+// are never candidates.
+func domainCandidates(email string) []string {
+	_, domain, ok := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	if !ok || domain == "" {
+		return nil
+	}
+	var out []string
+	for strings.Contains(domain, ".") {
+		out = append(out, domain)
+		_, domain, _ = strings.Cut(domain, ".")
+	}
+	return out
+}
+
+// generateCode returns a random six-digit code.
+func generateCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", fmt.Errorf("generate code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashCode binds the code to its invite so equal codes of different invites
+// have different hashes.
+func hashCode(token, code string) string {
+	sum := sha256.Sum256([]byte(token + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
@@ -1,6 +1,7 @@
 package engagement
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -45,9 +46,12 @@ func (h *Handler) Award(c echo.Context) error {
 	}
 
 	state, err := h.svc.Award(c.Request().Context(), userID, req)
-	if err != nil {
+	if errors.Is(err, ErrUnknownAction) || errors.Is(err, ErrMissingInstance) || errors.Is(err, ErrUnknownInstance) {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to award XP")
+	}
 	return c.JSON(http.StatusOK, state)
 }
 
@@ -71,6 +75,30 @@ func (h *Handler) Leaderboard(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]interface{}{"rankings": entries, "period": period, "user_rank": rank})
 }
 
+// AdminRebuild derives the engagement state of every user from the XP
+// ledger again.
+func (h *Handler) AdminRebuild(c echo.Context) error {
+	n, err := h.svc.RebuildAll(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to rebuild engagement state")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"rebuilt": n})
+}
+
+// AdminRebuildUser derives one user's engagement state from the XP ledger
+// again and returns it.
+func (h *Handler) AdminRebuildUser(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid user ID")
+	}
+	state, err := h.svc.Rebuild(c.Request().Context(), userID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to rebuild engagement state")
+	}
+	return c.JSON(http.StatusOK, state)
+}
+
 func deriveUUID(firebaseUID string) uuid.UUID {
 	return uuid.NewSHA1(uuid.NameSpaceDNS, []byte(firebaseUID))
 }
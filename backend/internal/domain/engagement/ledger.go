@@ -0,0 +1,131 @@
+package engagement
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// maxInstanceValue bounds a context value that goes into an idempotency key.
+const maxInstanceValue = 200
+
+// IdempotencyKey identifies the action instance of req: the action and the
+// values of its InstanceFields, or the day for daily_login.
+func IdempotencyKey(req AwardXPRequest, now time.Time) (string, error) {
+	fields, ok := InstanceFields[req.Action]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownAction, req.Action)
+	}
+	parts := []string{req.Action}
+	if req.Action == "daily_login" {
+		parts = append(parts, now.UTC().Format(dateLayout))
+	}
+	for _, f := range fields {
+		v, ok := req.Context[f]
+		if !ok || v == nil {
+			return "", fmt.Errorf("%w: context.%s is required for %s", ErrMissingInstance, f, req.Action)
+		}
+		s := strings.TrimSpace(fmt.Sprint(v))
+		if s == "" || len(s) > maxInstanceValue {
+			return "", fmt.Errorf("%w: invalid context.%s", ErrMissingInstance, f)
+		}
+		parts = append(parts, url.QueryEscape(s))
+	}
+	return strings.Join(parts, ":"), nil
+}
+
+// Derive computes the engagement state from a user's ledger as of now.
+// Days are UTC calendar days and weeks start on Monday.
+//
+// The streak counts consecutive active days. A streak freeze is earned
+// whenever the streak reaches a multiple of StreakFreezeEvery; it bridges a
+// single missed day and is then used up. A longer gap, or a missed day
+// without a freeze, halves the streak (the new day counts on top) and
+// forfeits the freeze. These are the rules of the web client.
+func Derive(txns []Transaction, now time.Time) *EngagementState {
+	txns = append([]Transaction(nil), txns...)
+	sort.SliceStable(txns, func(i, j int) bool { return txns[i].AwardedAt.Before(txns[j].AwardedAt) })
+
+	now = now.UTC()
+	weekStart := startOfWeek(now)
+
+	state := &EngagementState{LedgerCount: len(txns)}
+	var last time.Time
+	for _, t := range txns {
+		state.TotalXP += t.XP
+		if t.Action != ActionLegacyBalance && !t.AwardedAt.Before(weekStart) {
+			state.WeeklyXP += t.XP
+		}
+
+		day := startOfDay(t.AwardedAt.UTC())
+		switch gap := int(day.Sub(last).Hours() / 24); {
+		case last.IsZero():
+			state.CurrentStreak = 1
+		case gap == 0:
+			continue
+		case gap == 1, gap == 2 && state.StreakFreezeAvailable:
+			if gap == 2 {
+				state.StreakFreezeAvailable = false
+			}
+			state.CurrentStreak++
+			if state.CurrentStreak%StreakFreezeEvery == 0 {
+				state.StreakFreezeAvailable = true
+			}
+		default:
+			state.CurrentStreak = state.CurrentStreak/2 + 1
+			state.StreakFreezeAvailable = false
+		}
+		state.LongestStreak = max(state.LongestStreak, state.CurrentStreak)
+		last = day
+	}
+
+	if last.IsZero() {
+		last = now
+	}
+	state.LastActiveDate = last.Format(dateLayout)
+	state.Level, state.LevelTitle = levelFor(state.TotalXP)
+	return state
+}
+
+// asOf returns the stored state as it stands at now without further
+// activity. Once the streak can no longer be continued today it is halved
+// and the freeze forfeited, as Derive does at the next active day (which
+// then counts on top). Weekly XP earned before the current week drops out.
+func asOf(state EngagementState, now time.Time) *EngagementState {
+	last, err := time.Parse(dateLayout, state.LastActiveDate)
+	if err != nil {
+		return &state
+	}
+	now = now.UTC()
+	if last.Before(startOfWeek(now)) {
+		state.WeeklyXP = 0
+	}
+	switch gap := int(startOfDay(now).Sub(last).Hours() / 24); {
+	case gap > 2, gap == 2 && !state.StreakFreezeAvailable:
+		state.CurrentStreak /= 2
+		state.StreakFreezeAvailable = false
+	}
+	return &state
+}
+
+func levelFor(xp int) (int, string) {
+	for i := len(Levels) - 1; i > 0; i-- {
+		if xp >= Levels[i].MinXP {
+			return i + 1, Levels[i].Title
+		}
+	}
+	return 1, Levels[0].Title
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// startOfWeek returns the Monday of t's week.
+func startOfWeek(t time.Time) time.Time {
+	return startOfDay(t).AddDate(0, 0, -(int(t.Weekday())+6)%7)
+}
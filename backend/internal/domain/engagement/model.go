@@ -1,18 +1,23 @@
 package engagement
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type EngagementState struct {
-	CurrentStreak        int    `json:"current_streak"`
-	LongestStreak        int    `json:"longest_streak"`
-	TotalXP              int    `json:"total_xp"`
-	WeeklyXP             int    `json:"weekly_xp"`
-	Level                int    `json:"level"`
-	LevelTitle           string `json:"level_title"`
-	LastActiveDate       string `json:"last_active_date"`
+	CurrentStreak         int    `json:"current_streak"`
+	LongestStreak         int    `json:"longest_streak"`
+	TotalXP               int    `json:"total_xp"`
+	WeeklyXP              int    `json:"weekly_xp"`
+	Level                 int    `json:"level"`
+	LevelTitle            string `json:"level_title"`
+	LastActiveDate        string `json:"last_active_date"`
 	StreakFreezeAvailable bool   `json:"streak_freeze_available"`
+	// LedgerCount is the number of ledger transactions the state was
+	// derived from.
+	LedgerCount int `json:"-"`
 }
 
 type AwardXPRequest struct {
@@ -20,6 +25,17 @@ type AwardXPRequest struct {
 	Context map[string]interface{} `json:"context,omitempty"`
 }
 
+// Transaction is one entry of the append-only XP ledger.
+type Transaction struct {
+	ID             uuid.UUID              `json:"id"`
+	UserID         uuid.UUID              `json:"user_id"`
+	Action         string                 `json:"action"`
+	XP             int                    `json:"xp"`
+	IdempotencyKey string                 `json:"idempotency_key"`
+	Context        map[string]interface{} `json:"context,omitempty"`
+	AwardedAt      time.Time              `json:"awarded_at"`
+}
+
 type LeaderboardEntry struct {
 	Rank        int       `json:"rank"`
 	UserID      uuid.UUID `json:"user_id"`
@@ -39,6 +55,27 @@ var XPValues = map[string]int{
 	"daily_login":          5,
 }
 
+// InstanceFields are the context fields that identify one instance of an
+// action; XP is awarded once per instance. Actions without fields are
+// awarded once per user, daily_login once per day.
+var InstanceFields = map[string][]string{
+	"onboarding_complete":  nil,
+	"station_complete":     {"station_id", "session_id"},
+	"station_start":        {"station_id", "session_id"},
+	"vuca_module_complete": {"module_id"},
+	"quiz_correct":         {"question_id", "session_id"},
+	"daily_login":          nil,
+}
+
+// ActionLegacyBalance is the opening transaction that carried the XP of
+// the former engagement_state over into the ledger. It counts towards the
+// total but not the weekly XP.
+const ActionLegacyBalance = "legacy_balance"
+
+// StreakFreezeEvery is the streak length (and its multiples) that earns a
+// streak freeze. A learner holds at most one.
+const StreakFreezeEvery = 7
+
 // Level thresholds and titles
 var Levels = []struct {
 	MinXP int
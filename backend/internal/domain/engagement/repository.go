@@ -8,7 +8,25 @@ import (
 
 type Repository interface {
 	GetState(ctx context.Context, userID uuid.UUID) (*EngagementState, error)
+	// UpsertState stores a derived state unless the stored one was derived
+	// from more ledger transactions.
 	UpsertState(ctx context.Context, userID uuid.UUID, state *EngagementState) error
 	GetLeaderboard(ctx context.Context, period string, limit int) ([]LeaderboardEntry, error)
 	GetUserRank(ctx context.Context, userID uuid.UUID, period string) (int, error)
+
+	// AppendTransaction adds t to the ledger. It returns false if the user
+	// already has a transaction with the same idempotency key.
+	AppendTransaction(ctx context.Context, t *Transaction) (bool, error)
+	// ListTransactions returns the user's ledger, oldest first.
+	ListTransactions(ctx context.Context, userID uuid.UUID) ([]Transaction, error)
+	// ListUsers returns the users with ledger transactions or a stored state.
+	ListUsers(ctx context.Context) ([]uuid.UUID, error)
+
+	// SessionStation returns the station of one of the user's sessions (""
+	// if it names none); ok is false if the user has no such session.
+	SessionStation(ctx context.Context, userID, sessionID uuid.UUID) (station string, ok bool, err error)
+	// QuestionExists reports whether a reflection question has the ID.
+	QuestionExists(ctx context.Context, questionID string) (bool, error)
+	// HasModule reports whether the user has progress in a Lernreise module.
+	HasModule(ctx context.Context, userID uuid.UUID, moduleID string) (bool, error)
 }
@@ -2,77 +2,196 @@ package engagement
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"maps"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	// ErrUnknownAction is returned for actions without an XP value.
+	ErrUnknownAction = errors.New("unknown XP action")
+	// ErrMissingInstance is returned when the context lacks a field that
+	// identifies the action instance.
+	ErrMissingInstance = errors.New("missing action instance")
+	// ErrUnknownInstance is returned when a context field does not refer to
+	// a record of the user.
+	ErrUnknownInstance = errors.New("unknown action instance")
+)
+
 type Service struct {
 	repo Repository
+	now  func() time.Time
 }
 
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, now: time.Now}
+}
+
+// SetRepo injects the repository after the database is connected.
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
 }
 
+// Get returns the user's state as of now: the stored projection with the
+// streak decayed and the weekly XP reset if the user has been inactive
+// since.
 func (s *Service) Get(ctx context.Context, userID uuid.UUID) (*EngagementState, error) {
+	if s.repo == nil {
+		return Derive(nil, s.now()), nil
+	}
 	state, err := s.repo.GetState(ctx, userID)
 	if err != nil {
 		// Return default state if not found
-		return &EngagementState{
-			Level:          1,
-			LevelTitle:     "Entdecker",
-			LastActiveDate: time.Now().Format("2006-01-02"),
-		}, nil
+		return Derive(nil, s.now()), nil
 	}
-	return state, nil
+	return asOf(*state, s.now()), nil
 }
 
+// Award records the XP for one action instance in the ledger and returns
+// the state derived from it. The instance must refer to the user's own
+// records (see verifyInstance). Repeating an award for the same instance
+// leaves the ledger unchanged.
 func (s *Service) Award(ctx context.Context, userID uuid.UUID, req AwardXPRequest) (*EngagementState, error) {
 	xp, ok := XPValues[req.Action]
 	if !ok {
-		return nil, fmt.Errorf("unknown XP action: %s", req.Action)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAction, req.Action)
 	}
-
-	state, _ := s.repo.GetState(ctx, userID)
-	if state == nil {
-		state = &EngagementState{
-			Level:          1,
-			LevelTitle:     "Entdecker",
-			LastActiveDate: time.Now().Format("2006-01-02"),
-		}
+	now := s.now()
+	if _, err := IdempotencyKey(req, now); err != nil {
+		return nil, err
+	}
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	req, err := s.verifyInstance(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	key, err := IdempotencyKey(req, now)
+	if err != nil {
+		return nil, err
 	}
 
-	state.TotalXP += xp
-	state.WeeklyXP += xp
-	state.LastActiveDate = time.Now().Format("2006-01-02")
+	t := &Transaction{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Action:         req.Action,
+		XP:             xp,
+		IdempotencyKey: key,
+		Context:        req.Context,
+		AwardedAt:      now.UTC(),
+	}
+	if _, err := s.repo.AppendTransaction(ctx, t); err != nil {
+		return nil, fmt.Errorf("record XP: %w", err)
+	}
+	return s.Rebuild(ctx, userID)
+}
 
-	// Update level
-	for i := len(Levels) - 1; i >= 0; i-- {
-		if state.TotalXP >= Levels[i].MinXP {
-			state.Level = i + 1
-			state.LevelTitle = Levels[i].Title
-			break
-		}
+// verifyInstance checks that the context fields of req name records of the
+// user, so that made-up IDs cannot earn XP: session_id must be one of the
+// user's sessions and, with station_id, a session of that station;
+// question_id must be a reflection question; module_id a Lernreise module
+// the user has progress in. It returns req with the session ID in its
+// canonical form, so that each session has a single idempotency key.
+func (s *Service) verifyInstance(ctx context.Context, userID uuid.UUID, req AwardXPRequest) (AwardXPRequest, error) {
+	field := func(name string) string {
+		return strings.TrimSpace(fmt.Sprint(req.Context[name]))
 	}
+	req.Context = maps.Clone(req.Context)
 
-	// Update streak
-	today := time.Now().Format("2006-01-02")
-	if state.LastActiveDate != today {
-		state.CurrentStreak++
-		if state.CurrentStreak > state.LongestStreak {
-			state.LongestStreak = state.CurrentStreak
+	for _, f := range InstanceFields[req.Action] {
+		switch f {
+		case "session_id":
+			sessionID, err := uuid.Parse(field(f))
+			if err != nil {
+				return req, fmt.Errorf("%w: session %s", ErrUnknownInstance, field(f))
+			}
+			station, ok, err := s.repo.SessionStation(ctx, userID, sessionID)
+			if err != nil {
+				return req, fmt.Errorf("look up session: %w", err)
+			}
+			if !ok {
+				return req, fmt.Errorf("%w: session %s", ErrUnknownInstance, sessionID)
+			}
+			if _, ok := req.Context["station_id"]; ok && station != field("station_id") {
+				return req, fmt.Errorf("%w: session %s is not at station %s", ErrUnknownInstance, sessionID, field("station_id"))
+			}
+			req.Context[f] = sessionID.String()
+		case "question_id":
+			ok, err := s.repo.QuestionExists(ctx, field(f))
+			if err != nil {
+				return req, fmt.Errorf("look up question: %w", err)
+			}
+			if !ok {
+				return req, fmt.Errorf("%w: question %s", ErrUnknownInstance, field(f))
+			}
+		case "module_id":
+			ok, err := s.repo.HasModule(ctx, userID, field(f))
+			if err != nil {
+				return req, fmt.Errorf("look up module: %w", err)
+			}
+			if !ok {
+				return req, fmt.Errorf("%w: module %s", ErrUnknownInstance, field(f))
+			}
 		}
 	}
+	return req, nil
+}
 
+// Rebuild derives the user's state from the ledger and stores it.
+func (s *Service) Rebuild(ctx context.Context, userID uuid.UUID) (*EngagementState, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	txns, err := s.repo.ListTransactions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list XP transactions: %w", err)
+	}
+	state := Derive(txns, s.now())
 	if err := s.repo.UpsertState(ctx, userID, state); err != nil {
 		return nil, fmt.Errorf("update engagement: %w", err)
 	}
 	return state, nil
 }
 
+// RebuildAll rebuilds the state of every user with a ledger or a stored
+// state and returns how many were rebuilt. It continues past users that
+// fail and reports the first error.
+func (s *Service) RebuildAll(ctx context.Context) (int, error) {
+	if s.repo == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+	users, err := s.repo.ListUsers(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list engagement users: %w", err)
+	}
+	n := 0
+	var firstErr error
+	for _, id := range users {
+		if err := ctx.Err(); err != nil {
+			return n, err
+		}
+		if _, err := s.Rebuild(ctx, id); err != nil {
+			log.Printf("[engagement] rebuild %s: %v", id, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		n++
+	}
+	return n, firstErr
+}
+
 func (s *Service) Leaderboard(ctx context.Context, period string, limit int, userID uuid.UUID) ([]LeaderboardEntry, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
 	entries, err := s.repo.GetLeaderboard(ctx, period, limit)
 	if err != nil {
 		return nil, 0, err
@@ -0,0 +1,368 @@
+package engagement
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepo struct {
+	txns      []Transaction
+	states    map[uuid.UUID]*EngagementState
+	sessions  map[uuid.UUID]mockSession
+	questions map[string]bool
+	modules   map[uuid.UUID][]string
+}
+
+func newMockRepo() *mockRepo {
+	return &mockRepo{
+		states:    map[uuid.UUID]*EngagementState{},
+		sessions:  map[uuid.UUID]mockSession{},
+		questions: map[string]bool{},
+		modules:   map[uuid.UUID][]string{},
+	}
+}
+
+type mockSession struct {
+	user    uuid.UUID
+	station string
+}
+
+// session adds a session of user at station and returns its ID.
+func (m *mockRepo) session(user uuid.UUID, station string) uuid.UUID {
+	id := uuid.New()
+	m.sessions[id] = mockSession{user, station}
+	return id
+}
+
+func (m *mockRepo) SessionStation(_ context.Context, userID, sessionID uuid.UUID) (string, bool, error) {
+	s, ok := m.sessions[sessionID]
+	if !ok || s.user != userID {
+		return "", false, nil
+	}
+	return s.station, true, nil
+}
+
+func (m *mockRepo) QuestionExists(_ context.Context, questionID string) (bool, error) {
+	return m.questions[questionID], nil
+}
+
+func (m *mockRepo) HasModule(_ context.Context, userID uuid.UUID, moduleID string) (bool, error) {
+	return slices.Contains(m.modules[userID], moduleID), nil
+}
+
+func (m *mockRepo) GetState(_ context.Context, userID uuid.UUID) (*EngagementState, error) {
+	if s, ok := m.states[userID]; ok {
+		c := *s
+		return &c, nil
+	}
+	return nil, errors.New("no engagement state found")
+}
+
+func (m *mockRepo) UpsertState(_ context.Context, userID uuid.UUID, state *EngagementState) error {
+	if s, ok := m.states[userID]; ok && s.LedgerCount > state.LedgerCount {
+		return nil
+	}
+	c := *state
+	m.states[userID] = &c
+	return nil
+}
+
+func (m *mockRepo) GetLeaderboard(context.Context, string, int) ([]LeaderboardEntry, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) GetUserRank(context.Context, uuid.UUID, string) (int, error) {
+	return 0, nil
+}
+
+func (m *mockRepo) AppendTransaction(_ context.Context, t *Transaction) (bool, error) {
+	for _, x := range m.txns {
+		if x.UserID == t.UserID && x.IdempotencyKey == t.IdempotencyKey {
+			return false, nil
+		}
+	}
+	m.txns = append(m.txns, *t)
+	return true, nil
+}
+
+func (m *mockRepo) ListTransactions(_ context.Context, userID uuid.UUID) ([]Transaction, error) {
+	var out []Transaction
+	for _, t := range m.txns {
+		if t.UserID == userID {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRepo) ListUsers(context.Context) ([]uuid.UUID, error) {
+	seen := map[uuid.UUID]bool{}
+	var out []uuid.UUID
+	for _, t := range m.txns {
+		if !seen[t.UserID] {
+			seen[t.UserID] = true
+			out = append(out, t.UserID)
+		}
+	}
+	for id := range m.states {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+func day(s string) time.Time {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t.Add(10 * time.Hour)
+}
+
+// activeOn returns one 10 XP transaction per day.
+func activeOn(days ...string) []Transaction {
+	var txns []Transaction
+	for _, d := range days {
+		txns = append(txns, Transaction{Action: "station_start", XP: 10, AwardedAt: day(d)})
+	}
+	return txns
+}
+
+func TestDerive_Streak(t *testing.T) {
+	now := day("2026-03-10")
+	tests := []struct {
+		name             string
+		days             []string
+		current, longest int
+		freeze           bool
+	}{
+		{"first day", []string{"2026-03-01"}, 1, 1, false},
+		{"several awards on one day", []string{"2026-03-01", "2026-03-01"}, 1, 1, false},
+		{"consecutive days", []string{"2026-03-01", "2026-03-02", "2026-03-03"}, 3, 3, false},
+		{"missed day halves", []string{"2026-03-01", "2026-03-02", "2026-03-03", "2026-03-04", "2026-03-05", "2026-03-06", "2026-03-09"}, 4, 6, false},
+		{"seventh day earns freeze", []string{"2026-03-01", "2026-03-02", "2026-03-03", "2026-03-04", "2026-03-05", "2026-03-06", "2026-03-07"}, 7, 7, true},
+		{"freeze bridges one missed day", []string{"2026-03-01", "2026-03-02", "2026-03-03", "2026-03-04", "2026-03-05", "2026-03-06", "2026-03-07", "2026-03-09"}, 8, 8, false},
+		{"freeze does not bridge two days", []string{"2026-03-01", "2026-03-02", "2026-03-03", "2026-03-04", "2026-03-05", "2026-03-06", "2026-03-07", "2026-03-10"}, 4, 7, false},
+		{"used freeze is gone", []string{"2026-02-22", "2026-02-23", "2026-02-24", "2026-02-25", "2026-02-26", "2026-02-27", "2026-02-28", "2026-03-02", "2026-03-04"}, 5, 8, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Derive(activeOn(tt.days...), now)
+			if s.CurrentStreak != tt.current || s.LongestStreak != tt.longest || s.StreakFreezeAvailable != tt.freeze {
+				t.Errorf("streak = %d, longest %d, freeze %v; want %d, %d, %v",
+					s.CurrentStreak, s.LongestStreak, s.StreakFreezeAvailable, tt.current, tt.longest, tt.freeze)
+			}
+			if s.LastActiveDate != tt.days[len(tt.days)-1] {
+				t.Errorf("last active = %s", s.LastActiveDate)
+			}
+		})
+	}
+}
+
+func TestDerive_XPAndLevel(t *testing.T) {
+	// Tuesday; the week started on Monday 2026-03-09.
+	now := day("2026-03-10")
+	txns := []Transaction{
+		{Action: ActionLegacyBalance, XP: 480, AwardedAt: day("2026-03-09")},
+		{Action: "station_complete", XP: 50, AwardedAt: day("2026-03-08")},
+		{Action: "station_start", XP: 10, AwardedAt: day("2026-03-09")},
+		{Action: "quiz_correct", XP: 15, AwardedAt: day("2026-03-10")},
+	}
+	s := Derive(txns, now)
+	if s.TotalXP != 555 || s.WeeklyXP != 25 {
+		t.Errorf("xp = %d total, %d weekly; want 555, 25", s.TotalXP, s.WeeklyXP)
+	}
+	if s.Level != 3 || s.LevelTitle != "Abenteurer" || s.LedgerCount != 4 {
+		t.Errorf("state = %+v", s)
+	}
+
+	empty := Derive(nil, now)
+	if empty.Level != 1 || empty.LevelTitle != "Entdecker" || empty.LastActiveDate != "2026-03-10" {
+		t.Errorf("empty state = %+v", empty)
+	}
+}
+
+func TestGet_DecaysStreakAsOfNow(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	user := uuid.New()
+	week := []string{"2026-03-02", "2026-03-03", "2026-03-04", "2026-03-05", "2026-03-06", "2026-03-07", "2026-03-08"}
+	repo.states[user] = Derive(activeOn(week...), day("2026-03-08"))
+
+	tests := []struct {
+		today   string
+		current int
+		freeze  bool
+		weekly  int
+	}{
+		{"2026-03-08", 7, true, 70},
+		{"2026-03-09", 7, true, 0},
+		{"2026-03-10", 7, true, 0},
+		{"2026-03-11", 3, false, 0},
+		{"2026-04-20", 3, false, 0},
+	}
+	for _, tt := range tests {
+		svc.now = func() time.Time { return day(tt.today) }
+		s, err := svc.Get(context.Background(), user)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if s.CurrentStreak != tt.current || s.StreakFreezeAvailable != tt.freeze || s.WeeklyXP != tt.weekly || s.LongestStreak != 7 {
+			t.Errorf("%s: %+v", tt.today, s)
+		}
+	}
+
+	// Without a freeze a single missed day already breaks the streak.
+	repo.states[user] = Derive(activeOn("2026-03-09", "2026-03-10", "2026-03-11"), day("2026-03-11"))
+	svc.now = func() time.Time { return day("2026-03-13") }
+	if s, _ := svc.Get(context.Background(), user); s.CurrentStreak != 1 || s.WeeklyXP != 30 {
+		t.Errorf("after a missed day: %+v", s)
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	now := day("2026-03-10")
+	key, err := IdempotencyKey(AwardXPRequest{
+		Action:  "station_complete",
+		Context: map[string]interface{}{"station_id": "vuca:1", "session_id": "s-9", "score": 3},
+	}, now)
+	if err != nil || key != "station_complete:vuca%3A1:s-9" {
+		t.Errorf("key = %q, %v", key, err)
+	}
+	if key, _ := IdempotencyKey(AwardXPRequest{Action: "daily_login"}, now); key != "daily_login:2026-03-10" {
+		t.Errorf("daily key = %q", key)
+	}
+	if key, _ := IdempotencyKey(AwardXPRequest{Action: "onboarding_complete"}, now); key != "onboarding_complete" {
+		t.Errorf("onboarding key = %q", key)
+	}
+	if _, err := IdempotencyKey(AwardXPRequest{Action: "station_start", Context: map[string]interface{}{"station_id": "x"}}, now); !errors.Is(err, ErrMissingInstance) {
+		t.Errorf("missing session: err = %v", err)
+	}
+	if _, err := IdempotencyKey(AwardXPRequest{Action: "profile_view"}, now); !errors.Is(err, ErrUnknownAction) {
+		t.Errorf("unknown action: err = %v", err)
+	}
+}
+
+func TestAward_IsIdempotent(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	now := day("2026-03-10")
+	svc.now = func() time.Time { return now }
+	user := uuid.New()
+	ctx := context.Background()
+
+	first, second := repo.session(user, "st-1"), repo.session(user, "st-1")
+	req := AwardXPRequest{Action: "station_complete", Context: map[string]interface{}{"station_id": "st-1", "session_id": first.String()}}
+	for i := 0; i < 3; i++ {
+		s, err := svc.Award(ctx, user, req)
+		if err != nil {
+			t.Fatalf("Award: %v", err)
+		}
+		if s.TotalXP != 50 || s.CurrentStreak != 1 {
+			t.Errorf("after award %d: %+v", i+1, s)
+		}
+	}
+	req.Context["session_id"] = " " + strings.ToUpper(first.String())
+	if _, err := svc.Award(ctx, user, req); err != nil {
+		t.Fatalf("Award: %v", err)
+	}
+	if len(repo.txns) != 1 {
+		t.Fatalf("ledger has %d transactions, want 1", len(repo.txns))
+	}
+
+	now = day("2026-03-11")
+	req.Context["session_id"] = second.String()
+	s, err := svc.Award(ctx, user, req)
+	if err != nil {
+		t.Fatalf("Award: %v", err)
+	}
+	if s.TotalXP != 100 || s.CurrentStreak != 2 || s.LongestStreak != 2 || s.LastActiveDate != "2026-03-11" {
+		t.Errorf("next day: %+v", s)
+	}
+	if got, _ := svc.Get(ctx, user); got.TotalXP != 100 || got.CurrentStreak != 2 {
+		t.Errorf("stored state = %+v", got)
+	}
+
+	if _, err := svc.Award(ctx, user, AwardXPRequest{Action: "station_start"}); !errors.Is(err, ErrMissingInstance) {
+		t.Errorf("award without instance: err = %v", err)
+	}
+}
+
+func TestAward_RejectsForeignInstances(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.now = func() time.Time { return day("2026-03-10") }
+	user, other := uuid.New(), uuid.New()
+	ctx := context.Background()
+	own := repo.session(user, "st-1")
+	repo.questions["q-1"] = true
+	repo.modules[user] = []string{"vuca-1"}
+	repo.modules[other] = []string{"vuca-2"}
+
+	tests := []struct {
+		name   string
+		action string
+		fields map[string]interface{}
+	}{
+		{"fabricated session", "station_complete", map[string]interface{}{"station_id": "st-1", "session_id": uuid.New().String()}},
+		{"session of another user", "station_start", map[string]interface{}{"station_id": "st-1", "session_id": repo.session(other, "st-1").String()}},
+		{"session is no UUID", "station_start", map[string]interface{}{"station_id": "st-1", "session_id": "s-1"}},
+		{"session of another station", "station_complete", map[string]interface{}{"station_id": "st-2", "session_id": own.String()}},
+		{"session without station", "station_complete", map[string]interface{}{"station_id": "st-1", "session_id": repo.session(user, "").String()}},
+		{"fabricated question", "quiz_correct", map[string]interface{}{"question_id": "q-2", "session_id": own.String()}},
+		{"fabricated session for a question", "quiz_correct", map[string]interface{}{"question_id": "q-1", "session_id": uuid.New().String()}},
+		{"module of another user", "vuca_module_complete", map[string]interface{}{"module_id": "vuca-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.Award(ctx, user, AwardXPRequest{Action: tt.action, Context: tt.fields}); !errors.Is(err, ErrUnknownInstance) {
+				t.Errorf("err = %v, want ErrUnknownInstance", err)
+			}
+		})
+	}
+	if len(repo.txns) != 0 {
+		t.Fatalf("ledger has %d transactions, want none", len(repo.txns))
+	}
+
+	for _, req := range []AwardXPRequest{
+		{Action: "station_start", Context: map[string]interface{}{"station_id": "st-1", "session_id": own.String()}},
+		{Action: "quiz_correct", Context: map[string]interface{}{"question_id": "q-1", "session_id": own.String()}},
+		{Action: "vuca_module_complete", Context: map[string]interface{}{"module_id": "vuca-1"}},
+	} {
+		if _, err := svc.Award(ctx, user, req); err != nil {
+			t.Errorf("Award %s: %v", req.Action, err)
+		}
+	}
+	if len(repo.txns) != 3 {
+		t.Errorf("ledger has %d transactions, want 3", len(repo.txns))
+	}
+}
+
+func TestRebuildAll_ReplacesDriftedState(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.now = func() time.Time { return day("2026-03-10") }
+	user, stale := uuid.New(), uuid.New()
+	repo.txns = append(repo.txns, Transaction{UserID: user, Action: "onboarding_complete", XP: 100, IdempotencyKey: "onboarding_complete", AwardedAt: day("2026-03-09")})
+	repo.states[user] = &EngagementState{TotalXP: 9999, Level: 5}
+	repo.states[stale] = &EngagementState{TotalXP: 40}
+
+	n, err := svc.RebuildAll(context.Background())
+	if err != nil || n != 2 {
+		t.Fatalf("RebuildAll = %d, %v", n, err)
+	}
+	if s := repo.states[user]; s.TotalXP != 100 || s.Level != 2 || s.LevelTitle != "Pfadfinder" {
+		t.Errorf("rebuilt state = %+v", s)
+	}
+	if s := repo.states[stale]; s.TotalXP != 0 || s.Level != 1 {
+		t.Errorf("state without ledger = %+v", s)
+	}
+}
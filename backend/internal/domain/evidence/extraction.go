@@ -0,0 +1,135 @@
+package evidence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"skillr-mvp-v1/backend/internal/ai"
+	"skillr-mvp-v1/backend/internal/domain/session"
+	"skillr-mvp-v1/backend/internal/model"
+)
+
+// Extractor scores the dimensions a learner showed in a session.
+type Extractor interface {
+	Extract(ctx context.Context, sess *session.SessionDetailed) (*Extraction, error)
+}
+
+// Generator is the subset of ai.AIClient the AI extractor needs.
+type Generator interface {
+	Generate(ctx context.Context, req ai.ChatRequest) (*ai.ChatResponse, error)
+}
+
+// PromptSource loads a managed prompt template (satisfied by *ai.Orchestrator).
+type PromptSource interface {
+	GetPrompt(ctx context.Context, promptID string) (*model.PromptTemplate, error)
+}
+
+// Extraction is the result of one station-result run. Scores are 0-100,
+// confidence 0-1 per dimension.
+type Extraction struct {
+	Scores        map[string]float64
+	Confidence    map[string]float64
+	Summary       string
+	PromptID      string
+	PromptVersion int
+}
+
+// StationResultPromptID is the prompt ID looked up in the prompt store. When
+// no managed prompt exists the built-in station-result prompt is used.
+const StationResultPromptID = "station-result"
+
+const builtinStationResultVersion = 1
+
+// defaultConfidence is used for dimensions the model scored without stating
+// a confidence.
+const defaultConfidence = 0.5
+
+// AIExtractor runs the station-result extraction prompt over a session
+// transcript.
+type AIExtractor struct {
+	client  Generator
+	prompts PromptSource
+}
+
+// NewAIExtractor creates an AIExtractor. prompts may be nil to always use the
+// built-in prompt.
+func NewAIExtractor(client Generator, prompts PromptSource) *AIExtractor {
+	return &AIExtractor{client: client, prompts: prompts}
+}
+
+func (x *AIExtractor) Extract(ctx context.Context, sess *session.SessionDetailed) (*Extraction, error) {
+	instruction, _ := ai.ExtractPrompt(StationResultPromptID)
+	promptID, version, modelName := "builtin:"+StationResultPromptID, builtinStationResultVersion, ""
+	if x.prompts != nil {
+		if p, err := x.prompts.GetPrompt(ctx, StationResultPromptID); err == nil && p != nil && p.SystemInstruction != "" {
+			instruction, promptID, version, modelName = p.SystemInstruction, p.PromptID, p.Version, p.ModelConfig.Model
+		}
+	}
+
+	msg := fmt.Sprintf("Analysiere diese Station und bewerte die gezeigten Faehigkeiten:\n\nReise-Typ: %s\nStation: %s\n\n%s",
+		deref(sess.JourneyType), deref(sess.StationID), transcript(sess.Interactions))
+
+	resp, err := x.client.Generate(ctx, ai.ChatRequest{
+		Model:             modelName,
+		SystemInstruction: instruction,
+		Message:           msg,
+		ResponseMIMEType:  "application/json",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generate: %w", err)
+	}
+
+	var out struct {
+		DimensionScores map[string]float64 `json:"dimensionScores"`
+		Confidence      map[string]float64 `json:"confidence"`
+		Summary         string             `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(resp.Text), &out); err != nil {
+		return nil, fmt.Errorf("parse extraction: %w", err)
+	}
+
+	res := &Extraction{
+		Scores:        map[string]float64{},
+		Confidence:    map[string]float64{},
+		Summary:       strings.TrimSpace(out.Summary),
+		PromptID:      promptID,
+		PromptVersion: version,
+	}
+	for dim, score := range out.DimensionScores {
+		key := strings.ToLower(strings.TrimSpace(dim))
+		if key == "" || math.IsNaN(score) {
+			continue
+		}
+		res.Scores[key] = math.Round(math.Max(0, math.Min(score, 100))*100) / 100
+		conf, ok := out.Confidence[dim]
+		if !ok || math.IsNaN(conf) {
+			conf = defaultConfidence
+		}
+		res.Confidence[key] = math.Round(math.Max(0, math.Min(conf, 1))*100) / 100
+	}
+	return res, nil
+}
+
+// transcript renders interactions in the format of the extract endpoint.
+func transcript(interactions []session.Interaction) string {
+	var parts []string
+	for _, i := range interactions {
+		if i.UserInput != nil && *i.UserInput != "" {
+			parts = append(parts, "Nutzer: "+*i.UserInput)
+		}
+		if i.AssistantResponse != nil && *i.AssistantResponse != "" {
+			parts = append(parts, "Guide: "+*i.AssistantResponse)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
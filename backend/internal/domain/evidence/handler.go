@@ -1,6 +1,7 @@
 package evidence
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -26,9 +27,10 @@ func (h *Handler) List(c echo.Context) error {
 	userID := deriveUUID(userInfo.UID)
 
 	params := ListParams{
-		Limit:  intQuery(c, "limit", 20),
-		Offset: intQuery(c, "offset", 0),
-		UserID: userID,
+		Limit:            intQuery(c, "limit", 20),
+		Offset:           intQuery(c, "offset", 0),
+		UserID:           userID,
+		IncludeRetracted: c.QueryParam("include_retracted") == "true",
 	}
 	if et := c.QueryParam("evidence_type"); et != "" {
 		params.EvidenceType = &et
@@ -125,6 +127,259 @@ func (h *Handler) Verify(c echo.Context) error {
 	return c.JSON(http.StatusOK, result)
 }
 
+// Update edits one of the learner's entries, keeping the previous version.
+func (h *Handler) Update(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid evidence ID")
+	}
+	var req UpdateEvidenceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	entry, err := h.svc.Update(c.Request().Context(), deriveUUID(userInfo.UID), id, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrRetracted), errors.Is(err, ErrVersionConflict):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		case errors.Is(err, ErrNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusOK, entry)
+}
+
+// Retract withdraws one of the learner's entries for good.
+func (h *Handler) Retract(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid evidence ID")
+	}
+	var req RetractRequest
+	_ = c.Bind(&req)
+	entry, err := h.svc.Retract(c.Request().Context(), deriveUUID(userInfo.UID), id, req.Reason)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "evidence not found")
+	}
+	return c.JSON(http.StatusOK, entry)
+}
+
+// History returns an entry with its earlier versions and audit log.
+func (h *Handler) History(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid evidence ID")
+	}
+	history, err := h.svc.History(c.Request().Context(), deriveUUID(userInfo.UID), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "evidence not found")
+	}
+	return c.JSON(http.StatusOK, history)
+}
+
+// Revoke puts one of the learner's entries on the revocation list.
+func (h *Handler) Revoke(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid evidence ID")
+	}
+	var req RevokeRequest
+	_ = c.Bind(&req)
+	rev, err := h.svc.Revoke(c.Request().Context(), deriveUUID(userInfo.UID), id, req.Reason)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "evidence not found")
+	}
+	return c.JSON(http.StatusOK, rev)
+}
+
+// Unrevoke lifts a revocation the learner made.
+func (h *Handler) Unrevoke(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid evidence ID")
+	}
+	if err := h.svc.Unrevoke(c.Request().Context(), deriveUUID(userInfo.UID), id); err != nil {
+		if errors.Is(err, ErrAdminRevocation) {
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusNotFound, "evidence not found")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Revocations is the public revocation list. Reasons are not published.
+func (h *Handler) Revocations(c echo.Context) error {
+	list, total, err := h.svc.Revocations(c.Request().Context(), intQuery(c, "limit", 1000), intQuery(c, "offset", 0))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list revocations")
+	}
+	for i := range list {
+		list[i].Reason = ""
+	}
+	c.Response().Header().Set("Cache-Control", "public, max-age=60")
+	return c.JSON(http.StatusOK, map[string]interface{}{"revocations": list, "total": total})
+}
+
+// AdminRevocations lists the revocation list including reasons.
+func (h *Handler) AdminRevocations(c echo.Context) error {
+	list, total, err := h.svc.Revocations(c.Request().Context(), intQuery(c, "limit", 50), intQuery(c, "offset", 0))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list revocations")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"revocations": list, "total": total})
+}
+
+// AdminRevoke revokes any evidence entry.
+func (h *Handler) AdminRevoke(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid evidence ID")
+	}
+	var req RevokeRequest
+	_ = c.Bind(&req)
+	rev, err := h.svc.AdminRevoke(c.Request().Context(), adminID(c), id, req.Reason)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "evidence not found")
+	}
+	return c.JSON(http.StatusOK, rev)
+}
+
+// AdminUnrevoke lifts any revocation.
+func (h *Handler) AdminUnrevoke(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid evidence ID")
+	}
+	if err := h.svc.AdminUnrevoke(c.Request().Context(), adminID(c), id); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to lift revocation")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AdminAudit returns the audit log of any entry.
+func (h *Handler) AdminAudit(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid evidence ID")
+	}
+	audit, err := h.svc.AdminAudit(c.Request().Context(), id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to load audit log")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"audit": audit, "total": len(audit)})
+}
+
+// AdminResign re-signs entries that are unsigned or signed with a retired
+// key. Call repeatedly after a key rotation until signed is 0.
+func (h *Handler) AdminResign(c echo.Context) error {
+	n, err := h.svc.SignPending(c.Request().Context(), intQuery(c, "limit", 500))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"signed": n})
+}
+
+// Suggestions lists evidence extracted from the learner's sessions. Defaults
+// to pending suggestions awaiting review.
+func (h *Handler) Suggestions(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	params := SuggestionListParams{
+		UserID: deriveUUID(userInfo.UID),
+		Status: SuggestionPending,
+		Limit:  intQuery(c, "limit", 50),
+		Offset: intQuery(c, "offset", 0),
+	}
+	switch st := c.QueryParam("status"); st {
+	case "":
+	case "all":
+		params.Status = ""
+	case SuggestionPending, SuggestionAccepted, SuggestionDiscarded:
+		params.Status = st
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid status")
+	}
+	if sid := c.QueryParam("session_id"); sid != "" {
+		id, err := uuid.Parse(sid)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid session ID")
+		}
+		params.SessionID = &id
+	}
+	list, total, err := h.svc.Suggestions(c.Request().Context(), params)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list suggestions")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"suggestions": list, "total": total})
+}
+
+// AcceptSuggestion turns a suggestion into a visible evidence entry.
+func (h *Handler) AcceptSuggestion(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid suggestion ID")
+	}
+	entry, err := h.svc.AcceptSuggestion(c.Request().Context(), deriveUUID(userInfo.UID), id)
+	if err != nil {
+		if errors.Is(err, ErrSuggestionReviewed) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusNotFound, "suggestion not found")
+	}
+	return c.JSON(http.StatusCreated, entry)
+}
+
+// DiscardSuggestion rejects a suggestion.
+func (h *Handler) DiscardSuggestion(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid suggestion ID")
+	}
+	if err := h.svc.DiscardSuggestion(c.Request().Context(), deriveUUID(userInfo.UID), id); err != nil {
+		if errors.Is(err, ErrSuggestionReviewed) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusNotFound, "suggestion not found")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// adminID identifies the acting admin; RequireAdmin guarantees user info.
+func adminID(c echo.Context) uuid.UUID {
+	return deriveUUID(middleware.GetUserInfo(c).UID)
+}
+
 func deriveUUID(firebaseUID string) uuid.UUID {
 	return uuid.NewSHA1(uuid.NameSpaceDNS, []byte(firebaseUID))
 }
@@ -1,6 +1,7 @@
 package evidence
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,7 +17,130 @@ type PortfolioEntry struct {
 	Confidence           float64                `json:"confidence"`
 	Context              map[string]interface{} `json:"context,omitempty"`
 	VerificationToken    *string                `json:"-"`
+	Signature            *string                `json:"signature,omitempty"`
+	SigningKeyID         *string                `json:"signing_key_id,omitempty"`
+	SignedAt             *time.Time             `json:"signed_at,omitempty"`
+	Revocation           *Revocation            `json:"revocation,omitempty"`
+	// Version starts at 1 and increases with every edit; earlier versions
+	// are kept in the version history.
+	Version          int        `json:"version"`
+	RetractedAt      *time.Time `json:"retracted_at,omitempty"`
+	RetractionReason *string    `json:"retraction_reason,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateEvidenceRequest edits an entry. Omitted fields are kept. Skill
+// dimensions can only be changed on manual entries.
+type UpdateEvidenceRequest struct {
+	Summary         *string                `json:"summary,omitempty"`
+	SkillDimensions map[string]float64     `json:"skill_dimensions,omitempty"`
+	Context         map[string]interface{} `json:"context,omitempty"`
+}
+
+type RetractRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Version is an earlier state of an entry, superseded by an edit.
+type Version struct {
+	EvidenceID      uuid.UUID              `json:"evidence_id"`
+	Version         int                    `json:"version"`
+	Summary         string                 `json:"summary"`
+	SkillDimensions map[string]float64     `json:"skill_dimensions,omitempty"`
+	Confidence      float64                `json:"confidence"`
+	Context         map[string]interface{} `json:"context,omitempty"`
+	Signature       *string                `json:"signature,omitempty"`
+	SigningKeyID    *string                `json:"signing_key_id,omitempty"`
+	SupersededAt    time.Time              `json:"superseded_at"`
+}
+
+// Audit actions.
+const (
+	AuditCreate   = "create"
+	AuditUpdate   = "update"
+	AuditRetract  = "retract"
+	AuditRevoke   = "revoke"
+	AuditUnrevoke = "unrevoke"
+	AuditResign   = "resign"
+)
+
+// Audit actor roles; learner and admin match the revocation parties.
+const (
+	ActorLearner = RevokedByLearner
+	ActorAdmin   = RevokedByAdmin
+	ActorSystem  = "system"
+)
+
+// AuditEntry records one change to an entry. The audit log is append-only.
+type AuditEntry struct {
+	ID         uuid.UUID              `json:"id"`
+	EvidenceID uuid.UUID              `json:"evidence_id"`
+	ActorID    *uuid.UUID             `json:"actor_id,omitempty"`
+	ActorRole  string                 `json:"actor_role"`
+	Action     string                 `json:"action"`
+	Changes    map[string]interface{} `json:"changes,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// History is an entry with its earlier versions and audit log.
+type History struct {
+	Entry    *PortfolioEntry `json:"entry"`
+	Versions []Version       `json:"versions"`
+	Audit    []AuditEntry    `json:"audit"`
+}
+
+// Revocation parties.
+const (
+	RevokedByLearner = "learner"
+	RevokedByAdmin   = "admin"
+)
+
+// Revocation is an entry on the evidence revocation list.
+type Revocation struct {
+	EvidenceID uuid.UUID `json:"evidence_id"`
+	RevokedBy  string    `json:"revoked_by"`
+	Reason     string    `json:"reason,omitempty"`
+	RevokedAt  time.Time `json:"revoked_at"`
+}
+
+type RevokeRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Suggestion review states.
+const (
+	SuggestionPending   = "pending"
+	SuggestionAccepted  = "accepted"
+	SuggestionDiscarded = "discarded"
+)
+
+// Suggestion is one dimension extracted from a finished session. It becomes
+// an evidence entry only after the learner accepts it.
+type Suggestion struct {
+	ID                   uuid.UUID              `json:"id"`
+	UserID               uuid.UUID              `json:"user_id"`
+	SessionID            uuid.UUID              `json:"session_id"`
+	Dimension            string                 `json:"dimension"`
+	Score                float64                `json:"score"`
+	Confidence           float64                `json:"confidence"`
+	Summary              string                 `json:"summary"`
+	SourceInteractionIDs []uuid.UUID            `json:"source_interaction_ids,omitempty"`
+	Context              map[string]interface{} `json:"context,omitempty"`
+	Status               string                 `json:"status"`
+	EvidenceID           *uuid.UUID             `json:"evidence_id,omitempty"`
+	PromptID             string                 `json:"prompt_id"`
+	PromptVersion        int                    `json:"prompt_version"`
 	CreatedAt            time.Time              `json:"created_at"`
+	ReviewedAt           *time.Time             `json:"reviewed_at,omitempty"`
+}
+
+type SuggestionListParams struct {
+	UserID    uuid.UUID
+	SessionID *uuid.UUID
+	Status    string
+	Limit     int
+	Offset    int
 }
 
 type PortfolioEntryDetailed struct {
@@ -34,18 +158,41 @@ type CreateEvidenceRequest struct {
 	Context              map[string]interface{} `json:"context,omitempty"`
 }
 
+// Verification states.
+const (
+	VerificationValid     = "valid"
+	VerificationInvalid   = "invalid"
+	VerificationRevoked   = "revoked"
+	VerificationWithdrawn = "withdrawn"
+)
+
 type VerificationResult struct {
+	// Verified is true when the signature is valid and the entry is neither
+	// revoked nor withdrawn.
 	Verified         bool               `json:"verified"`
+	Status           string             `json:"status"`
+	SignatureValid   bool               `json:"signature_valid"`
+	Revoked          bool               `json:"revoked"`
+	RevokedAt        *time.Time         `json:"revoked_at,omitempty"`
+	Withdrawn        bool               `json:"withdrawn"`
+	WithdrawnAt      *time.Time         `json:"withdrawn_at,omitempty"`
+	Version          int                `json:"version,omitempty"`
+	KeyID            string             `json:"key_id,omitempty"`
 	EvidenceID       uuid.UUID          `json:"evidence_id"`
 	Summary          string             `json:"summary,omitempty"`
 	SkillDimensions  map[string]float64 `json:"skill_dimensions,omitempty"`
 	CreatedAt        *time.Time         `json:"created_at,omitempty"`
 	EndorsementCount int                `json:"endorsement_count,omitempty"`
+	// Signature and SignedPayload allow offline verification against the
+	// JWKS: the signature is base64url Ed25519 over the payload bytes.
+	Signature     string          `json:"signature,omitempty"`
+	SignedPayload json.RawMessage `json:"signed_payload,omitempty"`
 }
 
 type ListParams struct {
-	Limit        int
-	Offset       int
-	EvidenceType *string
-	UserID       uuid.UUID
+	Limit            int
+	Offset           int
+	EvidenceType     *string
+	UserID           uuid.UUID
+	IncludeRetracted bool
 }
@@ -2,15 +2,45 @@ package evidence
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// Mutating methods take the audit entry describing the change and append it
+// in the same transaction.
 type Repository interface {
-	Create(ctx context.Context, e *PortfolioEntry) error
+	Create(ctx context.Context, e *PortfolioEntry, audit *AuditEntry) error
 	GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*PortfolioEntry, error)
 	GetDetailedByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*PortfolioEntryDetailed, error)
 	List(ctx context.Context, params ListParams) ([]PortfolioEntry, int, error)
 	ListByDimension(ctx context.Context, userID uuid.UUID, dimension string) ([]PortfolioEntry, int, error)
 	GetByVerificationToken(ctx context.Context, id uuid.UUID, token string) (*PortfolioEntry, error)
+	// Update stores e as its new version and keeps version e.Version-1 in the
+	// history. It fails with ErrVersionConflict if the stored entry is no
+	// longer at that version, and revokes credentials issued for the entry.
+	Update(ctx context.Context, e *PortfolioEntry, audit *AuditEntry) error
+	// Retract withdraws the entry and revokes credentials issued for it.
+	Retract(ctx context.Context, id uuid.UUID, reason string, at time.Time, audit *AuditEntry) error
+	ListVersions(ctx context.Context, id uuid.UUID) ([]Version, error)
+	ListAudit(ctx context.Context, id uuid.UUID) ([]AuditEntry, error)
+	// ListUnsigned returns entries without a signature or signed with a key
+	// other than activeKeyID, oldest first. Retracted entries are skipped.
+	ListUnsigned(ctx context.Context, activeKeyID string, limit int) ([]PortfolioEntry, error)
+	UpdateSignature(ctx context.Context, id uuid.UUID, signature, keyID string, signedAt time.Time, audit *AuditEntry) error
+	// Revoke adds or replaces an entry on the revocation list and revokes
+	// credentials issued for it.
+	Revoke(ctx context.Context, r *Revocation, audit *AuditEntry) error
+	Unrevoke(ctx context.Context, id uuid.UUID, audit *AuditEntry) error
+	ListRevocations(ctx context.Context, limit, offset int) ([]Revocation, int, error)
+	// CreateSuggestions stores suggestions, skipping dimensions already
+	// suggested for the same session. It returns the number stored.
+	CreateSuggestions(ctx context.Context, suggestions []Suggestion) (int, error)
+	GetSuggestion(ctx context.Context, id, userID uuid.UUID) (*Suggestion, error)
+	ListSuggestions(ctx context.Context, params SuggestionListParams) ([]Suggestion, int, error)
+	// AcceptSuggestion stores e and marks the pending suggestion accepted in
+	// one transaction. Both fail with ErrSuggestionReviewed if the suggestion
+	// is no longer pending.
+	AcceptSuggestion(ctx context.Context, id uuid.UUID, e *PortfolioEntry, at time.Time, audit *AuditEntry) error
+	DiscardSuggestion(ctx context.Context, id uuid.UUID, at time.Time) error
 }
@@ -4,28 +4,132 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/domain/session"
+	"skillr-mvp-v1/backend/internal/signing"
 )
 
+// ErrAdminRevocation is returned when a learner tries to lift a revocation
+// made by an admin.
+var ErrAdminRevocation = errors.New("evidence was revoked by an admin")
+
+// ErrNotFound is returned when an entry does not exist or belongs to
+// another learner.
+var ErrNotFound = errors.New("evidence not found")
+
+// ErrRetracted is returned when changing an entry the learner withdrew.
+var ErrRetracted = errors.New("evidence was retracted")
+
+// ErrVersionConflict is returned when an entry changed since it was read.
+var ErrVersionConflict = errors.New("evidence was changed concurrently")
+
+// ErrSuggestionReviewed is returned when a suggestion was already accepted
+// or discarded.
+var ErrSuggestionReviewed = errors.New("suggestion already reviewed")
+
+// extractionTimeout bounds one asynchronous session extraction.
+const extractionTimeout = 60 * time.Second
+
+// Taxonomy resolves dimension keys against the skill registry (satisfied
+// by *taxonomy.Service).
+type Taxonomy interface {
+	Resolve(key string) (string, bool)
+	Normalize(dims map[string]float64) (map[string]float64, error)
+}
+
 type Service struct {
-	repo Repository
+	repo      Repository
+	keys      *signing.KeyRing
+	extractor Extractor
+	taxonomy  Taxonomy
+	wg        sync.WaitGroup
 }
 
 func NewService(repo Repository) *Service {
 	return &Service{repo: repo}
 }
 
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
+}
+
+// SetKeys sets the key ring used to sign new entries and verify signatures.
+func (s *Service) SetKeys(keys *signing.KeyRing) {
+	s.keys = keys
+}
+
+// SetExtractor enables evidence extraction when a session ends.
+func (s *Service) SetExtractor(x Extractor) {
+	s.extractor = x
+}
+
+// SetTaxonomy makes writes map dimension keys to canonical keys and reject
+// unknown ones. Without it keys are stored as given.
+func (s *Service) SetTaxonomy(t Taxonomy) {
+	s.taxonomy = t
+}
+
+// normalize rewrites the dimension keys of a write (see SetTaxonomy).
+func (s *Service) normalize(dims map[string]float64) (map[string]float64, error) {
+	if s.taxonomy == nil {
+		return dims, nil
+	}
+	return s.taxonomy.Normalize(dims)
+}
+
+// dimension maps a lookup key to its canonical key. Unknown keys are kept so
+// entries stored before the registry existed can still be found.
+func (s *Service) dimension(key string) string {
+	if s.taxonomy != nil {
+		if k, ok := s.taxonomy.Resolve(key); ok {
+			return k
+		}
+	}
+	return key
+}
+
+// Wait blocks until all in-flight extraction runs have finished.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
 func (s *Service) Create(ctx context.Context, userID uuid.UUID, req CreateEvidenceRequest) (*PortfolioEntry, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 	if req.Summary == "" {
 		return nil, fmt.Errorf("summary is required")
 	}
 	if req.EvidenceType == "" {
 		return nil, fmt.Errorf("evidence_type is required")
 	}
+	dims, err := s.normalize(req.SkillDimensions)
+	if err != nil {
+		return nil, err
+	}
+	req.SkillDimensions = dims
 
+	entry, err := s.newEntry(userID, req)
+	if err != nil {
+		return nil, err
+	}
+	audit := newAudit(entry.ID, &userID, ActorLearner, AuditCreate, map[string]interface{}{"evidence_type": entry.EvidenceType})
+	if err := s.repo.Create(ctx, entry, audit); err != nil {
+		return nil, fmt.Errorf("create evidence: %w", err)
+	}
+	return entry, nil
+}
+
+// newEntry builds a signed entry with a fresh verification token.
+func (s *Service) newEntry(userID uuid.UUID, req CreateEvidenceRequest) (*PortfolioEntry, error) {
 	confidence := 0.5
 	if req.Confidence != nil {
 		confidence = *req.Confidence
@@ -43,39 +147,530 @@ func (s *Service) Create(ctx context.Context, userID uuid.UUID, req CreateEviden
 		Confidence:           confidence,
 		Context:              req.Context,
 		VerificationToken:    &token,
+		Version:              1,
 		CreatedAt:            time.Now().UTC(),
 	}
-
-	if err := s.repo.Create(ctx, entry); err != nil {
-		return nil, fmt.Errorf("create evidence: %w", err)
+	if s.keys != nil {
+		if err := sign(s.keys, entry, entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("sign evidence: %w", err)
+		}
 	}
 	return entry, nil
 }
 
 func (s *Service) Get(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*PortfolioEntryDetailed, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 	return s.repo.GetDetailedByID(ctx, id, userID)
 }
 
 func (s *Service) List(ctx context.Context, params ListParams) ([]PortfolioEntry, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
 	return s.repo.List(ctx, params)
 }
 
 func (s *Service) ByDimension(ctx context.Context, userID uuid.UUID, dim string) ([]PortfolioEntry, int, error) {
-	return s.repo.ListByDimension(ctx, userID, dim)
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
+	return s.repo.ListByDimension(ctx, userID, s.dimension(dim))
+}
+
+// VerificationToken returns the token of the learner's entry that its
+// public verification link carries.
+func (s *Service) VerificationToken(ctx context.Context, userID, id uuid.UUID) (string, error) {
+	if s.repo == nil {
+		return "", fmt.Errorf("database not available")
+	}
+	entry, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil || entry.VerificationToken == nil {
+		return "", ErrNotFound
+	}
+	return *entry.VerificationToken, nil
 }
 
 func (s *Service) Verify(ctx context.Context, id uuid.UUID, token string) (*VerificationResult, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 	entry, err := s.repo.GetByVerificationToken(ctx, id, token)
 	if err != nil {
 		return nil, err
 	}
-	return &VerificationResult{
-		Verified:        true,
+	// A withdrawn entry no longer discloses its content.
+	if entry.RetractedAt != nil {
+		return &VerificationResult{
+			EvidenceID:  entry.ID,
+			Status:      VerificationWithdrawn,
+			Withdrawn:   true,
+			WithdrawnAt: entry.RetractedAt,
+		}, nil
+	}
+	res := &VerificationResult{
 		EvidenceID:      entry.ID,
 		Summary:         entry.Summary,
 		SkillDimensions: entry.SkillDimensions,
 		CreatedAt:       &entry.CreatedAt,
-	}, nil
+		Version:         entry.Version,
+	}
+	if s.keys != nil {
+		valid, payload := checkSignature(s.keys, entry)
+		res.SignatureValid = valid
+		res.SignedPayload = payload
+	}
+	if entry.SigningKeyID != nil {
+		res.KeyID = *entry.SigningKeyID
+	}
+	if entry.Signature != nil {
+		res.Signature = *entry.Signature
+	}
+	if entry.Revocation != nil {
+		res.Revoked = true
+		res.RevokedAt = &entry.Revocation.RevokedAt
+	}
+	res.Verified = res.SignatureValid && !res.Revoked
+	switch {
+	case res.Revoked:
+		res.Status = VerificationRevoked
+	case res.Verified:
+		res.Status = VerificationValid
+	default:
+		res.Status = VerificationInvalid
+	}
+	return res, nil
+}
+
+// Update edits one of the learner's entries. The previous state is kept as
+// a version, the entry is re-signed and credentials issued for it are
+// revoked, since they attest the old content.
+func (s *Service) Update(ctx context.Context, userID, id uuid.UUID, req UpdateEvidenceRequest) (*PortfolioEntry, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	entry, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	if entry.RetractedAt != nil {
+		return nil, ErrRetracted
+	}
+
+	if req.SkillDimensions != nil {
+		if req.SkillDimensions, err = s.normalize(req.SkillDimensions); err != nil {
+			return nil, err
+		}
+	}
+
+	// The audit log names changed fields but keeps no free text; earlier
+	// content stays in the versions, which are erased with the entry.
+	changes := map[string]interface{}{}
+	var fields []string
+	if req.Summary != nil && *req.Summary != entry.Summary {
+		if *req.Summary == "" {
+			return nil, fmt.Errorf("summary is required")
+		}
+		fields = append(fields, "summary")
+		entry.Summary = *req.Summary
+	}
+	if req.SkillDimensions != nil && !sameDimensions(req.SkillDimensions, entry.SkillDimensions) {
+		if entry.EvidenceType != "manual" {
+			return nil, fmt.Errorf("skill dimensions of %s evidence cannot be edited", entry.EvidenceType)
+		}
+		fields = append(fields, "skill_dimensions")
+		changes["skill_dimensions"] = map[string]interface{}{"from": entry.SkillDimensions, "to": req.SkillDimensions}
+		entry.SkillDimensions = req.SkillDimensions
+	}
+	if req.Context != nil {
+		fields = append(fields, "context")
+		entry.Context = req.Context
+	}
+	if len(fields) == 0 {
+		return entry, nil
+	}
+	changes["fields"] = fields
+
+	now := time.Now().UTC()
+	entry.Version++
+	entry.UpdatedAt = &now
+	if s.keys != nil {
+		if err := sign(s.keys, entry, now); err != nil {
+			return nil, fmt.Errorf("sign evidence: %w", err)
+		}
+	}
+	changes["version"] = entry.Version
+	if err := s.repo.Update(ctx, entry, newAudit(id, &userID, ActorLearner, AuditUpdate, changes)); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Retract withdraws one of the learner's entries. Unlike a revocation it is
+// final: the entry is hidden from lists and profiles, and verification
+// reports it as withdrawn without disclosing its content.
+func (s *Service) Retract(ctx context.Context, userID, id uuid.UUID, reason string) (*PortfolioEntry, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	entry, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.RetractedAt != nil {
+		return entry, nil
+	}
+	now := time.Now().UTC()
+	audit := newAudit(id, &userID, ActorLearner, AuditRetract, nil)
+	if err := s.repo.Retract(ctx, id, reason, now, audit); err != nil {
+		return nil, fmt.Errorf("retract evidence: %w", err)
+	}
+	entry.RetractedAt = &now
+	entry.RetractionReason = &reason
+	return entry, nil
+}
+
+// History returns one of the learner's entries with its earlier versions
+// and audit log.
+func (s *Service) History(ctx context.Context, userID, id uuid.UUID) (*History, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	entry, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.history(ctx, entry)
+}
+
+// AdminAudit returns the audit log of any entry.
+func (s *Service) AdminAudit(ctx context.Context, id uuid.UUID) ([]AuditEntry, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	return s.repo.ListAudit(ctx, id)
+}
+
+func (s *Service) history(ctx context.Context, entry *PortfolioEntry) (*History, error) {
+	versions, err := s.repo.ListVersions(ctx, entry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list versions: %w", err)
+	}
+	audit, err := s.repo.ListAudit(ctx, entry.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list audit: %w", err)
+	}
+	if versions == nil {
+		versions = []Version{}
+	}
+	if audit == nil {
+		audit = []AuditEntry{}
+	}
+	return &History{Entry: entry, Versions: versions, Audit: audit}, nil
+}
+
+// Run signs pending entries (see SignPending) every interval until ctx is
+// cancelled, so a key rotation is worked off without a restart. Only start
+// it with a persistent key: entries re-signed with an ephemeral key stop
+// verifying after a restart.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.SignPending(ctx, 500); err != nil {
+			log.Printf("[evidence] signing queue: %v", err)
+		} else if n > 0 {
+			log.Printf("[evidence] signed %d entries with key %s", n, s.keys.Active().ID)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SignPending signs entries that have no signature or were signed with a
+// retired key and returns the number of entries signed.
+func (s *Service) SignPending(ctx context.Context, limit int) (int, error) {
+	if s.repo == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+	if s.keys == nil {
+		return 0, fmt.Errorf("signing keys not configured")
+	}
+	entries, err := s.repo.ListUnsigned(ctx, s.keys.Active().ID, limit)
+	if err != nil {
+		return 0, fmt.Errorf("list unsigned evidence: %w", err)
+	}
+	now := time.Now().UTC()
+	signed := 0
+	for i := range entries {
+		e := &entries[i]
+		if err := sign(s.keys, e, now); err != nil {
+			return signed, fmt.Errorf("sign evidence %s: %w", e.ID, err)
+		}
+		audit := newAudit(e.ID, nil, ActorSystem, AuditResign, map[string]interface{}{"key_id": *e.SigningKeyID})
+		if err := s.repo.UpdateSignature(ctx, e.ID, *e.Signature, *e.SigningKeyID, now, audit); err != nil {
+			return signed, fmt.Errorf("store signature %s: %w", e.ID, err)
+		}
+		signed++
+	}
+	return signed, nil
+}
+
+// Revoke puts one of the learner's entries on the revocation list.
+func (s *Service) Revoke(ctx context.Context, userID, id uuid.UUID, reason string) (*Revocation, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	entry, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Revocation != nil {
+		return entry.Revocation, nil
+	}
+	return s.revoke(ctx, id, &userID, RevokedByLearner, reason)
+}
+
+// Unrevoke lifts a revocation made by the learner. Admin revocations can
+// only be lifted by an admin.
+func (s *Service) Unrevoke(ctx context.Context, userID, id uuid.UUID) error {
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	entry, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if entry.Revocation == nil {
+		return nil
+	}
+	if entry.Revocation.RevokedBy != RevokedByLearner {
+		return ErrAdminRevocation
+	}
+	return s.repo.Unrevoke(ctx, id, newAudit(id, &userID, ActorLearner, AuditUnrevoke, nil))
+}
+
+// AdminRevoke puts any entry on the revocation list, replacing a learner
+// revocation. adminID identifies the acting admin in the audit log.
+func (s *Service) AdminRevoke(ctx context.Context, adminID, id uuid.UUID, reason string) (*Revocation, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	return s.revoke(ctx, id, &adminID, RevokedByAdmin, reason)
+}
+
+// AdminUnrevoke lifts any revocation.
+func (s *Service) AdminUnrevoke(ctx context.Context, adminID, id uuid.UUID) error {
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	return s.repo.Unrevoke(ctx, id, newAudit(id, &adminID, ActorAdmin, AuditUnrevoke, nil))
+}
+
+func (s *Service) revoke(ctx context.Context, id uuid.UUID, actorID *uuid.UUID, by, reason string) (*Revocation, error) {
+	r := &Revocation{EvidenceID: id, RevokedBy: by, Reason: reason, RevokedAt: time.Now().UTC()}
+	audit := newAudit(id, actorID, by, AuditRevoke, nil)
+	if err := s.repo.Revoke(ctx, r, audit); err != nil {
+		return nil, fmt.Errorf("revoke evidence: %w", err)
+	}
+	return r, nil
+}
+
+// Revocations returns the revocation list, newest first.
+func (s *Service) Revocations(ctx context.Context, limit, offset int) ([]Revocation, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
+	return s.repo.ListRevocations(ctx, limit, offset)
+}
+
+// SessionEnded implements session.EndListener: it runs the station-result
+// extraction over the session in the background and stores one suggestion
+// per scored dimension for the learner to review.
+func (s *Service) SessionEnded(_ context.Context, sess *session.SessionDetailed) {
+	if s.repo == nil || s.extractor == nil {
+		return
+	}
+	if len(learnerInteractions(sess)) == 0 {
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), extractionTimeout)
+		defer cancel()
+		if n, err := s.extractSession(ctx, sess); err != nil {
+			log.Printf("[evidence] extraction for session %s failed: %v", sess.ID, err)
+		} else if n > 0 {
+			log.Printf("[evidence] %d suggestions extracted from session %s", n, sess.ID)
+		}
+	}()
+}
+
+func (s *Service) extractSession(ctx context.Context, sess *session.SessionDetailed) (int, error) {
+	res, err := s.extractor.Extract(ctx, sess)
+	if err != nil {
+		return 0, err
+	}
+
+	sources := learnerInteractions(sess)
+	ctxData := map[string]interface{}{"session_id": sess.ID.String()}
+	if sess.StationID != nil {
+		ctxData["station_id"] = *sess.StationID
+	}
+	if sess.JourneyType != nil {
+		ctxData["journey_type"] = *sess.JourneyType
+	}
+	summary := res.Summary
+	if summary == "" {
+		summary = "Automatisch aus einer Station erkannt."
+	}
+
+	// Model output is mapped onto the registry; keys it cannot resolve are
+	// dropped rather than failing the whole extraction.
+	scores, confidence := map[string]float64{}, map[string]float64{}
+	for key, score := range res.Scores {
+		dim := key
+		if s.taxonomy != nil {
+			var ok bool
+			if dim, ok = s.taxonomy.Resolve(key); !ok {
+				log.Printf("[evidence] session %s: dropping unknown dimension %q", sess.ID, key)
+				continue
+			}
+		}
+		if prev, seen := scores[dim]; !seen || score > prev {
+			scores[dim], confidence[dim] = score, res.Confidence[key]
+		}
+	}
+
+	now := time.Now().UTC()
+	var suggestions []Suggestion
+	for dim, score := range scores {
+		if score <= 0 {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{
+			ID:                   uuid.New(),
+			UserID:               sess.UserID,
+			SessionID:            sess.ID,
+			Dimension:            dim,
+			Score:                score,
+			Confidence:           confidence[dim],
+			Summary:              summary,
+			SourceInteractionIDs: sources,
+			Context:              ctxData,
+			Status:               SuggestionPending,
+			PromptID:             res.PromptID,
+			PromptVersion:        res.PromptVersion,
+			CreatedAt:            now,
+		})
+	}
+	if len(suggestions) == 0 {
+		return 0, nil
+	}
+	return s.repo.CreateSuggestions(ctx, suggestions)
+}
+
+// learnerInteractions returns the IDs of interactions with learner input,
+// which are the sources of extracted evidence.
+func learnerInteractions(sess *session.SessionDetailed) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, i := range sess.Interactions {
+		if i.UserInput != nil && *i.UserInput != "" {
+			ids = append(ids, i.ID)
+		}
+	}
+	return ids
+}
+
+// Suggestions lists the learner's extracted suggestions, newest first.
+func (s *Service) Suggestions(ctx context.Context, params SuggestionListParams) ([]Suggestion, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
+	return s.repo.ListSuggestions(ctx, params)
+}
+
+// AcceptSuggestion turns a pending suggestion into a signed evidence entry.
+func (s *Service) AcceptSuggestion(ctx context.Context, userID, id uuid.UUID) (*PortfolioEntry, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	sug, err := s.repo.GetSuggestion(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if sug.Status != SuggestionPending {
+		return nil, ErrSuggestionReviewed
+	}
+
+	ctxData := map[string]interface{}{"suggestion_id": sug.ID.String()}
+	for k, v := range sug.Context {
+		ctxData[k] = v
+	}
+	confidence := sug.Confidence
+	entry, err := s.newEntry(userID, CreateEvidenceRequest{
+		SourceInteractionIDs: sug.SourceInteractionIDs,
+		SkillDimensions:      map[string]float64{sug.Dimension: sug.Score},
+		EvidenceType:         "auto",
+		Summary:              sug.Summary,
+		Confidence:           &confidence,
+		Context:              ctxData,
+	})
+	if err != nil {
+		return nil, err
+	}
+	audit := newAudit(entry.ID, &userID, ActorLearner, AuditCreate, map[string]interface{}{
+		"evidence_type": entry.EvidenceType,
+		"suggestion_id": sug.ID.String(),
+	})
+	if err := s.repo.AcceptSuggestion(ctx, sug.ID, entry, entry.CreatedAt, audit); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// DiscardSuggestion rejects a pending suggestion; it never becomes evidence.
+func (s *Service) DiscardSuggestion(ctx context.Context, userID, id uuid.UUID) error {
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	sug, err := s.repo.GetSuggestion(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if sug.Status != SuggestionPending {
+		return ErrSuggestionReviewed
+	}
+	return s.repo.DiscardSuggestion(ctx, sug.ID, time.Now().UTC())
+}
+
+func newAudit(evidenceID uuid.UUID, actorID *uuid.UUID, role, action string, changes map[string]interface{}) *AuditEntry {
+	return &AuditEntry{
+		ID:         uuid.New(),
+		EvidenceID: evidenceID,
+		ActorID:    actorID,
+		ActorRole:  role,
+		Action:     action,
+		Changes:    changes,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+func sameDimensions(a, b map[string]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
 }
 
 func generateToken() string {
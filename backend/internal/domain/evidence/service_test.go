@@ -0,0 +1,649 @@
+package evidence
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/ai"
+	"skillr-mvp-v1/backend/internal/domain/session"
+	"skillr-mvp-v1/backend/internal/domain/taxonomy"
+	"skillr-mvp-v1/backend/internal/signing"
+)
+
+type mockRepo struct {
+	mu          sync.Mutex
+	entries     map[uuid.UUID]*PortfolioEntry
+	revocations map[uuid.UUID]*Revocation
+	suggestions map[uuid.UUID]*Suggestion
+	versions    map[uuid.UUID][]Version
+	audit       []AuditEntry
+}
+
+func newMockRepo() *mockRepo {
+	return &mockRepo{
+		entries:     map[uuid.UUID]*PortfolioEntry{},
+		revocations: map[uuid.UUID]*Revocation{},
+		suggestions: map[uuid.UUID]*Suggestion{},
+		versions:    map[uuid.UUID][]Version{},
+	}
+}
+
+// load returns a copy as the database would, with confidence rounded to
+// NUMERIC(3,2) and the revocation joined.
+func (m *mockRepo) load(id uuid.UUID) *PortfolioEntry {
+	e := *m.entries[id]
+	e.Confidence = float64(int(e.Confidence*100+0.5)) / 100
+	e.CreatedAt = e.CreatedAt.Truncate(time.Microsecond)
+	e.Revocation = m.revocations[id]
+	return &e
+}
+
+func (m *mockRepo) Create(_ context.Context, e *PortfolioEntry, audit *AuditEntry) error {
+	cp := *e
+	m.entries[e.ID] = &cp
+	m.audit = append(m.audit, *audit)
+	return nil
+}
+
+func (m *mockRepo) Update(_ context.Context, e *PortfolioEntry, audit *AuditEntry) error {
+	prev := m.entries[e.ID]
+	if prev.Version != e.Version-1 {
+		return ErrVersionConflict
+	}
+	m.versions[e.ID] = append(m.versions[e.ID], Version{EvidenceID: e.ID, Version: prev.Version, Summary: prev.Summary, Signature: prev.Signature})
+	cp := *e
+	m.entries[e.ID] = &cp
+	m.audit = append(m.audit, *audit)
+	return nil
+}
+
+func (m *mockRepo) Retract(_ context.Context, id uuid.UUID, reason string, at time.Time, audit *AuditEntry) error {
+	e := m.entries[id]
+	e.RetractedAt, e.RetractionReason = &at, &reason
+	m.audit = append(m.audit, *audit)
+	return nil
+}
+
+func (m *mockRepo) ListVersions(_ context.Context, id uuid.UUID) ([]Version, error) {
+	return m.versions[id], nil
+}
+
+func (m *mockRepo) ListAudit(_ context.Context, id uuid.UUID) ([]AuditEntry, error) {
+	var out []AuditEntry
+	for _, a := range m.audit {
+		if a.EvidenceID == id {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRepo) GetByID(_ context.Context, id, userID uuid.UUID) (*PortfolioEntry, error) {
+	e, ok := m.entries[id]
+	if !ok || e.UserID != userID {
+		return nil, fmt.Errorf("not found")
+	}
+	return m.load(id), nil
+}
+
+func (m *mockRepo) GetDetailedByID(ctx context.Context, id, userID uuid.UUID) (*PortfolioEntryDetailed, error) {
+	e, err := m.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &PortfolioEntryDetailed{PortfolioEntry: *e}, nil
+}
+
+func (m *mockRepo) List(_ context.Context, params ListParams) ([]PortfolioEntry, int, error) {
+	var out []PortfolioEntry
+	for id, e := range m.entries {
+		if e.UserID == params.UserID && (params.IncludeRetracted || e.RetractedAt == nil) {
+			out = append(out, *m.load(id))
+		}
+	}
+	return out, len(out), nil
+}
+
+func (m *mockRepo) ListByDimension(_ context.Context, userID uuid.UUID, dim string) ([]PortfolioEntry, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockRepo) GetByVerificationToken(_ context.Context, id uuid.UUID, token string) (*PortfolioEntry, error) {
+	e, ok := m.entries[id]
+	if !ok || e.VerificationToken == nil || *e.VerificationToken != token {
+		return nil, fmt.Errorf("not found")
+	}
+	return m.load(id), nil
+}
+
+func (m *mockRepo) ListUnsigned(_ context.Context, activeKeyID string, limit int) ([]PortfolioEntry, error) {
+	var out []PortfolioEntry
+	for id, e := range m.entries {
+		if e.SigningKeyID == nil || *e.SigningKeyID != activeKeyID {
+			out = append(out, *m.load(id))
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRepo) UpdateSignature(_ context.Context, id uuid.UUID, signature, keyID string, signedAt time.Time, audit *AuditEntry) error {
+	e := m.entries[id]
+	e.Signature, e.SigningKeyID, e.SignedAt = &signature, &keyID, &signedAt
+	m.audit = append(m.audit, *audit)
+	return nil
+}
+
+func (m *mockRepo) Revoke(_ context.Context, r *Revocation, audit *AuditEntry) error {
+	if _, ok := m.entries[r.EvidenceID]; !ok {
+		return fmt.Errorf("not found")
+	}
+	m.revocations[r.EvidenceID] = r
+	m.audit = append(m.audit, *audit)
+	return nil
+}
+
+func (m *mockRepo) Unrevoke(_ context.Context, id uuid.UUID, audit *AuditEntry) error {
+	delete(m.revocations, id)
+	m.audit = append(m.audit, *audit)
+	return nil
+}
+
+func (m *mockRepo) ListRevocations(_ context.Context, limit, offset int) ([]Revocation, int, error) {
+	var out []Revocation
+	for _, r := range m.revocations {
+		out = append(out, *r)
+	}
+	return out, len(out), nil
+}
+
+func (m *mockRepo) CreateSuggestions(_ context.Context, suggestions []Suggestion) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := 0
+	for _, s := range suggestions {
+		dup := false
+		for _, existing := range m.suggestions {
+			if existing.SessionID == s.SessionID && existing.Dimension == s.Dimension {
+				dup = true
+			}
+		}
+		if !dup {
+			cp := s
+			m.suggestions[s.ID] = &cp
+			stored++
+		}
+	}
+	return stored, nil
+}
+
+func (m *mockRepo) GetSuggestion(_ context.Context, id, userID uuid.UUID) (*Suggestion, error) {
+	s, ok := m.suggestions[id]
+	if !ok || s.UserID != userID {
+		return nil, fmt.Errorf("not found")
+	}
+	cp := *s
+	return &cp, nil
+}
+
+func (m *mockRepo) ListSuggestions(_ context.Context, params SuggestionListParams) ([]Suggestion, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Suggestion
+	for _, s := range m.suggestions {
+		if s.UserID == params.UserID && (params.Status == "" || s.Status == params.Status) {
+			out = append(out, *s)
+		}
+	}
+	return out, len(out), nil
+}
+
+func (m *mockRepo) AcceptSuggestion(ctx context.Context, id uuid.UUID, e *PortfolioEntry, at time.Time, audit *AuditEntry) error {
+	s := m.suggestions[id]
+	if s.Status != SuggestionPending {
+		return ErrSuggestionReviewed
+	}
+	s.Status, s.EvidenceID, s.ReviewedAt = SuggestionAccepted, &e.ID, &at
+	return m.Create(ctx, e, audit)
+}
+
+func (m *mockRepo) DiscardSuggestion(_ context.Context, id uuid.UUID, at time.Time) error {
+	s := m.suggestions[id]
+	if s.Status != SuggestionPending {
+		return ErrSuggestionReviewed
+	}
+	s.Status, s.ReviewedAt = SuggestionDiscarded, &at
+	return nil
+}
+
+func testKey(t *testing.T, b byte) *signing.Key {
+	t.Helper()
+	k, err := signing.NewKey(bytes.Repeat([]byte{b}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func createEntry(t *testing.T, svc *Service, userID uuid.UUID) *PortfolioEntry {
+	t.Helper()
+	confidence := 0.777
+	e, err := svc.Create(context.Background(), userID, CreateEvidenceRequest{
+		SkillDimensions: map[string]float64{"teamwork": 0.8},
+		EvidenceType:    "manual",
+		Summary:         "Hat ein Teamprojekt geleitet.",
+		Confidence:      &confidence,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestService_CreateSignsAndVerifies(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	key := testKey(t, 1)
+	svc.SetKeys(signing.NewKeyRing(key))
+	userID := uuid.New()
+
+	e := createEntry(t, svc, userID)
+	if e.Signature == nil || *e.SigningKeyID != key.ID {
+		t.Fatalf("expected entry signed with %s", key.ID)
+	}
+
+	res, err := svc.Verify(context.Background(), e.ID, *e.VerificationToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Verified || !res.SignatureValid || res.Revoked || res.KeyID != key.ID {
+		t.Fatalf("expected valid signature, got %+v", res)
+	}
+
+	// The returned payload and signature verify offline with the public key.
+	sig, _ := base64.RawURLEncoding.DecodeString(res.Signature)
+	if !key.Verify(res.SignedPayload, sig) {
+		t.Error("expected signed payload to verify offline")
+	}
+}
+
+func TestService_VerifyDetectsTampering(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.SetKeys(signing.NewKeyRing(testKey(t, 1)))
+	e := createEntry(t, svc, uuid.New())
+
+	repo.entries[e.ID].Summary = "Hat einen Weltkonzern geleitet."
+	res, err := svc.Verify(context.Background(), e.ID, *e.VerificationToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Verified || res.SignatureValid {
+		t.Errorf("expected tampered entry to fail, got %+v", res)
+	}
+}
+
+func TestService_SignPendingAfterRotation(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	oldKey, newKey := testKey(t, 1), testKey(t, 2)
+	svc.SetKeys(signing.NewKeyRing(oldKey))
+	userID := uuid.New()
+	e := createEntry(t, svc, userID)
+
+	// Rotate: old key retired, still verifies until the entry is re-signed.
+	svc.SetKeys(signing.NewKeyRing(newKey, oldKey))
+	res, _ := svc.Verify(context.Background(), e.ID, *e.VerificationToken)
+	if !res.SignatureValid || res.KeyID != oldKey.ID {
+		t.Fatalf("expected retired key to verify, got %+v", res)
+	}
+
+	n, err := svc.SignPending(context.Background(), 100)
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 entry re-signed, got %d (%v)", n, err)
+	}
+	res, _ = svc.Verify(context.Background(), e.ID, *e.VerificationToken)
+	if !res.SignatureValid || res.KeyID != newKey.ID {
+		t.Errorf("expected signature with new key, got %+v", res)
+	}
+	if n, _ := svc.SignPending(context.Background(), 100); n != 0 {
+		t.Errorf("expected nothing left to sign, got %d", n)
+	}
+}
+
+func TestService_RunSignsPending(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	oldKey, newKey := testKey(t, 1), testKey(t, 2)
+	svc.SetKeys(signing.NewKeyRing(oldKey))
+	e := createEntry(t, svc, uuid.New())
+	svc.SetKeys(signing.NewKeyRing(newKey, oldKey))
+
+	// A cancelled context stops Run after its first pass.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc.Run(ctx, time.Hour)
+
+	res, _ := svc.Verify(context.Background(), e.ID, *e.VerificationToken)
+	if !res.SignatureValid || res.KeyID != newKey.ID {
+		t.Errorf("expected signature with new key, got %+v", res)
+	}
+}
+
+func TestService_Revocation(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.SetKeys(signing.NewKeyRing(testKey(t, 1)))
+	userID := uuid.New()
+	e := createEntry(t, svc, userID)
+	ctx := context.Background()
+
+	if _, err := svc.Revoke(ctx, uuid.New(), e.ID, ""); err == nil {
+		t.Error("expected error revoking another learner's entry")
+	}
+	if _, err := svc.Revoke(ctx, userID, e.ID, "Falsch erfasst"); err != nil {
+		t.Fatal(err)
+	}
+	res, _ := svc.Verify(ctx, e.ID, *e.VerificationToken)
+	if res.Verified || !res.SignatureValid || !res.Revoked {
+		t.Errorf("expected valid signature but revoked, got %+v", res)
+	}
+	if err := svc.Unrevoke(ctx, userID, e.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Admin revocations cannot be lifted by the learner.
+	adminID := uuid.New()
+	if _, err := svc.AdminRevoke(ctx, adminID, e.ID, "fraud"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Unrevoke(ctx, userID, e.ID); !errors.Is(err, ErrAdminRevocation) {
+		t.Errorf("expected ErrAdminRevocation, got %v", err)
+	}
+	if err := svc.AdminUnrevoke(ctx, adminID, e.ID); err != nil {
+		t.Fatal(err)
+	}
+	res, _ = svc.Verify(ctx, e.ID, *e.VerificationToken)
+	if !res.Verified {
+		t.Errorf("expected entry verified after lifting revocation, got %+v", res)
+	}
+}
+
+func TestService_VerifyUnsigned(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	e := createEntry(t, svc, uuid.New())
+	svc.SetKeys(signing.NewKeyRing(testKey(t, 1)))
+
+	res, err := svc.Verify(context.Background(), e.ID, *e.VerificationToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Verified || res.SignatureValid {
+		t.Errorf("expected unsigned entry not verified, got %+v", res)
+	}
+}
+
+type mockExtractor struct {
+	res   *Extraction
+	calls int
+}
+
+func (m *mockExtractor) Extract(_ context.Context, _ *session.SessionDetailed) (*Extraction, error) {
+	m.calls++
+	return m.res, nil
+}
+
+func endedSession(userID uuid.UUID) *session.SessionDetailed {
+	station, journey := "station-v1", "vuca"
+	answer, reply := "Ich habe das Team neu organisiert.", "Spannend!"
+	return &session.SessionDetailed{
+		Session: session.Session{ID: uuid.New(), UserID: userID, StationID: &station, JourneyType: &journey},
+		Interactions: []session.Interaction{
+			{ID: uuid.New(), UserInput: &answer, AssistantResponse: &reply},
+			{ID: uuid.New(), AssistantResponse: &reply},
+		},
+	}
+}
+
+func TestService_SessionEndedCreatesSuggestions(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.SetKeys(signing.NewKeyRing(testKey(t, 1)))
+	svc.SetExtractor(&mockExtractor{res: &Extraction{
+		Scores:     map[string]float64{"teamwork": 82, "creativity": 0},
+		Confidence: map[string]float64{"teamwork": 0.9},
+		Summary:    "Hat das Team neu organisiert.",
+		PromptID:   "builtin:station-result",
+	}})
+	userID := uuid.New()
+	sess := endedSession(userID)
+
+	svc.SessionEnded(context.Background(), sess)
+	svc.Wait()
+	svc.SessionEnded(context.Background(), sess)
+	svc.Wait()
+
+	list, _, _ := svc.Suggestions(context.Background(), SuggestionListParams{UserID: userID, Status: SuggestionPending})
+	if len(list) != 1 {
+		t.Fatalf("expected one suggestion for the scored dimension, got %d", len(list))
+	}
+	sug := list[0]
+	if sug.Dimension != "teamwork" || sug.Confidence != 0.9 || sug.Context["station_id"] != "station-v1" {
+		t.Errorf("unexpected suggestion %+v", sug)
+	}
+	if len(sug.SourceInteractionIDs) != 1 || sug.SourceInteractionIDs[0] != sess.Interactions[0].ID {
+		t.Errorf("expected learner interaction as source, got %v", sug.SourceInteractionIDs)
+	}
+	if entries, _, _ := svc.List(context.Background(), ListParams{UserID: userID}); len(entries) != 0 {
+		t.Errorf("expected no visible evidence before review, got %d", len(entries))
+	}
+}
+
+func TestService_TaxonomyNormalizesDimensions(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.SetTaxonomy(taxonomy.NewService(nil))
+	userID := uuid.New()
+
+	e, err := svc.Create(context.Background(), userID, CreateEvidenceRequest{
+		SkillDimensions: map[string]float64{"Self_Awareness": 60, "uncertainty": 40},
+		EvidenceType:    "manual",
+		Summary:         "Hat in einer unklaren Lage ruhig entschieden.",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.SkillDimensions["self-awareness"] != 60 || e.SkillDimensions["ambiguity-tolerance"] != 40 || len(e.SkillDimensions) != 2 {
+		t.Errorf("expected canonical keys, got %v", e.SkillDimensions)
+	}
+
+	_, err = svc.Create(context.Background(), userID, CreateEvidenceRequest{
+		SkillDimensions: map[string]float64{"teamwrok": 70},
+		EvidenceType:    "manual",
+		Summary:         "Tippfehler",
+	})
+	if !errors.Is(err, taxonomy.ErrUnknownDimension) {
+		t.Errorf("expected unknown dimension error, got %v", err)
+	}
+
+	svc.SetExtractor(&mockExtractor{res: &Extraction{
+		Scores:     map[string]float64{"problem_solving": 50, "complexity": 70, "astrology": 90},
+		Confidence: map[string]float64{"problem_solving": 0.4, "complexity": 0.8},
+	}})
+	svc.SessionEnded(context.Background(), endedSession(userID))
+	svc.Wait()
+	list, _, _ := svc.Suggestions(context.Background(), SuggestionListParams{UserID: userID, Status: SuggestionPending})
+	if len(list) != 1 || list[0].Dimension != "problem-solving" || list[0].Score != 70 || list[0].Confidence != 0.8 {
+		t.Errorf("expected one merged problem-solving suggestion, got %+v", list)
+	}
+}
+
+func TestService_ReviewSuggestions(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.SetKeys(signing.NewKeyRing(testKey(t, 1)))
+	svc.SetExtractor(&mockExtractor{res: &Extraction{
+		Scores:     map[string]float64{"teamwork": 82, "resilience": 60},
+		Confidence: map[string]float64{"teamwork": 0.9, "resilience": 0.4},
+		Summary:    "Hat das Team neu organisiert.",
+	}})
+	userID := uuid.New()
+	ctx := context.Background()
+	svc.SessionEnded(ctx, endedSession(userID))
+	svc.Wait()
+
+	list, _, _ := svc.Suggestions(ctx, SuggestionListParams{UserID: userID, Status: SuggestionPending})
+	if len(list) != 2 {
+		t.Fatalf("expected 2 suggestions, got %d", len(list))
+	}
+	accept, discard := list[0], list[1]
+
+	if _, err := svc.AcceptSuggestion(ctx, uuid.New(), accept.ID); err == nil {
+		t.Error("expected error accepting another learner's suggestion")
+	}
+	entry, err := svc.AcceptSuggestion(ctx, userID, accept.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.EvidenceType != "auto" || entry.Confidence != accept.Confidence || entry.SkillDimensions[accept.Dimension] != accept.Score {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	if entry.Signature == nil || len(entry.SourceInteractionIDs) != 1 {
+		t.Errorf("expected signed entry linked to the source interaction, got %+v", entry)
+	}
+	if _, err := svc.AcceptSuggestion(ctx, userID, accept.ID); !errors.Is(err, ErrSuggestionReviewed) {
+		t.Errorf("expected ErrSuggestionReviewed, got %v", err)
+	}
+
+	if err := svc.DiscardSuggestion(ctx, userID, discard.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.AcceptSuggestion(ctx, userID, discard.ID); !errors.Is(err, ErrSuggestionReviewed) {
+		t.Errorf("expected discarded suggestion not to be accepted, got %v", err)
+	}
+	if entries, _, _ := svc.List(ctx, ListParams{UserID: userID}); len(entries) != 1 {
+		t.Errorf("expected one accepted entry, got %d", len(entries))
+	}
+}
+
+type mockGenerator struct {
+	text string
+	req  ai.ChatRequest
+}
+
+func (m *mockGenerator) Generate(_ context.Context, req ai.ChatRequest) (*ai.ChatResponse, error) {
+	m.req = req
+	return &ai.ChatResponse{Text: m.text}, nil
+}
+
+func TestAIExtractor_ParsesResponse(t *testing.T) {
+	gen := &mockGenerator{text: `{"dimensionScores": {"Teamwork": 120, "creativity": 55}, "confidence": {"Teamwork": 0.83}, "summary": "Gut."}`}
+	res, err := NewAIExtractor(gen, nil).Extract(context.Background(), endedSession(uuid.New()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Scores["teamwork"] != 100 || res.Confidence["teamwork"] != 0.83 {
+		t.Errorf("unexpected teamwork result: %v %v", res.Scores, res.Confidence)
+	}
+	if res.Confidence["creativity"] != defaultConfidence {
+		t.Errorf("expected default confidence, got %v", res.Confidence["creativity"])
+	}
+	if res.PromptID != "builtin:station-result" {
+		t.Errorf("unexpected prompt %s", res.PromptID)
+	}
+	if !strings.Contains(gen.req.Message, "Station: station-v1") || !strings.Contains(gen.req.Message, "Nutzer: Ich habe das Team") {
+		t.Errorf("expected station and transcript in message, got %q", gen.req.Message)
+	}
+}
+
+func TestService_UpdateKeepsVersions(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.SetKeys(signing.NewKeyRing(testKey(t, 1)))
+	userID := uuid.New()
+	ctx := context.Background()
+	e := createEntry(t, svc, userID)
+	firstSig := *e.Signature
+
+	summary := "Hat ein Teamprojekt mit fuenf Personen geleitet."
+	if _, err := svc.Update(ctx, uuid.New(), e.ID, UpdateEvidenceRequest{Summary: &summary}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for another learner, got %v", err)
+	}
+	updated, err := svc.Update(ctx, userID, e.ID, UpdateEvidenceRequest{Summary: &summary})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Version != 2 || updated.Summary != summary || *updated.Signature == firstSig {
+		t.Errorf("expected re-signed version 2, got %+v", updated)
+	}
+
+	res, _ := svc.Verify(ctx, e.ID, *e.VerificationToken)
+	if !res.Verified || res.Status != VerificationValid || res.Version != 2 {
+		t.Errorf("expected edited entry to verify, got %+v", res)
+	}
+
+	history, err := svc.History(ctx, userID, e.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history.Versions) != 1 || history.Versions[0].Summary != "Hat ein Teamprojekt geleitet." {
+		t.Errorf("expected original version kept, got %+v", history.Versions)
+	}
+	if len(history.Audit) != 2 || history.Audit[1].Action != AuditUpdate || *history.Audit[1].ActorID != userID {
+		t.Errorf("expected create and update audit entries, got %+v", history.Audit)
+	}
+	if changes := history.Audit[1].Changes; len(changes) != 2 || changes["summary"] != nil || changes["version"] != 2 {
+		t.Errorf("expected changed fields without the summary text, got %v", changes)
+	}
+
+	// Dimensions of non-manual evidence are not editable.
+	repo.entries[e.ID].EvidenceType = "auto"
+	if _, err := svc.Update(ctx, userID, e.ID, UpdateEvidenceRequest{SkillDimensions: map[string]float64{"teamwork": 1}}); err == nil {
+		t.Error("expected error editing dimensions of auto evidence")
+	}
+}
+
+func TestService_RetractShowsWithdrawn(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.SetKeys(signing.NewKeyRing(testKey(t, 1)))
+	userID := uuid.New()
+	ctx := context.Background()
+	e := createEntry(t, svc, userID)
+
+	if _, err := svc.Retract(ctx, userID, e.ID, "Nicht mehr relevant"); err != nil {
+		t.Fatal(err)
+	}
+	res, err := svc.Verify(ctx, e.ID, *e.VerificationToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Verified || !res.Withdrawn || res.Status != VerificationWithdrawn || res.Summary != "" {
+		t.Errorf("expected withdrawn entry without content, got %+v", res)
+	}
+
+	if entries, _, _ := svc.List(ctx, ListParams{UserID: userID}); len(entries) != 0 {
+		t.Errorf("expected retracted entry hidden, got %d", len(entries))
+	}
+	if entries, _, _ := svc.List(ctx, ListParams{UserID: userID, IncludeRetracted: true}); len(entries) != 1 {
+		t.Errorf("expected retracted entry on request, got %d", len(entries))
+	}
+
+	summary := "neu"
+	if _, err := svc.Update(ctx, userID, e.ID, UpdateEvidenceRequest{Summary: &summary}); !errors.Is(err, ErrRetracted) {
+		t.Errorf("expected ErrRetracted, got %v", err)
+	}
+	audit, _ := svc.AdminAudit(ctx, e.ID)
+	if last := audit[len(audit)-1]; last.Action != AuditRetract || len(last.Changes) != 0 {
+		t.Errorf("expected retract audit entry without the reason, got %+v", last)
+	}
+}
@@ -0,0 +1,81 @@
+package evidence
+
+import (
+	"encoding/base64"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/signing"
+)
+
+// signedPayload is the part of an evidence entry covered by the signature.
+// Values are normalised to what the database stores (confidence with two
+// decimals, timestamps in whole seconds) so a stored entry re-verifies.
+type signedPayload struct {
+	ID                   uuid.UUID          `json:"id"`
+	UserID               uuid.UUID          `json:"user_id"`
+	EvidenceType         string             `json:"evidence_type"`
+	Summary              string             `json:"summary"`
+	SkillDimensions      map[string]float64 `json:"skill_dimensions,omitempty"`
+	Confidence           float64            `json:"confidence"`
+	SourceInteractionIDs []uuid.UUID        `json:"source_interaction_ids,omitempty"`
+	CreatedAt            string             `json:"created_at"`
+	// Version is omitted for the first version so signatures made before
+	// entries could be edited stay valid.
+	Version int    `json:"version,omitempty"`
+	KeyID   string `json:"kid"`
+}
+
+// canonicalPayload returns the canonical JSON bytes signed for e with kid.
+func canonicalPayload(e *PortfolioEntry, kid string) ([]byte, error) {
+	version := 0
+	if e.Version > 1 {
+		version = e.Version
+	}
+	return signing.Canonicalize(signedPayload{
+		ID:                   e.ID,
+		UserID:               e.UserID,
+		EvidenceType:         e.EvidenceType,
+		Summary:              e.Summary,
+		SkillDimensions:      e.SkillDimensions,
+		Confidence:           math.Round(e.Confidence*100) / 100,
+		SourceInteractionIDs: e.SourceInteractionIDs,
+		CreatedAt:            e.CreatedAt.UTC().Format(time.RFC3339),
+		Version:              version,
+		KeyID:                kid,
+	})
+}
+
+// sign signs e with the active key and sets its signature fields.
+func sign(keys *signing.KeyRing, e *PortfolioEntry, at time.Time) error {
+	key := keys.Active()
+	payload, err := canonicalPayload(e, key.ID)
+	if err != nil {
+		return err
+	}
+	sig := base64.RawURLEncoding.EncodeToString(key.Sign(payload))
+	kid := key.ID
+	e.Signature = &sig
+	e.SigningKeyID = &kid
+	e.SignedAt = &at
+	return nil
+}
+
+// checkSignature verifies the stored signature of e. It returns the signed
+// payload so callers can hand it to offline verifiers.
+func checkSignature(keys *signing.KeyRing, e *PortfolioEntry) (bool, []byte) {
+	if e.Signature == nil || e.SigningKeyID == nil {
+		return false, nil
+	}
+	payload, err := canonicalPayload(e, *e.SigningKeyID)
+	if err != nil {
+		return false, nil
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(*e.Signature)
+	if err != nil {
+		return false, payload
+	}
+	return keys.Verify(*e.SigningKeyID, payload, sig), payload
+}
@@ -0,0 +1,279 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxFeedBytes limits the size of one feed.
+const maxFeedBytes = 20 << 20
+
+// baJobDetailURL is the public Jobsuche page of a BA posting, used as the
+// external URL of BA postings without their own link.
+const baJobDetailURL = "https://www.arbeitsagentur.de/jobsuche/jobdetail/"
+
+// feedItem is one posting as read from a feed, before validation.
+type feedItem struct {
+	Title              string             `json:"title"`
+	Company            string             `json:"company"`
+	Description        string             `json:"description"`
+	Location           string             `json:"location"`
+	ExternalURL        string             `json:"external_url"`
+	URL                string             `json:"url"`
+	RequiredDimensions map[string]float64 `json:"required_dimensions"`
+}
+
+// Fetcher downloads a feed from a URL.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) (body io.ReadCloser, contentType string, err error)
+}
+
+// HTTPFetcher fetches feeds over HTTP(S). It refuses to connect to loopback,
+// private and link-local addresses.
+type HTTPFetcher struct {
+	client *http.Client
+}
+
+func NewHTTPFetcher() *HTTPFetcher {
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: publicAddressOnly}
+	return &HTTPFetcher{client: &http.Client{
+		Timeout: 60 * time.Second,
+		// No proxy: the address check must see the feed host itself.
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	}}
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, url string) (io.ReadCloser, string, error) {
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return nil, "", fmt.Errorf("feed URL must use http or https")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid feed URL")
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("fetch feed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("fetch feed: status %d", resp.StatusCode)
+	}
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
+
+// publicAddressOnly rejects connections to internal networks (SSRF prevention).
+func publicAddressOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("feed URL cannot target internal addresses")
+	}
+	return nil
+}
+
+// detectFormat picks the feed format from the request, the file name or
+// the content type.
+func detectFormat(format, name, contentType string) (string, error) {
+	switch format {
+	case FormatJSON, FormatCSV, FormatBAXML:
+		return format, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unknown format %q", format)
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".xml":
+		return FormatBAXML, nil
+	}
+	switch ct := strings.ToLower(contentType); {
+	case strings.Contains(ct, "json"):
+		return FormatJSON, nil
+	case strings.Contains(ct, "csv"):
+		return FormatCSV, nil
+	case strings.Contains(ct, "xml"):
+		return FormatBAXML, nil
+	}
+	return "", fmt.Errorf("format is required")
+}
+
+// parseFeed reads all postings of a feed. Item-level problems are left to
+// validation; only unreadable feeds fail.
+func parseFeed(r io.Reader, format string) ([]feedItem, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxFeedBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read feed: %w", err)
+	}
+	if len(data) > maxFeedBytes {
+		return nil, fmt.Errorf("feed exceeds %d MB", maxFeedBytes>>20)
+	}
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	switch format {
+	case FormatJSON:
+		return parseJSONFeed(data)
+	case FormatCSV:
+		return parseCSVFeed(data)
+	case FormatBAXML:
+		return parseBAXMLFeed(data)
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// parseJSONFeed accepts an array of postings or an object with a
+// "postings" or "jobs" array.
+func parseJSONFeed(data []byte) ([]feedItem, error) {
+	var items []feedItem
+	if err := json.Unmarshal(data, &items); err == nil {
+		return items, nil
+	}
+	var wrapped struct {
+		Postings []feedItem `json:"postings"`
+		Jobs     []feedItem `json:"jobs"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return nil, fmt.Errorf("parse JSON feed: %w", err)
+	}
+	return append(wrapped.Postings, wrapped.Jobs...), nil
+}
+
+// parseCSVFeed reads a CSV with a header row (comma or semicolon separated).
+// required_dimensions is a list like "teamwork:60|empathy:80".
+func parseCSVFeed(data []byte) ([]feedItem, error) {
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.FieldsPerRecord = -1
+	if first, _, _ := bytes.Cut(data, []byte("\n")); bytes.Count(first, []byte(";")) > bytes.Count(first, []byte(",")) {
+		cr.Comma = ';'
+	}
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	col := map[string]int{}
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := col["title"]; !ok {
+		return nil, fmt.Errorf("CSV feed has no title column")
+	}
+	field := func(rec []string, names ...string) string {
+		for _, n := range names {
+			if i, ok := col[n]; ok && i < len(rec) && strings.TrimSpace(rec[i]) != "" {
+				return strings.TrimSpace(rec[i])
+			}
+		}
+		return ""
+	}
+
+	var items []feedItem
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV feed: %w", err)
+		}
+		item := feedItem{
+			Title:       field(rec, "title"),
+			Company:     field(rec, "company"),
+			Description: field(rec, "description"),
+			Location:    field(rec, "location"),
+			ExternalURL: field(rec, "external_url", "url"),
+		}
+		if dims := field(rec, "required_dimensions"); dims != "" {
+			item.RequiredDimensions = parseDimensionList(dims)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// parseDimensionList parses "key:level" (or "key=level") pairs separated by
+// "|", ";" or ",". Keys without a level get level 0 (the default
+// requirement); keys are validated later.
+func parseDimensionList(s string) map[string]float64 {
+	dims := map[string]float64{}
+	for _, pair := range strings.FieldsFunc(s, func(r rune) bool { return r == '|' || r == ';' || r == ',' }) {
+		key, level := strings.TrimSpace(pair), 0.0
+		if i := strings.LastIndexAny(key, ":="); i >= 0 {
+			if v, err := strconv.ParseFloat(strings.TrimSpace(key[i+1:]), 64); err == nil {
+				key, level = strings.TrimSpace(key[:i]), v
+			}
+		}
+		if key != "" {
+			dims[key] = level
+		}
+	}
+	return dims
+}
+
+// baPosting is the subset of a HR-BA-XML JobPositionPosting that is imported.
+type baPosting struct {
+	ID          string `xml:"JobPositionPostingId"`
+	Company     string `xml:"HiringOrg>HiringOrgName"`
+	Title       string `xml:"JobPositionInformation>JobPositionTitle>Degree"`
+	TitleDesc   string `xml:"JobPositionInformation>JobPositionTitleDescription"`
+	Description string `xml:"JobPositionInformation>JobPositionDescription>JobPositionDescription"`
+	PostalCode  string `xml:"JobPositionInformation>JobPositionDescription>JobPositionLocation>Location>PostalCode"`
+	City        string `xml:"JobPositionInformation>JobPositionDescription>JobPositionLocation>Location>Municipality"`
+	URL         string `xml:"JobPositionInformation>JobPositionDescription>ApplicationURL"`
+}
+
+// parseBAXMLFeed reads every JobPositionPosting element, wherever it is
+// nested in the document.
+func parseBAXMLFeed(data []byte) ([]feedItem, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var items []feedItem
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse XML feed: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != "JobPositionPosting" {
+			continue
+		}
+		var p baPosting
+		if err := dec.DecodeElement(&p, &start); err != nil {
+			return nil, fmt.Errorf("parse XML feed: %w", err)
+		}
+		item := feedItem{
+			Title:       strings.TrimSpace(p.Title),
+			Company:     strings.TrimSpace(p.Company),
+			Description: strings.TrimSpace(p.Description),
+			Location:    strings.TrimSpace(strings.TrimSpace(p.PostalCode) + " " + strings.TrimSpace(p.City)),
+			ExternalURL: strings.TrimSpace(p.URL),
+		}
+		if item.Title == "" {
+			item.Title = strings.TrimSpace(p.TitleDesc)
+		}
+		if item.ExternalURL == "" && strings.TrimSpace(p.ID) != "" {
+			item.ExternalURL = baJobDetailURL + strings.TrimSpace(p.ID)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,231 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"skillr-mvp-v1/backend/internal/honeycomb"
+	"skillr-mvp-v1/backend/internal/skillscore"
+)
+
+// ErrNoPostings is returned when no active posting matches an occupation.
+var ErrNoPostings = errors.New("no active job postings match the occupation")
+
+// ErrNoRequirements is returned when the target names no required dimensions.
+var ErrNoRequirements = errors.New("target has no required dimensions")
+
+// maxOccupationPostings bounds the postings averaged for an occupation.
+const maxOccupationPostings = 50
+
+// maxRecommendations is the number of Lernreisen and courses recommended.
+const maxRecommendations = 5
+
+// CourseCatalog lists the Honeycomb courses available in a learner's
+// context (satisfied by honeycomb.Client).
+type CourseCatalog interface {
+	ListCourses(ctx context.Context, ctxID string) ([]honeycomb.ListEntry, error)
+}
+
+// SetCourses enables Honeycomb course recommendations in gap analyses.
+func (s *Service) SetCourses(c CourseCatalog) {
+	s.courses = c
+}
+
+// GapAnalysis compares the learner's skill profile with a posting or an
+// occupation and recommends Lernreisen and Honeycomb courses for the gaps.
+func (s *Service) GapAnalysis(ctx context.Context, params GapParams) (*GapAnalysis, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	params.Occupation = strings.TrimSpace(params.Occupation)
+	if (params.PostingID == nil) == (params.Occupation == "") {
+		return nil, fmt.Errorf("exactly one of posting_id or occupation is required")
+	}
+
+	target, err := s.gapTarget(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := s.repo.LoadSkills(ctx, params.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("load skills: %w", err)
+	}
+	levels, evidence := s.learnerLevels(skills)
+	m, ok := s.score(Posting{RequiredDimensions: target.RequiredDimensions}, levels, evidence, params.Locale)
+	if !ok {
+		return nil, ErrNoRequirements
+	}
+	// Report the effective requirements: canonical keys, defaults applied.
+	target.RequiredDimensions = map[string]float64{}
+	for _, dm := range append(append([]DimensionMatch{}, m.Fits...), m.Gaps...) {
+		target.RequiredDimensions[dm.Dimension] = dm.Required
+	}
+
+	res := &GapAnalysis{
+		Target:            *target,
+		Score:             m.Score,
+		Fits:              m.Fits,
+		Gaps:              m.Gaps,
+		Explanation:       m.Explanation,
+		Lernreisen:        []Recommendation{},
+		Courses:           []Recommendation{},
+		ProfileComputedAt: skills.ProfileComputedAt,
+	}
+	if len(m.Gaps) == 0 {
+		return res, nil
+	}
+
+	lernreisen, err := s.repo.ListLernreisen(ctx, params.Brand)
+	if err != nil {
+		return nil, fmt.Errorf("list lernreisen: %w", err)
+	}
+	res.Lernreisen = s.recommendLernreisen(m.Gaps, lernreisen)
+	res.Courses = s.recommendCourses(ctx, params, m.Gaps)
+	return res, nil
+}
+
+// gapTarget loads the posting or averages the requirements of the active
+// postings whose title contains the occupation.
+func (s *Service) gapTarget(ctx context.Context, params GapParams) (*GapTarget, error) {
+	if params.PostingID != nil {
+		p, err := s.repo.GetByID(ctx, *params.PostingID)
+		if err != nil {
+			return nil, fmt.Errorf("get posting: %w", err)
+		}
+		if p == nil {
+			return nil, ErrNotFound
+		}
+		return &GapTarget{Kind: TargetPosting, Title: p.Title, PostingID: &p.ID, Postings: 1, RequiredDimensions: p.RequiredDimensions}, nil
+	}
+
+	postings, err := s.repo.ListActive(ctx, PostingFilter{Title: params.Occupation}, maxOccupationPostings)
+	if err != nil {
+		return nil, fmt.Errorf("list postings: %w", err)
+	}
+	if len(postings) == 0 {
+		return nil, ErrNoPostings
+	}
+	sums, counts := map[string]float64{}, map[string]int{}
+	target := &GapTarget{Kind: TargetOccupation, Title: params.Occupation, RequiredDimensions: map[string]float64{}}
+	for _, p := range postings {
+		if len(p.RequiredDimensions) == 0 {
+			continue
+		}
+		target.Postings++
+		for key, v := range p.RequiredDimensions {
+			level := skillscore.Clamp(v)
+			if level == 0 {
+				level = defaultRequired
+			}
+			dim := s.canonical(key)
+			sums[dim] += level
+			counts[dim]++
+		}
+	}
+	for dim, sum := range sums {
+		target.RequiredDimensions[dim] = round2(sum / float64(counts[dim]))
+	}
+	return target, nil
+}
+
+// recommendLernreisen ranks the Lernreisen training at least one gap by the
+// gap points they cover, then by catalogue order.
+func (s *Service) recommendLernreisen(gaps []DimensionMatch, lernreisen []Lernreise) []Recommendation {
+	type ranked struct {
+		rec   Recommendation
+		order int
+	}
+	var candidates []ranked
+	for _, lr := range lernreisen {
+		rec := Recommendation{ID: lr.ID, Title: lr.Title, Subtitle: lr.Subtitle, Icon: lr.Icon}
+		trains := map[string]bool{}
+		for _, d := range lr.Dimensions {
+			trains[s.canonical(d)] = true
+		}
+		for _, g := range gaps {
+			if trains[g.Dimension] {
+				rec.Covers = append(rec.Covers, g.Dimension)
+				rec.Weight += g.Gap
+			}
+		}
+		if len(rec.Covers) > 0 {
+			rec.Weight = round2(rec.Weight)
+			candidates = append(candidates, ranked{rec, lr.SortOrder})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].rec.Weight != candidates[j].rec.Weight {
+			return candidates[i].rec.Weight > candidates[j].rec.Weight
+		}
+		return candidates[i].order < candidates[j].order
+	})
+	recs := []Recommendation{}
+	for i := 0; i < len(candidates) && i < maxRecommendations; i++ {
+		recs = append(recs, candidates[i].rec)
+	}
+	return recs
+}
+
+// recommendCourses ranks the learner's Honeycomb courses whose name or
+// description mentions a gap dimension (by label in German or English).
+// Honeycomb being unavailable leaves the list empty.
+func (s *Service) recommendCourses(ctx context.Context, params GapParams, gaps []DimensionMatch) []Recommendation {
+	recs := []Recommendation{}
+	if s.courses == nil {
+		return recs
+	}
+	ctxID, err := s.repo.HoneycombCtxID(ctx, params.UserID)
+	if err != nil || ctxID == "" {
+		return recs
+	}
+	courses, err := s.courses.ListCourses(ctx, ctxID)
+	if err != nil {
+		log.Printf("gap analysis: list honeycomb courses: %v", err)
+		return recs
+	}
+
+	terms := make([][]string, len(gaps))
+	for i, g := range gaps {
+		terms[i] = s.searchTerms(g.Dimension)
+	}
+	for _, c := range courses {
+		text := strings.ToLower(c.Name + " " + c.Description)
+		rec := Recommendation{ID: c.ID, Title: c.Name, Description: c.Description}
+		for i, g := range gaps {
+			for _, t := range terms[i] {
+				if strings.Contains(text, t) {
+					rec.Covers = append(rec.Covers, g.Dimension)
+					rec.Weight += g.Gap
+					break
+				}
+			}
+		}
+		if len(rec.Covers) > 0 {
+			rec.Weight = round2(rec.Weight)
+			recs = append(recs, rec)
+		}
+	}
+	sort.SliceStable(recs, func(i, j int) bool { return recs[i].Weight > recs[j].Weight })
+	if len(recs) > maxRecommendations {
+		recs = recs[:maxRecommendations]
+	}
+	return recs
+}
+
+// searchTerms returns the lowercase words a course text may use for dim.
+func (s *Service) searchTerms(dim string) []string {
+	seen := map[string]bool{}
+	var terms []string
+	for _, t := range []string{s.label(dim, "de"), s.label(dim, "en"), strings.ReplaceAll(dim, "-", " ")} {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "" && !seen[t] {
+			seen[t] = true
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,209 @@
+package job
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"skillr-mvp-v1/backend/internal/middleware"
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Matches ranks active postings against the learner's skill profile.
+func (h *Handler) Matches(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	params := MatchParams{
+		UserID: deriveUUID(userInfo.UID),
+		Filter: PostingFilter{
+			Location: c.QueryParam("location"),
+			Source:   c.QueryParam("source"),
+		},
+		Locale: c.QueryParam("locale"),
+		Limit:  intQuery(c, "limit", 20),
+		Offset: intQuery(c, "offset", 0),
+	}
+	if v := c.QueryParam("min_score"); v != "" {
+		score, err := strconv.ParseFloat(v, 64)
+		if err != nil || score < 0 || score > 100 {
+			return echo.NewHTTPError(http.StatusBadRequest, "min_score must be between 0 and 100")
+		}
+		params.MinScore = score
+	}
+	res, err := h.svc.Matches(c.Request().Context(), params)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to match job postings")
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// Gaps compares the learner's skill profile with a posting (posting_id) or
+// an occupation and recommends Lernreisen and courses for the gaps.
+func (h *Handler) Gaps(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	params := GapParams{
+		UserID:     deriveUUID(userInfo.UID),
+		Occupation: c.QueryParam("occupation"),
+		Brand:      c.QueryParam("brand"),
+		Locale:     c.QueryParam("locale"),
+	}
+	if v := c.QueryParam("posting_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid posting ID")
+		}
+		params.PostingID = &id
+	}
+	if (params.PostingID == nil) == (strings.TrimSpace(params.Occupation) == "") {
+		return echo.NewHTTPError(http.StatusBadRequest, "exactly one of posting_id or occupation is required")
+	}
+	res, err := h.svc.GapAnalysis(c.Request().Context(), params)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotFound), errors.Is(err, ErrNoPostings):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrNoRequirements):
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to analyse skill gaps")
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// AdminList lists postings for administration, optionally filtered by
+// source, active flag and a title/company search.
+func (h *Handler) AdminList(c echo.Context) error {
+	params := AdminListParams{
+		Source: c.QueryParam("source"),
+		Query:  c.QueryParam("q"),
+		Limit:  intQuery(c, "limit", 50),
+		Offset: intQuery(c, "offset", 0),
+	}
+	if params.Limit > 200 {
+		params.Limit = 200
+	}
+	if v := c.QueryParam("active"); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "active must be true or false")
+		}
+		params.Active = &active
+	}
+	postings, total, err := h.svc.AdminList(c.Request().Context(), params)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list job postings")
+	}
+	if postings == nil {
+		postings = []Posting{}
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"postings": postings, "total": total})
+}
+
+func (h *Handler) AdminGet(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid posting ID")
+	}
+	p, err := h.svc.AdminGet(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to load job posting")
+	}
+	return c.JSON(http.StatusOK, p)
+}
+
+func (h *Handler) AdminCreate(c echo.Context) error {
+	var req PostingRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	p, err := h.svc.AdminCreate(c.Request().Context(), req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusCreated, p)
+}
+
+func (h *Handler) AdminUpdate(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid posting ID")
+	}
+	var req PostingRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	p, err := h.svc.AdminUpdate(c.Request().Context(), id, req)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusOK, p)
+}
+
+func (h *Handler) AdminDelete(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid posting ID")
+	}
+	if err := h.svc.AdminDelete(c.Request().Context(), id); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete job posting")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AdminImport imports a partner feed (JSON, CSV or BA XML) from the feed
+// directory or a URL.
+func (h *Handler) AdminImport(c echo.Context) error {
+	var req FeedRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	stats, err := h.svc.ImportFeed(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, ErrFeedDirDisabled) {
+			return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+		}
+		if stats != nil {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"message": err.Error(), "stats": stats})
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusOK, stats)
+}
+
+func deriveUUID(firebaseUID string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceDNS, []byte(firebaseUID))
+}
+
+func intQuery(c echo.Context, key string, def int) int {
+	if v := c.QueryParam(key); v != "" {
+		if i, err := strconv.Atoi(v); err == nil && i >= 0 {
+			return i
+		}
+	}
+	return def
+}
@@ -0,0 +1,226 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Posting is one row of job_postings. RequiredDimensions maps skill
+// dimension keys to the expected level (0-100).
+type Posting struct {
+	ID                 uuid.UUID          `json:"id"`
+	Title              string             `json:"title"`
+	Company            *string            `json:"company,omitempty"`
+	Description        *string            `json:"description,omitempty"`
+	RequiredDimensions map[string]float64 `json:"required_dimensions"`
+	Location           *string            `json:"location,omitempty"`
+	Source             *string            `json:"source,omitempty"`
+	ExternalURL        *string            `json:"external_url,omitempty"`
+	IsActive           bool               `json:"is_active"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+}
+
+// SameContent reports whether o carries the same feed content as p.
+func (p Posting) SameContent(o Posting) bool {
+	if p.Title != o.Title || !sameString(p.Company, o.Company) || !sameString(p.Description, o.Description) ||
+		!sameString(p.Location, o.Location) || !sameString(p.Source, o.Source) || !sameString(p.ExternalURL, o.ExternalURL) ||
+		len(p.RequiredDimensions) != len(o.RequiredDimensions) {
+		return false
+	}
+	for k, v := range p.RequiredDimensions {
+		if w, ok := o.RequiredDimensions[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
+
+func sameString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// PostingRequest creates or replaces a posting via the admin API.
+type PostingRequest struct {
+	Title              string             `json:"title"`
+	Company            *string            `json:"company,omitempty"`
+	Description        *string            `json:"description,omitempty"`
+	RequiredDimensions map[string]float64 `json:"required_dimensions"`
+	Location           *string            `json:"location,omitempty"`
+	Source             *string            `json:"source,omitempty"`
+	ExternalURL        *string            `json:"external_url,omitempty"`
+	IsActive           *bool              `json:"is_active,omitempty"`
+}
+
+type AdminListParams struct {
+	Source string
+	Active *bool
+	// Query matches title or company case-insensitively.
+	Query  string
+	Limit  int
+	Offset int
+}
+
+// Feed formats.
+const (
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+	FormatBAXML = "ba-xml"
+)
+
+// FeedRequest imports a partner feed from a file in the feed directory or
+// from a URL. Source names the feed; postings of that source missing from
+// the feed are deactivated unless KeepMissing is set.
+type FeedRequest struct {
+	Source      string `json:"source"`
+	Format      string `json:"format,omitempty"`
+	File        string `json:"file,omitempty"`
+	URL         string `json:"url,omitempty"`
+	KeepMissing bool   `json:"keep_missing,omitempty"`
+}
+
+// FeedStats summarises one feed import.
+type FeedStats struct {
+	Read        int      `json:"read"`
+	Created     int      `json:"created"`
+	Updated     int      `json:"updated"`
+	Unchanged   int      `json:"unchanged"`
+	Deactivated int      `json:"deactivated"`
+	Skipped     int      `json:"skipped"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+// Skip counts an item that was not imported and reports why, up to
+// maxFeedErrors messages.
+func (st *FeedStats) Skip(format string, args ...any) {
+	st.Skipped++
+	if len(st.Errors) < maxFeedErrors {
+		st.Errors = append(st.Errors, fmt.Sprintf(format, args...))
+	}
+}
+
+// PostingFilter narrows the postings considered for matching.
+type PostingFilter struct {
+	// Location and Title match case-insensitively as a substring.
+	Location string
+	Source   string
+	Title    string
+}
+
+// Skills is what the matcher knows about a learner: the dimension scores of
+// the latest computed profile and the evidence entries per dimension.
+type Skills struct {
+	ProfileScores     map[string]float64
+	ProfileComputedAt *time.Time
+	Evidence          map[string]EvidenceStat
+}
+
+// EvidenceStat aggregates the evidence entries naming one dimension.
+type EvidenceStat struct {
+	Count int
+	// MaxScore is the highest score (0-100) an entry gave the dimension.
+	MaxScore float64
+}
+
+// DimensionMatch compares one required dimension with the learner's level.
+type DimensionMatch struct {
+	Dimension     string  `json:"dimension"`
+	Label         string  `json:"label"`
+	Required      float64 `json:"required"`
+	Current       float64 `json:"current"`
+	Gap           float64 `json:"gap"`
+	EvidenceCount int     `json:"evidence_count"`
+}
+
+// Match is a posting scored against a learner. Score is 0-100: the share of
+// the required levels the learner reaches, weighted by required level.
+type Match struct {
+	Posting     Posting          `json:"posting"`
+	Score       float64          `json:"score"`
+	Fits        []DimensionMatch `json:"fits"`
+	Gaps        []DimensionMatch `json:"gaps"`
+	Explanation string           `json:"explanation"`
+}
+
+type MatchParams struct {
+	UserID   uuid.UUID
+	Filter   PostingFilter
+	MinScore float64
+	Locale   string
+	Limit    int
+	Offset   int
+}
+
+// MatchResult is one page of ranked matches.
+type MatchResult struct {
+	Matches           []Match    `json:"matches"`
+	Total             int        `json:"total"`
+	ProfileComputedAt *time.Time `json:"profile_computed_at,omitempty"`
+}
+
+// GapParams selects the target of a gap analysis: a posting or an
+// occupation, matched against the titles of active postings.
+type GapParams struct {
+	UserID     uuid.UUID
+	PostingID  *uuid.UUID
+	Occupation string
+	// Brand selects the brand's content pack catalogue for Lernreisen.
+	Brand  string
+	Locale string
+}
+
+// GapTarget describes what the learner was compared against. For an
+// occupation, RequiredDimensions averages the postings it matched.
+type GapTarget struct {
+	Kind               string             `json:"kind"`
+	Title              string             `json:"title"`
+	PostingID          *uuid.UUID         `json:"posting_id,omitempty"`
+	Postings           int                `json:"postings"`
+	RequiredDimensions map[string]float64 `json:"required_dimensions"`
+}
+
+// Gap target kinds.
+const (
+	TargetPosting    = "posting"
+	TargetOccupation = "occupation"
+)
+
+// Lernreise is a content pack Lernreise with the dimensions it trains.
+type Lernreise struct {
+	ID         string
+	Title      string
+	Subtitle   string
+	Icon       string
+	Dimensions []string
+	SortOrder  int
+}
+
+// Recommendation is a Lernreise or Honeycomb course that trains some of the
+// learner's gaps. Covers lists those dimensions, largest gap first.
+type Recommendation struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Subtitle    string   `json:"subtitle,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Icon        string   `json:"icon,omitempty"`
+	Covers      []string `json:"covers"`
+	// Weight is the summed gap (in points) of the covered dimensions.
+	Weight float64 `json:"weight"`
+}
+
+// GapAnalysis compares the learner's skill profile with a target.
+type GapAnalysis struct {
+	Target            GapTarget        `json:"target"`
+	Score             float64          `json:"score"`
+	Fits              []DimensionMatch `json:"fits"`
+	Gaps              []DimensionMatch `json:"gaps"`
+	Explanation       string           `json:"explanation"`
+	Lernreisen        []Recommendation `json:"lernreisen"`
+	Courses           []Recommendation `json:"courses"`
+	ProfileComputedAt *time.Time       `json:"profile_computed_at,omitempty"`
+}
@@ -0,0 +1,35 @@
+package job
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// ListActive returns active postings matching the filter, newest first.
+	ListActive(ctx context.Context, filter PostingFilter, limit int) ([]Posting, error)
+	// LoadSkills returns the learner's latest profile scores and evidence
+	// dimensions. Retracted and revoked evidence is left out.
+	LoadSkills(ctx context.Context, userID uuid.UUID) (*Skills, error)
+	// ListLernreisen returns the content pack Lernreisen available to brand
+	// (the default packs when brand is empty).
+	ListLernreisen(ctx context.Context, brand string) ([]Lernreise, error)
+	// HoneycombCtxID returns the learner's Honeycomb context, or "" if the
+	// learner has not used Honeycomb yet.
+	HoneycombCtxID(ctx context.Context, userID uuid.UUID) (string, error)
+
+	List(ctx context.Context, params AdminListParams) ([]Posting, int, error)
+	// GetByID returns a posting, or nil if it does not exist.
+	GetByID(ctx context.Context, id uuid.UUID) (*Posting, error)
+	Create(ctx context.Context, p *Posting) error
+	Update(ctx context.Context, p *Posting) error
+	// Delete removes a posting and reports whether it existed.
+	Delete(ctx context.Context, id uuid.UUID) (bool, error)
+	// ApplyFeed upserts postings by external_url in one transaction and,
+	// unless keepMissing is set, deactivates active postings of source
+	// whose external_url is not in the feed. Postings whose external_url
+	// belongs to another source or to an admin-created posting are left
+	// alone and reported as skipped. It fills the write counters of stats.
+	ApplyFeed(ctx context.Context, source string, postings []Posting, keepMissing bool, stats *FeedStats) error
+}
@@ -0,0 +1,481 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/skillscore"
+)
+
+// ErrNotFound is returned when a posting does not exist.
+var ErrNotFound = errors.New("job posting not found")
+
+// ErrFeedDirDisabled is returned when importing a file without a configured
+// feed directory.
+var ErrFeedDirDisabled = errors.New("job feed directory not configured")
+
+// maxFeedErrors bounds the item errors reported per import.
+const maxFeedErrors = 20
+
+// maxCandidates bounds the postings scored per request.
+const maxCandidates = 1000
+
+// defaultRequired is the expected level of a required dimension stored
+// without one.
+const defaultRequired = 50.0
+
+// Taxonomy resolves dimension keys and labels against the skill registry
+// (satisfied by *taxonomy.Service).
+type Taxonomy interface {
+	Resolve(key string) (string, bool)
+	Normalize(dims map[string]float64) (map[string]float64, error)
+	Label(key, locale string) string
+}
+
+type Service struct {
+	repo     Repository
+	taxonomy Taxonomy
+	fetcher  Fetcher
+	feedDir  string
+	courses  CourseCatalog
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo, fetcher: NewHTTPFetcher()}
+}
+
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
+}
+
+// SetTaxonomy maps posting and learner keys onto canonical dimensions and
+// provides labels for explanations.
+func (s *Service) SetTaxonomy(t Taxonomy) {
+	s.taxonomy = t
+}
+
+// SetFetcher replaces the fetcher used for feed URLs.
+func (s *Service) SetFetcher(f Fetcher) {
+	s.fetcher = f
+}
+
+// SetFeedDir sets the directory feed files are imported from.
+func (s *Service) SetFeedDir(dir string) {
+	s.feedDir = dir
+}
+
+// Matches scores the active postings against the learner and returns them
+// ranked by score, then by fewer gaps, then newest first.
+func (s *Service) Matches(ctx context.Context, params MatchParams) (*MatchResult, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	skills, err := s.repo.LoadSkills(ctx, params.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("load skills: %w", err)
+	}
+	postings, err := s.repo.ListActive(ctx, params.Filter, maxCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("list postings: %w", err)
+	}
+
+	levels, evidence := s.learnerLevels(skills)
+	matches := []Match{}
+	for _, p := range postings {
+		m, ok := s.score(p, levels, evidence, params.Locale)
+		if !ok || m.Score < params.MinScore {
+			continue
+		}
+		matches = append(matches, m)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		if len(matches[i].Gaps) != len(matches[j].Gaps) {
+			return len(matches[i].Gaps) < len(matches[j].Gaps)
+		}
+		return matches[i].Posting.CreatedAt.After(matches[j].Posting.CreatedAt)
+	})
+
+	res := &MatchResult{Matches: []Match{}, Total: len(matches), ProfileComputedAt: skills.ProfileComputedAt}
+	if params.Offset < len(matches) {
+		end := len(matches)
+		if params.Limit > 0 && params.Offset+params.Limit < end {
+			end = params.Offset + params.Limit
+		}
+		res.Matches = matches[params.Offset:end]
+	}
+	return res, nil
+}
+
+func (s *Service) AdminList(ctx context.Context, params AdminListParams) ([]Posting, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
+	return s.repo.List(ctx, params)
+}
+
+func (s *Service) AdminGet(ctx context.Context, id uuid.UUID) (*Posting, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	p, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, ErrNotFound
+	}
+	return p, nil
+}
+
+// AdminCreate adds a posting. New postings are active unless is_active is
+// false.
+func (s *Service) AdminCreate(ctx context.Context, req PostingRequest) (*Posting, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	now := time.Now().UTC()
+	p := &Posting{ID: uuid.New(), IsActive: true, CreatedAt: now, UpdatedAt: now}
+	applyRequest(p, req)
+	if err := s.validate(p); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, p); err != nil {
+		return nil, fmt.Errorf("create job posting: %w", err)
+	}
+	return p, nil
+}
+
+// AdminUpdate replaces a posting's fields. is_active is kept when omitted.
+func (s *Service) AdminUpdate(ctx context.Context, id uuid.UUID, req PostingRequest) (*Posting, error) {
+	p, err := s.AdminGet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	applyRequest(p, req)
+	if err := s.validate(p); err != nil {
+		return nil, err
+	}
+	p.UpdatedAt = time.Now().UTC()
+	if err := s.repo.Update(ctx, p); err != nil {
+		return nil, fmt.Errorf("update job posting: %w", err)
+	}
+	return p, nil
+}
+
+func (s *Service) AdminDelete(ctx context.Context, id uuid.UUID) error {
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	ok, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func applyRequest(p *Posting, req PostingRequest) {
+	p.Title = req.Title
+	p.Company = req.Company
+	p.Description = req.Description
+	p.RequiredDimensions = req.RequiredDimensions
+	p.Location = req.Location
+	p.Source = req.Source
+	p.ExternalURL = req.ExternalURL
+	if req.IsActive != nil {
+		p.IsActive = *req.IsActive
+	}
+}
+
+// validate trims a posting and checks its fields. Required dimensions must
+// be levels between 0 and 100 on keys the taxonomy knows; aliases are
+// stored under their canonical key.
+func (s *Service) validate(p *Posting) error {
+	p.Title = strings.TrimSpace(p.Title)
+	if p.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	for _, f := range []**string{&p.Company, &p.Description, &p.Location, &p.Source, &p.ExternalURL} {
+		if *f != nil {
+			if v := strings.TrimSpace(**f); v != "" {
+				*f = &v
+			} else {
+				*f = nil
+			}
+		}
+	}
+	if p.ExternalURL != nil {
+		u, err := url.Parse(*p.ExternalURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("external_url must be an http(s) URL")
+		}
+	}
+	for key, level := range p.RequiredDimensions {
+		if math.IsNaN(level) || level < 0 || level > 100 {
+			return fmt.Errorf("required level of %s must be between 0 and 100", key)
+		}
+	}
+	dims := map[string]float64{}
+	if s.taxonomy != nil && p.RequiredDimensions != nil {
+		var err error
+		if dims, err = s.taxonomy.Normalize(p.RequiredDimensions); err != nil {
+			return err
+		}
+	} else {
+		for key, level := range p.RequiredDimensions {
+			dims[strings.ToLower(strings.TrimSpace(key))] = level
+		}
+	}
+	p.RequiredDimensions = dims
+	return nil
+}
+
+// ImportFeed reads a partner feed from the feed directory or a URL and
+// upserts its postings by external_url. Invalid items and postings whose
+// external_url belongs to another source are skipped and reported; a feed without any valid posting is rejected so that a broken
+// feed cannot deactivate all postings of its source.
+func (s *Service) ImportFeed(ctx context.Context, req FeedRequest) (*FeedStats, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	req.Source = strings.TrimSpace(req.Source)
+	if req.Source == "" {
+		return nil, fmt.Errorf("source is required")
+	}
+	if (req.File == "") == (req.URL == "") {
+		return nil, fmt.Errorf("exactly one of file or url is required")
+	}
+
+	body, name, contentType, err := s.openFeed(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	format, err := detectFormat(req.Format, name, contentType)
+	if err != nil {
+		return nil, err
+	}
+	items, err := parseFeed(body, format)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &FeedStats{Read: len(items)}
+	seen := map[string]bool{}
+	var postings []Posting
+	for i, item := range items {
+		p, err := s.feedPosting(item, req.Source)
+		if err == nil && seen[*p.ExternalURL] {
+			err = fmt.Errorf("duplicate external_url %s", *p.ExternalURL)
+		}
+		if err != nil {
+			stats.Skip("posting %d: %v", i+1, err)
+			continue
+		}
+		seen[*p.ExternalURL] = true
+		postings = append(postings, *p)
+	}
+	if len(postings) == 0 {
+		return stats, fmt.Errorf("feed contains no valid postings")
+	}
+	if err := s.repo.ApplyFeed(ctx, req.Source, postings, req.KeepMissing, stats); err != nil {
+		return nil, fmt.Errorf("apply feed: %w", err)
+	}
+	return stats, nil
+}
+
+// openFeed opens the feed file (inside the feed directory) or fetches the URL.
+func (s *Service) openFeed(ctx context.Context, req FeedRequest) (io.ReadCloser, string, string, error) {
+	if req.URL != "" {
+		body, contentType, err := s.fetcher.Fetch(ctx, req.URL)
+		if err != nil {
+			return nil, "", "", err
+		}
+		u, _ := url.Parse(req.URL)
+		return body, u.Path, contentType, nil
+	}
+	if s.feedDir == "" {
+		return nil, "", "", ErrFeedDirDisabled
+	}
+	// Cleaning against "/" keeps the path inside the feed directory.
+	path := filepath.Join(s.feedDir, filepath.Clean("/"+req.File))
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("open %s: %w", req.File, errors.Unwrap(err))
+	}
+	return f, path, "", nil
+}
+
+// feedPosting validates one feed item. Feed postings need an external URL,
+// which identifies them across imports.
+func (s *Service) feedPosting(item feedItem, source string) (*Posting, error) {
+	p := &Posting{
+		Title:              item.Title,
+		Company:            &item.Company,
+		Description:        &item.Description,
+		RequiredDimensions: item.RequiredDimensions,
+		Location:           &item.Location,
+		Source:             &source,
+		ExternalURL:        &item.ExternalURL,
+		IsActive:           true,
+	}
+	if item.ExternalURL == "" {
+		p.ExternalURL = &item.URL
+	}
+	if err := s.validate(p); err != nil {
+		return nil, err
+	}
+	if p.ExternalURL == nil {
+		return nil, fmt.Errorf("external_url is required")
+	}
+	return p, nil
+}
+
+// learnerLevels returns the learner's level (0-100) and evidence count per
+// canonical dimension. Profile scores take precedence; dimensions only seen
+// in evidence use the highest evidence score.
+func (s *Service) learnerLevels(skills *Skills) (map[string]float64, map[string]int) {
+	levels, evidence := map[string]float64{}, map[string]int{}
+	for key, st := range skills.Evidence {
+		dim := s.canonical(key)
+		evidence[dim] += st.Count
+		levels[dim] = math.Max(levels[dim], skillscore.Clamp(st.MaxScore))
+	}
+	for key, score := range skills.ProfileScores {
+		levels[s.canonical(key)] = skillscore.Clamp(score)
+	}
+	return levels, evidence
+}
+
+// score compares a posting's requirements with the learner's levels.
+// Postings without requirements cannot be scored.
+func (s *Service) score(p Posting, levels map[string]float64, evidence map[string]int, locale string) (Match, bool) {
+	required := map[string]float64{}
+	for key, v := range p.RequiredDimensions {
+		level := skillscore.Clamp(v)
+		if level == 0 {
+			level = defaultRequired
+		}
+		dim := s.canonical(key)
+		required[dim] = math.Max(required[dim], level)
+	}
+	if len(required) == 0 {
+		return Match{}, false
+	}
+
+	m := Match{Posting: p, Fits: []DimensionMatch{}, Gaps: []DimensionMatch{}}
+	var reached, total float64
+	for dim, req := range required {
+		cur := levels[dim]
+		dm := DimensionMatch{
+			Dimension:     dim,
+			Label:         s.label(dim, locale),
+			Required:      round2(req),
+			Current:       round2(cur),
+			EvidenceCount: evidence[dim],
+		}
+		reached += math.Min(cur, req)
+		total += req
+		if cur >= req {
+			m.Fits = append(m.Fits, dm)
+		} else {
+			dm.Gap = round2(req - cur)
+			m.Gaps = append(m.Gaps, dm)
+		}
+	}
+	sort.Slice(m.Fits, func(i, j int) bool {
+		if m.Fits[i].Required != m.Fits[j].Required {
+			return m.Fits[i].Required > m.Fits[j].Required
+		}
+		return m.Fits[i].Dimension < m.Fits[j].Dimension
+	})
+	sortGaps(m.Gaps)
+	m.Score = round2(reached / total * 100)
+	m.Explanation = explain(m, len(required))
+	return m, true
+}
+
+// sortGaps orders gaps by size, largest first.
+func sortGaps(gaps []DimensionMatch) {
+	sort.Slice(gaps, func(i, j int) bool {
+		if gaps[i].Gap != gaps[j].Gap {
+			return gaps[i].Gap > gaps[j].Gap
+		}
+		return gaps[i].Dimension < gaps[j].Dimension
+	})
+}
+
+// maxExplainedGaps is the number of gaps named in an explanation.
+const maxExplainedGaps = 2
+
+// explain summarises a match in one or two German sentences.
+func explain(m Match, required int) string {
+	var b strings.Builder
+	if len(m.Gaps) == 0 {
+		fmt.Fprintf(&b, "Erfüllt alle %d Anforderungen.", required)
+	} else {
+		fmt.Fprintf(&b, "Erfüllt %d von %d Anforderungen. ", len(m.Fits), required)
+		var names []string
+		for i, g := range m.Gaps {
+			if i == maxExplainedGaps {
+				break
+			}
+			names = append(names, fmt.Sprintf("%s (%.0f Punkte)", g.Label, g.Gap))
+		}
+		if len(names) == 1 {
+			fmt.Fprintf(&b, "Größte Lücke: %s.", names[0])
+		} else {
+			fmt.Fprintf(&b, "Größte Lücken: %s.", strings.Join(names, ", "))
+		}
+	}
+	var unproven []string
+	for _, f := range m.Fits {
+		if f.EvidenceCount == 0 {
+			unproven = append(unproven, f.Label)
+		}
+	}
+	if len(unproven) > 0 {
+		fmt.Fprintf(&b, " Noch ohne Nachweis: %s.", strings.Join(unproven, ", "))
+	}
+	return b.String()
+}
+
+func (s *Service) canonical(key string) string {
+	if s.taxonomy != nil {
+		if k, ok := s.taxonomy.Resolve(key); ok {
+			return k
+		}
+	}
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+func (s *Service) label(dim, locale string) string {
+	if s.taxonomy == nil {
+		return dim
+	}
+	if locale == "" {
+		locale = "de"
+	}
+	return s.taxonomy.Label(dim, locale)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,501 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/domain/taxonomy"
+	"skillr-mvp-v1/backend/internal/honeycomb"
+)
+
+type mockRepo struct {
+	postings   []Posting
+	skills     *Skills
+	lernreisen []Lernreise
+	ctxID      string
+}
+
+func (m *mockRepo) ListActive(_ context.Context, filter PostingFilter, limit int) ([]Posting, error) {
+	var out []Posting
+	for _, p := range m.postings {
+		if !p.IsActive {
+			continue
+		}
+		if filter.Location != "" && (p.Location == nil || !strings.Contains(strings.ToLower(*p.Location), strings.ToLower(filter.Location))) {
+			continue
+		}
+		if filter.Source != "" && (p.Source == nil || *p.Source != filter.Source) {
+			continue
+		}
+		if filter.Title != "" && !strings.Contains(strings.ToLower(p.Title), strings.ToLower(filter.Title)) {
+			continue
+		}
+		out = append(out, p)
+	}
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *mockRepo) LoadSkills(_ context.Context, _ uuid.UUID) (*Skills, error) {
+	return m.skills, nil
+}
+
+func (m *mockRepo) ListLernreisen(_ context.Context, _ string) ([]Lernreise, error) {
+	return m.lernreisen, nil
+}
+
+func (m *mockRepo) HoneycombCtxID(_ context.Context, _ uuid.UUID) (string, error) {
+	return m.ctxID, nil
+}
+
+func (m *mockRepo) List(_ context.Context, _ AdminListParams) ([]Posting, int, error) {
+	return m.postings, len(m.postings), nil
+}
+
+func (m *mockRepo) GetByID(_ context.Context, id uuid.UUID) (*Posting, error) {
+	for i := range m.postings {
+		if m.postings[i].ID == id {
+			p := m.postings[i]
+			return &p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepo) Create(_ context.Context, p *Posting) error {
+	m.postings = append(m.postings, *p)
+	return nil
+}
+
+func (m *mockRepo) Update(_ context.Context, p *Posting) error {
+	for i := range m.postings {
+		if m.postings[i].ID == p.ID {
+			m.postings[i] = *p
+		}
+	}
+	return nil
+}
+
+func (m *mockRepo) Delete(_ context.Context, id uuid.UUID) (bool, error) {
+	for i := range m.postings {
+		if m.postings[i].ID == id {
+			m.postings = append(m.postings[:i], m.postings[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ApplyFeed mirrors the postgres upsert by external_url within source.
+func (m *mockRepo) ApplyFeed(_ context.Context, source string, postings []Posting, keepMissing bool, stats *FeedStats) error {
+	inFeed := map[string]bool{}
+	for _, p := range postings {
+		inFeed[*p.ExternalURL] = true
+		found := false
+		for i := range m.postings {
+			e := &m.postings[i]
+			if e.ExternalURL == nil || *e.ExternalURL != *p.ExternalURL {
+				continue
+			}
+			found = true
+			if e.Source == nil || *e.Source != source {
+				stats.Skip("posting %s belongs to another source", *p.ExternalURL)
+			} else if e.IsActive && e.SameContent(p) {
+				stats.Unchanged++
+			} else {
+				p.ID = e.ID
+				*e = p
+				stats.Updated++
+			}
+			break
+		}
+		if !found {
+			p.ID = uuid.New()
+			m.postings = append(m.postings, p)
+			stats.Created++
+		}
+	}
+	if !keepMissing {
+		for i := range m.postings {
+			e := &m.postings[i]
+			if e.IsActive && e.Source != nil && *e.Source == source && e.ExternalURL != nil && !inFeed[*e.ExternalURL] {
+				e.IsActive = false
+				stats.Deactivated++
+			}
+		}
+	}
+	return nil
+}
+
+type mockFetcher struct {
+	body        string
+	contentType string
+}
+
+func (f mockFetcher) Fetch(_ context.Context, _ string) (io.ReadCloser, string, error) {
+	return io.NopCloser(strings.NewReader(f.body)), f.contentType, nil
+}
+
+type mockCourses []honeycomb.ListEntry
+
+func (m mockCourses) ListCourses(_ context.Context, _ string) ([]honeycomb.ListEntry, error) {
+	return m, nil
+}
+
+func posting(title, location, source string, dims map[string]float64, created time.Time) Posting {
+	return Posting{
+		ID:                 uuid.New(),
+		Title:              title,
+		RequiredDimensions: dims,
+		Location:           &location,
+		Source:             &source,
+		IsActive:           true,
+		CreatedAt:          created,
+	}
+}
+
+func newTestService() (*Service, *mockRepo) {
+	now := time.Now()
+	repo := &mockRepo{
+		postings: []Posting{
+			posting("Mediengestalter:in", "Berlin", "ba", map[string]float64{"creativity": 70, "digital_literacy": 60}, now),
+			posting("Erzieher:in", "Hamburg", "partner", map[string]float64{"empathy": 80, "teamwork": 60, "Self_Awareness": 50}, now),
+			posting("Fachinformatiker:in", "Berlin-Mitte", "partner", map[string]float64{"analytical-thinking": 80, "problem_solving": 70}, now.Add(-time.Hour)),
+			posting("Ohne Profil", "Berlin", "ba", map[string]float64{}, now),
+		},
+		skills: &Skills{
+			ProfileScores: map[string]float64{"creativity": 85, "digital-literacy": 65, "empathy": 60, "teamwork": 70, "self-awareness": 50},
+			Evidence: map[string]EvidenceStat{
+				"creativity":      {Count: 2, MaxScore: 90},
+				"teamwork":        {Count: 1, MaxScore: 70},
+				"problem_solving": {Count: 1, MaxScore: 35},
+			},
+		},
+	}
+	svc := NewService(repo)
+	svc.SetTaxonomy(taxonomy.NewService(nil))
+	return svc, repo
+}
+
+func TestService_MatchesRanksAndExplains(t *testing.T) {
+	svc, _ := newTestService()
+
+	res, err := svc.Matches(context.Background(), MatchParams{UserID: uuid.New(), Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 3 {
+		t.Fatalf("expected postings without requirements to be skipped, got %d matches", res.Total)
+	}
+	titles := []string{res.Matches[0].Posting.Title, res.Matches[1].Posting.Title, res.Matches[2].Posting.Title}
+	if titles[0] != "Mediengestalter:in" || titles[1] != "Erzieher:in" || titles[2] != "Fachinformatiker:in" {
+		t.Fatalf("unexpected ranking %v", titles)
+	}
+
+	top := res.Matches[0]
+	if top.Score != 100 || len(top.Gaps) != 0 || len(top.Fits) != 2 {
+		t.Errorf("expected a full fit, got %+v", top)
+	}
+	if top.Explanation != "Erfüllt alle 2 Anforderungen. Noch ohne Nachweis: Digitale Kompetenz." {
+		t.Errorf("unexpected explanation %q", top.Explanation)
+	}
+
+	care := res.Matches[1]
+	if len(care.Gaps) != 1 || care.Gaps[0].Dimension != "empathy" || care.Gaps[0].Gap != 20 || care.Gaps[0].Label != "Empathie" {
+		t.Errorf("expected one empathy gap, got %+v", care.Gaps)
+	}
+	// (60 + 60 + 50) of (80 + 60 + 50) required points.
+	if care.Score != 89.47 {
+		t.Errorf("expected score 89.47, got %v", care.Score)
+	}
+
+	// Evidence-only dimensions count with their best evidence score.
+	it := res.Matches[2]
+	if it.Score != 23.33 || len(it.Gaps) != 2 || it.Gaps[0].Dimension != "analytical-thinking" || it.Gaps[1].Current != 35 {
+		t.Errorf("unexpected gaps %+v (score %v)", it.Gaps, it.Score)
+	}
+	if !strings.HasPrefix(it.Explanation, "Erfüllt 0 von 2 Anforderungen. Größte Lücken: Analytisches Denken (80 Punkte), Problemlösung (35 Punkte).") {
+		t.Errorf("unexpected explanation %q", it.Explanation)
+	}
+}
+
+func TestService_MatchesFiltersAndPages(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	res, _ := svc.Matches(ctx, MatchParams{Filter: PostingFilter{Location: "berlin"}, Limit: 10})
+	if res.Total != 2 {
+		t.Errorf("expected 2 Berlin matches, got %d", res.Total)
+	}
+	res, _ = svc.Matches(ctx, MatchParams{Filter: PostingFilter{Source: "partner"}, MinScore: 50, Limit: 10})
+	if res.Total != 1 || res.Matches[0].Posting.Title != "Erzieher:in" {
+		t.Errorf("expected one partner match above 50, got %+v", res.Matches)
+	}
+	res, _ = svc.Matches(ctx, MatchParams{Limit: 1, Offset: 1})
+	if res.Total != 3 || len(res.Matches) != 1 || res.Matches[0].Posting.Title != "Erzieher:in" {
+		t.Errorf("expected second match on page 2, got %+v", res.Matches)
+	}
+	res, _ = svc.Matches(ctx, MatchParams{Limit: 10, Offset: 5})
+	if res.Matches == nil || len(res.Matches) != 0 {
+		t.Errorf("expected an empty page, got %v", res.Matches)
+	}
+}
+
+func TestService_AdminCreateValidates(t *testing.T) {
+	svc, repo := newTestService()
+	ctx := context.Background()
+
+	p, err := svc.AdminCreate(ctx, PostingRequest{
+		Title:              "  Kaufmann/-frau  ",
+		Company:            strPtr(" "),
+		RequiredDimensions: map[string]float64{"Analytical_Thinking": 60, "volatility": 40},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Title != "Kaufmann/-frau" || p.Company != nil || !p.IsActive {
+		t.Errorf("expected a trimmed active posting, got %+v", p)
+	}
+	if p.RequiredDimensions["analytical-thinking"] != 60 || p.RequiredDimensions["adaptability"] != 40 {
+		t.Errorf("expected canonical dimension keys, got %v", p.RequiredDimensions)
+	}
+	if got, _ := repo.GetByID(ctx, p.ID); got == nil {
+		t.Error("expected the posting to be stored")
+	}
+
+	for _, req := range []PostingRequest{
+		{Title: " "},
+		{Title: "x", ExternalURL: strPtr("javascript:alert(1)")},
+		{Title: "x", RequiredDimensions: map[string]float64{"teamwork": 120}},
+		{Title: "x", RequiredDimensions: map[string]float64{"juggling": 50}},
+	} {
+		if _, err := svc.AdminCreate(ctx, req); err == nil {
+			t.Errorf("expected %+v to be rejected", req)
+		}
+	}
+	if _, err := svc.AdminUpdate(ctx, uuid.New(), PostingRequest{Title: "x"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestService_ImportFeedFormats(t *testing.T) {
+	feeds := map[string]string{
+		FormatJSON: `{"postings": [
+			{"title": "Koch", "company": "Zur Post", "location": "Köln", "url": "https://jobs.example/1", "required_dimensions": {"teamwork": 60}},
+			{"title": "Servicekraft", "external_url": "https://jobs.example/2"}
+		]}`,
+		FormatCSV: "\ufefftitle;company;location;url;required_dimensions\n" +
+			"Koch;Zur Post;Köln;https://jobs.example/1;teamwork:60\n" +
+			"Servicekraft;;;https://jobs.example/2;\n",
+		FormatBAXML: `<?xml version="1.0" encoding="UTF-8"?>
+			<HRBAXMLJobPositionPosting><Data>
+			<JobPositionPosting>
+				<JobPositionPostingId>10000-1</JobPositionPostingId>
+				<HiringOrg><HiringOrgName>Zur Post</HiringOrgName></HiringOrg>
+				<JobPositionInformation>
+					<JobPositionTitle><Degree>Koch</Degree></JobPositionTitle>
+					<JobPositionDescription>
+						<JobPositionLocation><Location><PostalCode>50667</PostalCode><Municipality>Köln</Municipality></Location></JobPositionLocation>
+						<ApplicationURL>https://jobs.example/1</ApplicationURL>
+					</JobPositionDescription>
+				</JobPositionInformation>
+			</JobPositionPosting>
+			<JobPositionPosting>
+				<JobPositionPostingId>10000-2</JobPositionPostingId>
+				<JobPositionInformation><JobPositionTitleDescription>Servicekraft</JobPositionTitleDescription></JobPositionInformation>
+			</JobPositionPosting>
+			</Data></HRBAXMLJobPositionPosting>`,
+	}
+	for format, body := range feeds {
+		t.Run(format, func(t *testing.T) {
+			repo := &mockRepo{}
+			svc := NewService(repo)
+			svc.SetTaxonomy(taxonomy.NewService(nil))
+			svc.SetFetcher(mockFetcher{body: body})
+
+			stats, err := svc.ImportFeed(context.Background(), FeedRequest{Source: "partner", Format: format, URL: "https://feeds.example/jobs"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stats.Read != 2 || stats.Created != 2 || stats.Skipped != 0 {
+				t.Fatalf("unexpected stats %+v", stats)
+			}
+			koch := repo.postings[0]
+			if koch.Title != "Koch" || *koch.Company != "Zur Post" || !strings.Contains(*koch.Location, "Köln") || *koch.ExternalURL != "https://jobs.example/1" || *koch.Source != "partner" {
+				t.Errorf("unexpected posting %+v", koch)
+			}
+			if format != FormatBAXML && koch.RequiredDimensions["teamwork"] != 60 {
+				t.Errorf("expected required dimensions, got %v", koch.RequiredDimensions)
+			}
+			if format == FormatBAXML && *repo.postings[1].ExternalURL != baJobDetailURL+"10000-2" {
+				t.Errorf("expected the Jobsuche URL as fallback, got %s", *repo.postings[1].ExternalURL)
+			}
+		})
+	}
+}
+
+func TestService_ImportFeedUpsertsAndDeactivates(t *testing.T) {
+	source, other := "partner", "ba"
+	gone, kept := "https://jobs.example/gone", "https://jobs.example/other"
+	repo := &mockRepo{postings: []Posting{
+		{ID: uuid.New(), Title: "Alt", Source: &source, ExternalURL: &gone, IsActive: true},
+		{ID: uuid.New(), Title: "BA", Source: &other, ExternalURL: &kept, IsActive: true},
+	}}
+	svc := NewService(repo)
+	svc.SetTaxonomy(taxonomy.NewService(nil))
+	feed := `[
+		{"title": "Koch", "url": "https://jobs.example/1"},
+		{"title": "Koch (Duplikat)", "url": "https://jobs.example/1"},
+		{"title": "", "url": "https://jobs.example/3"},
+		{"title": "Ohne Link"},
+		{"title": "Jongleur", "url": "https://jobs.example/4", "required_dimensions": {"juggling": 50}},
+		{"title": "Übernahme", "url": "https://jobs.example/other"}
+	]`
+	svc.SetFetcher(mockFetcher{body: feed, contentType: "application/json"})
+	ctx := context.Background()
+
+	stats, err := svc.ImportFeed(ctx, FeedRequest{Source: source, URL: "https://feeds.example/jobs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Created != 1 || stats.Skipped != 5 || stats.Deactivated != 1 || len(stats.Errors) != 5 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if repo.postings[0].IsActive || !repo.postings[1].IsActive {
+		t.Error("expected only the missing posting of the same source to be deactivated")
+	}
+	if repo.postings[1].Title != "BA" || *repo.postings[1].Source != other {
+		t.Error("expected the posting of another source to be left alone")
+	}
+
+	// Re-importing the same feed changes nothing; a changed title updates.
+	stats, _ = svc.ImportFeed(ctx, FeedRequest{Source: source, URL: "https://feeds.example/jobs"})
+	if stats.Created != 0 || stats.Unchanged != 1 || stats.Deactivated != 0 {
+		t.Errorf("expected an unchanged re-import, got %+v", stats)
+	}
+	svc.SetFetcher(mockFetcher{body: `[{"title": "Koch/Köchin", "url": "https://jobs.example/1"}]`, contentType: "application/json"})
+	stats, _ = svc.ImportFeed(ctx, FeedRequest{Source: source, URL: "https://feeds.example/jobs"})
+	if stats.Updated != 1 || repo.postings[2].Title != "Koch/Köchin" {
+		t.Errorf("expected an update, got %+v", stats)
+	}
+
+	// A feed without valid postings must not deactivate anything.
+	svc.SetFetcher(mockFetcher{body: `[{"title": ""}]`, contentType: "application/json"})
+	if _, err := svc.ImportFeed(ctx, FeedRequest{Source: source, URL: "https://feeds.example/jobs"}); err == nil {
+		t.Error("expected an empty feed to be rejected")
+	}
+	if !repo.postings[2].IsActive {
+		t.Error("expected postings to stay active after a rejected feed")
+	}
+}
+
+func TestService_ImportFeedFileConfinedToDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "jobs.csv"), []byte("title,url\nKoch,https://jobs.example/1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewService(&mockRepo{})
+	ctx := context.Background()
+
+	if _, err := svc.ImportFeed(ctx, FeedRequest{Source: "partner", File: "jobs.csv"}); !errors.Is(err, ErrFeedDirDisabled) {
+		t.Errorf("expected ErrFeedDirDisabled, got %v", err)
+	}
+	svc.SetFeedDir(dir)
+	stats, err := svc.ImportFeed(ctx, FeedRequest{Source: "partner", File: "../" + filepath.Base(dir) + "/jobs.csv"})
+	if err == nil || stats != nil {
+		t.Errorf("expected paths outside the feed dir to fail, got %+v", stats)
+	}
+	stats, err = svc.ImportFeed(ctx, FeedRequest{Source: "partner", File: "jobs.csv"})
+	if err != nil || stats.Created != 1 {
+		t.Errorf("expected one created posting, got %+v (%v)", stats, err)
+	}
+}
+
+func TestService_GapAnalysisPosting(t *testing.T) {
+	svc, repo := newTestService()
+	repo.lernreisen = []Lernreise{
+		{ID: "lr-loeten", Title: "Loeten", Dimensions: []string{"creativity", "initiative"}, SortOrder: 1},
+		{ID: "lr-rehkitz", Title: "Rehkitz pflegen", Dimensions: []string{"empathy", "curiosity"}, SortOrder: 7},
+		{ID: "lr-pflege", Title: "Pflegepraktikum", Dimensions: []string{"Empathy", "self_awareness"}, SortOrder: 9},
+	}
+	repo.ctxID = "ctx-1"
+	svc.SetCourses(mockCourses{
+		{ID: "c1", Name: "Empathie im Alltag", Description: "Zuhören lernen"},
+		{ID: "c2", Name: "Excel", Description: "Tabellen"},
+	})
+	target := repo.postings[1].ID // Erzieher:in: empathy 80, teamwork 60, self-awareness 50
+
+	res, err := svc.GapAnalysis(context.Background(), GapParams{PostingID: &target})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Target.Kind != TargetPosting || res.Target.Title != "Erzieher:in" || res.Target.RequiredDimensions["self-awareness"] != 50 {
+		t.Errorf("unexpected target %+v", res.Target)
+	}
+	if len(res.Gaps) != 1 || res.Gaps[0].Dimension != "empathy" || res.Gaps[0].Gap != 20 || len(res.Fits) != 2 {
+		t.Errorf("expected one empathy gap, got %+v", res.Gaps)
+	}
+	if len(res.Lernreisen) != 2 || res.Lernreisen[0].ID != "lr-rehkitz" || res.Lernreisen[0].Covers[0] != "empathy" {
+		t.Errorf("expected empathy Lernreisen in catalogue order, got %+v", res.Lernreisen)
+	}
+	if len(res.Courses) != 1 || res.Courses[0].ID != "c1" || res.Courses[0].Weight != 20 {
+		t.Errorf("expected the empathy course, got %+v", res.Courses)
+	}
+
+	// Without a Honeycomb context there are no course recommendations.
+	repo.ctxID = ""
+	res, _ = svc.GapAnalysis(context.Background(), GapParams{PostingID: &target})
+	if res.Courses == nil || len(res.Courses) != 0 {
+		t.Errorf("expected no courses, got %v", res.Courses)
+	}
+
+	missing := uuid.New()
+	if _, err := svc.GapAnalysis(context.Background(), GapParams{PostingID: &missing}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestService_GapAnalysisOccupation(t *testing.T) {
+	svc, repo := newTestService()
+	now := time.Now()
+	repo.postings = append(repo.postings,
+		posting("Erzieher:in Kita", "Köln", "ba", map[string]float64{"empathy": 60, "communication": 0}, now),
+		posting("Erzieherin (Teilzeit)", "Köln", "ba", map[string]float64{}, now),
+	)
+	ctx := context.Background()
+
+	res, err := svc.GapAnalysis(ctx, GapParams{Occupation: "erzieher"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Postings without requirements are not averaged.
+	if res.Target.Kind != TargetOccupation || res.Target.Postings != 2 {
+		t.Errorf("unexpected target %+v", res.Target)
+	}
+	req := res.Target.RequiredDimensions
+	if req["empathy"] != 70 || req["teamwork"] != 60 || req["communication"] != 50 {
+		t.Errorf("expected averaged requirements, got %v", req)
+	}
+	if len(res.Gaps) != 2 || res.Gaps[0].Dimension != "communication" || res.Gaps[1].Dimension != "empathy" {
+		t.Errorf("expected gaps ordered by size, got %+v", res.Gaps)
+	}
+
+	if _, err := svc.GapAnalysis(ctx, GapParams{Occupation: "Astronaut"}); !errors.Is(err, ErrNoPostings) {
+		t.Errorf("expected ErrNoPostings, got %v", err)
+	}
+	if _, err := svc.GapAnalysis(ctx, GapParams{Occupation: "Ohne Profil"}); !errors.Is(err, ErrNoRequirements) {
+		t.Errorf("expected ErrNoRequirements, got %v", err)
+	}
+}
+
+func strPtr(s string) *string { return &s }
@@ -1,6 +1,7 @@
 package journal
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -92,6 +93,9 @@ func (h *Handler) Record(c echo.Context) error {
 	}
 
 	interaction, err := h.svc.Record(c.Request().Context(), userID, req)
+	if errors.Is(err, ErrSessionNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "session not found")
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
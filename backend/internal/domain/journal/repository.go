@@ -7,6 +7,8 @@ import (
 )
 
 type Repository interface {
+	// Create stores i in one of its user's sessions; it returns
+	// ErrSessionNotFound if the user has no session i.SessionID.
 	Create(ctx context.Context, i *Interaction) error
 	List(ctx context.Context, params ListParams) ([]Interaction, int, error)
 	ListByStation(ctx context.Context, userID uuid.UUID, stationID string) ([]Interaction, int, error)
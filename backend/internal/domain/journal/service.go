@@ -2,33 +2,72 @@ package journal
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrSessionNotFound is returned when recording into a session the user
+// does not own.
+var ErrSessionNotFound = errors.New("session not found")
+
+// Taxonomy resolves dimension keys against the skill registry (satisfied
+// by *taxonomy.Service).
+type Taxonomy interface {
+	Resolve(key string) (string, bool)
+}
+
 type Service struct {
-	repo Repository
+	repo     Repository
+	taxonomy Taxonomy
 }
 
 func NewService(repo Repository) *Service {
 	return &Service{repo: repo}
 }
 
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
+}
+
+// SetTaxonomy makes dimension lookups accept aliases and ESCO URIs.
+func (s *Service) SetTaxonomy(t Taxonomy) {
+	s.taxonomy = t
+}
+
 func (s *Service) List(ctx context.Context, params ListParams) ([]Interaction, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
 	return s.repo.List(ctx, params)
 }
 
 func (s *Service) ByStation(ctx context.Context, userID uuid.UUID, stationID string) ([]Interaction, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
 	return s.repo.ListByStation(ctx, userID, stationID)
 }
 
 func (s *Service) ByDimension(ctx context.Context, userID uuid.UUID, dimension string) ([]Interaction, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
+	if s.taxonomy != nil {
+		if k, ok := s.taxonomy.Resolve(dimension); ok {
+			dimension = k
+		}
+	}
 	return s.repo.ListByDimension(ctx, userID, dimension)
 }
 
 func (s *Service) Record(ctx context.Context, userID uuid.UUID, req CreateInteractionRequest) (*Interaction, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 	if req.SessionID == uuid.Nil {
 		return nil, fmt.Errorf("session_id is required")
 	}
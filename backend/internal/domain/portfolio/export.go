@@ -3,9 +3,12 @@ package portfolio
 import (
 	"archive/zip"
 	"bytes"
+	"encoding/base64"
 	"fmt"
 	"html/template"
 	"strings"
+
+	"skillr-mvp-v1/backend/internal/domain/artifact"
 )
 
 const portfolioHTMLTemplate = `<!DOCTYPE html>
@@ -33,6 +36,9 @@ header p{color:#94a3b8;margin-top:.5rem;font-size:.9rem}
 .entry p{color:#94a3b8;font-size:.9rem;margin-bottom:1rem}
 .entry-meta{display:flex;gap:.5rem;flex-wrap:wrap;align-items:center}
 .tags{display:flex;gap:.5rem;flex-wrap:wrap;margin-top:.75rem}
+.badge-artifact{background:rgba(236,72,153,.15);color:#f472b6;border:1px solid rgba(236,72,153,.25)}
+.artifact-preview{display:block;max-width:100%;max-height:320px;margin:0 auto 1rem;border-radius:.5rem;background:#fff}
+h2{font-size:1.25rem;color:#f1f5f9;margin:2.5rem 0 1rem}
 .tag{font-size:.7rem;padding:.2rem .6rem;border-radius:9999px;background:rgba(139,92,246,.15);color:#a78bfa;border:1px solid rgba(139,92,246,.25)}
 footer{text-align:center;margin-top:3rem;padding-top:2rem;border-top:1px solid rgba(148,163,184,.1);color:#475569;font-size:.8rem}
 footer a{color:#818cf8;text-decoration:none}
@@ -56,6 +62,18 @@ footer a{color:#818cf8;text-decoration:none}
 </div>
 {{end}}
 </div>
+{{if .Artifacts}}<h2>Nachweise</h2>
+<div class="entries">
+{{range .Artifacts}}<div class="entry">
+{{if .Preview}}<img class="artifact-preview" src="{{.Preview}}" alt="Vorschau: {{.Title}}">{{end}}
+<h3>{{.Title}}</h3>
+{{if .Details}}<p>{{.Details}}</p>{{end}}
+<div class="entry-meta">
+<span class="badge badge-artifact">{{.Kind}}</span>
+</div>
+</div>
+{{end}}
+</div>{{end}}
 <footer>
 <p>Erstellt mit <a href="{{.BaseURL}}">SkillR</a></p>
 </footer>
@@ -67,13 +85,74 @@ type templateData struct {
 	DisplayName string
 	Entries     []PortfolioEntry
 	EntryCount  int
+	Artifacts   []exportArtifact
 	BaseURL     string
 }
 
+// exportArtifact is an artifact as shown in the export. Preview is the
+// thumbnail as a data URI, so the page stays self-contained.
+type exportArtifact struct {
+	Title   string
+	Kind    string
+	Details string
+	Preview template.URL
+}
+
+var artifactKinds = map[string]string{
+	artifact.TypePhoto:    "Foto",
+	artifact.TypeDocument: "Dokument",
+	artifact.TypeVideo:    "Video",
+	artifact.TypeLink:     "Link",
+}
+
+// exportArtifacts converts artifacts for the template; details are the
+// file name (or URL of links) and the metadata, e.g.
+// "bericht.pdf · 12 Seiten" or "clip.mp4 · 1280 × 720 · 0:42".
+func exportArtifacts(items []artifact.ExportItem) []exportArtifact {
+	out := make([]exportArtifact, 0, len(items))
+	for _, it := range items {
+		var details []string
+		if it.FileName != "" {
+			details = append(details, it.FileName)
+		} else if it.StorageRef != nil {
+			details = append(details, *it.StorageRef)
+		}
+		if it.Width > 0 && it.Height > 0 {
+			details = append(details, fmt.Sprintf("%d × %d", it.Width, it.Height))
+		}
+		switch {
+		case it.PageCount == 1:
+			details = append(details, "1 Seite")
+		case it.PageCount > 1:
+			details = append(details, fmt.Sprintf("%d Seiten", it.PageCount))
+		}
+		if it.DurationSeconds > 0 {
+			secs := int(it.DurationSeconds + 0.5)
+			details = append(details, fmt.Sprintf("%d:%02d", secs/60, secs%60))
+		}
+		a := exportArtifact{Title: it.Description, Kind: artifactKinds[it.ArtifactType], Details: strings.Join(details, " · ")}
+		if a.Kind == "" {
+			a.Kind = it.ArtifactType
+		}
+		if len(it.Thumbnail) > 0 {
+			a.Preview = template.URL("data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(it.Thumbnail))
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
 // renderPortfolioHTML renders a self-contained HTML page from the portfolio entries.
 // baseURL is the scheme+host used for the "Erstellt mit SkillR" link (e.g. "http://localhost:9090").
 // If empty, defaults to "https://skillr.app".
 func renderPortfolioHTML(displayName string, entries []PortfolioEntry, baseURL string) string {
+	return renderExportHTML(displayName, entries, nil, baseURL)
+}
+
+// renderExportHTML renders the portfolio page followed by the learner's
+// artifacts with their previews (only in the learner's own export, never
+// on the public page).
+func renderExportHTML(displayName string, entries []PortfolioEntry, artifacts []artifact.ExportItem, baseURL string) string {
 	tmpl, err := template.New("portfolio").Parse(portfolioHTMLTemplate)
 	if err != nil {
 		return fmt.Sprintf("<html><body><h1>Error rendering portfolio: %s</h1></body></html>", err.Error())
@@ -87,6 +166,7 @@ func renderPortfolioHTML(displayName string, entries []PortfolioEntry, baseURL s
 		DisplayName: displayName,
 		Entries:     entries,
 		EntryCount:  len(entries),
+		Artifacts:   exportArtifacts(artifacts),
 		BaseURL:     baseURL,
 	}
 
@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/domain/artifact"
 )
 
 func TestRenderPortfolioHTML_Empty(t *testing.T) {
@@ -108,3 +110,35 @@ func TestRenderPortfolioHTML_ValidHTMLStructure(t *testing.T) {
 		t.Error("expected German lang attribute")
 	}
 }
+
+func TestRenderExportHTML_Artifacts(t *testing.T) {
+	repo := "https://github.com/lea/roboter"
+	items := []artifact.ExportItem{
+		{
+			ExternalArtifact: artifact.ExternalArtifact{ArtifactType: artifact.TypeDocument, Description: "Praktikumsbericht", FileName: "bericht.pdf", PageCount: 12},
+			Thumbnail:        []byte{0xff, 0xd8, 0xff},
+		},
+		{ExternalArtifact: artifact.ExternalArtifact{ArtifactType: artifact.TypeVideo, Description: "Roboter faehrt", FileName: "clip.mp4", Width: 1280, Height: 720, DurationSeconds: 62.4}},
+		{ExternalArtifact: artifact.ExternalArtifact{ArtifactType: artifact.TypeLink, Description: "Code", StorageRef: &repo}},
+	}
+	html := renderExportHTML("Lea", nil, items, "")
+
+	for _, want := range []string{
+		"Nachweise",
+		`src="data:image/jpeg;base64,/9j/"`,
+		"bericht.pdf · 12 Seiten",
+		"clip.mp4 · 1280 × 720 · 1:02",
+		repo,
+		"Dokument", "Video", "Link",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected %q in export", want)
+		}
+	}
+	if strings.Count(html, "<img") != 1 {
+		t.Error("expected a preview image only for the artifact with a thumbnail")
+	}
+	if strings.Contains(renderPortfolioHTML("Lea", nil, ""), "Nachweise") {
+		t.Error("page without artifacts should have no artifact section")
+	}
+}
@@ -2,12 +2,12 @@ package portfolio
 
 import (
 	"net/http"
-	"strings"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
 	"skillr-mvp-v1/backend/internal/middleware"
+	"skillr-mvp-v1/backend/internal/resume"
 )
 
 // Handler exposes HTTP endpoints for portfolio operations.
@@ -150,6 +150,10 @@ func (h *Handler) Export(c echo.Context) error {
 		return err
 	}
 
+	// Artifacts are stored under the UUID derived from the auth UID, not
+	// the users.id resolved above.
+	artifactOwner := uuid.NewSHA1(uuid.NameSpaceDNS, []byte(middleware.GetUserInfo(c).UID))
+
 	format := ExportFormat(c.QueryParam("format"))
 	if format == "" {
 		format = ExportHTML
@@ -157,7 +161,7 @@ func (h *Handler) Export(c echo.Context) error {
 
 	switch format {
 	case ExportHTML:
-		html, err := h.svc.ExportHTML(c.Request().Context(), userID)
+		html, err := h.svc.ExportHTML(c.Request().Context(), userID, artifactOwner)
 		if err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 		}
@@ -165,7 +169,7 @@ func (h *Handler) Export(c echo.Context) error {
 		return c.HTML(http.StatusOK, html)
 
 	case ExportZIP:
-		data, err := h.svc.ExportZIP(c.Request().Context(), userID)
+		data, err := h.svc.ExportZIP(c.Request().Context(), userID, artifactOwner)
 		if err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 		}
@@ -179,7 +183,8 @@ func (h *Handler) Export(c echo.Context) error {
 
 // PublicPage returns the public portfolio for a given user ID (no auth required).
 // Content negotiation: browsers (Accept: text/html) get a rendered HTML page,
-// API clients (Accept: application/json) get JSON.
+// API clients (Accept: application/json) get JSON, and machine readers get
+// schema.org JSON-LD or JSON Resume (Accept or ?format=).
 func (h *Handler) PublicPage(c echo.Context) error {
 	userIDStr := c.Param("userId")
 	userID, err := uuid.Parse(userIDStr)
@@ -193,11 +198,16 @@ func (h *Handler) PublicPage(c echo.Context) error {
 	}
 
 	// Content negotiation: serve HTML to browsers, JSON to API clients
-	accept := c.Request().Header.Get("Accept")
-	if strings.Contains(accept, "text/html") {
-		baseURL := c.Scheme() + "://" + c.Request().Host
+	c.Response().Header().Add("Vary", "Accept")
+	baseURL := c.Scheme() + "://" + c.Request().Host
+	switch format := resume.Negotiate(c.Request(), true); format {
+	case resume.FormatHTML:
 		html := renderPortfolioHTML(page.DisplayName, page.Entries, baseURL)
 		return c.HTML(http.StatusOK, html)
+	case resume.FormatJSONLD, resume.FormatJSONResume:
+		doc := resume.Render(h.svc.PublicPerson(c.Request().Context(), page, baseURL), format)
+		c.Response().Header().Set(echo.HeaderContentType, resume.ContentType(format))
+		return c.JSON(http.StatusOK, doc)
 	}
 
 	return c.JSON(http.StatusOK, page)
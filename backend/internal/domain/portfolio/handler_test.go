@@ -1,6 +1,8 @@
 package portfolio
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -8,6 +10,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+
+	"skillr-mvp-v1/backend/internal/resume"
 )
 
 func setupTestHandler() (*Handler, *mockRepo) {
@@ -156,6 +160,104 @@ func TestHandler_PublicPage_HTML(t *testing.T) {
 	}
 }
 
+type mockProfiles struct{}
+
+func (mockProfiles) PublicPerson(_ context.Context, userID uuid.UUID, _ string) (*resume.Person, error) {
+	return &resume.Person{
+		ID:     userID,
+		Name:   "Ada",
+		Skills: []resume.Skill{{Key: "analytical", Label: "Analytisches Denken", Score: 72}},
+		Credentials: []resume.Credential{{
+			ID: uuid.MustParse("33333333-3333-3333-3333-333333333333"), Name: "Lernreise abgeschlossen",
+			Type: "journey_completion", IssuerName: "SkillR", VerifyURL: "http://example.com/api/v1/credentials/verify/33333333-3333-3333-3333-333333333333",
+		}},
+	}, nil
+}
+
+func TestHandler_PublicPage_JSONLD(t *testing.T) {
+	h, repo := setupTestHandler()
+	h.svc.SetProfiles(mockProfiles{})
+	uid := "11111111-1111-1111-1111-111111111111"
+	repo.entries = []PortfolioEntry{
+		{ID: uuid.MustParse("22222222-2222-2222-2222-222222222222"), UserID: uuid.MustParse(uid), Title: "My Project", Visibility: "public", Tags: []string{"Go"}},
+	}
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/portfolio/page/"+uid, nil)
+	req.Header.Set("Accept", "application/ld+json, application/json;q=0.5")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("userId")
+	c.SetParamValues(uid)
+
+	if err := h.PublicPage(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/ld+json") {
+		t.Errorf("expected JSON-LD content type, got %q", ct)
+	}
+	var doc struct {
+		Type          string                   `json:"@type"`
+		Name          string                   `json:"name"`
+		URL           string                   `json:"url"`
+		Skills        []map[string]interface{} `json:"skills"`
+		HasCredential []map[string]interface{} `json:"hasCredential"`
+		Reverse       struct {
+			Creator []map[string]interface{} `json:"creator"`
+		} `json:"@reverse"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if doc.Type != "Person" || doc.Name != "SkillR Learner" || !strings.HasSuffix(doc.URL, "/api/v1/portfolio/page/"+uid) {
+		t.Errorf("unexpected person: %+v", doc)
+	}
+	if len(doc.Skills) != 1 || doc.Skills[0]["termCode"] != "analytical" {
+		t.Errorf("expected profile skills, got %v", doc.Skills)
+	}
+	if len(doc.HasCredential) != 1 || doc.HasCredential[0]["credentialCategory"] != "journey_completion" {
+		t.Errorf("expected credential, got %v", doc.HasCredential)
+	}
+	if len(doc.Reverse.Creator) != 1 || doc.Reverse.Creator[0]["name"] != "My Project" {
+		t.Errorf("expected portfolio entry as work, got %v", doc.Reverse.Creator)
+	}
+}
+
+func TestHandler_PublicPage_JSONResume(t *testing.T) {
+	h, repo := setupTestHandler()
+	uid := "11111111-1111-1111-1111-111111111111"
+	repo.entries = []PortfolioEntry{
+		{ID: uuid.MustParse("22222222-2222-2222-2222-222222222222"), UserID: uuid.MustParse(uid), Title: "My Project", Visibility: "public", Tags: []string{"Go"}},
+	}
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/portfolio/page/"+uid+"?format=json-resume", nil)
+	req.Header.Set("Accept", "text/html")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("userId")
+	c.SetParamValues(uid)
+
+	if err := h.PublicPage(c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, resume.MediaJSONResume) {
+		t.Errorf("expected JSON Resume content type, got %q", ct)
+	}
+	var doc struct {
+		Basics   map[string]interface{}   `json:"basics"`
+		Projects []map[string]interface{} `json:"projects"`
+		Skills   []map[string]interface{} `json:"skills"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if doc.Basics["name"] != "SkillR Learner" || len(doc.Projects) != 1 || doc.Projects[0]["name"] != "My Project" {
+		t.Errorf("unexpected resume: %+v", doc)
+	}
+	if len(doc.Skills) != 0 {
+		t.Errorf("expected no skills without a profile source, got %v", doc.Skills)
+	}
+}
+
 func TestHandler_Delete_InvalidID(t *testing.T) {
 	h, _ := setupTestHandler()
 	e := echo.New()
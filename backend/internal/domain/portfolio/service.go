@@ -10,12 +10,29 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"skillr-mvp-v1/backend/internal/domain/artifact"
+	"skillr-mvp-v1/backend/internal/resume"
 )
 
 // Service contains the business logic for portfolio entries.
 type Service struct {
-	repo Repository
-	db   *pgxpool.Pool
+	repo      Repository
+	db        *pgxpool.Pool
+	profiles  ProfileSource
+	artifacts ArtifactSource
+}
+
+// ProfileSource provides the public skill profile of a learner as a
+// machine-readable person (satisfied by *profile.Service).
+type ProfileSource interface {
+	PublicPerson(ctx context.Context, userID uuid.UUID, baseURL string) (*resume.Person, error)
+}
+
+// ArtifactSource provides a learner's artifacts with thumbnails for the
+// export (satisfied by *artifact.Service).
+type ArtifactSource interface {
+	ExportItems(ctx context.Context, learnerID uuid.UUID) ([]artifact.ExportItem, error)
 }
 
 // NewService creates a Service with the given repository (may be nil).
@@ -33,6 +50,18 @@ func (s *Service) SetDB(db *pgxpool.Pool) {
 	s.db = db
 }
 
+// SetProfiles sets the source of the skills and credentials in the
+// JSON-LD and JSON Resume output of the public page.
+func (s *Service) SetProfiles(p ProfileSource) {
+	s.profiles = p
+}
+
+// SetArtifacts adds the learner's artifacts and their previews to the
+// HTML and ZIP export.
+func (s *Service) SetArtifacts(a ArtifactSource) {
+	s.artifacts = a
+}
+
 // ResolveUserID converts a middleware UID (Firebase UID or local auth UUID) into
 // the users.id UUID. Firebase UIDs are looked up via the firebase_uid column.
 // For local auth users the UID is already a valid UUID and is returned directly.
@@ -248,8 +277,36 @@ func (s *Service) PublicPage(ctx context.Context, userID uuid.UUID) (*PublicPort
 	}, nil
 }
 
+// PublicPerson describes the public page as a machine-readable person: the
+// public entries as works, plus the skills and credentials of the public
+// profile if the learner has one.
+func (s *Service) PublicPerson(ctx context.Context, page *PublicPortfolio, baseURL string) resume.Person {
+	p := resume.Person{ID: page.UserID}
+	if s.profiles != nil {
+		if prof, err := s.profiles.PublicPerson(ctx, page.UserID, baseURL); err == nil {
+			p = *prof
+		}
+	}
+	p.Name = page.DisplayName
+	p.URL = baseURL + "/api/v1/portfolio/page/" + page.UserID.String()
+	p.Works = nil
+	for _, e := range page.Entries {
+		p.Works = append(p.Works, resume.Work{
+			ID:          e.ID,
+			Title:       e.Title,
+			Description: e.Description,
+			Category:    e.Category,
+			Tags:        e.Tags,
+			CreatedAt:   e.CreatedAt,
+		})
+	}
+	return p
+}
+
 // ExportHTML generates a self-contained HTML string of the portfolio.
-func (s *Service) ExportHTML(ctx context.Context, userID uuid.UUID) (string, error) {
+// Artifacts are keyed by their own owner ID (see the artifact handler),
+// passed as artifactOwner.
+func (s *Service) ExportHTML(ctx context.Context, userID, artifactOwner uuid.UUID) (string, error) {
 	if s.repo == nil {
 		return "", fmt.Errorf("database not available")
 	}
@@ -262,12 +319,22 @@ func (s *Service) ExportHTML(ctx context.Context, userID uuid.UUID) (string, err
 		entries = []PortfolioEntry{}
 	}
 
-	return renderPortfolioHTML("SkillR Learner", entries, ""), nil
+	var artifacts []artifact.ExportItem
+	if s.artifacts != nil {
+		artifacts, err = s.artifacts.ExportItems(ctx, artifactOwner)
+		if err != nil {
+			// The export still has the entries; only the artifacts are missing.
+			log.Printf("[portfolio] export: list artifacts: %v", err)
+			artifacts = nil
+		}
+	}
+
+	return renderExportHTML("SkillR Learner", entries, artifacts, ""), nil
 }
 
 // ExportZIP generates a ZIP archive containing the portfolio HTML.
-func (s *Service) ExportZIP(ctx context.Context, userID uuid.UUID) ([]byte, error) {
-	html, err := s.ExportHTML(ctx, userID)
+func (s *Service) ExportZIP(ctx context.Context, userID, artifactOwner uuid.UUID) ([]byte, error) {
+	html, err := s.ExportHTML(ctx, userID, artifactOwner)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,372 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"skillr-mvp-v1/backend/internal/skillscore"
+)
+
+// CategoryDefinition maps a set of skill dimensions onto one SkillCategory.
+type CategoryDefinition struct {
+	Key        string   `json:"key"`
+	Label      string   `json:"label"`
+	Dimensions []string `json:"dimensions"`
+}
+
+// CategoryTable is the configurable dimension-to-category mapping used by the
+// computation engine. Aliases rewrite incoming keys (e.g. reflection capability
+// names) to canonical dimension keys before they are mapped.
+type CategoryTable struct {
+	Categories []CategoryDefinition `json:"categories"`
+	Aliases    map[string]string    `json:"aliases,omitempty"`
+}
+
+// DefaultCategoryTable returns the built-in mapping for the four SkillR categories.
+func DefaultCategoryTable() CategoryTable {
+	return CategoryTable{
+		Categories: []CategoryDefinition{
+			{Key: "hard-skills", Label: "Fachkompetenzen", Dimensions: []string{"analytical-thinking", "problem-solving", "digital-literacy", "planning"}},
+			{Key: "soft-skills", Label: "Sozialkompetenzen", Dimensions: []string{"teamwork", "communication", "empathy", "self-awareness"}},
+			{Key: "future-skills", Label: "Zukunftskompetenzen", Dimensions: []string{"creativity", "initiative", "adaptability", "curiosity"}},
+			{Key: "resilience", Label: "Resilienz", Dimensions: []string{"resilience", "persistence", "confidence", "ambiguity-tolerance"}},
+		},
+		Aliases: map[string]string{
+			"analytical_depth": "analytical-thinking",
+			"self_awareness":   "self-awareness",
+			"problem_solving":  "problem-solving",
+			"volatility":       "adaptability",
+			"uncertainty":      "ambiguity-tolerance",
+			"complexity":       "problem-solving",
+			"ambiguity":        "ambiguity-tolerance",
+		},
+	}
+}
+
+// LoadCategoryTable reads a CategoryTable from a JSON file.
+func LoadCategoryTable(path string) (CategoryTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return CategoryTable{}, fmt.Errorf("read category table: %w", err)
+	}
+	var t CategoryTable
+	if err := json.Unmarshal(data, &t); err != nil {
+		return CategoryTable{}, fmt.Errorf("parse category table: %w", err)
+	}
+	if len(t.Categories) == 0 {
+		return CategoryTable{}, fmt.Errorf("category table has no categories")
+	}
+	return t, nil
+}
+
+// canonical resolves aliases and normalises the key format.
+func (t CategoryTable) canonical(key string) string {
+	k := strings.ToLower(strings.TrimSpace(key))
+	if alias, ok := t.Aliases[k]; ok {
+		return alias
+	}
+	return k
+}
+
+// categoriesFor returns the keys of all categories containing dim.
+func (t CategoryTable) categoriesFor(dim string) []string {
+	var keys []string
+	for _, c := range t.Categories {
+		for _, d := range c.Dimensions {
+			if d == dim {
+				keys = append(keys, c.Key)
+				break
+			}
+		}
+	}
+	return keys
+}
+
+// Weights controls how strongly each signal source contributes to a dimension.
+type Weights struct {
+	Reflection            float64
+	EndorsementVerified   float64
+	EndorsementUnverified float64
+	Artifact              float64
+	Interaction           float64
+}
+
+// DefaultWeights returns the default source weights. Evidence entries are
+// weighted by their own Confidence value.
+func DefaultWeights() Weights {
+	return Weights{
+		Reflection:            1.0,
+		EndorsementVerified:   1.5,
+		EndorsementUnverified: 0.75,
+		Artifact:              0.5,
+		Interaction:           0.25,
+	}
+}
+
+// Signals is the raw learner activity the computation engine aggregates.
+type Signals struct {
+	Reflections  []ReflectionSignal
+	Evidence     []EvidenceSignal
+	Endorsements []EndorsementSignal
+	Artifacts    []ArtifactSignal
+	Interactions []InteractionSignal
+}
+
+// ReflectionSignal holds capability scores (0-100) of one reflection.
+type ReflectionSignal struct {
+	Scores map[string]float64
+}
+
+// EvidenceSignal holds one evidence entry's dimensions and confidence (0-1).
+type EvidenceSignal struct {
+	Dimensions map[string]float64
+	Confidence float64
+	Interests  []string
+}
+
+// EndorsementSignal holds one visible endorsement's dimensions.
+type EndorsementSignal struct {
+	Dimensions map[string]float64
+	Verified   bool
+}
+
+// ArtifactSignal holds one artifact's dimensions.
+type ArtifactSignal struct {
+	Dimensions map[string]float64
+}
+
+// InteractionSignal holds the ProfileImpact recorded on one interaction.
+type InteractionSignal struct {
+	ProfileImpact map[string]interface{}
+}
+
+const (
+	maxTopStrengths   = 3
+	maxTopInterests   = 5
+	strengthThreshold = 50.0
+	// completenessTarget is the number of weighted signals at which the
+	// volume component of Completeness saturates.
+	completenessTarget = 20.0
+)
+
+// Engine turns Signals into a SkillProfile.
+type Engine struct {
+	table   CategoryTable
+	weights Weights
+}
+
+// NewEngine creates an Engine with the given mapping table and weights.
+func NewEngine(table CategoryTable, weights Weights) *Engine {
+	return &Engine{table: table, weights: weights}
+}
+
+// Result is the outcome of a computation, before persistence.
+type Result struct {
+	SkillCategories []SkillCategory
+	DimensionScores map[string]float64
+	TopInterests    []string
+	TopStrengths    []string
+	Completeness    float64
+	EvidenceSummary *EvidenceSummary
+}
+
+type accumulator struct {
+	weighted map[string]float64
+	weights  map[string]float64
+}
+
+func (a *accumulator) add(dims map[string]float64, weight float64, table CategoryTable) {
+	if weight <= 0 {
+		return
+	}
+	for key, v := range dims {
+		dim := table.canonical(key)
+		if dim == "" {
+			continue
+		}
+		a.weighted[dim] += skillscore.Clamp(v) * weight
+		a.weights[dim] += weight
+	}
+}
+
+// Compute aggregates all signals into category and dimension scores.
+func (e *Engine) Compute(s Signals) Result {
+	acc := &accumulator{weighted: map[string]float64{}, weights: map[string]float64{}}
+	interests := map[string]int{}
+
+	for _, r := range s.Reflections {
+		acc.add(r.Scores, e.weights.Reflection, e.table)
+	}
+	for _, ev := range s.Evidence {
+		acc.add(ev.Dimensions, ev.Confidence, e.table)
+		for _, i := range ev.Interests {
+			countInterest(interests, i)
+		}
+	}
+	for _, en := range s.Endorsements {
+		w := e.weights.EndorsementUnverified
+		if en.Verified {
+			w = e.weights.EndorsementVerified
+		}
+		acc.add(en.Dimensions, w, e.table)
+	}
+	for _, a := range s.Artifacts {
+		acc.add(a.Dimensions, e.weights.Artifact, e.table)
+	}
+	for _, in := range s.Interactions {
+		dims, ints := splitProfileImpact(in.ProfileImpact)
+		acc.add(dims, e.weights.Interaction, e.table)
+		for _, i := range ints {
+			countInterest(interests, i)
+		}
+	}
+
+	dimScores := make(map[string]float64, len(acc.weighted))
+	for dim, sum := range acc.weighted {
+		dimScores[dim] = round2(sum / acc.weights[dim])
+	}
+
+	categories := make([]SkillCategory, 0, len(e.table.Categories))
+	coveredCategories := 0
+	for _, def := range e.table.Categories {
+		cat := SkillCategory{Key: def.Key, Label: def.Label, ContributingDimensions: []string{}}
+		var total float64
+		for _, dim := range def.Dimensions {
+			if score, ok := dimScores[dim]; ok {
+				total += score
+				cat.ContributingDimensions = append(cat.ContributingDimensions, dim)
+			}
+		}
+		if n := len(cat.ContributingDimensions); n > 0 {
+			cat.Score = round2(total / float64(n))
+			coveredCategories++
+		}
+		categories = append(categories, cat)
+	}
+
+	summary := &EvidenceSummary{
+		TotalInteractions: len(s.Interactions),
+		TotalReflections:  len(s.Reflections),
+		TotalEndorsements: len(s.Endorsements),
+		TotalArtifacts:    len(s.Artifacts),
+	}
+
+	return Result{
+		SkillCategories: categories,
+		DimensionScores: dimScores,
+		TopInterests:    topInterests(interests),
+		TopStrengths:    topStrengths(dimScores),
+		Completeness:    e.completeness(s, coveredCategories, acc),
+		EvidenceSummary: summary,
+	}
+}
+
+// completeness blends three components: how many signal sources the learner
+// has used (50%), how many categories have any data (30%), and the overall
+// signal volume (20%). The result is in [0,1].
+func (e *Engine) completeness(s Signals, coveredCategories int, acc *accumulator) float64 {
+	sources := 0
+	for _, present := range []bool{
+		len(s.Interactions) > 0,
+		len(s.Reflections) > 0,
+		len(s.Evidence) > 0,
+		len(s.Endorsements) > 0 || len(s.Artifacts) > 0,
+	} {
+		if present {
+			sources++
+		}
+	}
+	sourceScore := float64(sources) / 4
+
+	categoryScore := 0.0
+	if n := len(e.table.Categories); n > 0 {
+		categoryScore = float64(coveredCategories) / float64(n)
+	}
+
+	var volume float64
+	for _, w := range acc.weights {
+		volume += w
+	}
+	volumeScore := math.Min(volume/completenessTarget, 1)
+
+	return round2(0.5*sourceScore + 0.3*categoryScore + 0.2*volumeScore)
+}
+
+// splitProfileImpact separates numeric dimension entries from the optional
+// "interests" list in an interaction's ProfileImpact.
+func splitProfileImpact(impact map[string]interface{}) (map[string]float64, []string) {
+	dims := map[string]float64{}
+	var interests []string
+	for k, v := range impact {
+		switch val := v.(type) {
+		case float64:
+			dims[k] = val
+		case int:
+			dims[k] = float64(val)
+		case []interface{}:
+			if k == "interests" {
+				for _, item := range val {
+					if s, ok := item.(string); ok {
+						interests = append(interests, s)
+					}
+				}
+			}
+		case []string:
+			if k == "interests" {
+				interests = append(interests, val...)
+			}
+		}
+	}
+	return dims, interests
+}
+
+func countInterest(counts map[string]int, interest string) {
+	interest = strings.TrimSpace(interest)
+	if interest != "" {
+		counts[interest]++
+	}
+}
+
+func topInterests(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > maxTopInterests {
+		keys = keys[:maxTopInterests]
+	}
+	return keys
+}
+
+func topStrengths(scores map[string]float64) []string {
+	keys := make([]string, 0, len(scores))
+	for k, v := range scores {
+		if v >= strengthThreshold {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if scores[keys[i]] != scores[keys[j]] {
+			return scores[keys[i]] > scores[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > maxTopStrengths {
+		keys = keys[:maxTopStrengths]
+	}
+	return keys
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,131 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEngine_EmptySignals(t *testing.T) {
+	e := NewEngine(DefaultCategoryTable(), DefaultWeights())
+	res := e.Compute(Signals{})
+
+	if len(res.SkillCategories) != 4 {
+		t.Fatalf("expected 4 categories, got %d", len(res.SkillCategories))
+	}
+	for _, c := range res.SkillCategories {
+		if c.Score != 0 {
+			t.Errorf("expected score 0 for %s, got %v", c.Key, c.Score)
+		}
+	}
+	if res.Completeness != 0 {
+		t.Errorf("expected completeness 0, got %v", res.Completeness)
+	}
+}
+
+func TestEngine_CategoryScoreIsMeanOfDimensions(t *testing.T) {
+	e := NewEngine(DefaultCategoryTable(), DefaultWeights())
+	res := e.Compute(Signals{
+		Reflections: []ReflectionSignal{{Scores: map[string]float64{"creativity": 80, "curiosity": 40}}},
+	})
+
+	var future SkillCategory
+	for _, c := range res.SkillCategories {
+		if c.Key == "future-skills" {
+			future = c
+		}
+	}
+	if future.Score != 60 {
+		t.Errorf("expected future-skills 60, got %v", future.Score)
+	}
+	if len(future.ContributingDimensions) != 2 {
+		t.Errorf("expected 2 contributing dimensions, got %v", future.ContributingDimensions)
+	}
+}
+
+func TestEngine_VerifiedEndorsementsWeighMore(t *testing.T) {
+	e := NewEngine(DefaultCategoryTable(), DefaultWeights())
+	res := e.Compute(Signals{
+		Endorsements: []EndorsementSignal{
+			{Dimensions: map[string]float64{"teamwork": 90}, Verified: true},
+			{Dimensions: map[string]float64{"teamwork": 30}, Verified: false},
+		},
+	})
+
+	// (90*1.5 + 30*0.75) / 2.25 = 70
+	if got := res.DimensionScores["teamwork"]; got != 70 {
+		t.Errorf("expected teamwork 70, got %v", got)
+	}
+}
+
+func TestEngine_EvidenceWeightedByConfidence(t *testing.T) {
+	e := NewEngine(DefaultCategoryTable(), DefaultWeights())
+	res := e.Compute(Signals{
+		Evidence: []EvidenceSignal{
+			{Dimensions: map[string]float64{"empathy": 100}, Confidence: 0.9},
+			{Dimensions: map[string]float64{"empathy": 0}, Confidence: 0.1},
+			{Dimensions: map[string]float64{"planning": 100}, Confidence: 0},
+		},
+	})
+
+	if got := res.DimensionScores["empathy"]; got != 90 {
+		t.Errorf("expected empathy 90, got %v", got)
+	}
+	if _, ok := res.DimensionScores["planning"]; ok {
+		t.Error("expected zero-confidence evidence to be ignored")
+	}
+}
+
+func TestEngine_LowScoresStayOnScale(t *testing.T) {
+	e := NewEngine(DefaultCategoryTable(), DefaultWeights())
+	res := e.Compute(Signals{Reflections: []ReflectionSignal{{Scores: map[string]float64{"empathy": 1, "planning": 0.5}}}})
+
+	if res.DimensionScores["empathy"] != 1 || res.DimensionScores["planning"] != 0.5 {
+		t.Errorf("expected 1 and 0.5 to stay on the 0-100 scale, got %v", res.DimensionScores)
+	}
+}
+
+func TestEngine_InteractionProfileImpact(t *testing.T) {
+	e := NewEngine(DefaultCategoryTable(), DefaultWeights())
+	res := e.Compute(Signals{
+		Interactions: []InteractionSignal{
+			{ProfileImpact: map[string]interface{}{"initiative": 70.0, "interests": []interface{}{"Musik", "Technik"}}},
+			{ProfileImpact: map[string]interface{}{"interests": []interface{}{"Technik"}}},
+		},
+	})
+
+	if got := res.DimensionScores["initiative"]; got != 70 {
+		t.Errorf("expected initiative 70, got %v", got)
+	}
+	if len(res.TopInterests) != 2 || res.TopInterests[0] != "Technik" {
+		t.Errorf("expected Technik first, got %v", res.TopInterests)
+	}
+	if len(res.TopStrengths) != 1 || res.TopStrengths[0] != "initiative" {
+		t.Errorf("expected initiative as top strength, got %v", res.TopStrengths)
+	}
+}
+
+func TestLoadCategoryTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "table.json")
+	data := `{"categories":[{"key":"craft","label":"Handwerk","dimensions":["precision"]}],"aliases":{"genauigkeit":"precision"}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := LoadCategoryTable(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res := NewEngine(table, DefaultWeights()).Compute(Signals{
+		Reflections: []ReflectionSignal{{Scores: map[string]float64{"Genauigkeit": 55}}},
+	})
+	if len(res.SkillCategories) != 1 || res.SkillCategories[0].Score != 55 {
+		t.Errorf("expected craft score 55, got %+v", res.SkillCategories)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.json")
+	_ = os.WriteFile(empty, []byte(`{"categories":[]}`), 0o600)
+	if _, err := LoadCategoryTable(empty); err == nil {
+		t.Error("expected error for empty table")
+	}
+}
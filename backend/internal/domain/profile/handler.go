@@ -1,21 +1,29 @@
 package profile
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
 	"skillr-mvp-v1/backend/internal/middleware"
+	"skillr-mvp-v1/backend/internal/resume"
 )
 
 type Handler struct {
-	svc *Service
+	svc     *Service
+	baseURL string
 }
 
-func NewHandler(svc *Service) *Handler {
-	return &Handler{svc: svc}
+// NewHandler creates a Handler. baseURL is the public URL that links in
+// exports and machine-readable profiles point to; it is never taken from
+// the request, whose Host header the client controls.
+func NewHandler(svc *Service, baseURL string) *Handler {
+	return &Handler{svc: svc, baseURL: strings.TrimRight(baseURL, "/")}
 }
 
 func (h *Handler) Get(c echo.Context) error {
@@ -66,6 +74,68 @@ func (h *Handler) History(c echo.Context) error {
 	})
 }
 
+// defaultTrendRange is used when the trend request has no "from" parameter.
+const defaultTrendRange = 90 * 24 * time.Hour
+
+// Trend returns per-category and per-dimension time series over a range.
+func (h *Handler) Trend(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	userID := deriveUUID(userInfo.UID)
+
+	to := time.Now().UTC()
+	if v := c.QueryParam("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid to: use RFC3339")
+		}
+		to = t
+	}
+	from := to.Add(-defaultTrendRange)
+	if v := c.QueryParam("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid from: use RFC3339")
+		}
+		from = t
+	}
+	if to.Before(from) {
+		return echo.NewHTTPError(http.StatusBadRequest, "from must be before to")
+	}
+
+	trend, err := h.svc.Trend(c.Request().Context(), userID, from, to)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get trend")
+	}
+	return c.JSON(http.StatusOK, trend)
+}
+
+// Diff compares two snapshots given as ?from=<id>&to=<id>.
+func (h *Handler) Diff(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	userID := deriveUUID(userInfo.UID)
+
+	fromID, err := uuid.Parse(c.QueryParam("from"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid from snapshot ID")
+	}
+	toID, err := uuid.Parse(c.QueryParam("to"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid to snapshot ID")
+	}
+
+	diff, err := h.svc.Diff(c.Request().Context(), userID, fromID, toID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "snapshot not found")
+	}
+	return c.JSON(http.StatusOK, diff)
+}
+
 func (h *Handler) Public(c echo.Context) error {
 	userIDStr := c.Param("userId")
 	userID, err := uuid.Parse(userIDStr)
@@ -77,7 +147,50 @@ func (h *Handler) Public(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusNotFound, "user not found")
 	}
-	return c.JSON(http.StatusOK, pub)
+
+	// Content negotiation: JSON-LD and JSON Resume for machine readers
+	c.Response().Header().Add("Vary", "Accept")
+	format := resume.Negotiate(c.Request(), false)
+	if format == resume.FormatJSON {
+		return c.JSON(http.StatusOK, pub)
+	}
+	doc := resume.Render(PublicPerson(pub, h.baseURL), format)
+	c.Response().Header().Set(echo.HeaderContentType, resume.ContentType(format))
+	return c.JSON(http.StatusOK, doc)
+}
+
+// PublicPerson maps the public profile to the machine-readable person
+// rendered as JSON-LD or JSON Resume.
+func PublicPerson(pub *PublicProfile, baseURL string) resume.Person {
+	p := resume.Person{
+		ID:        pub.UserID,
+		Name:      pub.DisplayName,
+		URL:       baseURL + "/api/v1/portfolio/profile/public/" + pub.UserID.String(),
+		Strengths: pub.TopStrengths,
+		Interests: pub.TopInterests,
+	}
+	for _, cat := range pub.SkillCategories {
+		p.Skills = append(p.Skills, resume.Skill{
+			Key:      cat.Key,
+			Label:    cat.Label,
+			Score:    cat.Score,
+			Keywords: cat.ContributingDimensions,
+		})
+	}
+	resume.SortSkills(p.Skills)
+	for _, cred := range pub.Credentials {
+		p.Credentials = append(p.Credentials, resume.Credential{
+			ID:         cred.ID,
+			Name:       cred.Name,
+			Type:       cred.Type,
+			IssuerName: cred.IssuerName,
+			IssuerURL:  cred.IssuerURL,
+			IssuedAt:   cred.IssuedAt,
+			ExpiresAt:  cred.ExpiresAt,
+			VerifyURL:  baseURL + "/api/v1/credentials/verify/" + cred.ID.String(),
+		})
+	}
+	return p
 }
 
 func (h *Handler) Export(c echo.Context) error {
@@ -92,11 +205,31 @@ func (h *Handler) Export(c echo.Context) error {
 		format = "json"
 	}
 
-	profile, err := h.svc.Export(c.Request().Context(), userID, format)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, "no profile to export")
+	switch format {
+	case "json":
+		profile, err := h.svc.Export(c.Request().Context(), userID)
+		if errors.Is(err, ErrNoProfile) {
+			return echo.NewHTTPError(http.StatusNotFound, "no profile to export")
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "export failed")
+		}
+		return c.JSON(http.StatusOK, profile)
+
+	case "pdf":
+		data, err := h.svc.ExportPDF(c.Request().Context(), userID, h.baseURL)
+		if errors.Is(err, ErrNoProfile) {
+			return echo.NewHTTPError(http.StatusNotFound, "no profile to export")
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "export failed")
+		}
+		c.Response().Header().Set("Content-Disposition", `attachment; filename="kompetenzprofil.pdf"`)
+		return c.Blob(http.StatusOK, "application/pdf", data)
+
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "unsupported format: use json or pdf")
 	}
-	return c.JSON(http.StatusOK, profile)
 }
 
 func deriveUUID(firebaseUID string) uuid.UUID {
@@ -21,24 +21,51 @@ type EvidenceSummary struct {
 }
 
 type SkillProfile struct {
-	ID              uuid.UUID       `json:"id"`
-	UserID          uuid.UUID       `json:"user_id"`
-	SkillCategories []SkillCategory `json:"skill_categories"`
-	TopInterests    []string        `json:"top_interests,omitempty"`
-	TopStrengths    []string        `json:"top_strengths,omitempty"`
-	Completeness    float64         `json:"completeness"`
-	EvidenceSummary *EvidenceSummary `json:"evidence_summary,omitempty"`
-	LastComputedAt  time.Time       `json:"last_computed_at"`
-	CreatedAt       time.Time       `json:"created_at,omitempty"`
+	ID              uuid.UUID          `json:"id"`
+	UserID          uuid.UUID          `json:"user_id"`
+	SkillCategories []SkillCategory    `json:"skill_categories"`
+	DimensionScores map[string]float64 `json:"dimension_scores,omitempty"`
+	TopInterests    []string           `json:"top_interests,omitempty"`
+	TopStrengths    []string           `json:"top_strengths,omitempty"`
+	Completeness    float64            `json:"completeness"`
+	EvidenceSummary *EvidenceSummary   `json:"evidence_summary,omitempty"`
+	LastComputedAt  time.Time          `json:"last_computed_at"`
+	CreatedAt       time.Time          `json:"created_at,omitempty"`
 }
 
 type PublicProfile struct {
-	UserID              uuid.UUID       `json:"user_id"`
-	DisplayName         string          `json:"display_name"`
-	SkillCategories     []SkillCategory `json:"skill_categories"`
-	TopInterests        []string        `json:"top_interests,omitempty"`
-	TopStrengths        []string        `json:"top_strengths,omitempty"`
-	Completeness        float64         `json:"completeness"`
-	EndorsementCount    int             `json:"endorsement_count"`
-	VisibleEndorsements []interface{}   `json:"visible_endorsements,omitempty"`
+	UserID                   uuid.UUID           `json:"user_id"`
+	DisplayName              string              `json:"display_name"`
+	SkillCategories          []SkillCategory     `json:"skill_categories"`
+	TopInterests             []string            `json:"top_interests,omitempty"`
+	TopStrengths             []string            `json:"top_strengths,omitempty"`
+	Completeness             float64             `json:"completeness"`
+	EndorsementCount         int                 `json:"endorsement_count"`
+	VerifiedEndorsementCount int                 `json:"verified_endorsement_count"`
+	VisibleEndorsements      []PublicEndorsement `json:"visible_endorsements,omitempty"`
+	Credentials              []PublicCredential  `json:"credentials,omitempty"`
+}
+
+// PublicEndorsement is a visible endorsement on the public profile. Verified
+// endorsers confirmed their address with a one-time code; Organization is set
+// if the address belongs to a verified organisation domain.
+type PublicEndorsement struct {
+	EndorserName string    `json:"endorser_name"`
+	EndorserRole string    `json:"endorser_role"`
+	Verified     bool      `json:"endorser_verified"`
+	Organization string    `json:"endorser_organization,omitempty"`
+	Statement    string    `json:"statement"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// PublicCredential is an active, unexpired credential shown on the public
+// profile. The full document is served by the credential verify endpoint.
+type PublicCredential struct {
+	ID         uuid.UUID  `json:"id"`
+	Type       string     `json:"credential_type"`
+	Name       string     `json:"name"`
+	IssuerName string     `json:"issuer_name,omitempty"`
+	IssuerURL  string     `json:"issuer_url,omitempty"`
+	IssuedAt   time.Time  `json:"issued_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
 }
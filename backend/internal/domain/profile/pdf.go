@@ -0,0 +1,242 @@
+package profile
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/google/uuid"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Limits for the PDF export so the document stays at a few pages.
+const (
+	maxPDFEndorsements = 5
+	maxPDFEvidence     = 8
+)
+
+// ExportData is everything beyond the SkillProfile that the PDF renders.
+type ExportData struct {
+	DisplayName  string
+	Endorsements []ExportEndorsement
+	Evidence     []ExportEvidence
+	Brand        *Brand
+}
+
+// ExportEndorsement is a visible endorsement selected for export.
+type ExportEndorsement struct {
+	EndorserName string
+	EndorserRole string
+	Verified     bool
+	Organization string
+	Statement    string
+	CreatedAt    time.Time
+}
+
+// ExportEvidence is an evidence entry with its verification token, used to
+// build the QR code link.
+type ExportEvidence struct {
+	ID                uuid.UUID
+	Summary           string
+	VerificationToken string
+	CreatedAt         time.Time
+}
+
+// Brand is the partner styling applied to the export when the learner came
+// through a partner brand.
+type Brand struct {
+	Slug         string
+	Name         string
+	PrimaryColor string
+	AccentColor  string
+	SponsorLabel string
+}
+
+// defaultBrand is used for learners without partner attribution.
+var defaultBrand = Brand{Name: "Future SkillR", PrimaryColor: "#1e293b", AccentColor: "#6366f1"}
+
+// EvidenceVerifyURL builds the public verification link for an evidence entry.
+func EvidenceVerifyURL(baseURL string, id uuid.UUID, token string) string {
+	u := strings.TrimRight(baseURL, "/") + "/api/v1/portfolio/evidence/verify/" + id.String()
+	if token != "" {
+		u += "?token=" + token
+	}
+	return u
+}
+
+// RenderPDF renders the profile as an A4 PDF. baseURL is used for the
+// evidence verification links encoded in the QR codes.
+func RenderPDF(p *SkillProfile, data *ExportData, baseURL string) ([]byte, error) {
+	brand := defaultBrand
+	if data.Brand != nil {
+		brand = *data.Brand
+		if brand.Name == "" {
+			brand.Name = defaultBrand.Name
+		}
+	}
+	pr, pg, pb := hexColor(brand.PrimaryColor, defaultBrand.PrimaryColor)
+	ar, ag, ab := hexColor(brand.AccentColor, defaultBrand.AccentColor)
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("") // cp1252 for German umlauts
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(120, 120, 120)
+		footer := brand.Name
+		if brand.SponsorLabel != "" {
+			footer = brand.SponsorLabel
+		}
+		pdf.CellFormat(150, 5, tr(footer), "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 5, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	pdf.AddPage()
+
+	// Header band
+	pdf.SetFillColor(pr, pg, pb)
+	pdf.Rect(0, 0, 210, 32, "F")
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetXY(15, 8)
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 9, tr("Kompetenzprofil"), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	name := data.DisplayName
+	if name == "" {
+		name = "SkillR Learner"
+	}
+	pdf.CellFormat(0, 6, tr(fmt.Sprintf("%s  ·  %s  ·  Stand %s", name, brand.Name, p.LastComputedAt.Format("02.01.2006"))), "", 1, "L", false, 0, "")
+	pdf.SetY(40)
+
+	heading := func(title string) {
+		pdf.Ln(2)
+		pdf.SetFont("Helvetica", "B", 13)
+		pdf.SetTextColor(pr, pg, pb)
+		pdf.CellFormat(0, 8, tr(title), "", 1, "L", false, 0, "")
+		pdf.SetDrawColor(ar, ag, ab)
+		pdf.Line(15, pdf.GetY(), 195, pdf.GetY())
+		pdf.Ln(3)
+		pdf.SetTextColor(30, 30, 30)
+	}
+
+	// Categories with score bars
+	heading("Kompetenzbereiche")
+	for _, c := range p.SkillCategories {
+		pdf.SetFont("Helvetica", "", 10)
+		label := c.Label
+		if label == "" {
+			label = c.Key
+		}
+		y := pdf.GetY()
+		pdf.CellFormat(55, 6, tr(label), "", 0, "L", false, 0, "")
+		pdf.SetFillColor(230, 230, 235)
+		pdf.Rect(72, y+1.5, 100, 3.5, "F")
+		pdf.SetFillColor(ar, ag, ab)
+		if w := c.Score; w > 0 {
+			pdf.Rect(72, y+1.5, min(w, 100), 3.5, "F")
+		}
+		pdf.SetX(176)
+		pdf.CellFormat(19, 6, strconv.FormatFloat(c.Score, 'f', 0, 64), "", 1, "R", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 6, tr(fmt.Sprintf("Vollständigkeit des Profils: %.0f %%", p.Completeness*100)), "", 1, "L", false, 0, "")
+
+	// Strengths and interests
+	if len(p.TopStrengths) > 0 || len(p.TopInterests) > 0 {
+		heading("Stärken und Interessen")
+		pdf.SetFont("Helvetica", "", 10)
+		if len(p.TopStrengths) > 0 {
+			pdf.MultiCell(0, 6, tr("Stärken: "+strings.Join(p.TopStrengths, ", ")), "", "L", false)
+		}
+		if len(p.TopInterests) > 0 {
+			pdf.MultiCell(0, 6, tr("Interessen: "+strings.Join(p.TopInterests, ", ")), "", "L", false)
+		}
+	}
+
+	// Endorsements
+	if len(data.Endorsements) > 0 {
+		heading("Bestätigungen")
+		for i, e := range data.Endorsements {
+			if i >= maxPDFEndorsements {
+				break
+			}
+			pdf.SetFont("Helvetica", "B", 10)
+			who := e.EndorserName
+			if e.EndorserRole != "" {
+				who += " (" + e.EndorserRole + ")"
+			}
+			if e.Organization != "" {
+				who += ", " + e.Organization
+			}
+			if e.Verified {
+				who += "  - verifiziert"
+			}
+			pdf.CellFormat(0, 6, tr(who), "", 1, "L", false, 0, "")
+			pdf.SetFont("Helvetica", "I", 9)
+			pdf.MultiCell(0, 5, tr("\""+e.Statement+"\""), "", "L", false)
+			pdf.Ln(2)
+		}
+	}
+
+	// Evidence with QR codes
+	if len(data.Evidence) > 0 {
+		heading("Nachweise")
+		const qrSize = 24.0
+		for i, ev := range data.Evidence {
+			if i >= maxPDFEvidence {
+				break
+			}
+			link := EvidenceVerifyURL(baseURL, ev.ID, ev.VerificationToken)
+			png, err := qrcode.Encode(link, qrcode.Medium, 256)
+			if err != nil {
+				return nil, fmt.Errorf("encode evidence QR: %w", err)
+			}
+
+			if pdf.GetY()+qrSize > 277 {
+				pdf.AddPage()
+			}
+			y := pdf.GetY()
+			imgName := "qr-" + ev.ID.String()
+			pdf.RegisterImageOptionsReader(imgName, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+			pdf.ImageOptions(imgName, 15, y, qrSize, qrSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, link)
+
+			pdf.SetXY(15+qrSize+4, y+1)
+			pdf.SetFont("Helvetica", "", 10)
+			pdf.MultiCell(0, 5, tr(ev.Summary), "", "L", false)
+			pdf.SetX(15 + qrSize + 4)
+			pdf.SetFont("Helvetica", "", 8)
+			pdf.SetTextColor(100, 100, 100)
+			pdf.CellFormat(0, 5, tr("Erstellt am "+ev.CreatedAt.Format("02.01.2006")+"  ·  Nachweis-ID "+ev.ID.String()), "", 1, "L", false, 0, "")
+			pdf.SetTextColor(30, 30, 30)
+			pdf.SetY(max(pdf.GetY(), y+qrSize) + 3)
+		}
+	}
+
+	if err := pdf.Error(); err != nil {
+		return nil, fmt.Errorf("render pdf: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("write pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// hexColor parses "#rrggbb", falling back to def for invalid input.
+func hexColor(s, def string) (int, int, int) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) != 6 {
+		s = strings.TrimPrefix(def, "#")
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		v, _ = strconv.ParseUint(strings.TrimPrefix(def, "#"), 16, 32)
+	}
+	return int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff)
+}
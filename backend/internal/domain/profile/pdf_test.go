@@ -0,0 +1,93 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRenderPDF(t *testing.T) {
+	p := &SkillProfile{
+		ID:     uuid.New(),
+		UserID: uuid.New(),
+		SkillCategories: []SkillCategory{
+			{Key: "soft-skills", Label: "Sozialkompetenzen", Score: 72},
+			{Key: "resilience", Label: "Resilienz", Score: 0},
+		},
+		TopStrengths:   []string{"teamwork"},
+		TopInterests:   []string{"Technik", "Natur"},
+		Completeness:   0.6,
+		LastComputedAt: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	data := &ExportData{
+		DisplayName: "Jörg Müller",
+		Endorsements: []ExportEndorsement{
+			{EndorserName: "Frau Schäfer", EndorserRole: "teacher", Verified: true, Statement: "Übernimmt Verantwortung im Team."},
+		},
+		Evidence: []ExportEvidence{
+			{ID: uuid.New(), Summary: "Hat ein Teamprojekt geleitet.", VerificationToken: "abc", CreatedAt: time.Now()},
+		},
+		Brand: &Brand{Slug: "carls-zukunft", Name: "Carls Zukunft", PrimaryColor: "#2d1b14", AccentColor: "#e4553e", SponsorLabel: "Powered by Carls Zukunft"},
+	}
+
+	out, err := RenderPDF(p, data, "https://skillr.example")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(out, []byte("%PDF-")) {
+		t.Fatalf("expected PDF header, got %q", out[:min(len(out), 8)])
+	}
+	// Link annotations are stored uncompressed, so the verify URL is visible.
+	if !bytes.Contains(out, []byte("/api/v1/portfolio/evidence/verify/"+data.Evidence[0].ID.String()+"?token=abc")) {
+		t.Error("expected evidence verify link in PDF")
+	}
+}
+
+func TestRenderPDF_DefaultBrand(t *testing.T) {
+	p := &SkillProfile{SkillCategories: []SkillCategory{{Key: "hard-skills", Score: 150}}}
+	if _, err := RenderPDF(p, &ExportData{}, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestEvidenceVerifyURL(t *testing.T) {
+	id := uuid.New()
+	got := EvidenceVerifyURL("https://skillr.example/", id, "")
+	if got != "https://skillr.example/api/v1/portfolio/evidence/verify/"+id.String() {
+		t.Errorf("unexpected URL %s", got)
+	}
+}
+
+func TestHexColor(t *testing.T) {
+	if r, g, b := hexColor("#e4553e", "#000000"); r != 0xe4 || g != 0x55 || b != 0x3e {
+		t.Errorf("unexpected color %d,%d,%d", r, g, b)
+	}
+	if r, g, b := hexColor("nope", "#102030"); r != 0x10 || g != 0x20 || b != 0x30 {
+		t.Errorf("expected fallback color, got %d,%d,%d", r, g, b)
+	}
+}
+
+func TestService_ExportPDF(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	userID := uuid.New()
+	if _, err := svc.ExportPDF(context.Background(), userID, ""); !errors.Is(err, ErrNoProfile) {
+		t.Errorf("export without profile = %v, want ErrNoProfile", err)
+	}
+	if _, err := svc.Compute(context.Background(), userID); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := svc.ExportPDF(context.Background(), userID, "http://localhost")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(string(out), "%PDF-") {
+		t.Error("expected PDF output")
+	}
+}
@@ -2,13 +2,28 @@ package profile
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Repository interface {
+	// GetLatest returns the user's latest profile, or ErrNoProfile.
 	GetLatest(ctx context.Context, userID uuid.UUID) (*SkillProfile, error)
 	Create(ctx context.Context, p *SkillProfile) error
 	GetHistory(ctx context.Context, userID uuid.UUID, limit, offset int) ([]SkillProfile, int, error)
 	GetPublic(ctx context.Context, userID uuid.UUID) (*PublicProfile, error)
+	// LoadSignals collects the reflections, evidence, endorsements, artifacts
+	// and interactions the computation engine aggregates.
+	LoadSignals(ctx context.Context, userID uuid.UUID) (*Signals, error)
+	// GetRange returns the newest limit snapshots computed within [from, to],
+	// oldest first.
+	GetRange(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]SkillProfile, error)
+	GetByID(ctx context.Context, userID, id uuid.UUID) (*SkillProfile, error)
+	// ListContributions returns evidence, reflections and endorsements
+	// created in (since, until].
+	ListContributions(ctx context.Context, userID uuid.UUID, since, until time.Time) ([]Contribution, error)
+	// LoadExportData returns the display name, visible endorsements,
+	// verifiable evidence and partner brand used by the PDF export.
+	LoadExportData(ctx context.Context, userID uuid.UUID) (*ExportData, error)
 }
@@ -2,38 +2,78 @@ package profile
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/resume"
 )
 
+// ErrNoProfile is returned when the user has no computed profile yet.
+var ErrNoProfile = errors.New("no profile found")
+
 type Service struct {
-	repo Repository
+	repo   Repository
+	mu     sync.RWMutex
+	engine *Engine
 }
 
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, engine: NewEngine(DefaultCategoryTable(), DefaultWeights())}
+}
+
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
+}
+
+// SetCategoryTable replaces the dimension-to-category mapping used by Compute.
+// It is safe to call while profiles are computed.
+func (s *Service) SetCategoryTable(table CategoryTable) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.engine = NewEngine(table, s.engine.weights)
+}
+
+func (s *Service) currentEngine() *Engine {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.engine
 }
 
 func (s *Service) Get(ctx context.Context, userID uuid.UUID) (*SkillProfile, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 	return s.repo.GetLatest(ctx, userID)
 }
 
 func (s *Service) Compute(ctx context.Context, userID uuid.UUID) (*SkillProfile, error) {
-	// Stub computation — will use VertexAI Profile Computation Agent in Phase 4
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	signals, err := s.repo.LoadSignals(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("load profile signals: %w", err)
+	}
+	result := s.currentEngine().Compute(*signals)
+
+	now := time.Now().UTC()
 	profile := &SkillProfile{
-		ID:     uuid.New(),
-		UserID: userID,
-		SkillCategories: []SkillCategory{
-			{Key: "hard-skills", Label: "Fachkompetenzen", Score: 0, ContributingDimensions: []string{"analytical-thinking", "problem-solving"}},
-			{Key: "soft-skills", Label: "Sozialkompetenzen", Score: 0, ContributingDimensions: []string{"teamwork", "communication"}},
-			{Key: "future-skills", Label: "Zukunftskompetenzen", Score: 0, ContributingDimensions: []string{"creativity", "initiative"}},
-			{Key: "resilience", Label: "Resilienz", Score: 0, ContributingDimensions: []string{"resilience", "persistence"}},
-		},
-		Completeness:   0,
-		LastComputedAt: time.Now().UTC(),
-		CreatedAt:      time.Now().UTC(),
+		ID:              uuid.New(),
+		UserID:          userID,
+		SkillCategories: result.SkillCategories,
+		DimensionScores: result.DimensionScores,
+		TopInterests:    result.TopInterests,
+		TopStrengths:    result.TopStrengths,
+		Completeness:    result.Completeness,
+		EvidenceSummary: result.EvidenceSummary,
+		LastComputedAt:  now,
+		CreatedAt:       now,
 	}
 
 	if err := s.repo.Create(ctx, profile); err != nil {
@@ -43,14 +83,84 @@ func (s *Service) Compute(ctx context.Context, userID uuid.UUID) (*SkillProfile,
 }
 
 func (s *Service) History(ctx context.Context, userID uuid.UUID, limit, offset int) ([]SkillProfile, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
 	return s.repo.GetHistory(ctx, userID, limit, offset)
 }
 
+// Trend returns chart-ready series for snapshots computed within [from, to].
+func (s *Service) Trend(ctx context.Context, userID uuid.UUID, from, to time.Time) (*Trend, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid range: to is before from")
+	}
+	snapshots, err := s.repo.GetRange(ctx, userID, from, to, maxTrendSnapshots)
+	if err != nil {
+		return nil, fmt.Errorf("load snapshots: %w", err)
+	}
+	return BuildTrend(from, to, snapshots), nil
+}
+
+// Diff compares two of the user's snapshots. The order of the IDs does not
+// matter; the older snapshot is always treated as the starting point.
+func (s *Service) Diff(ctx context.Context, userID, fromID, toID uuid.UUID) (*Diff, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	from, err := s.repo.GetByID(ctx, userID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.repo.GetByID(ctx, userID, toID)
+	if err != nil {
+		return nil, err
+	}
+	if to.LastComputedAt.Before(from.LastComputedAt) {
+		from, to = to, from
+	}
+
+	contributions, err := s.repo.ListContributions(ctx, userID, from.LastComputedAt, to.LastComputedAt)
+	if err != nil {
+		return nil, fmt.Errorf("load contributions: %w", err)
+	}
+	return BuildDiff(s.currentEngine().table, from, to, contributions), nil
+}
+
 func (s *Service) Public(ctx context.Context, userID uuid.UUID) (*PublicProfile, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 	return s.repo.GetPublic(ctx, userID)
 }
 
-func (s *Service) Export(ctx context.Context, userID uuid.UUID, format string) (*SkillProfile, error) {
-	// For now, just return JSON format. PDF generation to be added later.
-	return s.repo.GetLatest(ctx, userID)
+// PublicPerson returns the public profile as a machine-readable person.
+func (s *Service) PublicPerson(ctx context.Context, userID uuid.UUID, baseURL string) (*resume.Person, error) {
+	pub, err := s.Public(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	p := PublicPerson(pub, baseURL)
+	return &p, nil
+}
+
+// Export returns the latest profile for the JSON export.
+func (s *Service) Export(ctx context.Context, userID uuid.UUID) (*SkillProfile, error) {
+	return s.Get(ctx, userID)
+}
+
+// ExportPDF renders the latest profile as a PDF. Evidence QR codes link to
+// the public verification endpoint under baseURL.
+func (s *Service) ExportPDF(ctx context.Context, userID uuid.UUID, baseURL string) ([]byte, error) {
+	p, err := s.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.repo.LoadExportData(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("load export data: %w", err)
+	}
+	return RenderPDF(p, data, baseURL)
 }
@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type mockRepo struct {
-	profiles map[uuid.UUID]*SkillProfile
+	profiles      map[uuid.UUID]*SkillProfile
+	signals       *Signals
+	snapshots     []SkillProfile
+	contributions []Contribution
 }
 
 func newMockRepo() *mockRepo {
@@ -19,7 +23,7 @@ func newMockRepo() *mockRepo {
 func (m *mockRepo) GetLatest(ctx context.Context, userID uuid.UUID) (*SkillProfile, error) {
 	p, ok := m.profiles[userID]
 	if !ok {
-		return nil, fmt.Errorf("not found")
+		return nil, ErrNoProfile
 	}
 	return p, nil
 }
@@ -41,6 +45,49 @@ func (m *mockRepo) GetPublic(ctx context.Context, userID uuid.UUID) (*PublicProf
 	return nil, fmt.Errorf("not found")
 }
 
+func (m *mockRepo) LoadSignals(ctx context.Context, userID uuid.UUID) (*Signals, error) {
+	if m.signals == nil {
+		return &Signals{}, nil
+	}
+	return m.signals, nil
+}
+
+func (m *mockRepo) GetRange(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]SkillProfile, error) {
+	var out []SkillProfile
+	for _, p := range m.snapshots {
+		if p.UserID == userID && !p.LastComputedAt.Before(from) && !p.LastComputedAt.After(to) {
+			out = append(out, p)
+		}
+	}
+	if len(out) > limit {
+		out = out[len(out)-limit:]
+	}
+	return out, nil
+}
+
+func (m *mockRepo) GetByID(ctx context.Context, userID, id uuid.UUID) (*SkillProfile, error) {
+	for i := range m.snapshots {
+		if m.snapshots[i].ID == id && m.snapshots[i].UserID == userID {
+			return &m.snapshots[i], nil
+		}
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func (m *mockRepo) ListContributions(ctx context.Context, userID uuid.UUID, since, until time.Time) ([]Contribution, error) {
+	var out []Contribution
+	for _, c := range m.contributions {
+		if c.CreatedAt.After(since) && !c.CreatedAt.After(until) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRepo) LoadExportData(ctx context.Context, userID uuid.UUID) (*ExportData, error) {
+	return &ExportData{DisplayName: "Test Learner"}, nil
+}
+
 func TestService_Compute(t *testing.T) {
 	repo := newMockRepo()
 	svc := NewService(repo)
@@ -73,3 +120,40 @@ func TestService_GetAfterCompute(t *testing.T) {
 		t.Errorf("expected user ID %s, got %s", userID, profile.UserID)
 	}
 }
+
+func TestService_ComputeAggregatesSignals(t *testing.T) {
+	repo := newMockRepo()
+	repo.signals = &Signals{
+		Reflections: []ReflectionSignal{{Scores: map[string]float64{"creativity": 80, "analytical_depth": 60}}},
+		Evidence:    []EvidenceSignal{{Dimensions: map[string]float64{"teamwork": 90}, Confidence: 0.8, Interests: []string{"Technik"}}},
+	}
+	svc := NewService(repo)
+	userID := uuid.New()
+
+	p, err := svc.Compute(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.DimensionScores["creativity"] != 80 {
+		t.Errorf("expected creativity 80, got %v", p.DimensionScores["creativity"])
+	}
+	if p.DimensionScores["analytical-thinking"] != 60 {
+		t.Errorf("expected alias analytical_depth -> analytical-thinking, got %v", p.DimensionScores)
+	}
+	if len(p.TopInterests) != 1 || p.TopInterests[0] != "Technik" {
+		t.Errorf("expected top interest Technik, got %v", p.TopInterests)
+	}
+	if p.EvidenceSummary == nil || p.EvidenceSummary.TotalReflections != 1 {
+		t.Errorf("expected evidence summary with 1 reflection, got %+v", p.EvidenceSummary)
+	}
+	if p.Completeness <= 0 {
+		t.Errorf("expected completeness > 0, got %v", p.Completeness)
+	}
+}
+
+func TestService_ComputeNilRepo(t *testing.T) {
+	svc := NewService(nil)
+	if _, err := svc.Compute(context.Background(), uuid.New()); err == nil {
+		t.Fatal("expected error with nil repo")
+	}
+}
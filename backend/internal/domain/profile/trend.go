@@ -0,0 +1,232 @@
+package profile
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxTrendSnapshots caps the number of snapshots loaded for one trend query.
+const maxTrendSnapshots = 500
+
+// Trend is a chart-ready time series over profile snapshots. Every series
+// has one value per entry in Timestamps; a nil value means the snapshot has
+// no score for that key.
+type Trend struct {
+	From         time.Time     `json:"from"`
+	To           time.Time     `json:"to"`
+	Timestamps   []time.Time   `json:"timestamps"`
+	Completeness []float64     `json:"completeness"`
+	Categories   []TrendSeries `json:"categories"`
+	Dimensions   []TrendSeries `json:"dimensions"`
+}
+
+// TrendSeries is the values of one category or dimension over time.
+type TrendSeries struct {
+	Key    string     `json:"key"`
+	Label  string     `json:"label,omitempty"`
+	Values []*float64 `json:"values"`
+	// Delta is the change between the first and last non-nil value.
+	Delta float64 `json:"delta"`
+}
+
+// ContributionType identifies the source of a profile change.
+type ContributionType string
+
+const (
+	ContributionEvidence    ContributionType = "evidence"
+	ContributionReflection  ContributionType = "reflection"
+	ContributionEndorsement ContributionType = "endorsement"
+)
+
+// Contribution is one piece of evidence, reflection or endorsement added
+// between two snapshots.
+type Contribution struct {
+	Type       ContributionType   `json:"type"`
+	ID         uuid.UUID          `json:"id"`
+	Summary    string             `json:"summary"`
+	Dimensions map[string]float64 `json:"dimensions,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// SnapshotRef identifies a snapshot in a diff.
+type SnapshotRef struct {
+	ID             uuid.UUID `json:"id"`
+	LastComputedAt time.Time `json:"last_computed_at"`
+	Completeness   float64   `json:"completeness"`
+}
+
+// ScoreChange describes how one category or dimension moved between two
+// snapshots, together with the contributions that touched it.
+type ScoreChange struct {
+	Key    string         `json:"key"`
+	Label  string         `json:"label,omitempty"`
+	From   float64        `json:"from"`
+	To     float64        `json:"to"`
+	Delta  float64        `json:"delta"`
+	Moved  bool           `json:"moved"`
+	Causes []Contribution `json:"causes"`
+}
+
+// Diff compares two snapshots.
+type Diff struct {
+	From              SnapshotRef    `json:"from"`
+	To                SnapshotRef    `json:"to"`
+	CompletenessDelta float64        `json:"completeness_delta"`
+	Categories        []ScoreChange  `json:"categories"`
+	Dimensions        []ScoreChange  `json:"dimensions"`
+	Contributions     []Contribution `json:"contributions"`
+}
+
+// BuildTrend turns snapshots (any order) into aligned chart series.
+func BuildTrend(from, to time.Time, snapshots []SkillProfile) *Trend {
+	sorted := make([]SkillProfile, len(snapshots))
+	copy(sorted, snapshots)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].LastComputedAt.Before(sorted[j].LastComputedAt) })
+
+	t := &Trend{
+		From:         from,
+		To:           to,
+		Timestamps:   make([]time.Time, len(sorted)),
+		Completeness: make([]float64, len(sorted)),
+	}
+
+	catLabels := map[string]string{}
+	var catOrder, dimOrder []string
+	catValues := map[string][]*float64{}
+	dimValues := map[string][]*float64{}
+
+	for i, p := range sorted {
+		t.Timestamps[i] = p.LastComputedAt
+		t.Completeness[i] = p.Completeness
+		for _, c := range p.SkillCategories {
+			if _, ok := catValues[c.Key]; !ok {
+				catValues[c.Key] = make([]*float64, len(sorted))
+				catOrder = append(catOrder, c.Key)
+			}
+			catLabels[c.Key] = c.Label
+			v := c.Score
+			catValues[c.Key][i] = &v
+		}
+		for dim, score := range p.DimensionScores {
+			if _, ok := dimValues[dim]; !ok {
+				dimValues[dim] = make([]*float64, len(sorted))
+				dimOrder = append(dimOrder, dim)
+			}
+			v := score
+			dimValues[dim][i] = &v
+		}
+	}
+	sort.Strings(dimOrder)
+
+	t.Categories = make([]TrendSeries, 0, len(catOrder))
+	for _, key := range catOrder {
+		t.Categories = append(t.Categories, newSeries(key, catLabels[key], catValues[key]))
+	}
+	t.Dimensions = make([]TrendSeries, 0, len(dimOrder))
+	for _, key := range dimOrder {
+		t.Dimensions = append(t.Dimensions, newSeries(key, "", dimValues[key]))
+	}
+	return t
+}
+
+func newSeries(key, label string, values []*float64) TrendSeries {
+	var first, last *float64
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		if first == nil {
+			first = v
+		}
+		last = v
+	}
+	s := TrendSeries{Key: key, Label: label, Values: values}
+	if first != nil {
+		s.Delta = round2(*last - *first)
+	}
+	return s
+}
+
+// BuildDiff compares two snapshots and attributes each change to the
+// contributions whose dimensions map onto the changed category or dimension.
+func BuildDiff(table CategoryTable, from, to *SkillProfile, contributions []Contribution) *Diff {
+	d := &Diff{
+		From:              SnapshotRef{ID: from.ID, LastComputedAt: from.LastComputedAt, Completeness: from.Completeness},
+		To:                SnapshotRef{ID: to.ID, LastComputedAt: to.LastComputedAt, Completeness: to.Completeness},
+		CompletenessDelta: round2(to.Completeness - from.Completeness),
+		Contributions:     contributions,
+	}
+	if d.Contributions == nil {
+		d.Contributions = []Contribution{}
+	}
+
+	// Index contributions by canonical dimension and by category.
+	byDim := map[string][]Contribution{}
+	byCat := map[string][]Contribution{}
+	for _, c := range contributions {
+		seenCat := map[string]bool{}
+		for key := range c.Dimensions {
+			dim := table.canonical(key)
+			byDim[dim] = append(byDim[dim], c)
+			for _, cat := range table.categoriesFor(dim) {
+				if !seenCat[cat] {
+					seenCat[cat] = true
+					byCat[cat] = append(byCat[cat], c)
+				}
+			}
+		}
+	}
+
+	fromCats := map[string]SkillCategory{}
+	for _, c := range from.SkillCategories {
+		fromCats[c.Key] = c
+	}
+	seen := map[string]bool{}
+	for _, c := range to.SkillCategories {
+		seen[c.Key] = true
+		d.Categories = append(d.Categories, newChange(c.Key, c.Label, fromCats[c.Key].Score, c.Score, byCat[c.Key]))
+	}
+	for _, c := range from.SkillCategories {
+		if !seen[c.Key] {
+			d.Categories = append(d.Categories, newChange(c.Key, c.Label, c.Score, 0, byCat[c.Key]))
+		}
+	}
+
+	dims := map[string]bool{}
+	for k := range from.DimensionScores {
+		dims[k] = true
+	}
+	for k := range to.DimensionScores {
+		dims[k] = true
+	}
+	keys := make([]string, 0, len(dims))
+	for k := range dims {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		d.Dimensions = append(d.Dimensions, newChange(k, "", from.DimensionScores[k], to.DimensionScores[k], byDim[k]))
+	}
+	// Largest movements first for dimensions; categories keep table order.
+	sort.SliceStable(d.Dimensions, func(i, j int) bool {
+		return math.Abs(d.Dimensions[i].Delta) > math.Abs(d.Dimensions[j].Delta)
+	})
+	if d.Categories == nil {
+		d.Categories = []ScoreChange{}
+	}
+	if d.Dimensions == nil {
+		d.Dimensions = []ScoreChange{}
+	}
+	return d
+}
+
+func newChange(key, label string, from, to float64, causes []Contribution) ScoreChange {
+	delta := round2(to - from)
+	if causes == nil {
+		causes = []Contribution{}
+	}
+	return ScoreChange{Key: key, Label: label, From: from, To: to, Delta: delta, Moved: delta != 0, Causes: causes}
+}
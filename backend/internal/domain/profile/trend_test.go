@@ -0,0 +1,115 @@
+package profile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func snapshot(userID uuid.UUID, at time.Time, future, teamwork float64) SkillProfile {
+	return SkillProfile{
+		ID:     uuid.New(),
+		UserID: userID,
+		SkillCategories: []SkillCategory{
+			{Key: "future-skills", Label: "Zukunftskompetenzen", Score: future},
+			{Key: "soft-skills", Label: "Sozialkompetenzen", Score: teamwork},
+		},
+		DimensionScores: map[string]float64{"creativity": future, "teamwork": teamwork},
+		Completeness:    0.5,
+		LastComputedAt:  at,
+	}
+}
+
+func TestBuildTrend_AlignsSeries(t *testing.T) {
+	userID := uuid.New()
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	s1 := snapshot(userID, base, 40, 50)
+	s2 := snapshot(userID, base.Add(24*time.Hour), 60, 50)
+	delete(s1.DimensionScores, "teamwork")
+
+	// Input order is newest first, as returned by GetHistory.
+	trend := BuildTrend(base, base.Add(48*time.Hour), []SkillProfile{s2, s1})
+
+	if len(trend.Timestamps) != 2 || !trend.Timestamps[0].Equal(base) {
+		t.Fatalf("expected timestamps sorted ascending, got %v", trend.Timestamps)
+	}
+	if len(trend.Categories) != 2 || trend.Categories[0].Key != "future-skills" {
+		t.Fatalf("unexpected categories: %+v", trend.Categories)
+	}
+	if trend.Categories[0].Delta != 20 {
+		t.Errorf("expected future-skills delta 20, got %v", trend.Categories[0].Delta)
+	}
+
+	var teamwork TrendSeries
+	for _, d := range trend.Dimensions {
+		if d.Key == "teamwork" {
+			teamwork = d
+		}
+	}
+	if len(teamwork.Values) != 2 || teamwork.Values[0] != nil || *teamwork.Values[1] != 50 {
+		t.Errorf("expected teamwork [nil, 50], got %v", teamwork.Values)
+	}
+}
+
+func TestBuildDiff_AttributesContributions(t *testing.T) {
+	userID := uuid.New()
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	from := snapshot(userID, base, 40, 50)
+	to := snapshot(userID, base.Add(time.Hour), 70, 50)
+	contrib := Contribution{Type: ContributionReflection, ID: uuid.New(), Dimensions: map[string]float64{"creativity": 90}}
+
+	diff := BuildDiff(DefaultCategoryTable(), &from, &to, []Contribution{contrib})
+
+	var future, soft ScoreChange
+	for _, c := range diff.Categories {
+		switch c.Key {
+		case "future-skills":
+			future = c
+		case "soft-skills":
+			soft = c
+		}
+	}
+	if !future.Moved || future.Delta != 30 {
+		t.Errorf("expected future-skills to move by 30, got %+v", future)
+	}
+	if len(future.Causes) != 1 || future.Causes[0].ID != contrib.ID {
+		t.Errorf("expected reflection as cause, got %+v", future.Causes)
+	}
+	if soft.Moved || len(soft.Causes) != 0 {
+		t.Errorf("expected soft-skills unchanged without causes, got %+v", soft)
+	}
+	if diff.Dimensions[0].Key != "creativity" {
+		t.Errorf("expected largest dimension change first, got %s", diff.Dimensions[0].Key)
+	}
+}
+
+func TestService_DiffOrdersSnapshots(t *testing.T) {
+	repo := newMockRepo()
+	userID := uuid.New()
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	older := snapshot(userID, base, 40, 50)
+	newer := snapshot(userID, base.Add(time.Hour), 70, 50)
+	repo.snapshots = []SkillProfile{older, newer}
+	repo.contributions = []Contribution{
+		{Type: ContributionEvidence, ID: uuid.New(), Dimensions: map[string]float64{"creativity": 90}, CreatedAt: base.Add(30 * time.Minute)},
+		{Type: ContributionEvidence, ID: uuid.New(), Dimensions: map[string]float64{"creativity": 90}, CreatedAt: base.Add(-time.Hour)},
+	}
+	svc := NewService(repo)
+
+	diff, err := svc.Diff(context.Background(), userID, newer.ID, older.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.From.ID != older.ID {
+		t.Errorf("expected older snapshot as from")
+	}
+	if len(diff.Contributions) != 1 {
+		t.Errorf("expected 1 contribution in range, got %d", len(diff.Contributions))
+	}
+
+	if _, err := svc.Diff(context.Background(), uuid.New(), older.ID, newer.ID); err == nil {
+		t.Error("expected error for another user's snapshots")
+	}
+}
@@ -0,0 +1,136 @@
+package qr
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"skillr-mvp-v1/backend/internal/domain/endorsement"
+	"skillr-mvp-v1/backend/internal/domain/evidence"
+	"skillr-mvp-v1/backend/internal/middleware"
+)
+
+type Handler struct {
+	svc     *Service
+	baseURL string
+}
+
+// NewHandler creates a Handler. baseURL is the public URL short links and
+// evidence links are built on; it is never taken from the request, whose
+// Host header the client controls.
+func NewHandler(svc *Service, baseURL string) *Handler {
+	return &Handler{svc: svc, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Invite renders a QR code for one of the learner's endorsement invites.
+func (h *Handler) Invite(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid invite ID")
+	}
+	userID := deriveUUID(userInfo.UID)
+	opts, err := h.options(c, userID)
+	if err != nil {
+		return err
+	}
+	code, err := h.svc.InviteCode(c.Request().Context(), userID, id, h.baseURL, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotFound), errors.Is(err, endorsement.ErrInviteNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "invite not found")
+		case errors.Is(err, endorsement.ErrInviteClosed):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to render qr code")
+	}
+	return send(c, code)
+}
+
+// Evidence renders a QR code for the verification link of one of the
+// learner's evidence entries.
+func (h *Handler) Evidence(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid evidence ID")
+	}
+	userID := deriveUUID(userInfo.UID)
+	opts, err := h.options(c, userID)
+	if err != nil {
+		return err
+	}
+	code, err := h.svc.EvidenceCode(c.Request().Context(), userID, id, h.baseURL, opts)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) || errors.Is(err, evidence.ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "evidence not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to render qr code")
+	}
+	return send(c, code)
+}
+
+// Open redirects a scanned code to its target (no auth). The target is
+// only sent in the Location header, which is not logged.
+func (h *Handler) Open(c echo.Context) error {
+	target, err := h.svc.Resolve(c.Request().Context(), c.Param("code"))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrExpired):
+			return echo.NewHTTPError(http.StatusGone, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to open qr code")
+	}
+	c.Response().Header().Set("Cache-Control", "no-store")
+	c.Response().Header().Set("Referrer-Policy", "no-referrer")
+	return c.Redirect(http.StatusFound, target)
+}
+
+// options reads ?format=png|svg, ?size=<px> and ?logo=true.
+func (h *Handler) options(c echo.Context, userID uuid.UUID) (Options, error) {
+	opts := Options{Format: c.QueryParam("format")}
+	if v := c.QueryParam("size"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, echo.NewHTTPError(http.StatusBadRequest, "invalid size")
+		}
+		opts.Size = size
+	}
+	if v := c.QueryParam("logo"); v != "" {
+		withLogo, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, echo.NewHTTPError(http.StatusBadRequest, "invalid logo flag")
+		}
+		if withLogo {
+			opts.Logo = h.svc.BrandLogo(c.Request().Context(), userID)
+		}
+	}
+	if err := normalize(&opts); err != nil {
+		return opts, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return opts, nil
+}
+
+func send(c echo.Context, code *Code) error {
+	h := c.Response().Header()
+	h.Set("Cache-Control", "no-store")
+	h.Set("X-QR-Expires-At", code.ExpiresAt.Format(time.RFC3339))
+	return c.Blob(http.StatusOK, code.ContentType, code.Image)
+}
+
+func deriveUUID(firebaseUID string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceDNS, []byte(firebaseUID))
+}
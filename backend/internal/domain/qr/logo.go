@@ -0,0 +1,79 @@
+package qr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	maxLogoBytes = 512 << 10
+	logoCacheTTL = time.Hour
+)
+
+type cachedLogo struct {
+	logo    *Logo
+	fetched time.Time
+}
+
+// fetchLogo loads a PNG, JPEG or GIF logo. Relative URLs are resolved
+// against the frontend URL; results are cached for an hour.
+func (s *Service) fetchLogo(ctx context.Context, raw string) (*Logo, error) {
+	ref, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid logo URL: %w", err)
+	}
+	if !ref.IsAbs() {
+		base, err := url.Parse(s.logoBase)
+		if err != nil || s.logoBase == "" {
+			return nil, fmt.Errorf("relative logo URL without base URL")
+		}
+		ref = base.ResolveReference(ref)
+	}
+	if ref.Scheme != "http" && ref.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported logo URL scheme %q", ref.Scheme)
+	}
+	key := ref.String()
+
+	s.logoMu.Lock()
+	cached, ok := s.logoCache[key]
+	s.logoMu.Unlock()
+	if ok && s.now().Sub(cached.fetched) < logoCacheTTL {
+		return cached.logo, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.logoClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch logo: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch logo: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxLogoBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read logo: %w", err)
+	}
+	if len(data) > maxLogoBytes {
+		return nil, fmt.Errorf("logo exceeds %d bytes", maxLogoBytes)
+	}
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/png", "image/jpeg", "image/gif":
+	default:
+		return nil, fmt.Errorf("unsupported logo type %s", contentType)
+	}
+	logo := &Logo{Data: data, ContentType: contentType}
+
+	s.logoMu.Lock()
+	s.logoCache[key] = cachedLogo{logo: logo, fetched: s.now()}
+	s.logoMu.Unlock()
+	return logo, nil
+}
@@ -0,0 +1,57 @@
+package qr
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Image formats.
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+const (
+	// LinkTTL is how long a scanned code keeps redirecting.
+	LinkTTL = 15 * time.Minute
+	// DefaultSize is the PNG edge length in pixels.
+	DefaultSize = 512
+	MinSize     = 128
+	MaxSize     = 1024
+)
+
+// Link is a short-lived redirect to Target. Only the hash of its code is
+// stored.
+type Link struct {
+	CodeHash  string
+	OwnerID   uuid.UUID
+	Target    string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Options control rendering.
+type Options struct {
+	Format string
+	// Size is the PNG edge length in pixels; SVGs scale freely.
+	Size int
+	// Logo is placed in the centre; the code then uses the highest error
+	// correction level so it stays readable.
+	Logo *Logo
+}
+
+// Logo is a decoded brand logo with its original bytes (embedded in SVGs).
+type Logo struct {
+	Data        []byte
+	ContentType string
+}
+
+// Code is a rendered QR code.
+type Code struct {
+	Image       []byte
+	ContentType string
+	// URL is the encoded short link, valid until ExpiresAt.
+	URL       string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,136 @@
+package qr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // logo formats
+	_ "image/jpeg"
+	"image/png"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// logoShare is the logo's share of the code's edge length. With the
+// highest error correction level (30%) the covered modules are recovered.
+const logoShare = 0.22
+
+// Render encodes content as a PNG or SVG QR code and returns the image and
+// its content type. opts must be normalized.
+func Render(content string, opts Options) ([]byte, string, error) {
+	level := qrcode.Medium
+	if opts.Logo != nil {
+		level = qrcode.Highest
+	}
+	q, err := qrcode.New(content, level)
+	if err != nil {
+		return nil, "", fmt.Errorf("encode qr code: %w", err)
+	}
+	if opts.Format == FormatSVG {
+		return renderSVG(q.Bitmap(), opts.Logo), "image/svg+xml", nil
+	}
+	out, err := renderPNG(q, opts.Size, opts.Logo)
+	if err != nil {
+		return nil, "", err
+	}
+	return out, "image/png", nil
+}
+
+func renderPNG(q *qrcode.QRCode, size int, logo *Logo) ([]byte, error) {
+	src := q.Image(size)
+	b := src.Bounds()
+	img := image.NewRGBA(b)
+	draw.Draw(img, b, src, b.Min, draw.Src)
+
+	if logo != nil {
+		l, _, err := image.Decode(bytes.NewReader(logo.Data))
+		if err != nil {
+			return nil, fmt.Errorf("decode logo: %w", err)
+		}
+		side := int(float64(b.Dx()) * logoShare)
+		pad := side / 8
+		center := b.Min.Add(image.Pt(b.Dx()/2, b.Dy()/2))
+		box := image.Rect(center.X-side/2-pad, center.Y-side/2-pad, center.X+side/2+pad, center.Y+side/2+pad)
+		draw.Draw(img, box, image.NewUniform(color.White), image.Point{}, draw.Src)
+		drawScaled(img, image.Rect(center.X-side/2, center.Y-side/2, center.X+side/2, center.Y+side/2), l)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// drawScaled draws src into dst's rect r (nearest neighbour), keeping the
+// aspect ratio and centring it.
+func drawScaled(dst draw.Image, r image.Rectangle, src image.Image) {
+	sb := src.Bounds()
+	if sb.Empty() || r.Empty() {
+		return
+	}
+	scale := min(float64(r.Dx())/float64(sb.Dx()), float64(r.Dy())/float64(sb.Dy()))
+	w, h := int(float64(sb.Dx())*scale), int(float64(sb.Dy())*scale)
+	off := image.Pt(r.Min.X+(r.Dx()-w)/2, r.Min.Y+(r.Dy()-h)/2)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := src.At(sb.Min.X+int(float64(x)/scale), sb.Min.Y+int(float64(y)/scale))
+			bg := dst.At(off.X+x, off.Y+y)
+			dst.Set(off.X+x, off.Y+y, over(c, bg))
+		}
+	}
+}
+
+// over composites c over bg (logos are often transparent PNGs).
+func over(c, bg color.Color) color.Color {
+	r, g, b, a := c.RGBA()
+	if a == 0xffff {
+		return c
+	}
+	br, bgG, bb, _ := bg.RGBA()
+	inv := 0xffff - a
+	return color.RGBA64{
+		R: uint16(r + br*inv/0xffff),
+		G: uint16(g + bgG*inv/0xffff),
+		B: uint16(b + bb*inv/0xffff),
+		A: 0xffff,
+	}
+}
+
+// renderSVG draws the bitmap (including the quiet zone) with one unit per
+// module, merging horizontal runs into single path segments.
+func renderSVG(bitmap [][]bool, logo *Logo) []byte {
+	n := len(bitmap)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, n, n)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="#ffffff"/><path fill="#000000" d="`, n, n)
+	for y, row := range bitmap {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+			run := 1
+			for x+run < len(row) && row[x+run] {
+				run++
+			}
+			fmt.Fprintf(&sb, "M%d %dh%dv1h-%dz", x, y, run, run)
+			x += run
+		}
+	}
+	sb.WriteString(`"/>`)
+	if logo != nil {
+		side := float64(n) * logoShare
+		pad := side / 8
+		pos := (float64(n) - side) / 2
+		fmt.Fprintf(&sb, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="#ffffff"/>`, pos-pad, pos-pad, side+2*pad, side+2*pad)
+		fmt.Fprintf(&sb, `<image x="%.2f" y="%.2f" width="%.2f" height="%.2f" href="data:%s;base64,%s"/>`,
+			pos, pos, side, side, logo.ContentType, base64.StdEncoding.EncodeToString(logo.Data))
+	}
+	sb.WriteString(`</svg>`)
+	return []byte(sb.String())
+}
@@ -0,0 +1,19 @@
+package qr
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	CreateLink(ctx context.Context, l *Link) error
+	// GetLink returns the link with the code hash, or nil.
+	GetLink(ctx context.Context, codeHash string) (*Link, error)
+	// DeleteExpired removes links that expired before now.
+	DeleteExpired(ctx context.Context, now time.Time) (int, error)
+	// BrandLogoURL returns the logoUrl of the user's active partner brand,
+	// or "".
+	BrandLogoURL(ctx context.Context, userID uuid.UUID) (string, error)
+}
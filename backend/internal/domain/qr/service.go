@@ -0,0 +1,231 @@
+package qr
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/domain/profile"
+)
+
+var (
+	// ErrNotFound is returned for unknown codes and targets.
+	ErrNotFound = errors.New("qr code not found")
+	// ErrExpired is returned for codes past their expiry.
+	ErrExpired = errors.New("qr code has expired")
+)
+
+// LinkPath is the public redirect path; the code is appended.
+const LinkPath = "/api/v1/q/"
+
+// InviteLinks resolves a learner's endorsement invite to its link
+// (satisfied by *endorsement.Service).
+type InviteLinks interface {
+	InviteLink(ctx context.Context, learnerID, inviteID uuid.UUID) (string, error)
+}
+
+// EvidenceTokens returns the verification token of a learner's evidence
+// entry (satisfied by *evidence.Service).
+type EvidenceTokens interface {
+	VerificationToken(ctx context.Context, userID, id uuid.UUID) (string, error)
+}
+
+type Service struct {
+	repo     Repository
+	invites  InviteLinks
+	evidence EvidenceTokens
+	now      func() time.Time
+
+	logoBase   string
+	logoClient *http.Client
+	logoMu     sync.Mutex
+	logoCache  map[string]cachedLogo
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{
+		repo:       repo,
+		now:        time.Now,
+		logoClient: &http.Client{Timeout: 5 * time.Second},
+		logoCache:  map[string]cachedLogo{},
+	}
+}
+
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
+}
+
+// SetTargets sets the sources of invite links and evidence tokens.
+func (s *Service) SetTargets(invites InviteLinks, evidence EvidenceTokens) {
+	s.invites = invites
+	s.evidence = evidence
+}
+
+// SetLogoBaseURL sets the frontend URL that relative brand logo URLs such
+// as "/icons/app-icon.png" are resolved against.
+func (s *Service) SetLogoBaseURL(u string) {
+	s.logoBase = u
+}
+
+// InviteCode renders a QR code for the learner's endorsement invite.
+// linkBase is the public API URL the short link is built on.
+func (s *Service) InviteCode(ctx context.Context, learnerID, inviteID uuid.UUID, linkBase string, opts Options) (*Code, error) {
+	if s.invites == nil {
+		return nil, ErrNotFound
+	}
+	target, err := s.invites.InviteLink(ctx, learnerID, inviteID)
+	if err != nil {
+		return nil, err
+	}
+	return s.generate(ctx, learnerID, target, linkBase, opts)
+}
+
+// EvidenceCode renders a QR code for the public verification link of the
+// learner's evidence entry.
+func (s *Service) EvidenceCode(ctx context.Context, userID, evidenceID uuid.UUID, linkBase string, opts Options) (*Code, error) {
+	if s.evidence == nil {
+		return nil, ErrNotFound
+	}
+	token, err := s.evidence.VerificationToken(ctx, userID, evidenceID)
+	if err != nil {
+		return nil, err
+	}
+	return s.generate(ctx, userID, profile.EvidenceVerifyURL(linkBase, evidenceID, token), linkBase, opts)
+}
+
+// generate stores a short link to target and renders it. The code itself
+// is only part of the image.
+func (s *Service) generate(ctx context.Context, ownerID uuid.UUID, target, linkBase string, opts Options) (*Code, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	if err := normalize(&opts); err != nil {
+		return nil, err
+	}
+	code, err := generateCode()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().UTC()
+	link := &Link{
+		CodeHash:  hashCode(code),
+		OwnerID:   ownerID,
+		Target:    target,
+		CreatedAt: now,
+		ExpiresAt: now.Add(LinkTTL),
+	}
+	if err := s.repo.CreateLink(ctx, link); err != nil {
+		return nil, fmt.Errorf("store qr link: %w", err)
+	}
+	url := strings.TrimRight(linkBase, "/") + LinkPath + code
+	img, contentType, err := Render(url, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Code{Image: img, ContentType: contentType, URL: url, ExpiresAt: link.ExpiresAt}, nil
+}
+
+// Resolve returns the target of an unexpired code.
+func (s *Service) Resolve(ctx context.Context, code string) (string, error) {
+	if s.repo == nil {
+		return "", fmt.Errorf("database not available")
+	}
+	if code == "" {
+		return "", ErrNotFound
+	}
+	link, err := s.repo.GetLink(ctx, hashCode(code))
+	if err != nil {
+		return "", fmt.Errorf("get qr link: %w", err)
+	}
+	if link == nil {
+		return "", ErrNotFound
+	}
+	if !s.now().Before(link.ExpiresAt) {
+		return "", ErrExpired
+	}
+	return link.Target, nil
+}
+
+// BrandLogo returns the logo of the user's partner brand, or nil if the
+// user has none or it cannot be loaded.
+func (s *Service) BrandLogo(ctx context.Context, userID uuid.UUID) *Logo {
+	if s.repo == nil {
+		return nil
+	}
+	raw, err := s.repo.BrandLogoURL(ctx, userID)
+	if err != nil {
+		log.Printf("[qr] brand logo lookup: %v", err)
+		return nil
+	}
+	if raw == "" {
+		return nil
+	}
+	logo, err := s.fetchLogo(ctx, raw)
+	if err != nil {
+		log.Printf("[qr] load brand logo: %v", err)
+		return nil
+	}
+	return logo
+}
+
+// Run deletes expired links every interval until ctx is cancelled.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if s.repo != nil {
+			if _, err := s.repo.DeleteExpired(ctx, s.now().UTC()); err != nil {
+				log.Printf("[qr] delete expired links: %v", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func normalize(opts *Options) error {
+	switch opts.Format {
+	case "":
+		opts.Format = FormatPNG
+	case FormatPNG, FormatSVG:
+	default:
+		return fmt.Errorf("format must be %s or %s", FormatPNG, FormatSVG)
+	}
+	if opts.Size == 0 {
+		opts.Size = DefaultSize
+	}
+	if opts.Size < MinSize || opts.Size > MaxSize {
+		return fmt.Errorf("size must be between %d and %d", MinSize, MaxSize)
+	}
+	return nil
+}
+
+// generateCode returns a random URL-safe code. 16 bytes keep the QR code
+// small enough to scan from a phone screen.
+func generateCode() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate code: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,238 @@
+package qr
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"skillr-mvp-v1/backend/internal/domain/evidence"
+)
+
+type mockRepo struct {
+	links   map[string]*Link
+	logoURL string
+}
+
+func newMockRepo() *mockRepo {
+	return &mockRepo{links: map[string]*Link{}}
+}
+
+func (m *mockRepo) CreateLink(_ context.Context, l *Link) error {
+	cp := *l
+	m.links[l.CodeHash] = &cp
+	return nil
+}
+
+func (m *mockRepo) GetLink(_ context.Context, codeHash string) (*Link, error) {
+	return m.links[codeHash], nil
+}
+
+func (m *mockRepo) DeleteExpired(_ context.Context, now time.Time) (int, error) {
+	n := 0
+	for h, l := range m.links {
+		if l.ExpiresAt.Before(now) {
+			delete(m.links, h)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (m *mockRepo) BrandLogoURL(context.Context, uuid.UUID) (string, error) {
+	return m.logoURL, nil
+}
+
+type stubTargets struct{ token string }
+
+func (s stubTargets) InviteLink(context.Context, uuid.UUID, uuid.UUID) (string, error) {
+	return "https://app.example/endorse?token=" + s.token, nil
+}
+
+func (s stubTargets) VerificationToken(_ context.Context, _, id uuid.UUID) (string, error) {
+	if s.token == "" {
+		return "", evidence.ErrNotFound
+	}
+	return s.token, nil
+}
+
+func newTestService() (*Service, *mockRepo) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.SetTargets(stubTargets{token: "secret-token"}, stubTargets{token: "secret-token"})
+	return svc, repo
+}
+
+// codeFromURL returns the short-link code encoded in a QR code.
+func codeFromURL(t *testing.T, u string) string {
+	t.Helper()
+	_, code, ok := strings.Cut(u, LinkPath)
+	if !ok || code == "" {
+		t.Fatalf("unexpected short link %q", u)
+	}
+	return code
+}
+
+func TestInviteCode_EncodesShortLivedLink(t *testing.T) {
+	svc, repo := newTestService()
+	ctx := context.Background()
+
+	code, err := svc.InviteCode(ctx, uuid.New(), uuid.New(), "https://api.example/", Options{})
+	if err != nil {
+		t.Fatalf("InviteCode: %v", err)
+	}
+	if strings.Contains(code.URL, "secret-token") || !strings.HasPrefix(code.URL, "https://api.example"+LinkPath) {
+		t.Errorf("QR code must encode the short link only, got %q", code.URL)
+	}
+	if code.ContentType != "image/png" {
+		t.Errorf("default format: got %s", code.ContentType)
+	}
+	if got := time.Until(code.ExpiresAt); got <= 0 || got > LinkTTL {
+		t.Errorf("expiry %v not within LinkTTL", got)
+	}
+
+	c := codeFromURL(t, code.URL)
+	if _, ok := repo.links[c]; ok {
+		t.Error("the raw code must not be stored")
+	}
+	target, err := svc.Resolve(ctx, c)
+	if err != nil || target != "https://app.example/endorse?token=secret-token" {
+		t.Errorf("Resolve = %q, %v", target, err)
+	}
+
+	svc.now = func() time.Time { return time.Now().Add(LinkTTL + time.Second) }
+	if _, err := svc.Resolve(ctx, c); !errors.Is(err, ErrExpired) {
+		t.Errorf("expired code: got %v, want ErrExpired", err)
+	}
+	if _, err := svc.Resolve(ctx, "unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown code: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestEvidenceCode(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	id := uuid.New()
+
+	code, err := svc.EvidenceCode(ctx, uuid.New(), id, "https://api.example", Options{Format: FormatSVG})
+	if err != nil {
+		t.Fatalf("EvidenceCode: %v", err)
+	}
+	target, _ := svc.Resolve(ctx, codeFromURL(t, code.URL))
+	if target != "https://api.example/api/v1/portfolio/evidence/verify/"+id.String()+"?token=secret-token" {
+		t.Errorf("unexpected target %q", target)
+	}
+
+	svc.SetTargets(nil, stubTargets{})
+	if _, err := svc.EvidenceCode(ctx, uuid.New(), id, "https://api.example", Options{}); !errors.Is(err, evidence.ErrNotFound) {
+		t.Errorf("unknown evidence: got %v", err)
+	}
+	if _, err := svc.InviteCode(ctx, uuid.New(), id, "https://api.example", Options{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("without invite source: got %v", err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	opts := Options{}
+	if err := normalize(&opts); err != nil || opts.Format != FormatPNG || opts.Size != DefaultSize {
+		t.Errorf("defaults: %+v, %v", opts, err)
+	}
+	for _, bad := range []Options{{Format: "gif"}, {Size: MinSize - 1}, {Size: MaxSize + 1}} {
+		if err := normalize(&bad); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func testLogo(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			img.Set(x, y, color.RGBA{R: 0xff, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRender(t *testing.T) {
+	logo := &Logo{Data: testLogo(t), ContentType: "image/png"}
+
+	out, ct, err := Render("https://api.example/api/v1/q/abc", Options{Format: FormatPNG, Size: 256, Logo: logo})
+	if err != nil || ct != "image/png" {
+		t.Fatalf("Render png: %s, %v", ct, err)
+	}
+	img, err := png.Decode(bytes.NewReader(out))
+	if err != nil {
+		t.Fatalf("decode png: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 256 || b.Dy() != 256 {
+		t.Errorf("size = %v, want 256x256", b)
+	}
+	if r, g, _, _ := img.At(128, 128).RGBA(); r != 0xffff || g != 0 {
+		t.Error("expected the logo in the centre")
+	}
+
+	svg, ct, err := Render("https://api.example/api/v1/q/abc", Options{Format: FormatSVG, Logo: logo})
+	if err != nil || ct != "image/svg+xml" {
+		t.Fatalf("Render svg: %s, %v", ct, err)
+	}
+	s := string(svg)
+	if !strings.HasPrefix(s, "<svg ") || !strings.HasSuffix(s, "</svg>") || !strings.Contains(s, `href="data:image/png;base64,`) {
+		t.Errorf("unexpected svg: %.200s", s)
+	}
+}
+
+func TestBrandLogo(t *testing.T) {
+	logo := testLogo(t)
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if r.URL.Path != "/icons/logo.png" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(logo)
+	}))
+	defer srv.Close()
+
+	svc, repo := newTestService()
+	svc.SetLogoBaseURL(srv.URL)
+	ctx := context.Background()
+
+	if svc.BrandLogo(ctx, uuid.New()) != nil {
+		t.Error("users without a brand have no logo")
+	}
+	repo.logoURL = "/icons/logo.png"
+	for i := 0; i < 2; i++ {
+		l := svc.BrandLogo(ctx, uuid.New())
+		if l == nil || l.ContentType != "image/png" {
+			t.Fatalf("BrandLogo = %+v", l)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("logo fetched %d times, want 1 (cached)", hits)
+	}
+
+	repo.logoURL = "/icons/missing.png"
+	if svc.BrandLogo(ctx, uuid.New()) != nil {
+		t.Error("a missing logo must be skipped")
+	}
+	repo.logoURL = "file:///etc/passwd"
+	if svc.BrandLogo(ctx, uuid.New()) != nil {
+		t.Error("non-HTTP logo URLs must be rejected")
+	}
+}
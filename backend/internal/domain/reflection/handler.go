@@ -1,6 +1,7 @@
 package reflection
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -84,6 +85,101 @@ func (h *Handler) Capabilities(c echo.Context) error {
 	return c.JSON(http.StatusOK, scores)
 }
 
+// Next selects the next question for the learner (?station_id=&locale=).
+func (h *Handler) Next(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+
+	userID := getUserUUID(userInfo.UID)
+
+	next, err := h.svc.NextQuestion(c.Request().Context(), userID, c.QueryParam("station_id"), c.QueryParam("locale"))
+	if errors.Is(err, ErrNoQuestions) {
+		return echo.NewHTTPError(http.StatusNotFound, "no question available")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to select question")
+	}
+
+	return c.JSON(http.StatusOK, next)
+}
+
+// AdminListQuestions lists the question bank (?station_id=&capability=&locale=&active=true).
+func (h *Handler) AdminListQuestions(c echo.Context) error {
+	filter := QuestionFilter{
+		StationID:  c.QueryParam("station_id"),
+		Capability: c.QueryParam("capability"),
+		Locale:     c.QueryParam("locale"),
+		ActiveOnly: c.QueryParam("active") == "true",
+	}
+
+	questions, err := h.svc.ListQuestions(c.Request().Context(), filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list questions")
+	}
+	if questions == nil {
+		questions = []Question{}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"questions": questions,
+		"total":     len(questions),
+	})
+}
+
+// AdminCreateQuestion adds a question or a new locale of a question.
+func (h *Handler) AdminCreateQuestion(c echo.Context) error {
+	var req QuestionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	q, err := h.svc.CreateQuestion(c.Request().Context(), req)
+	if errors.Is(err, ErrQuestionExists) || errors.Is(err, ErrQuestionMismatch) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, q)
+}
+
+// AdminUpdateQuestion replaces one locale of a question.
+func (h *Handler) AdminUpdateQuestion(c echo.Context) error {
+	var req QuestionRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	q, err := h.svc.UpdateQuestion(c.Request().Context(), c.Param("id"), c.Param("locale"), req)
+	if errors.Is(err, ErrQuestionNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "question not found")
+	}
+	if errors.Is(err, ErrQuestionMismatch) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, q)
+}
+
+// AdminDeactivateQuestion retires a question in all locales.
+func (h *Handler) AdminDeactivateQuestion(c echo.Context) error {
+	err := h.svc.DeactivateQuestion(c.Request().Context(), c.Param("id"))
+	if errors.Is(err, ErrQuestionNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "question not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to deactivate question")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
 func getUserUUID(firebaseUID string) uuid.UUID {
 	return uuid.NewSHA1(uuid.NameSpaceDNS, []byte(firebaseUID))
 }
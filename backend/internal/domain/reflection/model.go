@@ -6,6 +6,19 @@ import (
 	"github.com/google/uuid"
 )
 
+// Scoring status values for a reflection.
+const (
+	ScoringPending = "pending"
+	ScoringScored  = "scored"
+	ScoringFailed  = "failed"
+)
+
+// Scorers that can produce a reflection's scores.
+const (
+	ScorerAI        = "ai"
+	ScorerHeuristic = "heuristic"
+)
+
 type ReflectionResult struct {
 	ID               uuid.UUID        `json:"id"`
 	UserID           uuid.UUID        `json:"user_id"`
@@ -14,7 +27,25 @@ type ReflectionResult struct {
 	Response         string           `json:"response"`
 	ResponseTimeMs   int              `json:"response_time_ms"`
 	CapabilityScores CapabilityScores `json:"capability_scores"`
+	ScoringStatus    string           `json:"scoring_status"`
+	ScoreRationale   string           `json:"score_rationale,omitempty"`
+	PromptID         string           `json:"prompt_id,omitempty"`
+	PromptVersion    int              `json:"prompt_version,omitempty"`
+	ScoredBy         string           `json:"scored_by,omitempty"`
+	ScoredAt         *time.Time       `json:"scored_at,omitempty"`
 	CreatedAt        time.Time        `json:"created_at"`
+	// ScoringAttempts counts scoring runs that failed transiently.
+	ScoringAttempts int `json:"-"`
+}
+
+// ScoreResult is the outcome of scoring one reflection.
+type ScoreResult struct {
+	Scores        CapabilityScores
+	Rationale     string
+	PromptID      string
+	PromptVersion int
+	// Scorer is ScorerAI or ScorerHeuristic.
+	Scorer string
 }
 
 type CapabilityScores struct {
@@ -38,3 +69,103 @@ type ListParams struct {
 	StationID *string
 	UserID    uuid.UUID
 }
+
+// Capability keys, as used in CapabilityScores.
+const (
+	CapabilityAnalyticalDepth = "analytical_depth"
+	CapabilityCreativity      = "creativity"
+	CapabilityConfidence      = "confidence"
+	CapabilityResilience      = "resilience"
+	CapabilitySelfAwareness   = "self_awareness"
+)
+
+// Capabilities lists all capability keys in display order.
+var Capabilities = []string{
+	CapabilityAnalyticalDepth,
+	CapabilityCreativity,
+	CapabilityConfidence,
+	CapabilityResilience,
+	CapabilitySelfAwareness,
+}
+
+// Get returns the score of a capability key.
+func (c CapabilityScores) Get(capability string) float64 {
+	switch capability {
+	case CapabilityAnalyticalDepth:
+		return c.AnalyticalDepth
+	case CapabilityCreativity:
+		return c.Creativity
+	case CapabilityConfidence:
+		return c.Confidence
+	case CapabilityResilience:
+		return c.Resilience
+	case CapabilitySelfAwareness:
+		return c.SelfAwareness
+	}
+	return 0
+}
+
+// Question difficulty levels.
+const (
+	DifficultyEasy   = 1
+	DifficultyMedium = 2
+	DifficultyHard   = 3
+)
+
+// DefaultLocale is used when a question has no translation in the
+// requested locale.
+const DefaultLocale = "de"
+
+// Question is one locale of a reflection question. A question probes one
+// capability; StationID limits it to one station (nil means any station).
+// Translations share the ID.
+type Question struct {
+	ID         string    `json:"id"`
+	Locale     string    `json:"locale"`
+	StationID  *string   `json:"station_id,omitempty"`
+	Capability string    `json:"capability"`
+	Difficulty int       `json:"difficulty"`
+	Text       string    `json:"question"`
+	FollowUp   string    `json:"follow_up,omitempty"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// QuestionRequest creates or replaces one locale of a question via the
+// admin API.
+type QuestionRequest struct {
+	ID         string  `json:"id"`
+	Locale     string  `json:"locale"`
+	StationID  *string `json:"station_id,omitempty"`
+	Capability string  `json:"capability"`
+	Difficulty int     `json:"difficulty"`
+	Text       string  `json:"question"`
+	FollowUp   string  `json:"follow_up,omitempty"`
+	Active     *bool   `json:"active,omitempty"`
+}
+
+// QuestionFilter narrows a question listing. Empty fields match all.
+type QuestionFilter struct {
+	ID         string
+	StationID  string
+	Capability string
+	Locale     string
+	ActiveOnly bool
+}
+
+// Selection reasons.
+const (
+	ReasonLeastEvidenced = "least_evidenced"
+	ReasonWeakest        = "weakest"
+)
+
+// NextQuestion is the question selected for a learner, with the capability
+// it targets and why.
+type NextQuestion struct {
+	Question      Question `json:"question"`
+	Capability    string   `json:"capability"`
+	Score         float64  `json:"score"`
+	EvidenceCount int      `json:"evidence_count"`
+	Reason        string   `json:"reason"`
+}
@@ -0,0 +1,341 @@
+package reflection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownQuestion  = errors.New("unknown question_id")
+	ErrQuestionNotFound = errors.New("question not found")
+	ErrQuestionExists   = errors.New("question already exists in this locale")
+	ErrQuestionMismatch = errors.New("capability and station_id must match the other locales of the question")
+	ErrNoQuestions      = errors.New("no matching questions")
+)
+
+const (
+	// evidenceTarget is the number of answered questions per capability
+	// below which a capability counts as least-evidenced.
+	evidenceTarget = 3
+	// recentQuestions is how many of the latest answered questions are
+	// not asked again while alternatives exist.
+	recentQuestions = 5
+	maxQuestionLen  = 1000
+)
+
+var (
+	questionIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+	localePattern     = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+)
+
+// validateQuestionID checks that the question exists and is active, and
+// that it may be asked at the station.
+func (s *Service) validateQuestionID(ctx context.Context, questionID, stationID string) error {
+	questions, err := s.repo.ListQuestions(ctx, QuestionFilter{ID: questionID, ActiveOnly: true})
+	if err != nil {
+		return fmt.Errorf("load question: %w", err)
+	}
+	if len(questions) == 0 {
+		return ErrUnknownQuestion
+	}
+	if st := questions[0].StationID; st != nil && *st != stationID {
+		return fmt.Errorf("question %s is not asked at station %s", questionID, stationID)
+	}
+	return nil
+}
+
+// NextQuestion selects the next reflection question for a learner. Capabilities
+// with fewer than evidenceTarget answered questions come first (fewest
+// first), then the capability with the weakest aggregated score. Within the
+// capability, station-specific questions win over general ones, and the
+// difficulty closest to the learner's level is chosen; recently answered
+// questions are skipped while alternatives exist.
+func (s *Service) NextQuestion(ctx context.Context, userID uuid.UUID, stationID, locale string) (*NextQuestion, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	if locale == "" {
+		locale = DefaultLocale
+	}
+
+	scores, err := s.repo.GetAggregatedCapabilities(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate capabilities: %w", err)
+	}
+	answered, err := s.repo.CountAnswered(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("count answered questions: %w", err)
+	}
+	recentIDs, err := s.repo.RecentQuestionIDs(ctx, userID, recentQuestions)
+	if err != nil {
+		return nil, fmt.Errorf("load recent questions: %w", err)
+	}
+	recent := map[string]bool{}
+	for _, id := range recentIDs {
+		recent[id] = true
+	}
+
+	for _, capability := range rankCapabilities(*scores, answered) {
+		questions, err := s.repo.ListQuestions(ctx, QuestionFilter{Capability: capability, ActiveOnly: true})
+		if err != nil {
+			return nil, fmt.Errorf("list questions: %w", err)
+		}
+		score := scores.Get(capability)
+		q := pickQuestion(questions, stationID, locale, targetDifficulty(score, answered[capability]), recent)
+		if q == nil {
+			continue
+		}
+		reason := ReasonWeakest
+		if answered[capability] < evidenceTarget {
+			reason = ReasonLeastEvidenced
+		}
+		return &NextQuestion{
+			Question:      *q,
+			Capability:    capability,
+			Score:         score,
+			EvidenceCount: answered[capability],
+			Reason:        reason,
+		}, nil
+	}
+	return nil, ErrNoQuestions
+}
+
+// rankCapabilities orders capabilities by selection priority.
+func rankCapabilities(scores CapabilityScores, answered map[string]int) []string {
+	ranked := append([]string{}, Capabilities...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		lowA, lowB := answered[a] < evidenceTarget, answered[b] < evidenceTarget
+		if lowA != lowB {
+			return lowA
+		}
+		if lowA && answered[a] != answered[b] {
+			return answered[a] < answered[b]
+		}
+		return scores.Get(a) < scores.Get(b)
+	})
+	return ranked
+}
+
+// targetDifficulty maps a capability score (0-100) to a difficulty level.
+// Unevidenced capabilities start easy.
+func targetDifficulty(score float64, answered int) int {
+	switch {
+	case answered == 0 || score < 40:
+		return DifficultyEasy
+	case score < 70:
+		return DifficultyMedium
+	}
+	return DifficultyHard
+}
+
+// pickQuestion chooses among the active questions of one capability. Each
+// question is taken in locale, falling back to DefaultLocale.
+func pickQuestion(questions []Question, stationID, locale string, difficulty int, recent map[string]bool) *Question {
+	byID := map[string]Question{}
+	for _, q := range questions {
+		if q.StationID != nil && *q.StationID != stationID {
+			continue
+		}
+		if q.Locale != locale && q.Locale != DefaultLocale {
+			continue
+		}
+		if cur, ok := byID[q.ID]; ok && cur.Locale == locale {
+			continue
+		}
+		byID[q.ID] = q
+	}
+
+	var candidates, fresh []Question
+	for _, q := range byID {
+		candidates = append(candidates, q)
+		if !recent[q.ID] {
+			fresh = append(fresh, q)
+		}
+	}
+	if len(fresh) > 0 {
+		candidates = fresh
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	distance := func(q Question) int {
+		d := q.Difficulty - difficulty
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.StationID != nil) != (b.StationID != nil) {
+			return a.StationID != nil
+		}
+		if distance(a) != distance(b) {
+			return distance(a) < distance(b)
+		}
+		return a.ID < b.ID
+	})
+	return &candidates[0]
+}
+
+// ListQuestions returns the question bank for the admin API.
+func (s *Service) ListQuestions(ctx context.Context, filter QuestionFilter) ([]Question, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	return s.repo.ListQuestions(ctx, filter)
+}
+
+// CreateQuestion adds a question or a new locale of an existing question.
+// Active defaults to true for a new question and to the state of the other
+// locales for a translation.
+func (s *Service) CreateQuestion(ctx context.Context, req QuestionRequest) (*Question, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	q, err := validateQuestion(req)
+	if err != nil {
+		return nil, err
+	}
+	others, err := s.otherLocales(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	// A new translation of a retired question stays retired.
+	if req.Active == nil && len(others) > 0 {
+		q.Active = others[0].Active
+	}
+	now := time.Now().UTC()
+	q.CreatedAt, q.UpdatedAt = now, now
+	if err := s.repo.CreateQuestion(ctx, q); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// UpdateQuestion replaces one locale of a question. Active keeps its stored
+// value when omitted.
+func (s *Service) UpdateQuestion(ctx context.Context, id, locale string, req QuestionRequest) (*Question, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	existing, err := s.repo.GetQuestion(ctx, id, locale)
+	if err != nil {
+		return nil, fmt.Errorf("load question: %w", err)
+	}
+	if existing == nil {
+		return nil, ErrQuestionNotFound
+	}
+	req.ID, req.Locale = id, locale
+	if req.Active == nil {
+		req.Active = &existing.Active
+	}
+	q, err := validateQuestion(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.otherLocales(ctx, q); err != nil {
+		return nil, err
+	}
+	q.CreatedAt, q.UpdatedAt = existing.CreatedAt, time.Now().UTC()
+	if err := s.repo.UpdateQuestion(ctx, q); err != nil {
+		return nil, fmt.Errorf("update question: %w", err)
+	}
+	return q, nil
+}
+
+// DeactivateQuestion retires a question in all locales. Reflections keep
+// referring to it, so questions are never deleted.
+func (s *Service) DeactivateQuestion(ctx context.Context, id string) error {
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	ok, err := s.repo.DeactivateQuestion(ctx, id)
+	if err != nil {
+		return fmt.Errorf("deactivate question: %w", err)
+	}
+	if !ok {
+		return ErrQuestionNotFound
+	}
+	return nil
+}
+
+// otherLocales returns the other locales of q. Translations must share the
+// capability and station, which answer counts and station checks read from
+// any one locale.
+func (s *Service) otherLocales(ctx context.Context, q *Question) ([]Question, error) {
+	questions, err := s.repo.ListQuestions(ctx, QuestionFilter{ID: q.ID})
+	if err != nil {
+		return nil, fmt.Errorf("load question: %w", err)
+	}
+	var others []Question
+	for _, o := range questions {
+		if o.Locale == q.Locale {
+			continue
+		}
+		if o.Capability != q.Capability || !sameStation(o.StationID, q.StationID) {
+			return nil, ErrQuestionMismatch
+		}
+		others = append(others, o)
+	}
+	return others, nil
+}
+
+func sameStation(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func validateQuestion(req QuestionRequest) (*Question, error) {
+	q := &Question{
+		ID:         strings.TrimSpace(req.ID),
+		Locale:     strings.TrimSpace(req.Locale),
+		Capability: strings.TrimSpace(req.Capability),
+		Difficulty: req.Difficulty,
+		Text:       strings.TrimSpace(req.Text),
+		FollowUp:   strings.TrimSpace(req.FollowUp),
+		Active:     req.Active == nil || *req.Active,
+	}
+	if q.Locale == "" {
+		q.Locale = DefaultLocale
+	}
+	if req.StationID != nil {
+		if st := strings.TrimSpace(*req.StationID); st != "" {
+			q.StationID = &st
+		}
+	}
+	if !questionIDPattern.MatchString(q.ID) {
+		return nil, fmt.Errorf("id must be 1-64 lowercase letters, digits, '-' or '_'")
+	}
+	if !localePattern.MatchString(q.Locale) {
+		return nil, fmt.Errorf("invalid locale %q", q.Locale)
+	}
+	valid := false
+	for _, c := range Capabilities {
+		valid = valid || c == q.Capability
+	}
+	if !valid {
+		return nil, fmt.Errorf("capability must be one of %s", strings.Join(Capabilities, ", "))
+	}
+	if q.Difficulty < DifficultyEasy || q.Difficulty > DifficultyHard {
+		return nil, fmt.Errorf("difficulty must be between %d and %d", DifficultyEasy, DifficultyHard)
+	}
+	if q.Text == "" {
+		return nil, fmt.Errorf("question is required")
+	}
+	if len(q.Text) > maxQuestionLen || len(q.FollowUp) > maxQuestionLen {
+		return nil, fmt.Errorf("question and follow_up must not exceed %d characters", maxQuestionLen)
+	}
+	return q, nil
+}
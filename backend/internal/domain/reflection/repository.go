@@ -2,6 +2,7 @@ package reflection
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -9,5 +10,30 @@ import (
 type Repository interface {
 	Create(ctx context.Context, r *ReflectionResult) error
 	List(ctx context.Context, params ListParams) ([]ReflectionResult, int, error)
+	// GetAggregatedCapabilities averages scores over scored reflections only.
 	GetAggregatedCapabilities(ctx context.Context, userID uuid.UUID) (*CapabilityScores, error)
+	UpdateScores(ctx context.Context, id uuid.UUID, status string, res *ScoreResult, scoredAt time.Time) error
+	ListPending(ctx context.Context, limit int) ([]ReflectionResult, error)
+	// RecordScoringAttempt counts a transiently failed scoring run of a
+	// reflection, which stays pending.
+	RecordScoringAttempt(ctx context.Context, id uuid.UUID) error
+
+	// ListQuestions returns the question bank entries matching the filter,
+	// ordered by capability, difficulty and ID.
+	ListQuestions(ctx context.Context, filter QuestionFilter) ([]Question, error)
+	// GetQuestion returns one locale of a question, or nil if it does not exist.
+	GetQuestion(ctx context.Context, id, locale string) (*Question, error)
+	// CreateQuestion fails with ErrQuestionExists if the locale of the
+	// question exists.
+	CreateQuestion(ctx context.Context, q *Question) error
+	UpdateQuestion(ctx context.Context, q *Question) error
+	// DeactivateQuestion deactivates all locales of a question and reports
+	// whether it existed.
+	DeactivateQuestion(ctx context.Context, id string) (bool, error)
+	// CountAnswered returns per capability how many of the user's scored
+	// reflections answered a question probing it.
+	CountAnswered(ctx context.Context, userID uuid.UUID) (map[string]int, error)
+	// RecentQuestionIDs returns the question IDs of the user's latest
+	// reflections, newest first.
+	RecentQuestionIDs(ctx context.Context, userID uuid.UUID, limit int) ([]string, error)
 }
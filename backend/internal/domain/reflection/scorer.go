@@ -0,0 +1,236 @@
+package reflection
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	"skillr-mvp-v1/backend/internal/ai"
+	"skillr-mvp-v1/backend/internal/model"
+	"skillr-mvp-v1/backend/internal/skillscore"
+)
+
+// Scorer assigns capability scores to a submitted reflection.
+type Scorer interface {
+	Score(ctx context.Context, r *ReflectionResult) (*ScoreResult, error)
+}
+
+// Generator is the subset of ai.AIClient the AI scorer needs.
+type Generator interface {
+	Generate(ctx context.Context, req ai.ChatRequest) (*ai.ChatResponse, error)
+}
+
+// PromptSource loads a managed prompt template (satisfied by *ai.Orchestrator).
+type PromptSource interface {
+	GetPrompt(ctx context.Context, promptID string) (*model.PromptTemplate, error)
+}
+
+// ScoringPromptID is the prompt ID looked up in the prompt store. When no
+// managed prompt exists the built-in prompt below is used.
+const ScoringPromptID = "reflection-score"
+
+const (
+	builtinScoringPromptID      = "builtin:" + ScoringPromptID
+	builtinScoringPromptVersion = 1
+	heuristicPromptID           = "heuristic"
+	heuristicPromptVersion      = 1
+)
+
+const builtinScoringPrompt = `Du bist ein Bewertungs-Tool. Bewerte die Reflexionsantwort eines Jugendlichen auf eine Frage.
+Antworte NUR mit validem JSON in diesem Format:
+{"analytical_depth": 0, "creativity": 0, "confidence": 0, "resilience": 0, "self_awareness": 0, "rationale": "..."}
+Regeln:
+- Alle Scores von 0-100
+- analytical_depth: Begruendungen, Ursachen, Zusammenhaenge
+- creativity: eigene Ideen, ungewoehnliche Perspektiven
+- confidence: Ueberzeugung in die eigenen Faehigkeiten
+- resilience: Umgang mit Rueckschlaegen und Unsicherheit
+- self_awareness: Wahrnehmung eigener Gefuehle, Staerken und Grenzen
+- Sehr kurze oder schnell abgegebene Antworten zeigen wenig Evidenz: bewerte vorsichtig
+- rationale: 1-2 Saetze auf Deutsch`
+
+// maxScoringAttempts is how many times the AI scorer tries a reflection
+// while the AI service is unavailable before it falls back to the heuristic.
+const maxScoringAttempts = 5
+
+var (
+	// ErrInvalidScores is returned when the model's answer is not a complete
+	// set of scores between 0 and 100.
+	ErrInvalidScores = errors.New("invalid capability scores")
+	// ErrScoringUnavailable is returned when scoring failed for a transient
+	// reason; the reflection stays pending and is tried again.
+	ErrScoringUnavailable = errors.New("scoring temporarily unavailable")
+)
+
+// AIScorer scores reflections with the extraction prompt. While the AI
+// service is unreachable or fails with a server error, it returns
+// ErrScoringUnavailable so that the reflection is retried, for up to
+// maxScoringAttempts attempts. If the AI call fails otherwise, all attempts
+// are used up or its answer is invalid, it logs why and scores with the
+// heuristic scorer instead, so reflections never stay unscored; the result
+// records which scorer produced it.
+type AIScorer struct {
+	client   Generator
+	prompts  PromptSource
+	fallback Scorer
+}
+
+// NewAIScorer creates an AIScorer. prompts may be nil to always use the
+// built-in prompt.
+func NewAIScorer(client Generator, prompts PromptSource) *AIScorer {
+	return &AIScorer{client: client, prompts: prompts, fallback: HeuristicScorer{}}
+}
+
+func (s *AIScorer) Score(ctx context.Context, r *ReflectionResult) (*ScoreResult, error) {
+	instruction, promptID, version, modelName := builtinScoringPrompt, builtinScoringPromptID, builtinScoringPromptVersion, ""
+	if s.prompts != nil {
+		if p, err := s.prompts.GetPrompt(ctx, ScoringPromptID); err == nil && p != nil && p.SystemInstruction != "" {
+			instruction, promptID, version, modelName = p.SystemInstruction, p.PromptID, p.Version, p.ModelConfig.Model
+		}
+	}
+
+	sig := signalsOf(r)
+	msg := fmt.Sprintf("Station: %s\nFrage: %s\nAntwortzeit: %d Sekunden\nWortanzahl: %d\n\nAntwort:\n%s",
+		r.StationID, r.QuestionID, r.ResponseTimeMs/1000, sig.words, r.Response)
+
+	resp, err := s.client.Generate(ctx, ai.ChatRequest{
+		Model:             modelName,
+		SystemInstruction: instruction,
+		Message:           msg,
+		ResponseMIMEType:  "application/json",
+	})
+	if err != nil {
+		if ai.IsTransient(err) && r.ScoringAttempts+1 < maxScoringAttempts {
+			return nil, fmt.Errorf("%w: %v", ErrScoringUnavailable, err)
+		}
+		log.Printf("[reflection] AI scoring of %s failed, using heuristic: %v", r.ID, err)
+		return s.fallback.Score(ctx, r)
+	}
+	scores, rationale, err := parseScores(resp.Text)
+	if err != nil {
+		log.Printf("[reflection] AI scoring of %s failed, using heuristic: %v", r.ID, err)
+		return s.fallback.Score(ctx, r)
+	}
+
+	return &ScoreResult{
+		Scores:        scores,
+		Rationale:     rationale,
+		PromptID:      promptID,
+		PromptVersion: version,
+		Scorer:        ScorerAI,
+	}, nil
+}
+
+// parseScores reads the model's JSON answer. Every capability must be
+// present and between 0 and 100.
+func parseScores(text string) (CapabilityScores, string, error) {
+	var out struct {
+		AnalyticalDepth *float64 `json:"analytical_depth"`
+		Creativity      *float64 `json:"creativity"`
+		Confidence      *float64 `json:"confidence"`
+		Resilience      *float64 `json:"resilience"`
+		SelfAwareness   *float64 `json:"self_awareness"`
+		Rationale       string   `json:"rationale"`
+	}
+	if err := json.Unmarshal([]byte(text), &out); err != nil {
+		return CapabilityScores{}, "", fmt.Errorf("%w: %v", ErrInvalidScores, err)
+	}
+	fields := map[string]*float64{
+		CapabilityAnalyticalDepth: out.AnalyticalDepth,
+		CapabilityCreativity:      out.Creativity,
+		CapabilityConfidence:      out.Confidence,
+		CapabilityResilience:      out.Resilience,
+		CapabilitySelfAwareness:   out.SelfAwareness,
+	}
+	for _, c := range Capabilities {
+		if v := fields[c]; v == nil || !skillscore.Valid(*v) {
+			return CapabilityScores{}, "", fmt.Errorf("%w: %s is missing or out of range", ErrInvalidScores, c)
+		}
+	}
+	return CapabilityScores{
+		AnalyticalDepth: clampScore(*out.AnalyticalDepth),
+		Creativity:      clampScore(*out.Creativity),
+		Confidence:      clampScore(*out.Confidence),
+		Resilience:      clampScore(*out.Resilience),
+		SelfAwareness:   clampScore(*out.SelfAwareness),
+	}, out.Rationale, nil
+}
+
+// HeuristicScorer scores reflections from response length, response time
+// and simple keyword cues. It is used when no AI client is configured and
+// as the fallback when an AI call fails.
+type HeuristicScorer struct{}
+
+var capabilityCues = map[string][]string{
+	"analytical_depth": {"weil", "deshalb", "daher", "dadurch", "grund", "because", "therefore"},
+	"creativity":       {"idee", "vielleicht", "stattdessen", "anders", "ausprobieren", "erfinden", "idea"},
+	"confidence":       {"ich kann", "ich schaffe", "sicher", "stolz", "gut darin", "i can"},
+	"resilience":       {"trotzdem", "nochmal", "weitermachen", "nicht aufgeben", "geschafft", "fehler"},
+	"self_awareness":   {"ich fühle", "ich fuehle", "gemerkt", "gelernt", "mir ist", "für mich", "fuer mich"},
+}
+
+func (HeuristicScorer) Score(_ context.Context, r *ReflectionResult) (*ScoreResult, error) {
+	sig := signalsOf(r)
+	text := strings.ToLower(r.Response)
+
+	score := func(key string) float64 {
+		hits := 0
+		for _, cue := range capabilityCues[key] {
+			if strings.Contains(text, cue) {
+				hits++
+			}
+		}
+		// Base evidence from effort, plus up to 30 points from cue words.
+		return clampScore(20 + 50*sig.effort + math.Min(float64(hits)*10, 30))
+	}
+
+	return &ScoreResult{
+		Scores: CapabilityScores{
+			AnalyticalDepth: score("analytical_depth"),
+			Creativity:      score("creativity"),
+			Confidence:      score("confidence"),
+			Resilience:      score("resilience"),
+			SelfAwareness:   score("self_awareness"),
+		},
+		Rationale:     fmt.Sprintf("Heuristische Bewertung: %d Woerter, %d Sekunden Antwortzeit.", sig.words, r.ResponseTimeMs/1000),
+		PromptID:      heuristicPromptID,
+		PromptVersion: heuristicPromptVersion,
+		Scorer:        ScorerHeuristic,
+	}, nil
+}
+
+type responseSignals struct {
+	words int
+	// effort in [0,1] combines length and time spent answering.
+	effort float64
+}
+
+// signalsOf derives the length and response-time signals of a reflection.
+// Long answers count up to 80 words; time counts up to 60 seconds, with
+// answers typed faster than ~5 words per second treated as pasted.
+func signalsOf(r *ReflectionResult) responseSignals {
+	words := len(strings.Fields(r.Response))
+	length := math.Min(float64(words)/80, 1)
+
+	timing := 0.5 // unknown response time: neutral
+	if r.ResponseTimeMs > 0 {
+		secs := float64(r.ResponseTimeMs) / 1000
+		timing = math.Min(secs/60, 1)
+		if words > 0 && float64(words)/secs > 5 {
+			timing *= 0.5
+		}
+	}
+
+	return responseSignals{words: words, effort: 0.7*length + 0.3*timing}
+}
+
+func clampScore(v float64) float64 {
+	if math.IsNaN(v) {
+		return 0
+	}
+	return math.Round(math.Max(0, math.Min(v, 100))*100) / 100
+}
@@ -2,21 +2,64 @@ package reflection
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// scoringTimeout bounds one asynchronous scoring run.
+const scoringTimeout = 60 * time.Second
+
+// maxConcurrentScoring bounds the scoring runs in flight. Reflections that
+// find no free slot stay pending until the next Run pass.
+const maxConcurrentScoring = 4
+
 type Service struct {
-	repo Repository
+	repo   Repository
+	scorer Scorer
+	wg     sync.WaitGroup
+	slots  chan struct{}
+
+	mu   sync.Mutex
+	busy map[uuid.UUID]bool
 }
 
+// NewService creates a Service that scores reflections heuristically until
+// an AI scorer is configured via SetScorer.
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{
+		repo:   repo,
+		scorer: HeuristicScorer{},
+		slots:  make(chan struct{}, maxConcurrentScoring),
+		busy:   map[uuid.UUID]bool{},
+	}
 }
 
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
+}
+
+// SetScorer replaces the scorer used for new and pending reflections.
+func (s *Service) SetScorer(scorer Scorer) {
+	s.scorer = scorer
+}
+
+// Wait blocks until all in-flight scoring runs have finished.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+// Submit stores the reflection as pending and scores it in the background,
+// or leaves it to the next Run pass when all scoring slots are taken.
 func (s *Service) Submit(ctx context.Context, userID uuid.UUID, req CreateReflectionRequest) (*ReflectionResult, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 	if req.StationID == "" {
 		return nil, fmt.Errorf("station_id is required")
 	}
@@ -26,6 +69,9 @@ func (s *Service) Submit(ctx context.Context, userID uuid.UUID, req CreateReflec
 	if req.Response == "" {
 		return nil, fmt.Errorf("response is required")
 	}
+	if err := s.validateQuestionID(ctx, req.QuestionID, req.StationID); err != nil {
+		return nil, err
+	}
 
 	result := &ReflectionResult{
 		ID:             uuid.New(),
@@ -34,27 +80,123 @@ func (s *Service) Submit(ctx context.Context, userID uuid.UUID, req CreateReflec
 		QuestionID:     req.QuestionID,
 		Response:       req.Response,
 		ResponseTimeMs: req.ResponseTimeMs,
-		CapabilityScores: CapabilityScores{
-			// Stub scoring — will be replaced by VertexAI analysis in Phase 4
-			AnalyticalDepth: 50,
-			Creativity:      50,
-			Confidence:      50,
-			Resilience:      50,
-			SelfAwareness:   50,
-		},
-		CreatedAt: time.Now().UTC(),
+		ScoringStatus:  ScoringPending,
+		CreatedAt:      time.Now().UTC(),
 	}
 
 	if err := s.repo.Create(ctx, result); err != nil {
 		return nil, fmt.Errorf("submit reflection: %w", err)
 	}
+
+	s.scoreAsync(*result)
 	return result, nil
 }
 
+// Run schedules scoring of pending reflections every interval until ctx is
+// cancelled; this picks up reflections interrupted by a restart and those
+// that found no free scoring slot.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.ScorePending(ctx, 100); err != nil {
+			log.Printf("[reflection] scoring queue: %v", err)
+		} else if n > 0 {
+			log.Printf("[reflection] scheduled scoring for %d reflections", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ScorePending schedules scoring for up to limit reflections that are still
+// pending and returns how many were scheduled. Reflections being scored are
+// skipped, and scheduling stops when all slots are taken.
+func (s *Service) ScorePending(ctx context.Context, limit int) (int, error) {
+	if s.repo == nil {
+		return 0, fmt.Errorf("database not available")
+	}
+	pending, err := s.repo.ListPending(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("list pending reflections: %w", err)
+	}
+	n := 0
+	for _, r := range pending {
+		if s.scoreAsync(r) {
+			n++
+		} else if len(s.slots) == cap(s.slots) {
+			break
+		}
+	}
+	return n, nil
+}
+
+// scoreAsync scores r in the background if a slot is free and r is not
+// being scored already. It reports whether a run was started.
+func (s *Service) scoreAsync(r ReflectionResult) bool {
+	s.mu.Lock()
+	if s.busy[r.ID] {
+		s.mu.Unlock()
+		return false
+	}
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		s.mu.Unlock()
+		return false
+	}
+	s.busy[r.ID] = true
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.busy, r.ID)
+			<-s.slots
+			s.mu.Unlock()
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), scoringTimeout)
+		defer cancel()
+		s.score(ctx, &r)
+	}()
+	return true
+}
+
+func (s *Service) score(ctx context.Context, r *ReflectionResult) {
+	res, err := s.scorer.Score(ctx, r)
+	if errors.Is(err, ErrScoringUnavailable) {
+		log.Printf("[reflection] scoring %s postponed: %v", r.ID, err)
+		if err := s.repo.RecordScoringAttempt(ctx, r.ID); err != nil {
+			log.Printf("[reflection] record scoring attempt for %s: %v", r.ID, err)
+		}
+		return
+	}
+	status := ScoringScored
+	if err != nil {
+		log.Printf("[reflection] scoring %s failed: %v", r.ID, err)
+		status = ScoringFailed
+		res = nil
+	}
+	if err := s.repo.UpdateScores(ctx, r.ID, status, res, time.Now().UTC()); err != nil {
+		log.Printf("[reflection] store scores for %s: %v", r.ID, err)
+	}
+}
+
 func (s *Service) List(ctx context.Context, params ListParams) ([]ReflectionResult, int, error) {
+	if s.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
 	return s.repo.List(ctx, params)
 }
 
 func (s *Service) Capabilities(ctx context.Context, userID uuid.UUID) (*CapabilityScores, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
 	return s.repo.GetAggregatedCapabilities(ctx, userID)
 }
@@ -2,25 +2,187 @@ package reflection
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"google.golang.org/genai"
+
+	"skillr-mvp-v1/backend/internal/ai"
 )
 
 type mockRepo struct {
+	mu          sync.Mutex
 	reflections map[uuid.UUID]*ReflectionResult
+	questions   []Question
 }
 
+// newMockRepo seeds the question bank with the question IDs the tests submit.
 func newMockRepo() *mockRepo {
-	return &mockRepo{reflections: make(map[uuid.UUID]*ReflectionResult)}
+	return &mockRepo{
+		reflections: make(map[uuid.UUID]*ReflectionResult),
+		questions: []Question{
+			{ID: "q1", Locale: DefaultLocale, Capability: CapabilityAnalyticalDepth, Difficulty: DifficultyEasy, Text: "Was war schwierig?", Active: true},
+			{ID: "q", Locale: DefaultLocale, Capability: CapabilityCreativity, Difficulty: DifficultyEasy, Text: "Was wuerdest du anders machen?", Active: true},
+		},
+	}
+}
+
+func (m *mockRepo) ListQuestions(ctx context.Context, filter QuestionFilter) ([]Question, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []Question
+	for _, q := range m.questions {
+		if (filter.ID != "" && q.ID != filter.ID) || (filter.Capability != "" && q.Capability != filter.Capability) ||
+			(filter.Locale != "" && q.Locale != filter.Locale) || (filter.ActiveOnly && !q.Active) {
+			continue
+		}
+		result = append(result, q)
+	}
+	return result, nil
+}
+
+func (m *mockRepo) GetQuestion(ctx context.Context, id, locale string) (*Question, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, q := range m.questions {
+		if q.ID == id && q.Locale == locale {
+			return &q, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepo) CreateQuestion(ctx context.Context, q *Question) error {
+	if existing, _ := m.GetQuestion(ctx, q.ID, q.Locale); existing != nil {
+		return ErrQuestionExists
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.questions = append(m.questions, *q)
+	return nil
+}
+
+func (m *mockRepo) UpdateQuestion(ctx context.Context, q *Question) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.questions {
+		if m.questions[i].ID == q.ID && m.questions[i].Locale == q.Locale {
+			m.questions[i] = *q
+		}
+	}
+	return nil
+}
+
+func (m *mockRepo) DeactivateQuestion(ctx context.Context, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	found := false
+	for i := range m.questions {
+		if m.questions[i].ID == id {
+			m.questions[i].Active = false
+			found = true
+		}
+	}
+	return found, nil
+}
+
+func (m *mockRepo) CountAnswered(ctx context.Context, userID uuid.UUID) (map[string]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	capabilities := map[string]string{}
+	for _, q := range m.questions {
+		capabilities[q.ID] = q.Capability
+	}
+	counts := map[string]int{}
+	for _, r := range m.reflections {
+		if r.UserID == userID && r.ScoringStatus == ScoringScored && capabilities[r.QuestionID] != "" {
+			counts[capabilities[r.QuestionID]]++
+		}
+	}
+	return counts, nil
+}
+
+func (m *mockRepo) RecentQuestionIDs(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var recent []ReflectionResult
+	for _, r := range m.reflections {
+		if r.UserID == userID {
+			recent = append(recent, *r)
+		}
+	}
+	sort.Slice(recent, func(i, j int) bool { return recent[i].CreatedAt.After(recent[j].CreatedAt) })
+	var ids []string
+	for i := 0; i < len(recent) && i < limit; i++ {
+		ids = append(ids, recent[i].QuestionID)
+	}
+	return ids, nil
 }
 
 func (m *mockRepo) Create(ctx context.Context, r *ReflectionResult) error {
-	m.reflections[r.ID] = r
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *r
+	m.reflections[r.ID] = &cp
+	return nil
+}
+
+func (m *mockRepo) UpdateScores(ctx context.Context, id uuid.UUID, status string, res *ScoreResult, scoredAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.reflections[id]
+	if !ok {
+		return errors.New("not found")
+	}
+	r.ScoringStatus = status
+	if res != nil {
+		r.CapabilityScores = res.Scores
+		r.ScoreRationale = res.Rationale
+		r.PromptID = res.PromptID
+		r.PromptVersion = res.PromptVersion
+		r.ScoredBy = res.Scorer
+		r.ScoredAt = &scoredAt
+	}
+	return nil
+}
+
+func (m *mockRepo) ListPending(ctx context.Context, limit int) ([]ReflectionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []ReflectionResult
+	for _, r := range m.reflections {
+		if r.ScoringStatus == ScoringPending && len(result) < limit {
+			result = append(result, *r)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRepo) RecordScoringAttempt(ctx context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.reflections[id]
+	if !ok {
+		return errors.New("not found")
+	}
+	r.ScoringAttempts++
 	return nil
 }
 
+func (m *mockRepo) get(id uuid.UUID) ReflectionResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return *m.reflections[id]
+}
+
 func (m *mockRepo) List(ctx context.Context, params ListParams) ([]ReflectionResult, int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	var result []ReflectionResult
 	for _, r := range m.reflections {
 		if r.UserID == params.UserID {
@@ -31,10 +193,12 @@ func (m *mockRepo) List(ctx context.Context, params ListParams) ([]ReflectionRes
 }
 
 func (m *mockRepo) GetAggregatedCapabilities(ctx context.Context, userID uuid.UUID) (*CapabilityScores, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	var total CapabilityScores
 	count := 0
 	for _, r := range m.reflections {
-		if r.UserID == userID {
+		if r.UserID == userID && r.ScoringStatus == ScoringScored {
 			total.AnalyticalDepth += r.CapabilityScores.AnalyticalDepth
 			total.Creativity += r.CapabilityScores.Creativity
 			total.Confidence += r.CapabilityScores.Confidence
@@ -75,8 +239,17 @@ func TestService_Submit(t *testing.T) {
 	if result.StationID != "station-v1" {
 		t.Errorf("expected station-v1, got %s", result.StationID)
 	}
-	if result.CapabilityScores.AnalyticalDepth != 50 {
-		t.Errorf("expected stub score 50, got %f", result.CapabilityScores.AnalyticalDepth)
+	if result.ScoringStatus != ScoringPending {
+		t.Errorf("expected pending status on submit, got %s", result.ScoringStatus)
+	}
+
+	svc.Wait()
+	stored := repo.get(result.ID)
+	if stored.ScoringStatus != ScoringScored {
+		t.Errorf("expected scored status after scoring, got %s", stored.ScoringStatus)
+	}
+	if stored.PromptID != heuristicPromptID || stored.ScoredBy != ScorerHeuristic {
+		t.Errorf("expected heuristic scores, got %s by %s", stored.PromptID, stored.ScoredBy)
 	}
 }
 
@@ -95,18 +268,433 @@ func TestService_Capabilities(t *testing.T) {
 	svc := NewService(repo)
 
 	userID := uuid.New()
+	svc.SetScorer(&mockScorer{scores: CapabilityScores{AnalyticalDepth: 72}})
 	_, _ = svc.Submit(context.Background(), userID, CreateReflectionRequest{
 		StationID:      "station-v1",
 		QuestionID:     "q1",
 		Response:       "test",
 		ResponseTimeMs: 1000,
 	})
+	svc.Wait()
 
 	scores, err := svc.Capabilities(context.Background(), userID)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if scores.AnalyticalDepth != 50 {
-		t.Errorf("expected 50, got %f", scores.AnalyticalDepth)
+	if scores.AnalyticalDepth != 72 {
+		t.Errorf("expected 72, got %f", scores.AnalyticalDepth)
+	}
+}
+
+func TestService_Submit_UnknownQuestion(t *testing.T) {
+	repo := newMockRepo()
+	station := "station-a"
+	repo.questions = append(repo.questions,
+		Question{ID: "q-station", Locale: DefaultLocale, StationID: &station, Capability: CapabilityResilience, Difficulty: DifficultyEasy, Text: "?", Active: true},
+		Question{ID: "q-retired", Locale: DefaultLocale, Capability: CapabilityResilience, Difficulty: DifficultyEasy, Text: "?", Active: false},
+	)
+	svc := NewService(repo)
+
+	for _, tc := range []struct{ station, question string }{
+		{"station-v1", "r-unknown"},
+		{"station-v1", "q-retired"},
+		{"station-b", "q-station"},
+	} {
+		_, err := svc.Submit(context.Background(), uuid.New(), CreateReflectionRequest{
+			StationID: tc.station, QuestionID: tc.question, Response: "Antwort",
+		})
+		if err == nil {
+			t.Errorf("expected %s at %s to be rejected", tc.question, tc.station)
+		}
+	}
+	if _, err := svc.Submit(context.Background(), uuid.New(), CreateReflectionRequest{
+		StationID: "station-a", QuestionID: "q-station", Response: "Antwort",
+	}); err != nil {
+		t.Errorf("expected station question to be accepted: %v", err)
+	}
+	svc.Wait()
+}
+
+func TestService_NextQuestion(t *testing.T) {
+	repo := newMockRepo()
+	station := "station-a"
+	repo.questions = []Question{
+		{ID: "a-1", Locale: "de", Capability: CapabilityAnalyticalDepth, Difficulty: DifficultyEasy, Text: "a1", Active: true},
+		{ID: "c-1", Locale: "de", Capability: CapabilityCreativity, Difficulty: DifficultyEasy, Text: "c1", Active: true},
+		{ID: "f-1", Locale: "de", Capability: CapabilityConfidence, Difficulty: DifficultyEasy, Text: "f1", Active: true},
+		{ID: "r-1", Locale: "de", Capability: CapabilityResilience, Difficulty: DifficultyEasy, Text: "r1", Active: true},
+		{ID: "r-1", Locale: "en", Capability: CapabilityResilience, Difficulty: DifficultyEasy, Text: "r1 en", Active: true},
+		{ID: "r-3", Locale: "de", Capability: CapabilityResilience, Difficulty: DifficultyHard, Text: "r3", Active: true},
+		{ID: "r-st", Locale: "de", StationID: &station, Capability: CapabilityResilience, Difficulty: DifficultyMedium, Text: "rs", Active: true},
+		{ID: "s-1", Locale: "de", Capability: CapabilitySelfAwareness, Difficulty: DifficultyEasy, Text: "s1", Active: true},
+	}
+	svc := NewService(repo)
+	userID := uuid.New()
+	created := time.Now()
+	add := func(question string, scores CapabilityScores) {
+		id := uuid.New()
+		created = created.Add(time.Minute)
+		repo.reflections[id] = &ReflectionResult{ID: id, UserID: userID, QuestionID: question, ScoringStatus: ScoringScored, CapabilityScores: scores, CreatedAt: created}
+	}
+	// Every capability has enough evidence; analytical depth is the weakest.
+	scores := CapabilityScores{AnalyticalDepth: 60, Creativity: 70, Confidence: 65, Resilience: 80, SelfAwareness: 75}
+	for _, q := range []string{"a-1", "c-1", "f-1", "r-1", "s-1"} {
+		for i := 0; i < evidenceTarget; i++ {
+			add(q, scores)
+		}
+	}
+
+	next, err := svc.NextQuestion(context.Background(), userID, "station-b", "en")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.Capability != CapabilityAnalyticalDepth || next.Reason != ReasonWeakest {
+		t.Errorf("expected weakest capability analytical_depth, got %s (%s)", next.Capability, next.Reason)
+	}
+
+	// A capability below the evidence target wins over the weakest score.
+	repo.questions = append(repo.questions, Question{ID: "n-1", Locale: "de", Capability: CapabilityCreativity, Difficulty: DifficultyEasy, Text: "n1", Active: true})
+	for id, r := range repo.reflections {
+		if r.QuestionID == "c-1" {
+			delete(repo.reflections, id)
+		}
+	}
+	next, err = svc.NextQuestion(context.Background(), userID, "station-b", "en")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.Capability != CapabilityCreativity || next.Reason != ReasonLeastEvidenced || next.EvidenceCount != 0 {
+		t.Errorf("expected least-evidenced creativity, got %+v", next)
+	}
+	if next.Question.Difficulty != DifficultyEasy {
+		t.Errorf("expected an easy question without evidence, got %d", next.Question.Difficulty)
+	}
+}
+
+func TestPickQuestion(t *testing.T) {
+	station := "station-a"
+	questions := []Question{
+		{ID: "r-1", Locale: "de", Difficulty: DifficultyEasy, Text: "de"},
+		{ID: "r-1", Locale: "en", Difficulty: DifficultyEasy, Text: "en"},
+		{ID: "r-3", Locale: "de", Difficulty: DifficultyHard, Text: "hard"},
+		{ID: "r-fr", Locale: "fr", Difficulty: DifficultyHard, Text: "fr"},
+		{ID: "r-st", Locale: "de", StationID: &station, Difficulty: DifficultyMedium, Text: "station"},
+	}
+
+	if q := pickQuestion(questions, "station-b", "en", DifficultyEasy, nil); q == nil || q.ID != "r-1" || q.Text != "en" {
+		t.Errorf("expected English r-1, got %+v", q)
+	}
+	if q := pickQuestion(questions, "station-b", "en", DifficultyHard, nil); q == nil || q.ID != "r-3" {
+		t.Errorf("expected hard German fallback r-3, got %+v", q)
+	}
+	if q := pickQuestion(questions, "station-a", "de", DifficultyEasy, nil); q == nil || q.ID != "r-st" {
+		t.Errorf("expected station question, got %+v", q)
+	}
+	if q := pickQuestion(questions, "station-b", "de", DifficultyEasy, map[string]bool{"r-1": true}); q == nil || q.ID != "r-3" {
+		t.Errorf("expected recent r-1 to be skipped, got %+v", q)
+	}
+	if q := pickQuestion(questions, "station-b", "de", DifficultyEasy, map[string]bool{"r-1": true, "r-3": true}); q == nil || q.ID != "r-1" {
+		t.Errorf("expected recent questions when nothing else is left, got %+v", q)
+	}
+}
+
+func TestService_CreateQuestionValidates(t *testing.T) {
+	svc := NewService(newMockRepo())
+	ctx := context.Background()
+
+	for _, req := range []QuestionRequest{
+		{ID: "Bad ID", Capability: CapabilityCreativity, Difficulty: 1, Text: "?"},
+		{ID: "x", Capability: "humor", Difficulty: 1, Text: "?"},
+		{ID: "x", Capability: CapabilityCreativity, Difficulty: 4, Text: "?"},
+		{ID: "x", Locale: "deutsch", Capability: CapabilityCreativity, Difficulty: 1, Text: "?"},
+		{ID: "x", Capability: CapabilityCreativity, Difficulty: 1},
+	} {
+		if _, err := svc.CreateQuestion(ctx, req); err == nil {
+			t.Errorf("expected %+v to be rejected", req)
+		}
+	}
+
+	q, err := svc.CreateQuestion(ctx, QuestionRequest{ID: "q1", Locale: "en", Capability: CapabilityAnalyticalDepth, Difficulty: 2, Text: " What was hard? "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Text != "What was hard?" || !q.Active {
+		t.Errorf("unexpected question: %+v", q)
+	}
+	if _, err := svc.CreateQuestion(ctx, QuestionRequest{ID: "q1", Locale: "en", Capability: CapabilityAnalyticalDepth, Difficulty: 2, Text: "?"}); !errors.Is(err, ErrQuestionExists) {
+		t.Errorf("expected ErrQuestionExists, got %v", err)
+	}
+	if err := svc.DeactivateQuestion(ctx, "missing"); !errors.Is(err, ErrQuestionNotFound) {
+		t.Errorf("expected ErrQuestionNotFound, got %v", err)
+	}
+}
+
+func TestService_QuestionTranslationsStayConsistent(t *testing.T) {
+	svc := NewService(newMockRepo())
+	ctx := context.Background()
+	station := "werkstatt"
+
+	if _, err := svc.CreateQuestion(ctx, QuestionRequest{ID: "q9", StationID: &station, Capability: CapabilityCreativity, Difficulty: 1, Text: "Was war neu?"}); err != nil {
+		t.Fatal(err)
+	}
+	other := "labor"
+	for _, req := range []QuestionRequest{
+		{ID: "q9", Locale: "en", StationID: &station, Capability: CapabilityAnalyticalDepth, Difficulty: 1, Text: "What was new?"},
+		{ID: "q9", Locale: "en", StationID: &other, Capability: CapabilityCreativity, Difficulty: 1, Text: "What was new?"},
+		{ID: "q9", Locale: "en", Capability: CapabilityCreativity, Difficulty: 1, Text: "What was new?"},
+	} {
+		if _, err := svc.CreateQuestion(ctx, req); !errors.Is(err, ErrQuestionMismatch) {
+			t.Errorf("create %+v: expected ErrQuestionMismatch, got %v", req, err)
+		}
+	}
+	if _, err := svc.CreateQuestion(ctx, QuestionRequest{ID: "q9", Locale: "en", StationID: &station, Capability: CapabilityCreativity, Difficulty: 1, Text: "What was new?"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.UpdateQuestion(ctx, "q9", "en", QuestionRequest{StationID: &station, Capability: CapabilityAnalyticalDepth, Difficulty: 1, Text: "What was new?"}); !errors.Is(err, ErrQuestionMismatch) {
+		t.Errorf("update: expected ErrQuestionMismatch, got %v", err)
+	}
+
+	// Updating or translating a retired question keeps it retired.
+	if err := svc.DeactivateQuestion(ctx, "q9"); err != nil {
+		t.Fatal(err)
+	}
+	q, err := svc.UpdateQuestion(ctx, "q9", "en", QuestionRequest{StationID: &station, Capability: CapabilityCreativity, Difficulty: 2, Text: "What was new to you?"})
+	if err != nil || q.Active {
+		t.Errorf("update of a retired question = %+v, %v", q, err)
+	}
+	q, err = svc.CreateQuestion(ctx, QuestionRequest{ID: "q9", Locale: "fr", StationID: &station, Capability: CapabilityCreativity, Difficulty: 1, Text: "Quoi de neuf ?"})
+	if err != nil || q.Active {
+		t.Errorf("translation of a retired question = %+v, %v", q, err)
+	}
+}
+
+type mockScorer struct {
+	scores CapabilityScores
+	err    error
+}
+
+func (m *mockScorer) Score(ctx context.Context, r *ReflectionResult) (*ScoreResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &ScoreResult{Scores: m.scores, PromptID: "test", PromptVersion: 1}, nil
+}
+
+func TestService_ScoringFailureMarksFailed(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.SetScorer(&mockScorer{err: errors.New("boom")})
+
+	result, err := svc.Submit(context.Background(), uuid.New(), CreateReflectionRequest{
+		StationID: "s", QuestionID: "q", Response: "r",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svc.Wait()
+
+	if got := repo.get(result.ID).ScoringStatus; got != ScoringFailed {
+		t.Errorf("expected failed status, got %s", got)
+	}
+}
+
+func TestService_ScorePending(t *testing.T) {
+	repo := newMockRepo()
+	id := uuid.New()
+	repo.reflections[id] = &ReflectionResult{ID: id, UserID: uuid.New(), Response: "alt", ScoringStatus: ScoringPending}
+	svc := NewService(repo)
+
+	n, err := svc.ScorePending(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svc.Wait()
+	if n != 1 {
+		t.Errorf("expected 1 pending reflection, got %d", n)
+	}
+	if got := repo.get(id).ScoringStatus; got != ScoringScored {
+		t.Errorf("expected scored status, got %s", got)
+	}
+}
+
+// blockingScorer holds every run until release is closed.
+type blockingScorer struct {
+	mu      sync.Mutex
+	running int
+	peak    int
+	release chan struct{}
+}
+
+func (b *blockingScorer) Score(ctx context.Context, r *ReflectionResult) (*ScoreResult, error) {
+	b.mu.Lock()
+	b.running++
+	b.peak = max(b.peak, b.running)
+	b.mu.Unlock()
+	<-b.release
+	b.mu.Lock()
+	b.running--
+	b.mu.Unlock()
+	return &ScoreResult{PromptID: "test", Scorer: ScorerAI}, nil
+}
+
+func TestService_BoundsConcurrentScoring(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	scorer := &blockingScorer{release: make(chan struct{})}
+	svc.SetScorer(scorer)
+	ctx := context.Background()
+
+	for i := 0; i < maxConcurrentScoring+3; i++ {
+		if _, err := svc.Submit(ctx, uuid.New(), CreateReflectionRequest{StationID: "s", QuestionID: "q", Response: "r"}); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	}
+	if n, err := svc.ScorePending(ctx, 100); err != nil || n != 0 {
+		t.Errorf("ScorePending with all slots taken = %d, %v", n, err)
+	}
+	close(scorer.release)
+	svc.Wait()
+	if scorer.peak > maxConcurrentScoring {
+		t.Errorf("%d runs in flight, want at most %d", scorer.peak, maxConcurrentScoring)
+	}
+	if pending, _ := repo.ListPending(ctx, 100); len(pending) != 3 {
+		t.Fatalf("%d reflections pending, want 3", len(pending))
+	}
+
+	if n, err := svc.ScorePending(ctx, 100); err != nil || n != 3 {
+		t.Errorf("ScorePending = %d, %v; want 3", n, err)
+	}
+	svc.Wait()
+	if pending, _ := repo.ListPending(ctx, 100); len(pending) != 0 {
+		t.Errorf("%d reflections still pending", len(pending))
+	}
+}
+
+type mockGenerator struct {
+	text string
+	err  error
+	req  ai.ChatRequest
+}
+
+func (m *mockGenerator) Generate(ctx context.Context, req ai.ChatRequest) (*ai.ChatResponse, error) {
+	m.req = req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &ai.ChatResponse{Text: m.text}, nil
+}
+
+func TestAIScorer_ParsesResponse(t *testing.T) {
+	gen := &mockGenerator{text: `{"analytical_depth": 81, "creativity": 92.5, "confidence": 40, "resilience": 55, "self_awareness": 66, "rationale": "Gute Begruendung."}`}
+	scorer := NewAIScorer(gen, nil)
+
+	res, err := scorer.Score(context.Background(), &ReflectionResult{
+		StationID: "station-v1", QuestionID: "q1", Response: "Ich habe gelernt, weil ...", ResponseTimeMs: 45000,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Scores.AnalyticalDepth != 81 || res.Scores.Creativity != 92.5 || res.Scorer != ScorerAI {
+		t.Errorf("unexpected scores: %+v by %s", res.Scores, res.Scorer)
+	}
+	if res.Rationale != "Gute Begruendung." {
+		t.Errorf("unexpected rationale: %q", res.Rationale)
+	}
+	if res.PromptID != builtinScoringPromptID || res.PromptVersion != builtinScoringPromptVersion {
+		t.Errorf("unexpected prompt %s v%d", res.PromptID, res.PromptVersion)
+	}
+	if !strings.Contains(gen.req.Message, "Antwortzeit: 45 Sekunden") {
+		t.Errorf("expected response time signal in prompt, got %q", gen.req.Message)
+	}
+}
+
+func TestAIScorer_FallsBackToHeuristic(t *testing.T) {
+	for name, tt := range map[string]struct {
+		err      error
+		attempts int
+	}{
+		"rejected request":    {genai.APIError{Code: 400}, 0},
+		"last attempt failed": {errors.New("connection refused"), maxScoringAttempts - 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			scorer := NewAIScorer(&mockGenerator{err: tt.err}, nil)
+			res, err := scorer.Score(context.Background(), &ReflectionResult{Response: "kurz", ScoringAttempts: tt.attempts})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.PromptID != heuristicPromptID || res.Scorer != ScorerHeuristic {
+				t.Errorf("expected heuristic fallback, got %s by %s", res.PromptID, res.Scorer)
+			}
+		})
+	}
+}
+
+func TestAIScorer_RetriesTransientErrors(t *testing.T) {
+	for name, err := range map[string]error{
+		"transport":    errors.New("connection refused"),
+		"server error": genai.APIError{Code: 503},
+		"rate limited": genai.APIError{Code: 429},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res, scoreErr := NewAIScorer(&mockGenerator{err: err}, nil).Score(context.Background(), &ReflectionResult{Response: "kurz"})
+			if !errors.Is(scoreErr, ErrScoringUnavailable) {
+				t.Errorf("Score = %+v, %v; want ErrScoringUnavailable", res, scoreErr)
+			}
+		})
+	}
+}
+
+func TestService_TransientScoringFailureStaysPending(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	svc.SetScorer(NewAIScorer(&mockGenerator{err: genai.APIError{Code: 503}}, nil))
+	ctx := context.Background()
+
+	result, err := svc.Submit(ctx, uuid.New(), CreateReflectionRequest{StationID: "s", QuestionID: "q", Response: "r"})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	svc.Wait()
+	for i := 1; i < maxScoringAttempts; i++ {
+		if got := repo.get(result.ID); got.ScoringStatus != ScoringPending || got.ScoringAttempts != i {
+			t.Fatalf("after attempt %d: status %q, %d attempts", i, got.ScoringStatus, got.ScoringAttempts)
+		}
+		if _, err := svc.ScorePending(ctx, 100); err != nil {
+			t.Fatalf("ScorePending: %v", err)
+		}
+		svc.Wait()
+	}
+	if got := repo.get(result.ID); got.ScoringStatus != ScoringScored || got.ScoredBy != ScorerHeuristic {
+		t.Errorf("after %d attempts: status %q by %q", maxScoringAttempts, got.ScoringStatus, got.ScoredBy)
+	}
+}
+
+func TestAIScorer_RejectsInvalidScores(t *testing.T) {
+	for name, text := range map[string]string{
+		"out of range":  `{"analytical_depth": 81, "creativity": 140, "confidence": 40, "resilience": 55, "self_awareness": 66}`,
+		"negative":      `{"analytical_depth": -5, "creativity": 10, "confidence": 40, "resilience": 55, "self_awareness": 66}`,
+		"missing field": `{"analytical_depth": 81, "creativity": 10, "confidence": 40, "resilience": 55}`,
+		"not json":      `Die Antwort ist gut.`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := parseScores(text); !errors.Is(err, ErrInvalidScores) {
+				t.Errorf("parseScores: err = %v", err)
+			}
+			res, err := NewAIScorer(&mockGenerator{text: text}, nil).Score(context.Background(), &ReflectionResult{Response: "kurz"})
+			if err != nil || res.Scorer != ScorerHeuristic {
+				t.Errorf("expected heuristic scores, got %+v, %v", res, err)
+			}
+		})
+	}
+}
+
+func TestHeuristicScorer_RewardsEffort(t *testing.T) {
+	short, _ := HeuristicScorer{}.Score(context.Background(), &ReflectionResult{Response: "ok", ResponseTimeMs: 1000})
+	long, _ := HeuristicScorer{}.Score(context.Background(), &ReflectionResult{
+		Response:       strings.Repeat("Ich habe gelernt, dass ich trotzdem weitermachen kann, weil ich eine Idee hatte. ", 6),
+		ResponseTimeMs: 90000,
+	})
+	if long.Scores.SelfAwareness <= short.Scores.SelfAwareness {
+		t.Errorf("expected longer, thoughtful answer to score higher: %v vs %v", long.Scores, short.Scores)
 	}
 }
@@ -8,8 +8,15 @@ import (
 	"github.com/google/uuid"
 )
 
+// EndListener is notified once when a session is ended, with the session's
+// interactions loaded.
+type EndListener interface {
+	SessionEnded(ctx context.Context, sess *SessionDetailed)
+}
+
 type Service struct {
-	repo Repository
+	repo    Repository
+	onEnded EndListener
 }
 
 func NewService(repo Repository) *Service {
@@ -21,6 +28,11 @@ func (s *Service) SetRepo(repo Repository) {
 	s.repo = repo
 }
 
+// SetEndListener registers the listener notified when a session ends.
+func (s *Service) SetEndListener(l EndListener) {
+	s.onEnded = l
+}
+
 func (s *Service) Create(ctx context.Context, userID uuid.UUID, req CreateSessionRequest) (*Session, error) {
 	sess := &Session{
 		ID:          uuid.New(),
@@ -55,6 +67,7 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, re
 		return nil, err
 	}
 
+	ended := sess.EndedAt == nil && req.EndedAt != nil
 	if req.EndedAt != nil {
 		sess.EndedAt = req.EndedAt
 	}
@@ -65,6 +78,11 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, userID uuid.UUID, re
 	if err := s.repo.Update(ctx, sess); err != nil {
 		return nil, fmt.Errorf("update session: %w", err)
 	}
+	if ended && s.onEnded != nil {
+		if detailed, err := s.repo.GetDetailedByID(ctx, id, userID); err == nil {
+			s.onEnded.SessionEnded(ctx, detailed)
+		}
+	}
 	return sess, nil
 }
 
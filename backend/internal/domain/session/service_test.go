@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -108,3 +109,42 @@ func TestService_List(t *testing.T) {
 		t.Errorf("expected 2 sessions, got %d", len(sessions))
 	}
 }
+
+type recordingListener struct {
+	ended []uuid.UUID
+}
+
+func (l *recordingListener) SessionEnded(_ context.Context, sess *SessionDetailed) {
+	l.ended = append(l.ended, sess.ID)
+}
+
+func TestService_UpdateNotifiesEndOnce(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	listener := &recordingListener{}
+	svc.SetEndListener(listener)
+
+	userID := uuid.New()
+	sess, err := svc.Create(context.Background(), userID, CreateSessionRequest{SessionType: "station"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	station := "station-v1"
+	if _, err := svc.Update(context.Background(), sess.ID, userID, UpdateSessionRequest{StationID: &station}); err != nil {
+		t.Fatal(err)
+	}
+	if len(listener.ended) != 0 {
+		t.Fatalf("expected no notification before the session ends")
+	}
+
+	now := time.Now().UTC()
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Update(context.Background(), sess.ID, userID, UpdateSessionRequest{EndedAt: &now}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(listener.ended) != 1 || listener.ended[0] != sess.ID {
+		t.Errorf("expected one end notification, got %v", listener.ended)
+	}
+}
@@ -0,0 +1,125 @@
+package share
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"skillr-mvp-v1/backend/internal/middleware"
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+func (h *Handler) List(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	links, err := h.svc.List(c.Request().Context(), deriveUUID(userInfo.UID))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list share links")
+	}
+	if links == nil {
+		links = []Link{}
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"links": links, "total": len(links)})
+}
+
+// Create returns the new link including its token, which is shown only once.
+func (h *Handler) Create(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	var req CreateLinkRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	l, err := h.svc.Create(c.Request().Context(), deriveUUID(userInfo.UID), req)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusCreated, l)
+}
+
+func (h *Handler) Get(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid share link ID")
+	}
+	l, err := h.svc.Get(c.Request().Context(), id, deriveUUID(userInfo.UID))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to load share link")
+	}
+	return c.JSON(http.StatusOK, l)
+}
+
+func (h *Handler) Revoke(c echo.Context) error {
+	userInfo := middleware.GetUserInfo(c)
+	if userInfo == nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+	}
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid share link ID")
+	}
+	if err := h.svc.Revoke(c.Request().Context(), id, deriveUUID(userInfo.UID)); err != nil {
+		switch {
+		case errors.Is(err, ErrNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrGone):
+			return echo.NewHTTPError(http.StatusConflict, "share link is already revoked")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to revoke share link")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Open serves the shared profile (no auth). Password-protected links are
+// opened with POST and {"password": "..."}, keeping the password out of
+// URLs and logs.
+func (h *Handler) Open(c echo.Context) error {
+	var req struct {
+		Password string `json:"password"`
+	}
+	if c.Request().Method == http.MethodPost {
+		if err := c.Bind(&req); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+		}
+	}
+	p, err := h.svc.Open(c.Request().Context(), c.Param("token"), req.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrGone):
+			return echo.NewHTTPError(http.StatusGone, err.Error())
+		case errors.Is(err, ErrPasswordRequired):
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{"message": err.Error(), "password_required": true})
+		case errors.Is(err, ErrWrongPassword):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to load shared profile")
+	}
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, p)
+}
+
+func deriveUUID(firebaseUID string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceDNS, []byte(firebaseUID))
+}
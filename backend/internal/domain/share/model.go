@@ -0,0 +1,123 @@
+package share
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Link statuses, derived from revoked_at and expires_at.
+const (
+	StatusActive  = "active"
+	StatusExpired = "expired"
+	StatusRevoked = "revoked"
+)
+
+// Limits for share links.
+const (
+	MaxNameLen        = 100
+	MinPasswordLen    = 8
+	DefaultExpiryDays = 30
+	MaxExpiryDays     = 365
+	// MaxScopeItems bounds each list of a scope.
+	MaxScopeItems = 100
+)
+
+// Scope selects what a share link shows. Omitted lists share nothing of
+// that kind; portfolio entries may be shared even when they are private.
+type Scope struct {
+	// Categories are skill category keys of the latest profile.
+	Categories       []string    `json:"categories"`
+	Endorsements     []uuid.UUID `json:"endorsements"`
+	Evidence         []uuid.UUID `json:"evidence"`
+	PortfolioEntries []uuid.UUID `json:"portfolio_entries"`
+}
+
+// Link is a named, expiring link to a tailored public profile. Only the
+// SHA-256 hash of its token is stored; the token (and URL) is returned
+// once, on creation.
+type Link struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	Name         string     `json:"name"`
+	Scope        Scope      `json:"scope"`
+	HasPassword  bool       `json:"has_password"`
+	PasswordHash string     `json:"-"`
+	TokenHash    string     `json:"-"`
+	Token        string     `json:"token,omitempty"`
+	URL          string     `json:"url,omitempty"`
+	Status       string     `json:"status"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	ViewCount    int        `json:"view_count"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CreateLinkRequest creates a share link. ExpiresAt defaults to
+// DefaultExpiryDays from now.
+type CreateLinkRequest struct {
+	Name      string     `json:"name"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Password  string     `json:"password,omitempty"`
+	Scope     Scope      `json:"scope"`
+}
+
+// ScopeCount is the number of scope items owned by the learner, per kind.
+type ScopeCount struct {
+	Endorsements     int
+	Evidence         int
+	PortfolioEntries int
+}
+
+// Category is a skill category of the shared profile.
+type Category struct {
+	Key                    string   `json:"key"`
+	Label                  string   `json:"label"`
+	Score                  float64  `json:"score"`
+	ContributingDimensions []string `json:"contributing_dimensions,omitempty"`
+}
+
+type Endorsement struct {
+	ID               uuid.UUID          `json:"id"`
+	EndorserName     string             `json:"endorser_name"`
+	EndorserRole     string             `json:"endorser_role"`
+	EndorserVerified bool               `json:"endorser_verified"`
+	Organization     string             `json:"endorser_organization,omitempty"`
+	SkillDimensions  map[string]float64 `json:"skill_dimensions,omitempty"`
+	Statement        string             `json:"statement"`
+	Context          *string            `json:"context,omitempty"`
+	CreatedAt        time.Time          `json:"created_at"`
+}
+
+// Evidence is a shared evidence entry. Retracted and revoked entries are
+// never shared.
+type Evidence struct {
+	ID              uuid.UUID          `json:"id"`
+	EvidenceType    string             `json:"evidence_type"`
+	Summary         string             `json:"summary"`
+	SkillDimensions map[string]float64 `json:"skill_dimensions,omitempty"`
+	Confidence      float64            `json:"confidence"`
+	Signed          bool               `json:"signed"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+type PortfolioEntry struct {
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Category    string    `json:"category"`
+	Tags        []string  `json:"tags"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SharedProfile is what a share link shows. It has no user ID, which would
+// open the full public profile.
+type SharedProfile struct {
+	DisplayName      string           `json:"display_name"`
+	SkillCategories  []Category       `json:"skill_categories"`
+	Endorsements     []Endorsement    `json:"endorsements"`
+	Evidence         []Evidence       `json:"evidence"`
+	PortfolioEntries []PortfolioEntry `json:"portfolio_entries"`
+	ExpiresAt        time.Time        `json:"expires_at"`
+}
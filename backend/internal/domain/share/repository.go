@@ -0,0 +1,26 @@
+package share
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, l *Link) error
+	List(ctx context.Context, userID uuid.UUID) ([]Link, error)
+	// GetByID returns the learner's link, or nil if it does not exist.
+	GetByID(ctx context.Context, id, userID uuid.UUID) (*Link, error)
+	// GetByTokenHash returns the link with the token hash, or nil.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Link, error)
+	// Revoke marks an active link revoked and reports whether it did.
+	Revoke(ctx context.Context, id, userID uuid.UUID, at time.Time) (bool, error)
+	RecordView(ctx context.Context, id uuid.UUID, at time.Time) error
+	// CountScope counts the scope's published endorsements, evidence entries
+	// and portfolio entries that belong to the learner.
+	CountScope(ctx context.Context, userID uuid.UUID, scope Scope) (*ScopeCount, error)
+	// LoadContent loads the learner's data selected by scope. Endorsements
+	// that are no longer published are left out.
+	LoadContent(ctx context.Context, userID uuid.UUID, scope Scope) (*SharedProfile, error)
+}
@@ -0,0 +1,266 @@
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrNotFound is returned for unknown links and tokens.
+	ErrNotFound = errors.New("share link not found")
+	// ErrGone is returned for revoked or expired links.
+	ErrGone = errors.New("share link is no longer available")
+	// ErrPasswordRequired is returned when a protected link is opened
+	// without a password.
+	ErrPasswordRequired = errors.New("password required")
+	ErrWrongPassword    = errors.New("wrong password")
+)
+
+type Service struct {
+	repo Repository
+	now  func() time.Time
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
+}
+
+// Create validates the request and stores a new link. The returned link
+// carries the token and URL, which are not retrievable later.
+func (s *Service) Create(ctx context.Context, userID uuid.UUID, req CreateLinkRequest) (*Link, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	now := s.now().UTC()
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if len(req.Name) > MaxNameLen {
+		return nil, fmt.Errorf("name must be at most %d characters", MaxNameLen)
+	}
+	expiresAt := now.AddDate(0, 0, DefaultExpiryDays)
+	if req.ExpiresAt != nil {
+		expiresAt = req.ExpiresAt.UTC()
+	}
+	if !expiresAt.After(now) {
+		return nil, fmt.Errorf("expires_at must be in the future")
+	}
+	if expiresAt.After(now.AddDate(0, 0, MaxExpiryDays)) {
+		return nil, fmt.Errorf("expires_at must be within %d days", MaxExpiryDays)
+	}
+	if req.Password != "" && len(req.Password) < MinPasswordLen {
+		return nil, fmt.Errorf("password must be at least %d characters", MinPasswordLen)
+	}
+	scope, err := s.validateScope(ctx, userID, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	l := &Link{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      req.Name,
+		Scope:     scope,
+		TokenHash: hashToken(token),
+		Token:     token,
+		URL:       "/share/" + token,
+		Status:    StatusActive,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	if req.Password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("hash password: %w", err)
+		}
+		l.PasswordHash = string(hash)
+		l.HasPassword = true
+	}
+	if err := s.repo.Create(ctx, l); err != nil {
+		return nil, fmt.Errorf("create share link: %w", err)
+	}
+	return l, nil
+}
+
+// validateScope deduplicates the scope and checks that every endorsement,
+// evidence entry and portfolio entry belongs to the learner. Endorsements
+// must be published (accepted, visible and not moderated).
+func (s *Service) validateScope(ctx context.Context, userID uuid.UUID, scope Scope) (Scope, error) {
+	out := Scope{
+		Categories:       []string{},
+		Endorsements:     uniqueIDs(scope.Endorsements),
+		Evidence:         uniqueIDs(scope.Evidence),
+		PortfolioEntries: uniqueIDs(scope.PortfolioEntries),
+	}
+	seen := map[string]bool{}
+	for _, c := range scope.Categories {
+		key := strings.ToLower(strings.TrimSpace(c))
+		if key != "" && !seen[key] {
+			seen[key] = true
+			out.Categories = append(out.Categories, key)
+		}
+	}
+	for _, n := range []int{len(out.Categories), len(out.Endorsements), len(out.Evidence), len(out.PortfolioEntries)} {
+		if n > MaxScopeItems {
+			return Scope{}, fmt.Errorf("a scope list may contain at most %d items", MaxScopeItems)
+		}
+	}
+	if len(out.Categories)+len(out.Endorsements)+len(out.Evidence)+len(out.PortfolioEntries) == 0 {
+		return Scope{}, fmt.Errorf("scope must include at least one item")
+	}
+
+	count, err := s.repo.CountScope(ctx, userID, out)
+	if err != nil {
+		return Scope{}, fmt.Errorf("check scope: %w", err)
+	}
+	switch {
+	case count.Endorsements != len(out.Endorsements):
+		return Scope{}, fmt.Errorf("scope contains unknown or unpublished endorsements")
+	case count.Evidence != len(out.Evidence):
+		return Scope{}, fmt.Errorf("scope contains unknown or withdrawn evidence")
+	case count.PortfolioEntries != len(out.PortfolioEntries):
+		return Scope{}, fmt.Errorf("scope contains unknown portfolio entries")
+	}
+	return out, nil
+}
+
+func (s *Service) List(ctx context.Context, userID uuid.UUID) ([]Link, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	links, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	for i := range links {
+		links[i].Status = status(&links[i], now)
+	}
+	return links, nil
+}
+
+func (s *Service) Get(ctx context.Context, id, userID uuid.UUID) (*Link, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	l, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return nil, ErrNotFound
+	}
+	l.Status = status(l, s.now())
+	return l, nil
+}
+
+// Revoke disables a link immediately. Revoked links stay listed with
+// their view statistics.
+func (s *Service) Revoke(ctx context.Context, id, userID uuid.UUID) error {
+	if s.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	ok, err := s.repo.Revoke(ctx, id, userID, s.now().UTC())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if l, err := s.repo.GetByID(ctx, id, userID); err == nil && l != nil {
+			return ErrGone
+		}
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Open resolves a token to the shared profile and counts the view.
+func (s *Service) Open(ctx context.Context, token, password string) (*SharedProfile, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	l, err := s.repo.GetByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return nil, ErrNotFound
+	}
+	now := s.now().UTC()
+	if status(l, now) != StatusActive {
+		return nil, ErrGone
+	}
+	if l.PasswordHash != "" {
+		if password == "" {
+			return nil, ErrPasswordRequired
+		}
+		if bcrypt.CompareHashAndPassword([]byte(l.PasswordHash), []byte(password)) != nil {
+			return nil, ErrWrongPassword
+		}
+	}
+
+	p, err := s.repo.LoadContent(ctx, l.UserID, l.Scope)
+	if err != nil {
+		return nil, fmt.Errorf("load shared content: %w", err)
+	}
+	p.ExpiresAt = l.ExpiresAt
+	if err := s.repo.RecordView(ctx, l.ID, now); err != nil {
+		return nil, fmt.Errorf("record view: %w", err)
+	}
+	return p, nil
+}
+
+func status(l *Link, now time.Time) string {
+	switch {
+	case l.RevokedAt != nil:
+		return StatusRevoked
+	case !now.Before(l.ExpiresAt):
+		return StatusExpired
+	}
+	return StatusActive
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	out := []uuid.UUID{}
+	seen := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		if id != uuid.Nil && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashToken is the lookup key of a token; tokens are high-entropy, so a
+// plain SHA-256 suffices.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,216 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepo struct {
+	links        map[uuid.UUID]*Link
+	endorsements map[uuid.UUID]uuid.UUID // id -> learner
+	evidence     map[uuid.UUID]uuid.UUID
+	entries      map[uuid.UUID]uuid.UUID
+}
+
+func newMockRepo() *mockRepo {
+	return &mockRepo{
+		links:        map[uuid.UUID]*Link{},
+		endorsements: map[uuid.UUID]uuid.UUID{},
+		evidence:     map[uuid.UUID]uuid.UUID{},
+		entries:      map[uuid.UUID]uuid.UUID{},
+	}
+}
+
+func (m *mockRepo) Create(_ context.Context, l *Link) error {
+	stored := *l
+	stored.Token, stored.URL = "", ""
+	m.links[l.ID] = &stored
+	return nil
+}
+
+func (m *mockRepo) List(_ context.Context, userID uuid.UUID) ([]Link, error) {
+	var out []Link
+	for _, l := range m.links {
+		if l.UserID == userID {
+			out = append(out, *l)
+		}
+	}
+	return out, nil
+}
+
+func (m *mockRepo) GetByID(_ context.Context, id, userID uuid.UUID) (*Link, error) {
+	if l, ok := m.links[id]; ok && l.UserID == userID {
+		c := *l
+		return &c, nil
+	}
+	return nil, nil
+}
+
+func (m *mockRepo) GetByTokenHash(_ context.Context, tokenHash string) (*Link, error) {
+	for _, l := range m.links {
+		if l.TokenHash == tokenHash {
+			c := *l
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockRepo) Revoke(_ context.Context, id, userID uuid.UUID, at time.Time) (bool, error) {
+	l, ok := m.links[id]
+	if !ok || l.UserID != userID || l.RevokedAt != nil {
+		return false, nil
+	}
+	l.RevokedAt = &at
+	return true, nil
+}
+
+func (m *mockRepo) RecordView(_ context.Context, id uuid.UUID, at time.Time) error {
+	m.links[id].ViewCount++
+	m.links[id].LastViewedAt = &at
+	return nil
+}
+
+func (m *mockRepo) CountScope(_ context.Context, userID uuid.UUID, scope Scope) (*ScopeCount, error) {
+	count := func(ids []uuid.UUID, owners map[uuid.UUID]uuid.UUID) int {
+		n := 0
+		for _, id := range ids {
+			if owners[id] == userID {
+				n++
+			}
+		}
+		return n
+	}
+	return &ScopeCount{
+		Endorsements:     count(scope.Endorsements, m.endorsements),
+		Evidence:         count(scope.Evidence, m.evidence),
+		PortfolioEntries: count(scope.PortfolioEntries, m.entries),
+	}, nil
+}
+
+func (m *mockRepo) LoadContent(_ context.Context, _ uuid.UUID, scope Scope) (*SharedProfile, error) {
+	p := &SharedProfile{DisplayName: "Mia"}
+	for _, key := range scope.Categories {
+		p.SkillCategories = append(p.SkillCategories, Category{Key: key})
+	}
+	for _, id := range scope.Endorsements {
+		p.Endorsements = append(p.Endorsements, Endorsement{ID: id})
+	}
+	return p, nil
+}
+
+func TestService_CreateValidates(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	ctx := context.Background()
+	learner, other := uuid.New(), uuid.New()
+	own, foreign := uuid.New(), uuid.New()
+	repo.endorsements[own] = learner
+	repo.endorsements[foreign] = other
+
+	l, err := svc.Create(ctx, learner, CreateLinkRequest{
+		Name:  " Bewerbung Tischlerei ",
+		Scope: Scope{Categories: []string{"Soft-Skills", "soft-skills"}, Endorsements: []uuid.UUID{own, own}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Name != "Bewerbung Tischlerei" || len(l.Token) != 64 || l.URL != "/share/"+l.Token || l.Status != StatusActive {
+		t.Errorf("unexpected link %+v", l)
+	}
+	if len(l.Scope.Categories) != 1 || len(l.Scope.Endorsements) != 1 {
+		t.Errorf("expected a deduplicated scope, got %+v", l.Scope)
+	}
+	if d := time.Until(l.ExpiresAt); d < 29*24*time.Hour || d > 31*24*time.Hour {
+		t.Errorf("expected the default expiry, got %v", l.ExpiresAt)
+	}
+	if stored := repo.links[l.ID]; stored.TokenHash != hashToken(l.Token) || stored.Token != "" {
+		t.Error("expected only the token hash to be stored")
+	}
+
+	past, far := time.Now().Add(-time.Hour), time.Now().AddDate(2, 0, 0)
+	scope := Scope{Categories: []string{"soft-skills"}}
+	for name, req := range map[string]CreateLinkRequest{
+		"no name":          {Scope: scope},
+		"expired":          {Name: "x", ExpiresAt: &past, Scope: scope},
+		"too far":          {Name: "x", ExpiresAt: &far, Scope: scope},
+		"short password":   {Name: "x", Password: "123", Scope: scope},
+		"empty scope":      {Name: "x"},
+		"foreign item":     {Name: "x", Scope: Scope{Endorsements: []uuid.UUID{foreign}}},
+		"unknown evidence": {Name: "x", Scope: Scope{Evidence: []uuid.UUID{uuid.New()}}},
+	} {
+		if _, err := svc.Create(ctx, learner, req); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestService_OpenTracksViewsAndRevocation(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	ctx := context.Background()
+	learner := uuid.New()
+
+	l, err := svc.Create(ctx, learner, CreateLinkRequest{Name: "Praktikum", Password: "geheim123", Scope: Scope{Categories: []string{"resilience"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Open(ctx, l.Token, ""); !errors.Is(err, ErrPasswordRequired) {
+		t.Errorf("expected ErrPasswordRequired, got %v", err)
+	}
+	if _, err := svc.Open(ctx, l.Token, "falsch123"); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("expected ErrWrongPassword, got %v", err)
+	}
+	p, err := svc.Open(ctx, l.Token, "geheim123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.SkillCategories) != 1 || !p.ExpiresAt.Equal(l.ExpiresAt) {
+		t.Errorf("unexpected shared profile %+v", p)
+	}
+	if got, _ := svc.Get(ctx, l.ID, learner); got.ViewCount != 1 || got.LastViewedAt == nil || !got.HasPassword {
+		t.Errorf("expected one recorded view, got %+v", got)
+	}
+	if _, err := svc.Open(ctx, "unknown", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := svc.Revoke(ctx, l.ID, uuid.New()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected other learners to get ErrNotFound, got %v", err)
+	}
+	if err := svc.Revoke(ctx, l.ID, learner); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Revoke(ctx, l.ID, learner); !errors.Is(err, ErrGone) {
+		t.Errorf("expected ErrGone for a second revoke, got %v", err)
+	}
+	if _, err := svc.Open(ctx, l.Token, "geheim123"); !errors.Is(err, ErrGone) {
+		t.Errorf("expected a revoked link to be gone, got %v", err)
+	}
+	links, _ := svc.List(ctx, learner)
+	if len(links) != 1 || links[0].Status != StatusRevoked {
+		t.Errorf("expected the revoked link to stay listed, got %+v", links)
+	}
+}
+
+func TestService_OpenExpired(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	ctx := context.Background()
+
+	l, err := svc.Create(ctx, uuid.New(), CreateLinkRequest{Name: "Kurz", Scope: Scope{Categories: []string{"resilience"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc.now = func() time.Time { return l.ExpiresAt }
+	if _, err := svc.Open(ctx, l.Token, ""); !errors.Is(err, ErrGone) {
+		t.Errorf("expected an expired link to be gone, got %v", err)
+	}
+	if got, _ := svc.Get(ctx, l.ID, l.UserID); got.Status != StatusExpired || got.ViewCount != 0 {
+		t.Errorf("expected an expired link without views, got %+v", got)
+	}
+}
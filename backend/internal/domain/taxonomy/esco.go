@@ -0,0 +1,218 @@
+package taxonomy
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// escoRow is one skill of an ESCO CSV dump (skills_<lang>.csv).
+type escoRow struct {
+	uri       string
+	preferred string
+	alt       []string
+}
+
+// ParseESCO applies an ESCO skills CSV dump to a copy of base. Dimensions
+// listed in req.Mapping (or matched by label with req.AutoMatch) get their
+// ESCO URI and, where missing, a label in req.Locale. With req.Category set,
+// all remaining skills are added as dimensions of that category.
+func ParseESCO(r io.Reader, base *Registry, req ImportRequest) (*Registry, ImportStats, error) {
+	var stats ImportStats
+	locale := req.Locale
+	if locale == "" {
+		locale = DefaultLocale
+	}
+	reg := base.clone()
+	if err := reg.Validate(); err != nil {
+		return nil, stats, err
+	}
+	if req.Category != "" {
+		if _, ok := reg.idx().categories[req.Category]; !ok {
+			return nil, stats, fmt.Errorf("unknown category %q", req.Category)
+		}
+	}
+
+	rows, skipped, err := readESCO(r)
+	if err != nil {
+		return nil, stats, err
+	}
+	stats.Rows, stats.Skipped = len(rows)+skipped, skipped
+
+	byURI := make(map[string]*escoRow, len(rows))
+	byLabel := map[string]string{}
+	ambiguous := map[string]bool{}
+	for i := range rows {
+		row := &rows[i]
+		byURI[row.uri] = row
+		for _, l := range append([]string{row.preferred}, row.alt...) {
+			l = strings.ToLower(strings.TrimSpace(l))
+			if l == "" {
+				continue
+			}
+			if prev, ok := byLabel[l]; ok && prev != row.uri {
+				ambiguous[l] = true
+			}
+			byLabel[l] = row.uri
+		}
+	}
+
+	// Explicit mappings win over existing URIs and label matches.
+	unknown, missing := map[string]bool{}, map[string]bool{}
+	for key, uri := range req.Mapping {
+		k, ok := reg.Resolve(key)
+		if !ok {
+			unknown[key] = true
+			continue
+		}
+		if _, ok := byURI[uri]; !ok {
+			missing[uri] = true
+			continue
+		}
+		for i := range reg.Dimensions {
+			if reg.Dimensions[i].ESCOURI == uri && reg.Dimensions[i].Key != k {
+				reg.Dimensions[i].ESCOURI = ""
+			}
+		}
+		reg.Dimensions[reg.idx().dimensions[k]].ESCOURI = uri
+		stats.Mapped++
+	}
+	if len(unknown) > 0 {
+		return nil, stats, &UnknownDimensionsError{Keys: sortedKeys(unknown)}
+	}
+	if len(missing) > 0 {
+		return nil, stats, fmt.Errorf("ESCO URIs not found in dump: %s", strings.Join(sortedKeys(missing), ", "))
+	}
+
+	assigned := map[string]bool{}
+	for i := range reg.Dimensions {
+		if uri := reg.Dimensions[i].ESCOURI; uri != "" {
+			assigned[uri] = true
+		}
+	}
+	for i := range reg.Dimensions {
+		d := &reg.Dimensions[i]
+		if d.ESCOURI == "" && req.AutoMatch {
+			l := strings.ToLower(label(d.Labels, locale, ""))
+			if uri, ok := byLabel[l]; ok && !ambiguous[l] && !assigned[uri] {
+				d.ESCOURI = uri
+				assigned[uri] = true
+				stats.Mapped++
+			}
+		}
+		row, ok := byURI[d.ESCOURI]
+		if !ok || row.preferred == "" {
+			continue
+		}
+		if d.Labels == nil {
+			d.Labels = map[string]string{}
+		}
+		if d.Labels[locale] == "" {
+			d.Labels[locale] = row.preferred
+			stats.Labeled++
+		}
+	}
+
+	if req.Category != "" {
+		taken := map[string]bool{}
+		for _, d := range reg.Dimensions {
+			taken[d.Key] = true
+		}
+		for alias := range reg.idx().aliases {
+			taken[alias] = true
+		}
+		for _, row := range rows {
+			if assigned[row.uri] {
+				continue
+			}
+			key := slug(row.preferred)
+			if key == "" || taken[key] {
+				stats.Skipped++
+				continue
+			}
+			taken[key] = true
+			reg.Dimensions = append(reg.Dimensions, Dimension{
+				Key:      key,
+				Category: req.Category,
+				Labels:   map[string]string{locale: row.preferred},
+				ESCOURI:  row.uri,
+			})
+			stats.Added++
+		}
+	}
+
+	if err := reg.Validate(); err != nil {
+		return nil, stats, err
+	}
+	return reg, stats, nil
+}
+
+// readESCO reads the released skills of a dump. Columns are located by their
+// header names, so dumps of any ESCO version and language can be used.
+func readESCO(r io.Reader) ([]escoRow, int, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, 0, fmt.Errorf("read ESCO header: %w", err)
+	}
+	col := map[string]int{}
+	for i, h := range header {
+		col[strings.TrimSpace(strings.TrimPrefix(h, "\ufeff"))] = i
+	}
+	uriCol, ok := col["conceptUri"]
+	if !ok {
+		return nil, 0, fmt.Errorf("ESCO dump has no conceptUri column")
+	}
+	prefCol, ok := col["preferredLabel"]
+	if !ok {
+		return nil, 0, fmt.Errorf("ESCO dump has no preferredLabel column")
+	}
+	field := func(rec []string, name string) string {
+		if i, ok := col[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var rows []escoRow
+	skipped := 0
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("read ESCO dump: %w", err)
+		}
+		if uriCol >= len(rec) || prefCol >= len(rec) {
+			skipped++
+			continue
+		}
+		row := escoRow{uri: strings.TrimSpace(rec[uriCol]), preferred: strings.TrimSpace(rec[prefCol])}
+		if status := field(rec, "status"); row.uri == "" || (status != "" && status != "released") {
+			skipped++
+			continue
+		}
+		if alt := field(rec, "altLabels"); alt != "" {
+			row.alt = strings.Split(alt, "\n")
+		}
+		rows = append(rows, row)
+	}
+	return rows, skipped, nil
+}
+
+// slug derives a dimension key from an ESCO label.
+func slug(s string) string {
+	return NormalizeKey(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s))
+}
@@ -0,0 +1,84 @@
+package taxonomy
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(svc *Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Get returns the current registry, or the version given by ?version=.
+func (h *Handler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+	if v := c.QueryParam("version"); v != "" {
+		version, err := strconv.Atoi(v)
+		if err != nil || version < 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid version")
+		}
+		reg, err := h.svc.Version(ctx, version)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to load taxonomy")
+		}
+		return c.JSON(http.StatusOK, reg)
+	}
+	reg, err := h.svc.Current(ctx)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to load taxonomy")
+	}
+	return c.JSON(http.StatusOK, reg)
+}
+
+// Versions lists all published registry versions, newest first.
+func (h *Handler) Versions(c echo.Context) error {
+	versions, err := h.svc.Versions(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list taxonomy versions")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"versions": versions, "total": len(versions)})
+}
+
+// Resolve maps ?key= (a dimension key, alias or ESCO URI) to its dimension.
+func (h *Handler) Resolve(c echo.Context) error {
+	key := c.QueryParam("key")
+	if key == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "key is required")
+	}
+	reg := h.svc.Registry()
+	dim, ok := reg.Dimension(key)
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "unknown skill dimension")
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"version": reg.Version, "dimension": dim})
+}
+
+// AdminImportESCO imports an ESCO CSV dump from the server's import directory.
+func (h *Handler) AdminImportESCO(c echo.Context) error {
+	var req ImportRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	res, err := h.svc.ImportESCO(c.Request().Context(), req)
+	if err != nil {
+		if errors.Is(err, ErrImportDisabled) {
+			return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	status := http.StatusOK
+	if res.Published {
+		status = http.StatusCreated
+	}
+	return c.JSON(status, res)
+}
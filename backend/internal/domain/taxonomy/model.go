@@ -0,0 +1,122 @@
+package taxonomy
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is used when a label is missing in the requested locale.
+const DefaultLocale = "de"
+
+// ErrUnknownDimension is matched by errors.Is for writes that use dimension
+// keys the registry cannot resolve.
+var ErrUnknownDimension = errors.New("unknown skill dimension")
+
+// ErrInvalidScore is returned for dimension scores outside 0-100.
+var ErrInvalidScore = errors.New("invalid skill dimension score")
+
+// ErrNotFound is returned when a registry version does not exist.
+var ErrNotFound = errors.New("taxonomy version not found")
+
+// UnknownDimensionsError lists the keys of a write that could not be resolved.
+type UnknownDimensionsError struct {
+	Keys []string
+}
+
+func (e *UnknownDimensionsError) Error() string {
+	return "unknown skill dimensions: " + strings.Join(e.Keys, ", ")
+}
+
+func (e *UnknownDimensionsError) Is(target error) bool {
+	return target == ErrUnknownDimension
+}
+
+// Category groups dimensions. Categories without a parent are the top-level
+// categories shown on the skill profile.
+type Category struct {
+	Key    string            `json:"key"`
+	Parent string            `json:"parent,omitempty"`
+	Labels map[string]string `json:"labels"`
+}
+
+// Dimension is one canonical skill dimension key.
+type Dimension struct {
+	Key      string            `json:"key"`
+	Category string            `json:"category"`
+	Labels   map[string]string `json:"labels"`
+	// ESCOURI is the concept URI of the matching ESCO skill, if mapped.
+	ESCOURI string `json:"esco_uri,omitempty"`
+}
+
+// Registry is one published version of the skill taxonomy. Aliases rewrite
+// legacy or alternative keys (e.g. reflection capability names) to canonical
+// dimension keys.
+type Registry struct {
+	Version    int               `json:"version"`
+	Source     string            `json:"source"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Categories []Category        `json:"categories"`
+	Dimensions []Dimension       `json:"dimensions"`
+	Aliases    map[string]string `json:"aliases,omitempty"`
+
+	index *index
+}
+
+// VersionInfo describes a published registry version without its content.
+type VersionInfo struct {
+	Version    int       `json:"version"`
+	Source     string    `json:"source"`
+	Dimensions int       `json:"dimensions"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Info returns the version summary of r.
+func (r *Registry) Info() VersionInfo {
+	return VersionInfo{Version: r.Version, Source: r.Source, Dimensions: len(r.Dimensions), CreatedAt: r.CreatedAt}
+}
+
+// ImportRequest configures an ESCO CSV import from the import directory.
+type ImportRequest struct {
+	// File is the path of the CSV dump relative to the import directory.
+	File string `json:"file"`
+	// Locale is the language of the dump's labels (default "de").
+	Locale string `json:"locale,omitempty"`
+	// Mapping assigns ESCO concept URIs to dimension keys.
+	Mapping map[string]string `json:"mapping,omitempty"`
+	// AutoMatch maps unmapped dimensions whose label equals an ESCO
+	// preferred or alternative label.
+	AutoMatch bool `json:"auto_match,omitempty"`
+	// Category, when set, adds every unmatched ESCO skill as a new dimension
+	// of this category.
+	Category string `json:"category,omitempty"`
+	// DryRun reports the stats without publishing a new version.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// ImportStats summarises one ESCO import.
+type ImportStats struct {
+	Rows    int `json:"rows"`
+	Skipped int `json:"skipped"`
+	Mapped  int `json:"mapped"`
+	Labeled int `json:"labeled"`
+	Added   int `json:"added"`
+}
+
+// ImportResult is the registry produced by an import and its stats.
+type ImportResult struct {
+	Registry  *Registry   `json:"registry"`
+	Stats     ImportStats `json:"stats"`
+	Published bool        `json:"published"`
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
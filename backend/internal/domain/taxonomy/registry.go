@@ -0,0 +1,281 @@
+package taxonomy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"skillr-mvp-v1/backend/internal/domain/profile"
+	"skillr-mvp-v1/backend/internal/skillscore"
+)
+
+// Default returns the built-in registry. It mirrors the four categories and
+// aliases of profile.DefaultCategoryTable.
+func Default() *Registry {
+	return &Registry{
+		Version: 1,
+		Source:  "builtin",
+		Categories: []Category{
+			{Key: "hard-skills", Labels: labels("Fachkompetenzen", "Hard skills")},
+			{Key: "soft-skills", Labels: labels("Sozialkompetenzen", "Soft skills")},
+			{Key: "future-skills", Labels: labels("Zukunftskompetenzen", "Future skills")},
+			{Key: "resilience", Labels: labels("Resilienz", "Resilience")},
+		},
+		Dimensions: []Dimension{
+			{Key: "analytical-thinking", Category: "hard-skills", Labels: labels("Analytisches Denken", "Analytical thinking")},
+			{Key: "problem-solving", Category: "hard-skills", Labels: labels("Problemlösung", "Problem solving")},
+			{Key: "digital-literacy", Category: "hard-skills", Labels: labels("Digitale Kompetenz", "Digital literacy")},
+			{Key: "planning", Category: "hard-skills", Labels: labels("Planung", "Planning")},
+			{Key: "teamwork", Category: "soft-skills", Labels: labels("Teamarbeit", "Teamwork")},
+			{Key: "communication", Category: "soft-skills", Labels: labels("Kommunikation", "Communication")},
+			{Key: "empathy", Category: "soft-skills", Labels: labels("Empathie", "Empathy")},
+			{Key: "self-awareness", Category: "soft-skills", Labels: labels("Selbstwahrnehmung", "Self-awareness")},
+			{Key: "creativity", Category: "future-skills", Labels: labels("Kreativität", "Creativity")},
+			{Key: "initiative", Category: "future-skills", Labels: labels("Eigeninitiative", "Initiative")},
+			{Key: "adaptability", Category: "future-skills", Labels: labels("Anpassungsfähigkeit", "Adaptability")},
+			{Key: "curiosity", Category: "future-skills", Labels: labels("Neugier", "Curiosity")},
+			{Key: "resilience", Category: "resilience", Labels: labels("Resilienz", "Resilience")},
+			{Key: "persistence", Category: "resilience", Labels: labels("Durchhaltevermögen", "Persistence")},
+			{Key: "confidence", Category: "resilience", Labels: labels("Selbstvertrauen", "Confidence")},
+			{Key: "ambiguity-tolerance", Category: "resilience", Labels: labels("Ambiguitätstoleranz", "Tolerance of ambiguity")},
+		},
+		Aliases: map[string]string{
+			"analytical-depth": "analytical-thinking",
+			"volatility":       "adaptability",
+			"change":           "adaptability",
+			"uncertainty":      "ambiguity-tolerance",
+			"complexity":       "problem-solving",
+			"ambiguity":        "ambiguity-tolerance",
+		},
+	}
+}
+
+func labels(de, en string) map[string]string {
+	return map[string]string{"de": de, "en": en}
+}
+
+// NormalizeKey brings a key into canonical form: lower case, with
+// underscores and spaces replaced by hyphens.
+func NormalizeKey(key string) string {
+	k := strings.ToLower(strings.TrimSpace(key))
+	return strings.Join(strings.FieldsFunc(k, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' ' || r == '\t'
+	}), "-")
+}
+
+// index speeds up key resolution. It is built by Validate.
+type index struct {
+	dimensions map[string]int
+	categories map[string]int
+	aliases    map[string]string
+	esco       map[string]string
+}
+
+// Validate checks the registry for consistency and builds its lookup index.
+// Keys must be canonical, categories must form a tree and every alias and
+// ESCO URI must resolve to exactly one dimension.
+func (r *Registry) Validate() error {
+	idx := &index{
+		dimensions: map[string]int{},
+		categories: map[string]int{},
+		aliases:    map[string]string{},
+		esco:       map[string]string{},
+	}
+	for i, c := range r.Categories {
+		if c.Key == "" || NormalizeKey(c.Key) != c.Key {
+			return fmt.Errorf("category key %q is not canonical", c.Key)
+		}
+		if _, dup := idx.categories[c.Key]; dup {
+			return fmt.Errorf("duplicate category %q", c.Key)
+		}
+		idx.categories[c.Key] = i
+	}
+	for _, c := range r.Categories {
+		seen := map[string]bool{c.Key: true}
+		for p := c.Parent; p != ""; p = r.Categories[idx.categories[p]].Parent {
+			if _, ok := idx.categories[p]; !ok {
+				return fmt.Errorf("category %q has unknown parent %q", c.Key, p)
+			}
+			if seen[p] {
+				return fmt.Errorf("category %q is part of a cycle", c.Key)
+			}
+			seen[p] = true
+		}
+	}
+	for i, d := range r.Dimensions {
+		if d.Key == "" || NormalizeKey(d.Key) != d.Key {
+			return fmt.Errorf("dimension key %q is not canonical", d.Key)
+		}
+		if _, dup := idx.dimensions[d.Key]; dup {
+			return fmt.Errorf("duplicate dimension %q", d.Key)
+		}
+		if _, ok := idx.categories[d.Category]; !ok {
+			return fmt.Errorf("dimension %q has unknown category %q", d.Key, d.Category)
+		}
+		if d.ESCOURI != "" {
+			if other, dup := idx.esco[d.ESCOURI]; dup {
+				return fmt.Errorf("ESCO URI %s is mapped to both %q and %q", d.ESCOURI, other, d.Key)
+			}
+			idx.esco[d.ESCOURI] = d.Key
+		}
+		idx.dimensions[d.Key] = i
+	}
+	for alias, target := range r.Aliases {
+		k := NormalizeKey(alias)
+		if _, ok := idx.dimensions[k]; ok {
+			return fmt.Errorf("alias %q shadows a dimension", alias)
+		}
+		if _, ok := idx.dimensions[target]; !ok {
+			return fmt.Errorf("alias %q points to unknown dimension %q", alias, target)
+		}
+		idx.aliases[k] = target
+	}
+	r.index = idx
+	return nil
+}
+
+func (r *Registry) idx() *index {
+	if r.index == nil {
+		_ = r.Validate()
+	}
+	return r.index
+}
+
+// Resolve maps a key, alias or ESCO URI to its canonical dimension key.
+func (r *Registry) Resolve(key string) (string, bool) {
+	idx := r.idx()
+	if idx == nil {
+		return "", false
+	}
+	if k, ok := idx.esco[strings.TrimSpace(key)]; ok {
+		return k, true
+	}
+	k := NormalizeKey(key)
+	if _, ok := idx.dimensions[k]; ok {
+		return k, true
+	}
+	if target, ok := idx.aliases[k]; ok {
+		return target, true
+	}
+	return "", false
+}
+
+// Normalize rewrites the keys of dims to canonical dimension keys. Keys that
+// resolve to the same dimension keep the highest score. Unknown keys fail
+// the whole write with an *UnknownDimensionsError, scores outside 0-100
+// with ErrInvalidScore.
+func (r *Registry) Normalize(dims map[string]float64) (map[string]float64, error) {
+	if dims == nil {
+		return nil, nil
+	}
+	var invalid []string
+	for key, score := range dims {
+		if !skillscore.Valid(score) {
+			invalid = append(invalid, key)
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return nil, fmt.Errorf("%w: %s must be between 0 and 100", ErrInvalidScore, strings.Join(invalid, ", "))
+	}
+	out := make(map[string]float64, len(dims))
+	unknown := map[string]bool{}
+	for key, score := range dims {
+		k, ok := r.Resolve(key)
+		if !ok {
+			unknown[key] = true
+			continue
+		}
+		if prev, seen := out[k]; !seen || score > prev {
+			out[k] = score
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, &UnknownDimensionsError{Keys: sortedKeys(unknown)}
+	}
+	return out, nil
+}
+
+// Dimension returns the dimension a key, alias or ESCO URI resolves to.
+func (r *Registry) Dimension(key string) (*Dimension, bool) {
+	k, ok := r.Resolve(key)
+	if !ok {
+		return nil, false
+	}
+	d := r.Dimensions[r.idx().dimensions[k]]
+	return &d, true
+}
+
+// Label returns the label of a dimension in locale, falling back to the
+// default locale and then to the key itself.
+func (r *Registry) Label(key, locale string) string {
+	d, ok := r.Dimension(key)
+	if !ok {
+		return key
+	}
+	return label(d.Labels, locale, d.Key)
+}
+
+func label(l map[string]string, locale, fallback string) string {
+	if v := l[locale]; v != "" {
+		return v
+	}
+	if v := l[DefaultLocale]; v != "" {
+		return v
+	}
+	return fallback
+}
+
+// root returns the top-level ancestor of a category.
+func (r *Registry) root(key string) string {
+	idx := r.idx()
+	for {
+		p := r.Categories[idx.categories[key]].Parent
+		if p == "" {
+			return key
+		}
+		key = p
+	}
+}
+
+// CategoryTable derives the profile computation mapping. Each top-level
+// category collects the dimensions of its whole subtree; aliases and ESCO
+// URIs are carried over so signals using them are still counted.
+func (r *Registry) CategoryTable(locale string) profile.CategoryTable {
+	idx := r.idx()
+	table := profile.CategoryTable{Aliases: map[string]string{}}
+	pos := map[string]int{}
+	for _, c := range r.Categories {
+		if c.Parent != "" {
+			continue
+		}
+		pos[c.Key] = len(table.Categories)
+		table.Categories = append(table.Categories, profile.CategoryDefinition{Key: c.Key, Label: label(c.Labels, locale, c.Key)})
+	}
+	for _, d := range r.Dimensions {
+		i := pos[r.root(d.Category)]
+		table.Categories[i].Dimensions = append(table.Categories[i].Dimensions, d.Key)
+		if u := strings.ReplaceAll(d.Key, "-", "_"); u != d.Key {
+			table.Aliases[u] = d.Key
+		}
+	}
+	for alias, target := range idx.aliases {
+		table.Aliases[alias] = target
+		if u := strings.ReplaceAll(alias, "-", "_"); u != alias {
+			table.Aliases[u] = target
+		}
+	}
+	for uri, key := range idx.esco {
+		table.Aliases[strings.ToLower(uri)] = key
+	}
+	return table
+}
+
+// clone returns a deep copy of r without its index.
+func (r *Registry) clone() *Registry {
+	data, _ := json.Marshal(r)
+	var c Registry
+	_ = json.Unmarshal(data, &c)
+	return &c
+}
@@ -0,0 +1,13 @@
+package taxonomy
+
+import "context"
+
+type Repository interface {
+	// Latest returns the newest published registry, or nil if none exists.
+	Latest(ctx context.Context) (*Registry, error)
+	// Get returns the registry of a version, or nil if it does not exist.
+	Get(ctx context.Context, version int) (*Registry, error)
+	ListVersions(ctx context.Context) ([]VersionInfo, error)
+	// Create publishes r. It fails if r.Version already exists.
+	Create(ctx context.Context, r *Registry) error
+}
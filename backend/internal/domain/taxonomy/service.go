@@ -0,0 +1,206 @@
+package taxonomy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrImportDisabled is returned when no import directory is configured.
+var ErrImportDisabled = errors.New("taxonomy import directory not configured")
+
+// Service holds the current registry. Writes in other domains resolve their
+// dimension keys through it; until Load succeeds the built-in registry is used.
+type Service struct {
+	repo      Repository
+	importDir string
+
+	mu       sync.RWMutex
+	current  *Registry
+	onChange []func(*Registry)
+}
+
+func NewService(repo Repository) *Service {
+	reg := Default()
+	_ = reg.Validate()
+	return &Service{repo: repo, current: reg}
+}
+
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (s *Service) SetRepo(repo Repository) {
+	s.repo = repo
+}
+
+// SetImportDir sets the directory ESCO dumps are imported from.
+func (s *Service) SetImportDir(dir string) {
+	s.importDir = dir
+}
+
+// OnChange registers fn to be called with every newly loaded or published
+// registry, and once right away with the current one.
+func (s *Service) OnChange(fn func(*Registry)) {
+	s.mu.Lock()
+	s.onChange = append(s.onChange, fn)
+	reg := s.current
+	s.mu.Unlock()
+	fn(reg)
+}
+
+// Registry returns the current registry.
+func (s *Service) Registry() *Registry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current
+}
+
+func (s *Service) set(reg *Registry) {
+	s.mu.Lock()
+	s.current = reg
+	listeners := append([]func(*Registry){}, s.onChange...)
+	s.mu.Unlock()
+	for _, fn := range listeners {
+		fn(reg)
+	}
+}
+
+// Load reads the latest published registry. An empty store is seeded with
+// the built-in registry as version 1.
+func (s *Service) Load(ctx context.Context) (*Registry, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	reg, err := s.repo.Latest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load taxonomy: %w", err)
+	}
+	if reg == nil {
+		reg = Default()
+		reg.CreatedAt = time.Now().UTC()
+		if err := s.repo.Create(ctx, reg); err != nil {
+			return nil, fmt.Errorf("seed taxonomy: %w", err)
+		}
+	}
+	if err := reg.Validate(); err != nil {
+		return nil, fmt.Errorf("taxonomy version %d: %w", reg.Version, err)
+	}
+	s.set(reg)
+	return reg, nil
+}
+
+// Current returns the latest published registry, picking up versions
+// published by other instances.
+func (s *Service) Current(ctx context.Context) (*Registry, error) {
+	if s.repo == nil {
+		return s.Registry(), nil
+	}
+	cur := s.Registry()
+	latest, err := s.repo.Latest(ctx)
+	if err != nil || latest == nil || latest.Version == cur.Version {
+		return cur, err
+	}
+	if err := latest.Validate(); err != nil {
+		return nil, fmt.Errorf("taxonomy version %d: %w", latest.Version, err)
+	}
+	s.set(latest)
+	return latest, nil
+}
+
+// Version returns a published registry version.
+func (s *Service) Version(ctx context.Context, version int) (*Registry, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	reg, err := s.repo.Get(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	if reg == nil {
+		return nil, ErrNotFound
+	}
+	return reg, nil
+}
+
+func (s *Service) Versions(ctx context.Context) ([]VersionInfo, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	return s.repo.ListVersions(ctx)
+}
+
+// Resolve maps a key, alias or ESCO URI to its canonical dimension key.
+func (s *Service) Resolve(key string) (string, bool) {
+	return s.Registry().Resolve(key)
+}
+
+// Normalize rewrites dimension keys of a write to canonical keys and rejects
+// unknown ones (see Registry.Normalize).
+func (s *Service) Normalize(dims map[string]float64) (map[string]float64, error) {
+	return s.Registry().Normalize(dims)
+}
+
+// Label returns the label of a dimension in locale (see Registry.Label).
+func (s *Service) Label(key, locale string) string {
+	return s.Registry().Label(key, locale)
+}
+
+// Publish stores reg as the next version and makes it current.
+func (s *Service) Publish(ctx context.Context, reg *Registry, source string) (*Registry, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+	if err := reg.Validate(); err != nil {
+		return nil, err
+	}
+	cur, err := s.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reg.Version = cur.Version + 1
+	reg.Source = source
+	reg.CreatedAt = time.Now().UTC()
+	if err := s.repo.Create(ctx, reg); err != nil {
+		return nil, fmt.Errorf("publish taxonomy: %w", err)
+	}
+	s.set(reg)
+	return reg, nil
+}
+
+// ImportESCO applies an ESCO CSV dump from the import directory to the
+// current registry and publishes the result unless req.DryRun is set.
+func (s *Service) ImportESCO(ctx context.Context, req ImportRequest) (*ImportResult, error) {
+	if s.importDir == "" {
+		return nil, ErrImportDisabled
+	}
+	if req.File == "" {
+		return nil, fmt.Errorf("file is required")
+	}
+	// Cleaning against "/" keeps the path inside the import directory.
+	path := filepath.Join(s.importDir, filepath.Clean("/"+req.File))
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", req.File, errors.Unwrap(err))
+	}
+	defer f.Close()
+
+	cur, err := s.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reg, stats, err := ParseESCO(f, cur, req)
+	if err != nil {
+		return nil, err
+	}
+	res := &ImportResult{Registry: reg, Stats: stats}
+	if req.DryRun {
+		return res, nil
+	}
+	if res.Registry, err = s.Publish(ctx, reg, "esco:"+filepath.Base(path)); err != nil {
+		return nil, err
+	}
+	res.Published = true
+	return res, nil
+}
@@ -0,0 +1,250 @@
+package taxonomy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"skillr-mvp-v1/backend/internal/domain/profile"
+)
+
+type mockRepo struct {
+	mu       sync.Mutex
+	versions map[int]*Registry
+}
+
+func newMockRepo() *mockRepo {
+	return &mockRepo{versions: map[int]*Registry{}}
+}
+
+func (m *mockRepo) Latest(_ context.Context) (*Registry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var latest *Registry
+	for _, r := range m.versions {
+		if latest == nil || r.Version > latest.Version {
+			latest = r
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	return latest.clone(), nil
+}
+
+func (m *mockRepo) Get(_ context.Context, version int) (*Registry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if r, ok := m.versions[version]; ok {
+		return r.clone(), nil
+	}
+	return nil, nil
+}
+
+func (m *mockRepo) ListVersions(_ context.Context) ([]VersionInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []VersionInfo
+	for _, r := range m.versions {
+		out = append(out, r.Info())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version > out[j].Version })
+	return out, nil
+}
+
+func (m *mockRepo) Create(_ context.Context, r *Registry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, dup := m.versions[r.Version]; dup {
+		return fmt.Errorf("version %d exists", r.Version)
+	}
+	m.versions[r.Version] = r.clone()
+	return nil
+}
+
+// escoDump is a small excerpt in the column layout of ESCO's skills_de.csv.
+const escoDump = "\ufeffconceptType,conceptUri,skillType,reuseLevel,preferredLabel,altLabels,hiddenLabels,status\n" +
+	"KnowledgeSkillCompetence,http://data.europa.eu/esco/skill/team,skill/competence,transversal,im Team arbeiten,\"Teamarbeit\nTeamfähigkeit\",,released\n" +
+	"KnowledgeSkillCompetence,http://data.europa.eu/esco/skill/empathy,skill/competence,transversal,Empathie zeigen,Empathie,,released\n" +
+	"KnowledgeSkillCompetence,http://data.europa.eu/esco/skill/python,knowledge,sector-specific,Python (Programmierung),,,released\n" +
+	"KnowledgeSkillCompetence,http://data.europa.eu/esco/skill/old,skill/competence,transversal,Veraltet,,,obsolete\n"
+
+func TestDefault_MirrorsProfileCategoryTable(t *testing.T) {
+	reg := Default()
+	if err := reg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	got, want := reg.CategoryTable("de"), profile.DefaultCategoryTable()
+	if len(got.Categories) != len(want.Categories) {
+		t.Fatalf("expected %d categories, got %d", len(want.Categories), len(got.Categories))
+	}
+	for i, c := range want.Categories {
+		if got.Categories[i].Key != c.Key || got.Categories[i].Label != c.Label || !reflect.DeepEqual(got.Categories[i].Dimensions, c.Dimensions) {
+			t.Errorf("category %d: expected %+v, got %+v", i, c, got.Categories[i])
+		}
+	}
+	for alias, target := range want.Aliases {
+		if got.Aliases[alias] != target {
+			t.Errorf("alias %s: expected %s, got %s", alias, target, got.Aliases[alias])
+		}
+	}
+}
+
+func TestRegistry_NormalizeMapsAndRejects(t *testing.T) {
+	reg := Default()
+
+	dims, err := reg.Normalize(map[string]float64{"Problem Solving": 40, "complexity": 65, "TEAMWORK": 80})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{"problem-solving": 65, "teamwork": 80}
+	if !reflect.DeepEqual(dims, want) {
+		t.Errorf("expected %v, got %v", want, dims)
+	}
+
+	_, err = reg.Normalize(map[string]float64{"teamwork": 1, "teamwrok": 2, "empaty": 3})
+	var unknown *UnknownDimensionsError
+	if !errors.As(err, &unknown) || !errors.Is(err, ErrUnknownDimension) {
+		t.Fatalf("expected unknown dimensions error, got %v", err)
+	}
+	if !reflect.DeepEqual(unknown.Keys, []string{"empaty", "teamwrok"}) {
+		t.Errorf("unexpected unknown keys %v", unknown.Keys)
+	}
+	if _, err := reg.Normalize(map[string]float64{"teamwork": 140}); !errors.Is(err, ErrInvalidScore) {
+		t.Errorf("expected score above 100 to be rejected, got %v", err)
+	}
+	if reg.Label("self_awareness", "en") != "Self-awareness" || reg.Label("empathy", "fr") != "Empathie" {
+		t.Error("expected labels with fallback to the default locale")
+	}
+}
+
+func TestRegistry_ValidateRejectsInconsistencies(t *testing.T) {
+	cases := map[string]func(r *Registry){
+		"non-canonical key": func(r *Registry) { r.Dimensions[0].Key = "Analytical_Thinking" },
+		"unknown category":  func(r *Registry) { r.Dimensions[0].Category = "misc" },
+		"duplicate key":     func(r *Registry) { r.Dimensions[1].Key = r.Dimensions[0].Key },
+		"category cycle": func(r *Registry) {
+			r.Categories[0].Parent = "soft-skills"
+			r.Categories[1].Parent = "hard-skills"
+		},
+		"alias shadows dimension": func(r *Registry) { r.Aliases["teamwork"] = "empathy" },
+		"dangling alias":          func(r *Registry) { r.Aliases["grit"] = "grit" },
+		"duplicate ESCO URI": func(r *Registry) {
+			r.Dimensions[0].ESCOURI = "http://data.europa.eu/esco/skill/x"
+			r.Dimensions[1].ESCOURI = "http://data.europa.eu/esco/skill/x"
+		},
+	}
+	for name, mutate := range cases {
+		reg := Default()
+		mutate(reg)
+		if err := reg.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestParseESCO_MapsLabelsAndAddsSkills(t *testing.T) {
+	base := Default()
+	base.Categories = append(base.Categories, Category{Key: "esco", Parent: "hard-skills", Labels: labels("ESCO", "ESCO")})
+
+	_, _, err := ParseESCO(strings.NewReader(escoDump), base, ImportRequest{
+		Mapping:   map[string]string{"team_work": "http://data.europa.eu/esco/skill/team"},
+		AutoMatch: true,
+		Category:  "esco",
+	})
+	if err == nil {
+		t.Fatal("expected unknown mapping key to fail")
+	}
+
+	reg, stats, err := ParseESCO(strings.NewReader(escoDump), base, ImportRequest{
+		Locale:    "de",
+		Mapping:   map[string]string{"teamwork": "http://data.europa.eu/esco/skill/team"},
+		AutoMatch: true,
+		Category:  "esco",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Rows != 4 || stats.Skipped != 1 || stats.Mapped != 2 || stats.Added != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if k, ok := reg.Resolve("http://data.europa.eu/esco/skill/team"); !ok || k != "teamwork" {
+		t.Errorf("expected ESCO URI to resolve to teamwork, got %q", k)
+	}
+	if k, _ := reg.Resolve("http://data.europa.eu/esco/skill/empathy"); k != "empathy" {
+		t.Errorf("expected auto-matched empathy, got %q", k)
+	}
+	if d, ok := reg.Dimension("python-programmierung"); !ok || d.Category != "esco" {
+		t.Errorf("expected imported skill in esco category, got %+v", d)
+	}
+	if base.Dimensions[4].ESCOURI != "" {
+		t.Error("expected the base registry to stay unchanged")
+	}
+	// Imported subcategories roll up into their top-level profile category.
+	table := reg.CategoryTable("de")
+	if dims := table.Categories[0].Dimensions; dims[len(dims)-1] != "python-programmierung" {
+		t.Errorf("expected imported dimension under hard-skills, got %v", dims)
+	}
+}
+
+func TestService_LoadPublishAndImport(t *testing.T) {
+	repo := newMockRepo()
+	svc := NewService(repo)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "skills_de.csv"), []byte(escoDump), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var seen []int
+	svc.OnChange(func(r *Registry) { seen = append(seen, r.Version) })
+
+	if _, err := svc.ImportESCO(context.Background(), ImportRequest{File: "skills_de.csv"}); !errors.Is(err, ErrImportDisabled) {
+		t.Fatalf("expected import to be disabled, got %v", err)
+	}
+	svc.SetImportDir(dir)
+
+	reg, err := svc.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reg.Version != 1 || repo.versions[1] == nil {
+		t.Fatalf("expected built-in registry seeded as version 1, got %d", reg.Version)
+	}
+
+	res, err := svc.ImportESCO(context.Background(), ImportRequest{File: "skills_de.csv", AutoMatch: true, DryRun: true})
+	if err != nil || res.Published || len(repo.versions) != 1 {
+		t.Fatalf("expected dry run without publishing, got %+v, %v", res, err)
+	}
+	if _, err := svc.ImportESCO(context.Background(), ImportRequest{File: "../../etc/passwd"}); err == nil {
+		t.Error("expected paths outside the import directory to fail")
+	}
+
+	res, err = svc.ImportESCO(context.Background(), ImportRequest{File: "skills_de.csv", AutoMatch: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Published || res.Registry.Version != 2 || res.Registry.Source != "esco:skills_de.csv" {
+		t.Fatalf("expected version 2 from the ESCO dump, got %+v", res.Registry.Info())
+	}
+	if k, ok := svc.Resolve("http://data.europa.eu/esco/skill/empathy"); !ok || k != "empathy" {
+		t.Errorf("expected the published version to be current, got %q", k)
+	}
+	if !reflect.DeepEqual(seen, []int{1, 1, 2}) {
+		t.Errorf("expected change notifications for versions 1, 1, 2, got %v", seen)
+	}
+
+	old, err := svc.Version(context.Background(), 1)
+	if err != nil || old.Source != "builtin" {
+		t.Errorf("expected version 1 to stay readable, got %+v, %v", old, err)
+	}
+	if _, err := svc.Version(context.Background(), 9); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package mail
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Handler exposes the outbox and bounce list to admins.
+type Handler struct {
+	outbox *Outbox
+}
+
+func NewHandler(outbox *Outbox) *Handler {
+	return &Handler{outbox: outbox}
+}
+
+// ListOutbox lists outbox messages (?status=&limit=&offset=).
+func (h *Handler) ListOutbox(c echo.Context) error {
+	limit := intQuery(c, "limit", 50)
+	if limit > 200 {
+		limit = 200
+	}
+	messages, total, err := h.outbox.List(c.Request().Context(), c.QueryParam("status"), limit, intQuery(c, "offset", 0))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list mails")
+	}
+	if messages == nil {
+		messages = []OutboxMessage{}
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"messages": messages,
+		"total":    total,
+	})
+}
+
+// Retry requeues a failed or bounced message.
+func (h *Handler) Retry(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid mail ID")
+	}
+	err = h.outbox.Retry(c.Request().Context(), id)
+	if errors.Is(err, ErrNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "no failed or bounced mail with this ID")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to requeue mail")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handler) ListBounces(c echo.Context) error {
+	limit := intQuery(c, "limit", 50)
+	if limit > 200 {
+		limit = 200
+	}
+	bounces, total, err := h.outbox.ListBounces(c.Request().Context(), limit, intQuery(c, "offset", 0))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to list bounces")
+	}
+	if bounces == nil {
+		bounces = []Bounce{}
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"bounces": bounces,
+		"total":   total,
+	})
+}
+
+// RecordBounce stores a bounce reported by a delivery status notification.
+func (h *Handler) RecordBounce(c echo.Context) error {
+	var req BounceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	if err := h.outbox.RecordBounce(c.Request().Context(), req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteBounce lifts the suppression of an address.
+func (h *Handler) DeleteBounce(c echo.Context) error {
+	err := h.outbox.DeleteBounce(c.Request().Context(), c.Param("email"))
+	if errors.Is(err, ErrNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "no bounce for this address")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to delete bounce")
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func intQuery(c echo.Context, key string, def int) int {
+	if v := c.QueryParam(key); v != "" {
+		if i, err := strconv.Atoi(v); err == nil && i >= 0 {
+			return i
+		}
+	}
+	return def
+}
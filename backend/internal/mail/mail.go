@@ -0,0 +1,64 @@
+// Package mail sends transactional email: localized templates, a provider
+// interface with an SMTP implementation (MailHog in local development) and
+// a persistent outbox that retries temporary failures and tracks bounces.
+package mail
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/mail"
+	"strings"
+)
+
+// Message is a rendered email.
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	// ID is used as Message-ID so retries of one outbox entry can be
+	// recognized by the receiving side.
+	ID string
+}
+
+// Provider delivers a message. Errors wrapped in PermanentError mean the
+// recipient rejected the message (a bounce); all other errors are retried.
+type Provider interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// PermanentError marks a delivery failure that will not go away on retry,
+// e.g. an SMTP 5xx reply for an unknown mailbox.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return "permanent: " + e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// IsPermanent reports whether err is a permanent delivery failure.
+func IsPermanent(err error) bool {
+	var p *PermanentError
+	return errors.As(err, &p)
+}
+
+// LogProvider logs recipient and subject instead of sending. It is used
+// when no SMTP server is configured; bodies are not logged since they
+// carry tokens.
+type LogProvider struct{}
+
+func (LogProvider) Send(_ context.Context, msg Message) error {
+	log.Printf("[mail] not sent (no SMTP configured): to=%s subject=%q", msg.To, msg.Subject)
+	return nil
+}
+
+// ValidAddress reports whether addr is a single plain email address.
+func ValidAddress(addr string) bool {
+	if strings.ContainsAny(addr, "\r\n") {
+		return false
+	}
+	a, err := mail.ParseAddress(addr)
+	return err == nil && a.Name == "" && a.Address == addr
+}
@@ -0,0 +1,316 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outbox statuses.
+const (
+	StatusPending    = "pending"
+	StatusSent       = "sent"
+	StatusFailed     = "failed"
+	StatusBounced    = "bounced"
+	StatusSuppressed = "suppressed"
+)
+
+// Bounce kinds. A hard bounce suppresses the address at once, soft bounces
+// after softBounceLimit occurrences.
+const (
+	BounceHard = "hard"
+	BounceSoft = "soft"
+)
+
+const softBounceLimit = 3
+
+var (
+	ErrNotFound       = errors.New("not found")
+	ErrInvalidAddress = errors.New("invalid email address")
+)
+
+// retryBackoff is the delay before each retry of a temporary failure; a
+// message is given up after len(retryBackoff)+1 attempts.
+var retryBackoff = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	12 * time.Hour,
+}
+
+const (
+	// claimLease keeps a claimed message from being picked up again while
+	// it is being sent.
+	claimLease = 5 * time.Minute
+	batchSize  = 50
+)
+
+// OutboxMessage is a rendered message waiting for or past delivery. Bodies
+// are cleared once the message is sent, since they may carry tokens.
+type OutboxMessage struct {
+	ID            uuid.UUID  `json:"id"`
+	To            string     `json:"to"`
+	Template      string     `json:"template"`
+	Locale        string     `json:"locale"`
+	Subject       string     `json:"subject"`
+	Text          string     `json:"-"`
+	HTML          string     `json:"-"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	LastError     string     `json:"last_error,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// Bounce records delivery failures of one address.
+type Bounce struct {
+	Email      string    `json:"email"`
+	Kind       string    `json:"kind"`
+	Reason     string    `json:"reason"`
+	Count      int       `json:"count"`
+	FirstAt    time.Time `json:"first_at"`
+	LastAt     time.Time `json:"last_at"`
+	Suppressed bool      `json:"suppressed"`
+}
+
+// BounceRequest reports a bounce received outside the SMTP session, e.g.
+// a delivery status notification.
+type BounceRequest struct {
+	Email  string `json:"email"`
+	Kind   string `json:"kind"`
+	Reason string `json:"reason"`
+}
+
+type Repository interface {
+	Insert(ctx context.Context, m *OutboxMessage) error
+	// ClaimDue returns up to limit pending messages due at now, counts the
+	// attempt and postpones them by lease so other workers skip them.
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]OutboxMessage, error)
+	MarkSent(ctx context.Context, id uuid.UUID, at time.Time) error
+	MarkRetry(ctx context.Context, id uuid.UUID, next time.Time, lastErr string) error
+	// MarkFinal ends delivery with status failed or bounced.
+	MarkFinal(ctx context.Context, id uuid.UUID, status, lastErr string) error
+	List(ctx context.Context, status string, limit, offset int) ([]OutboxMessage, int, error)
+	// Requeue makes a failed or bounced message pending again and reports
+	// whether it existed in one of those states.
+	Requeue(ctx context.Context, id uuid.UUID, now time.Time) (bool, error)
+
+	// IsSuppressed reports whether the address has a hard bounce or at
+	// least softLimit soft bounces.
+	IsSuppressed(ctx context.Context, email string, softLimit int) (bool, error)
+	RecordBounce(ctx context.Context, email, kind, reason string, at time.Time) error
+	ListBounces(ctx context.Context, limit, offset int) ([]Bounce, int, error)
+	// DeleteBounce lifts the suppression and reports whether there was one.
+	DeleteBounce(ctx context.Context, email string) (bool, error)
+}
+
+// Outbox renders messages into the persistent outbox and delivers them in
+// the background.
+type Outbox struct {
+	repo     Repository
+	provider Provider
+	renderer *Renderer
+	from     string
+	now      func() time.Time
+}
+
+func NewOutbox(repo Repository, provider Provider, renderer *Renderer, from string) *Outbox {
+	return &Outbox{repo: repo, provider: provider, renderer: renderer, from: from, now: time.Now}
+}
+
+// SetRepo replaces the repository (used for lazy DB injection after startup).
+func (o *Outbox) SetRepo(repo Repository) {
+	o.repo = repo
+}
+
+// Enqueue renders a template for one recipient and stores it for delivery.
+// Messages to suppressed addresses are stored with status suppressed and
+// never sent.
+func (o *Outbox) Enqueue(ctx context.Context, to, template, locale string, data map[string]interface{}) (uuid.UUID, error) {
+	if o.repo == nil {
+		return uuid.Nil, fmt.Errorf("database not available")
+	}
+	if !ValidAddress(to) {
+		return uuid.Nil, ErrInvalidAddress
+	}
+	msg, err := o.renderer.Render(template, locale, data)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	suppressed, err := o.repo.IsSuppressed(ctx, to, softBounceLimit)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("check bounces: %w", err)
+	}
+
+	now := o.now().UTC()
+	m := &OutboxMessage{
+		ID:            uuid.New(),
+		To:            to,
+		Template:      template,
+		Locale:        o.renderer.resolveLocale(template, locale),
+		Subject:       msg.Subject,
+		Text:          msg.Text,
+		HTML:          msg.HTML,
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	if suppressed {
+		m.Status, m.Text, m.HTML = StatusSuppressed, "", ""
+		m.LastError = "address suppressed after bounces"
+		log.Printf("[mail] %s to suppressed address %s not sent", template, to)
+	}
+	if err := o.repo.Insert(ctx, m); err != nil {
+		return uuid.Nil, fmt.Errorf("store mail: %w", err)
+	}
+	return m.ID, nil
+}
+
+// Run delivers due messages every interval until ctx is cancelled.
+func (o *Outbox) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := o.ProcessDue(ctx); err != nil {
+			log.Printf("[mail] outbox: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDue sends the messages that are due and returns how many were sent.
+func (o *Outbox) ProcessDue(ctx context.Context) (int, error) {
+	if o.repo == nil {
+		return 0, nil
+	}
+	due, err := o.repo.ClaimDue(ctx, o.now().UTC(), batchSize, claimLease)
+	if err != nil {
+		return 0, fmt.Errorf("claim due mails: %w", err)
+	}
+	sent := 0
+	for _, m := range due {
+		if o.deliver(ctx, m) {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// deliver sends one claimed message and records the outcome. m.Attempts
+// already counts this attempt.
+func (o *Outbox) deliver(ctx context.Context, m OutboxMessage) bool {
+	err := o.provider.Send(ctx, Message{
+		From:    o.from,
+		To:      m.To,
+		Subject: m.Subject,
+		Text:    m.Text,
+		HTML:    m.HTML,
+		ID:      m.ID.String(),
+	})
+	now := o.now().UTC()
+	switch {
+	case err == nil:
+		if err := o.repo.MarkSent(ctx, m.ID, now); err != nil {
+			log.Printf("[mail] mark %s sent: %v", m.ID, err)
+		}
+		return true
+	case IsPermanent(err):
+		log.Printf("[mail] %s to %s bounced: %v", m.Template, m.To, err)
+		if err := o.repo.RecordBounce(ctx, m.To, BounceHard, err.Error(), now); err != nil {
+			log.Printf("[mail] record bounce for %s: %v", m.To, err)
+		}
+		if err := o.repo.MarkFinal(ctx, m.ID, StatusBounced, err.Error()); err != nil {
+			log.Printf("[mail] mark %s bounced: %v", m.ID, err)
+		}
+	case m.Attempts > len(retryBackoff):
+		log.Printf("[mail] %s to %s failed after %d attempts: %v", m.Template, m.To, m.Attempts, err)
+		if err := o.repo.MarkFinal(ctx, m.ID, StatusFailed, err.Error()); err != nil {
+			log.Printf("[mail] mark %s failed: %v", m.ID, err)
+		}
+	default:
+		next := now.Add(retryBackoff[m.Attempts-1])
+		if err := o.repo.MarkRetry(ctx, m.ID, next, err.Error()); err != nil {
+			log.Printf("[mail] reschedule %s: %v", m.ID, err)
+		}
+	}
+	return false
+}
+
+// List returns outbox messages, newest first; status filters when set.
+func (o *Outbox) List(ctx context.Context, status string, limit, offset int) ([]OutboxMessage, int, error) {
+	if o.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
+	return o.repo.List(ctx, status, limit, offset)
+}
+
+// Retry requeues a failed or bounced message.
+func (o *Outbox) Retry(ctx context.Context, id uuid.UUID) error {
+	if o.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	ok, err := o.repo.Requeue(ctx, id, o.now().UTC())
+	if err != nil {
+		return fmt.Errorf("requeue mail: %w", err)
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (o *Outbox) ListBounces(ctx context.Context, limit, offset int) ([]Bounce, int, error) {
+	if o.repo == nil {
+		return nil, 0, fmt.Errorf("database not available")
+	}
+	bounces, total, err := o.repo.ListBounces(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range bounces {
+		bounces[i].Suppressed = bounces[i].Kind == BounceHard || bounces[i].Count >= softBounceLimit
+	}
+	return bounces, total, nil
+}
+
+// RecordBounce stores a bounce reported outside the SMTP session.
+func (o *Outbox) RecordBounce(ctx context.Context, req BounceRequest) error {
+	if o.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	if !ValidAddress(req.Email) {
+		return ErrInvalidAddress
+	}
+	if req.Kind == "" {
+		req.Kind = BounceHard
+	}
+	if req.Kind != BounceHard && req.Kind != BounceSoft {
+		return fmt.Errorf("kind must be %q or %q", BounceHard, BounceSoft)
+	}
+	return o.repo.RecordBounce(ctx, req.Email, req.Kind, req.Reason, o.now().UTC())
+}
+
+// DeleteBounce lifts the suppression of an address.
+func (o *Outbox) DeleteBounce(ctx context.Context, email string) error {
+	if o.repo == nil {
+		return fmt.Errorf("database not available")
+	}
+	ok, err := o.repo.DeleteBounce(ctx, email)
+	if err != nil {
+		return fmt.Errorf("delete bounce: %w", err)
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package mail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mockRepo struct {
+	msgs    map[uuid.UUID]*OutboxMessage
+	bounces map[string]*Bounce
+}
+
+func newMockRepo() *mockRepo {
+	return &mockRepo{msgs: map[uuid.UUID]*OutboxMessage{}, bounces: map[string]*Bounce{}}
+}
+
+func (r *mockRepo) Insert(_ context.Context, m *OutboxMessage) error {
+	cp := *m
+	r.msgs[m.ID] = &cp
+	return nil
+}
+
+func (r *mockRepo) ClaimDue(_ context.Context, now time.Time, limit int, lease time.Duration) ([]OutboxMessage, error) {
+	var out []OutboxMessage
+	for _, m := range r.msgs {
+		if m.Status == StatusPending && !m.NextAttemptAt.After(now) && len(out) < limit {
+			m.Attempts++
+			m.NextAttemptAt = now.Add(lease)
+			out = append(out, *m)
+		}
+	}
+	return out, nil
+}
+
+func (r *mockRepo) MarkSent(_ context.Context, id uuid.UUID, at time.Time) error {
+	m := r.msgs[id]
+	m.Status, m.SentAt, m.Text, m.HTML = StatusSent, &at, "", ""
+	return nil
+}
+
+func (r *mockRepo) MarkRetry(_ context.Context, id uuid.UUID, next time.Time, lastErr string) error {
+	m := r.msgs[id]
+	m.NextAttemptAt, m.LastError = next, lastErr
+	return nil
+}
+
+func (r *mockRepo) MarkFinal(_ context.Context, id uuid.UUID, status, lastErr string) error {
+	m := r.msgs[id]
+	m.Status, m.LastError = status, lastErr
+	return nil
+}
+
+func (r *mockRepo) List(context.Context, string, int, int) ([]OutboxMessage, int, error) {
+	return nil, 0, nil
+}
+
+func (r *mockRepo) Requeue(_ context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	m, ok := r.msgs[id]
+	if !ok || (m.Status != StatusFailed && m.Status != StatusBounced) {
+		return false, nil
+	}
+	m.Status, m.Attempts, m.NextAttemptAt = StatusPending, 0, now
+	return true, nil
+}
+
+func (r *mockRepo) IsSuppressed(_ context.Context, email string, softLimit int) (bool, error) {
+	b, ok := r.bounces[email]
+	return ok && (b.Kind == BounceHard || b.Count >= softLimit), nil
+}
+
+func (r *mockRepo) RecordBounce(_ context.Context, email, kind, reason string, at time.Time) error {
+	b, ok := r.bounces[email]
+	if !ok {
+		r.bounces[email] = &Bounce{Email: email, Kind: kind, Reason: reason, Count: 1, FirstAt: at, LastAt: at}
+		return nil
+	}
+	b.Count++
+	b.Reason, b.LastAt = reason, at
+	if kind == BounceHard {
+		b.Kind = BounceHard
+	}
+	return nil
+}
+
+func (r *mockRepo) ListBounces(context.Context, int, int) ([]Bounce, int, error) {
+	var out []Bounce
+	for _, b := range r.bounces {
+		out = append(out, *b)
+	}
+	return out, len(out), nil
+}
+
+func (r *mockRepo) DeleteBounce(_ context.Context, email string) (bool, error) {
+	_, ok := r.bounces[email]
+	delete(r.bounces, email)
+	return ok, nil
+}
+
+type mockProvider struct {
+	err  error
+	sent []Message
+}
+
+func (p *mockProvider) Send(_ context.Context, msg Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.sent = append(p.sent, msg)
+	return nil
+}
+
+func newTestOutbox(t *testing.T) (*Outbox, *mockRepo, *mockProvider, *time.Time) {
+	t.Helper()
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+	repo, provider := newMockRepo(), &mockProvider{}
+	o := NewOutbox(repo, provider, r, "SkillR <noreply@skillr.local>")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	o.now = func() time.Time { return now }
+	return o, repo, provider, &now
+}
+
+func TestOutbox_EnqueueAndSend(t *testing.T) {
+	o, repo, provider, _ := newTestOutbox(t)
+	ctx := context.Background()
+
+	id, err := o.Enqueue(ctx, "lehrer@example.com", TemplatePasswordReset, "en-US", templateData())
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if m := repo.msgs[id]; m.Locale != "en" || m.Status != StatusPending || m.HTML == "" {
+		t.Fatalf("unexpected stored message: %+v", m)
+	}
+
+	sent, err := o.ProcessDue(ctx)
+	if err != nil || sent != 1 {
+		t.Fatalf("ProcessDue = %d, %v", sent, err)
+	}
+	if len(provider.sent) != 1 || provider.sent[0].From != "SkillR <noreply@skillr.local>" || provider.sent[0].ID != id.String() {
+		t.Errorf("unexpected sent message: %+v", provider.sent)
+	}
+	if m := repo.msgs[id]; m.Status != StatusSent || m.Text != "" {
+		t.Errorf("expected sent message without body, got %+v", m)
+	}
+}
+
+func TestOutbox_EnqueueRejectsInvalidAddress(t *testing.T) {
+	o, _, _, _ := newTestOutbox(t)
+	for _, to := range []string{"", "no-at-sign", "a@b.c\r\nBcc: x@y.z", "Name <a@b.c>"} {
+		if _, err := o.Enqueue(context.Background(), to, TemplatePasswordReset, "de", templateData()); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("Enqueue(%q) = %v, want ErrInvalidAddress", to, err)
+		}
+	}
+}
+
+func TestOutbox_RetryBackoffThenFail(t *testing.T) {
+	o, repo, provider, now := newTestOutbox(t)
+	ctx := context.Background()
+	provider.err = fmt.Errorf("connection refused")
+
+	id, err := o.Enqueue(ctx, "a@example.com", TemplateReminder, "de", templateData())
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	for i, wait := range retryBackoff {
+		if _, err := o.ProcessDue(ctx); err != nil {
+			t.Fatalf("ProcessDue: %v", err)
+		}
+		m := repo.msgs[id]
+		if m.Status != StatusPending || !m.NextAttemptAt.Equal(now.Add(wait)) {
+			t.Fatalf("attempt %d: expected retry after %v, got %+v", i+1, wait, m)
+		}
+		*now = m.NextAttemptAt
+	}
+	if _, err := o.ProcessDue(ctx); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+	if m := repo.msgs[id]; m.Status != StatusFailed || m.Attempts != len(retryBackoff)+1 {
+		t.Errorf("expected failed after %d attempts, got %+v", len(retryBackoff)+1, m)
+	}
+
+	if err := o.Retry(ctx, id); err != nil {
+		t.Fatalf("Retry: %v", err)
+	}
+	provider.err = nil
+	if sent, _ := o.ProcessDue(ctx); sent != 1 {
+		t.Errorf("expected requeued mail to be sent, got %d", sent)
+	}
+	if err := o.Retry(ctx, id); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Retry of sent mail = %v, want ErrNotFound", err)
+	}
+}
+
+func TestOutbox_PermanentErrorSuppressesAddress(t *testing.T) {
+	o, repo, provider, _ := newTestOutbox(t)
+	ctx := context.Background()
+	provider.err = &PermanentError{Err: fmt.Errorf("550 mailbox unavailable")}
+
+	id, _ := o.Enqueue(ctx, "gone@example.com", TemplateEndorsementInvite, "de", templateData())
+	if _, err := o.ProcessDue(ctx); err != nil {
+		t.Fatalf("ProcessDue: %v", err)
+	}
+	if m := repo.msgs[id]; m.Status != StatusBounced {
+		t.Fatalf("expected bounced, got %+v", m)
+	}
+
+	provider.err = nil
+	id2, err := o.Enqueue(ctx, "gone@example.com", TemplateReminder, "de", templateData())
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if m := repo.msgs[id2]; m.Status != StatusSuppressed || m.HTML != "" {
+		t.Errorf("expected suppressed message without body, got %+v", m)
+	}
+	if sent, _ := o.ProcessDue(ctx); sent != 0 || len(provider.sent) != 0 {
+		t.Error("suppressed mail must not be sent")
+	}
+
+	bounces, _, _ := o.ListBounces(ctx, 50, 0)
+	if len(bounces) != 1 || !bounces[0].Suppressed {
+		t.Errorf("expected one suppressed bounce, got %+v", bounces)
+	}
+	if err := o.DeleteBounce(ctx, "gone@example.com"); err != nil {
+		t.Fatalf("DeleteBounce: %v", err)
+	}
+	if err := o.DeleteBounce(ctx, "gone@example.com"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second DeleteBounce = %v, want ErrNotFound", err)
+	}
+}
+
+func TestOutbox_SoftBouncesSuppressAtLimit(t *testing.T) {
+	o, _, _, _ := newTestOutbox(t)
+	ctx := context.Background()
+	for i := 0; i < softBounceLimit; i++ {
+		if err := o.RecordBounce(ctx, BounceRequest{Email: "full@example.com", Kind: BounceSoft, Reason: "452 mailbox full"}); err != nil {
+			t.Fatalf("RecordBounce: %v", err)
+		}
+		bounces, _, _ := o.ListBounces(ctx, 50, 0)
+		if want := i+1 >= softBounceLimit; bounces[0].Suppressed != want {
+			t.Errorf("after %d soft bounces suppressed = %v, want %v", i+1, bounces[0].Suppressed, want)
+		}
+	}
+	if err := o.RecordBounce(ctx, BounceRequest{Email: "full@example.com", Kind: "spam"}); err == nil {
+		t.Error("expected error for unknown bounce kind")
+	}
+}
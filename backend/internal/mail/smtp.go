@@ -0,0 +1,172 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// SMTPProvider sends through an SMTP relay. STARTTLS is used when the
+// server offers it; credentials are only sent over TLS or to localhost.
+type SMTPProvider struct {
+	Addr     string // host:port
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// NewSMTPProvider creates a provider for host:port.
+func NewSMTPProvider(host, port, username, password, from string) *SMTPProvider {
+	return &SMTPProvider{
+		Addr:     net.JoinHostPort(host, port),
+		Username: username,
+		Password: password,
+		From:     from,
+		Timeout:  30 * time.Second,
+	}
+}
+
+func (p *SMTPProvider) Send(ctx context.Context, msg Message) error {
+	if msg.From == "" {
+		msg.From = p.From
+	}
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return &PermanentError{Err: fmt.Errorf("invalid sender: %w", err)}
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return &PermanentError{Err: fmt.Errorf("invalid recipient: %w", err)}
+	}
+	body, err := buildMIME(msg)
+	if err != nil {
+		return &PermanentError{Err: err}
+	}
+
+	dialer := &net.Dialer{Timeout: p.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.Addr)
+	if err != nil {
+		return fmt.Errorf("connect smtp: %w", err)
+	}
+	deadline := time.Now().Add(p.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	host, _, _ := net.SplitHostPort(p.Addr)
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if p.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", p.Username, p.Password, host)); err != nil {
+			return classify("smtp auth", err)
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return classify("smtp MAIL FROM", err)
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return classify("smtp RCPT TO", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return classify("smtp DATA", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("smtp write: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return classify("smtp DATA", err)
+	}
+	return c.Quit()
+}
+
+// classify turns 5xx replies into permanent errors.
+func classify(step string, err error) error {
+	var te *textproto.Error
+	if errors.As(err, &te) && te.Code >= 500 {
+		return &PermanentError{Err: fmt.Errorf("%s: %w", step, err)}
+	}
+	return fmt.Errorf("%s: %w", step, err)
+}
+
+// buildMIME renders msg as a multipart/alternative message (text and HTML)
+// with quoted-printable bodies.
+func buildMIME(msg Message) ([]byte, error) {
+	if !ValidAddress(msg.To) {
+		return nil, fmt.Errorf("invalid recipient %q", msg.To)
+	}
+	boundary := randomHex(16)
+	id := msg.ID
+	if id == "" {
+		id = randomHex(16)
+	}
+	domain := "localhost"
+	if a, err := mail.ParseAddress(msg.From); err == nil {
+		if _, d, ok := strings.Cut(a.Address, "@"); ok {
+			domain = d
+		}
+	}
+
+	var b bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&b, "%s: %s\r\n", k, v) }
+	header("From", msg.From)
+	header("To", msg.To)
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", time.Now().UTC().Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<%s@%s>", id, domain))
+	header("MIME-Version", "1.0")
+	header("Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", boundary))
+	b.WriteString("\r\n")
+
+	for _, part := range []struct{ ctype, body string }{
+		{"text/plain", msg.Text},
+		{"text/html", msg.HTML},
+	} {
+		if part.body == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "--%s\r\n", boundary)
+		fmt.Fprintf(&b, "Content-Type: %s; charset=utf-8\r\n", part.ctype)
+		b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		qp := quotedprintable.NewWriter(&b)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+		b.WriteString("\r\n")
+	}
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+	return b.Bytes(), nil
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,181 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTP is a minimal SMTP server without extensions. rcptCode is the reply
+// to RCPT TO; received holds the DATA of accepted messages.
+type fakeSMTP struct {
+	ln       net.Listener
+	rcptCode string
+	received chan string
+}
+
+func startFakeSMTP(t *testing.T, rcptCode string) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeSMTP{ln: ln, rcptCode: rcptCode, received: make(chan string, 1)}
+	t.Cleanup(func() { ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTP) serve() {
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(cmd, "MAIL FROM"):
+			reply("250 ok")
+		case strings.HasPrefix(cmd, "RCPT TO"):
+			reply(s.rcptCode)
+		case cmd == "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.received <- data.String()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func testMessage() Message {
+	return Message{
+		From:    "SkillR <noreply@skillr.local>",
+		To:      "lehrer@example.com",
+		Subject: "Einladung für eine Rückmeldung",
+		Text:    "Hallo äöü\n",
+		HTML:    "<p>Hallo äöü</p>",
+		ID:      "abc123",
+	}
+}
+
+func TestSMTPProvider_Send(t *testing.T) {
+	srv := startFakeSMTP(t, "250 ok")
+	host, port, _ := net.SplitHostPort(srv.ln.Addr().String())
+	p := NewSMTPProvider(host, port, "", "", "")
+	p.Timeout = 5 * time.Second
+
+	if err := p.Send(context.Background(), testMessage()); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	select {
+	case data := <-srv.received:
+		if !strings.Contains(data, "Message-ID: <abc123@skillr.local>") {
+			t.Errorf("expected Message-ID header, got:\n%s", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+}
+
+func TestSMTPProvider_RejectedRecipientIsPermanent(t *testing.T) {
+	srv := startFakeSMTP(t, "550 no such user")
+	host, port, _ := net.SplitHostPort(srv.ln.Addr().String())
+	p := NewSMTPProvider(host, port, "", "", "SkillR <noreply@skillr.local>")
+	p.Timeout = 5 * time.Second
+
+	err := p.Send(context.Background(), testMessage())
+	if !IsPermanent(err) {
+		t.Errorf("expected permanent error, got %v", err)
+	}
+}
+
+func TestSMTPProvider_ConnectErrorIsTemporary(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	ln.Close()
+
+	err = NewSMTPProvider(host, port, "", "", "SkillR <noreply@skillr.local>").Send(context.Background(), testMessage())
+	if err == nil || IsPermanent(err) {
+		t.Errorf("expected temporary error, got %v", err)
+	}
+}
+
+func TestBuildMIME(t *testing.T) {
+	raw, err := buildMIME(testMessage())
+	if err != nil {
+		t.Fatalf("buildMIME: %v", err)
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != testMessage().Subject {
+		t.Errorf("subject = %q, %v", subject, err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("content type = %q, %v", mediaType, err)
+	}
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	var types, bodies []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("part: %v", err)
+		}
+		body, _ := io.ReadAll(part) // quoted-printable is decoded by the reader
+		types = append(types, part.Header.Get("Content-Type"))
+		bodies = append(bodies, string(body))
+	}
+	if len(types) != 2 || !strings.HasPrefix(types[0], "text/plain") || !strings.HasPrefix(types[1], "text/html") {
+		t.Fatalf("unexpected parts %v", types)
+	}
+	if strings.TrimSpace(bodies[0]) != "Hallo äöü" || bodies[1] != "<p>Hallo äöü</p>" {
+		t.Errorf("unexpected bodies %q", bodies)
+	}
+
+	bad := testMessage()
+	bad.To = "x@example.com\r\nBcc: y@example.com"
+	if _, err := buildMIME(bad); err == nil {
+		t.Error("expected error for header injection in recipient")
+	}
+}
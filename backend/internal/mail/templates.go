@@ -0,0 +1,109 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Template names.
+const (
+	TemplateEndorsementInvite = "endorsement_invite"
+	TemplatePasswordReset     = "password_reset"
+	TemplateVerification      = "verification"
+	TemplateReminder          = "reminder"
+	TemplateArtifactRejected  = "artifact_rejected"
+)
+
+// DefaultLocale is used when a template has no translation in the requested
+// locale.
+const DefaultLocale = "de"
+
+// AppName is passed to every template.
+const AppName = "SkillR"
+
+//go:embed templates
+var templateFS embed.FS
+
+// Renderer renders the embedded templates. Each templates/<locale>/<name>.tmpl
+// defines "subject", "text" and "content"; the HTML part wraps "content" in
+// the shared layout.
+type Renderer struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+// NewRenderer parses all embedded templates.
+func NewRenderer() (*Renderer, error) {
+	r := &Renderer{text: map[string]*texttemplate.Template{}, html: map[string]*htmltemplate.Template{}}
+	files, err := fs.Glob(templateFS, "templates/*/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		locale := path.Base(path.Dir(file))
+		key := locale + "/" + strings.TrimSuffix(path.Base(file), ".tmpl")
+		t, err := texttemplate.ParseFS(templateFS, file)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", file, err)
+		}
+		h, err := htmltemplate.ParseFS(templateFS, "templates/layout.tmpl", file)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", file, err)
+		}
+		r.text[key], r.html[key] = t, h
+	}
+	return r, nil
+}
+
+// Has reports whether the template exists in the default locale.
+func (r *Renderer) Has(name string) bool {
+	_, ok := r.text[DefaultLocale+"/"+name]
+	return ok
+}
+
+// Render renders a template in locale ("en-GB" falls back to "en", then to
+// DefaultLocale) and returns the message without sender and recipient.
+func (r *Renderer) Render(name, locale string, data map[string]interface{}) (Message, error) {
+	locale = r.resolveLocale(name, locale)
+	key := locale + "/" + name
+	t, ok := r.text[key]
+	if !ok {
+		return Message{}, fmt.Errorf("unknown mail template %q", name)
+	}
+
+	vars := map[string]interface{}{"AppName": AppName, "Locale": locale}
+	for k, v := range data {
+		vars[k] = v
+	}
+	var subject, text, html bytes.Buffer
+	if err := t.ExecuteTemplate(&subject, "subject", vars); err != nil {
+		return Message{}, fmt.Errorf("render %s subject: %w", key, err)
+	}
+	if err := t.ExecuteTemplate(&text, "text", vars); err != nil {
+		return Message{}, fmt.Errorf("render %s text: %w", key, err)
+	}
+	if err := r.html[key].ExecuteTemplate(&html, "html", vars); err != nil {
+		return Message{}, fmt.Errorf("render %s html: %w", key, err)
+	}
+	return Message{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+		HTML:    html.String(),
+	}, nil
+}
+
+func (r *Renderer) resolveLocale(name, locale string) string {
+	locale = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(locale)), "_", "-")
+	for _, l := range []string{locale, strings.SplitN(locale, "-", 2)[0]} {
+		if _, ok := r.text[l+"/"+name]; ok && l != "" {
+			return l
+		}
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,84 @@
+package mail
+
+import (
+	"strings"
+	"testing"
+)
+
+func templateData() map[string]interface{} {
+	return map[string]interface{}{
+		"LearnerName": "Ada",
+		"Role":        "Lehrkraft",
+		"Message":     "Danke!",
+		"InviteURL":   "http://localhost:3000/endorse?token=abc",
+		"ResetURL":    "http://localhost:3000/reset-password?token=abc",
+		"ExpiresAt":   "01.02.2027",
+		"ExpiresIn":   60,
+		"Code":        "123456",
+		"FileName":    "praktikum.pdf",
+		"Description": "Praktikumsbericht",
+		"AppURL":      "http://localhost:3000",
+	}
+}
+
+func TestRenderAllTemplates(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+	for _, name := range []string{TemplateEndorsementInvite, TemplatePasswordReset, TemplateVerification, TemplateReminder, TemplateArtifactRejected} {
+		for _, locale := range []string{"de", "en"} {
+			msg, err := r.Render(name, locale, templateData())
+			if err != nil {
+				t.Fatalf("%s/%s: %v", locale, name, err)
+			}
+			if msg.Subject == "" || strings.Contains(msg.Subject, "\n") {
+				t.Errorf("%s/%s: bad subject %q", locale, name, msg.Subject)
+			}
+			if strings.Contains(msg.Text, "<no value>") || strings.Contains(msg.HTML, "<no value>") {
+				t.Errorf("%s/%s: missing template data", locale, name)
+			}
+			if !strings.Contains(msg.HTML, `<html lang="`+locale+`"`) {
+				t.Errorf("%s/%s: expected layout with lang attribute", locale, name)
+			}
+		}
+	}
+	if r.Has("unknown") {
+		t.Error("Has(unknown) = true")
+	}
+	if _, err := r.Render("unknown", "de", nil); err == nil {
+		t.Error("expected error for unknown template")
+	}
+}
+
+func TestRenderLocaleFallback(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+	cases := map[string]string{"en_GB": "en", "EN-us": "en", "fr": "de", "": "de", "de-AT": "de"}
+	for in, want := range cases {
+		if got := r.resolveLocale(TemplatePasswordReset, in); got != want {
+			t.Errorf("resolveLocale(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRenderEscapesHTML(t *testing.T) {
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+	data := templateData()
+	data["LearnerName"] = `<script>alert(1)</script>`
+	msg, err := r.Render(TemplateEndorsementInvite, "de", data)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if strings.Contains(msg.HTML, "<script>") {
+		t.Error("HTML part must escape template data")
+	}
+	if !strings.Contains(msg.Text, "<script>") {
+		t.Error("text part must keep data verbatim")
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"skillr-mvp-v1/backend/internal/domain/artifact"
@@ -19,12 +21,36 @@ func NewArtifactRepository(pool *pgxpool.Pool) *ArtifactRepository {
 	return &ArtifactRepository{pool: pool}
 }
 
+const artifactColumns = `id, learner_id, artifact_type, description, skill_dimensions, storage_ref, endorsement_ids, uploaded_at,
+	COALESCE(object_key, ''), COALESCE(file_name, ''), COALESCE(content_type, ''), COALESCE(size_bytes, 0), COALESCE(checksum_sha256, ''),
+	COALESCE(scan_status, ''), COALESCE(scan_signature, ''), scanned_at,
+	COALESCE(preview_status, ''), COALESCE(preview_key, ''), COALESCE(width, 0), COALESCE(height, 0),
+	COALESCE(page_count, 0), COALESCE(duration_ms, 0)`
+
+func scanArtifact(row pgx.Row) (*artifact.ExternalArtifact, error) {
+	a := &artifact.ExternalArtifact{}
+	var dimJSON []byte
+	var durationMS int64
+	if err := row.Scan(&a.ID, &a.LearnerID, &a.ArtifactType, &a.Description, &dimJSON, &a.StorageRef, &a.EndorsementIDs, &a.UploadedAt,
+		&a.ObjectKey, &a.FileName, &a.ContentType, &a.SizeBytes, &a.Checksum,
+		&a.ScanStatus, &a.ScanSignature, &a.ScannedAt,
+		&a.PreviewStatus, &a.PreviewKey, &a.Width, &a.Height, &a.PageCount, &durationMS); err != nil {
+		return nil, err
+	}
+	a.DurationSeconds = float64(durationMS) / 1000
+	a.HasPreview = a.PreviewKey != ""
+	_ = json.Unmarshal(dimJSON, &a.SkillDimensions)
+	return a, nil
+}
+
 func (r *ArtifactRepository) Create(ctx context.Context, a *artifact.ExternalArtifact) error {
 	dimJSON, _ := json.Marshal(a.SkillDimensions)
 	_, err := r.pool.Exec(ctx,
-		`INSERT INTO external_artifacts (id, learner_id, artifact_type, description, skill_dimensions, storage_ref, endorsement_ids, uploaded_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		`INSERT INTO external_artifacts (id, learner_id, artifact_type, description, skill_dimensions, storage_ref, endorsement_ids, uploaded_at,
+		                                 object_key, file_name, content_type, size_bytes, checksum_sha256, scan_status, preview_status)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, 0), NULLIF($13, ''), NULLIF($14, ''), NULLIF($15, ''))`,
 		a.ID, a.LearnerID, a.ArtifactType, a.Description, dimJSON, a.StorageRef, a.EndorsementIDs, a.UploadedAt,
+		a.ObjectKey, a.FileName, a.ContentType, a.SizeBytes, a.Checksum, a.ScanStatus, a.PreviewStatus,
 	)
 	if err != nil {
 		return fmt.Errorf("insert artifact: %w", err)
@@ -33,17 +59,13 @@ func (r *ArtifactRepository) Create(ctx context.Context, a *artifact.ExternalArt
 }
 
 func (r *ArtifactRepository) GetByID(ctx context.Context, id uuid.UUID, learnerID uuid.UUID) (*artifact.ExternalArtifact, error) {
-	a := &artifact.ExternalArtifact{}
-	var dimJSON []byte
-	err := r.pool.QueryRow(ctx,
-		`SELECT id, learner_id, artifact_type, description, skill_dimensions, storage_ref, endorsement_ids, uploaded_at
-		 FROM external_artifacts WHERE id = $1 AND learner_id = $2`,
+	a, err := scanArtifact(r.pool.QueryRow(ctx,
+		`SELECT `+artifactColumns+` FROM external_artifacts WHERE id = $1 AND learner_id = $2`,
 		id, learnerID,
-	).Scan(&a.ID, &a.LearnerID, &a.ArtifactType, &a.Description, &dimJSON, &a.StorageRef, &a.EndorsementIDs, &a.UploadedAt)
+	))
 	if err != nil {
 		return nil, fmt.Errorf("get artifact: %w", err)
 	}
-	_ = json.Unmarshal(dimJSON, &a.SkillDimensions)
 	return a, nil
 }
 
@@ -56,7 +78,7 @@ func (r *ArtifactRepository) GetDetailedByID(ctx context.Context, id uuid.UUID,
 }
 
 func (r *ArtifactRepository) List(ctx context.Context, learnerID uuid.UUID, artifactType *string, limit, offset int) ([]artifact.ExternalArtifact, int, error) {
-	query := `SELECT id, learner_id, artifact_type, description, skill_dimensions, storage_ref, endorsement_ids, uploaded_at FROM external_artifacts WHERE learner_id = $1`
+	query := `SELECT ` + artifactColumns + ` FROM external_artifacts WHERE learner_id = $1`
 	countQuery := `SELECT COUNT(*) FROM external_artifacts WHERE learner_id = $1`
 	args := []interface{}{learnerID}
 	argIdx := 2
@@ -84,13 +106,11 @@ func (r *ArtifactRepository) List(ctx context.Context, learnerID uuid.UUID, arti
 
 	var artifacts []artifact.ExternalArtifact
 	for rows.Next() {
-		var a artifact.ExternalArtifact
-		var dimJSON []byte
-		if err := rows.Scan(&a.ID, &a.LearnerID, &a.ArtifactType, &a.Description, &dimJSON, &a.StorageRef, &a.EndorsementIDs, &a.UploadedAt); err != nil {
+		a, err := scanArtifact(rows)
+		if err != nil {
 			return nil, 0, fmt.Errorf("scan artifact: %w", err)
 		}
-		_ = json.Unmarshal(dimJSON, &a.SkillDimensions)
-		artifacts = append(artifacts, a)
+		artifacts = append(artifacts, *a)
 	}
 	return artifacts, total, nil
 }
@@ -119,3 +139,75 @@ func (r *ArtifactRepository) LinkEndorsement(ctx context.Context, id uuid.UUID,
 	}
 	return r.GetDetailedByID(ctx, id, learnerID)
 }
+
+func (r *ArtifactRepository) ListScanQueue(ctx context.Context, limit int) ([]artifact.ExternalArtifact, error) {
+	return r.listQueue(ctx, `scan_status IN ('pending', 'unscanned')`, limit)
+}
+
+func (r *ArtifactRepository) ListPreviewQueue(ctx context.Context, limit int) ([]artifact.ExternalArtifact, error) {
+	return r.listQueue(ctx, `preview_status = 'pending' AND scan_status = 'clean'`, limit)
+}
+
+// listQueue returns up to limit stored files matching cond, oldest first.
+func (r *ArtifactRepository) listQueue(ctx context.Context, cond string, limit int) ([]artifact.ExternalArtifact, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+artifactColumns+` FROM external_artifacts
+		 WHERE `+cond+` AND object_key IS NOT NULL
+		 ORDER BY uploaded_at LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list artifact queue: %w", err)
+	}
+	defer rows.Close()
+
+	var artifacts []artifact.ExternalArtifact
+	for rows.Next() {
+		a, err := scanArtifact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan artifact: %w", err)
+		}
+		artifacts = append(artifacts, *a)
+	}
+	return artifacts, rows.Err()
+}
+
+func (r *ArtifactRepository) SetPreview(ctx context.Context, a *artifact.ExternalArtifact) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE external_artifacts
+		 SET preview_status = $2, preview_key = NULLIF($3, ''), width = NULLIF($4, 0), height = NULLIF($5, 0),
+		     page_count = NULLIF($6, 0), duration_ms = NULLIF($7::bigint, 0)
+		 WHERE id = $1`,
+		a.ID, a.PreviewStatus, a.PreviewKey, a.Width, a.Height, a.PageCount, int64(a.DurationSeconds*1000),
+	)
+	if err != nil {
+		return fmt.Errorf("set preview: %w", err)
+	}
+	return nil
+}
+
+// SetScanResult records a scan verdict. Infected files no longer have
+// stored objects, so their object and preview keys are cleared.
+func (r *ArtifactRepository) SetScanResult(ctx context.Context, id uuid.UUID, status, signature string, at time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE external_artifacts
+		 SET scan_status = $2, scan_signature = NULLIF($3, ''), scanned_at = $4,
+		     object_key = CASE WHEN $2 = 'infected' THEN NULL ELSE object_key END,
+		     preview_key = CASE WHEN $2 = 'infected' THEN NULL ELSE preview_key END
+		 WHERE id = $1`,
+		id, status, signature, at,
+	)
+	if err != nil {
+		return fmt.Errorf("set scan result: %w", err)
+	}
+	return nil
+}
+
+func (r *ArtifactRepository) LearnerEmail(ctx context.Context, learnerID uuid.UUID) (string, error) {
+	var email string
+	err := r.pool.QueryRow(ctx, `SELECT COALESCE(email, '') FROM users WHERE id = $1`, learnerID).Scan(&email)
+	if err != nil {
+		return "", fmt.Errorf("get learner email: %w", err)
+	}
+	return email, nil
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"skillr-mvp-v1/backend/internal/domain/credential"
+)
+
+type CredentialRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCredentialRepository(pool *pgxpool.Pool) *CredentialRepository {
+	return &CredentialRepository{pool: pool}
+}
+
+const credentialColumns = `id, user_id, subject_type, subject_id, credential_type, key_id, status, document, issued_at, expires_at, revoked_at, revocation_reason`
+
+func scanCredential(row pgx.Row) (*credential.Credential, error) {
+	c := &credential.Credential{}
+	var doc []byte
+	if err := row.Scan(&c.ID, &c.UserID, &c.SubjectType, &c.SubjectID, &c.CredentialType, &c.KeyID, &c.Status, &doc, &c.IssuedAt, &c.ExpiresAt, &c.RevokedAt, &c.RevocationReason); err != nil {
+		return nil, err
+	}
+	c.Document = json.RawMessage(doc)
+	return c, nil
+}
+
+// LoadSource reads the record a credential is issued for. Lernreisen must be
+// completed and endorsements published.
+func (r *CredentialRepository) LoadSource(ctx context.Context, userID uuid.UUID, subjectType credential.SubjectType, subjectID uuid.UUID) (*credential.Source, error) {
+	src := &credential.Source{Type: subjectType, ID: subjectID, UserID: userID}
+	var dimJSON []byte
+	var err error
+
+	switch subjectType {
+	case credential.SubjectEvidence:
+		var evidenceType string
+		err = r.pool.QueryRow(ctx,
+			`SELECT pe.summary, pe.skill_dimensions, pe.evidence_type::text, pe.created_at
+			 FROM portfolio_entries pe
+			 WHERE pe.id = $1 AND pe.user_id = $2 AND pe.retracted_at IS NULL
+			   AND NOT EXISTS (SELECT 1 FROM evidence_revocations r WHERE r.evidence_id = pe.id)`,
+			subjectID, userID,
+		).Scan(&src.Description, &dimJSON, &evidenceType, &src.OccurredAt)
+		src.Name = "Kompetenznachweis"
+		if evidenceType == "endorsed" {
+			src.Name = "Bestätigter Kompetenznachweis"
+		}
+	case credential.SubjectLernreise:
+		err = r.pool.QueryRow(ctx,
+			`SELECT li.title, COALESCE(li.completed_at, li.updated_at)
+			 FROM lernreise_instances li
+			 WHERE li.id = $1 AND li.user_id = $2 AND li.status = 'completed'`,
+			subjectID, userID,
+		).Scan(&src.Name, &src.OccurredAt)
+		src.Description = "Abgeschlossene Lernreise: " + src.Name
+	case credential.SubjectEndorsement:
+		err = r.pool.QueryRow(ctx,
+			`SELECT e.endorser_name, e.endorser_role::text, e.statement, e.skill_dimensions, e.created_at
+			 FROM endorsements e
+			 WHERE e.id = $1 AND e.learner_id = $2
+			   AND e.visible AND e.status = 'accepted' AND e.moderation_status = 'clear'`,
+			subjectID, userID,
+		).Scan(&src.EndorserName, &src.EndorserRole, &src.Description, &dimJSON, &src.OccurredAt)
+		src.Name = "Kompetenzbestätigung"
+	default:
+		return nil, fmt.Errorf("unknown subject type %q", subjectType)
+	}
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("%s not found or not eligible", subjectType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load credential source: %w", err)
+	}
+	_ = json.Unmarshal(dimJSON, &src.Dimensions)
+	return src, nil
+}
+
+func (r *CredentialRepository) Create(ctx context.Context, c *credential.Credential) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO credentials (id, user_id, subject_type, subject_id, credential_type, key_id, status, document, issued_at, expires_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		c.ID, c.UserID, c.SubjectType, c.SubjectID, c.CredentialType, c.KeyID, c.Status, []byte(c.Document), c.IssuedAt, c.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert credential: %w", err)
+	}
+	return nil
+}
+
+func (r *CredentialRepository) GetByID(ctx context.Context, id uuid.UUID) (*credential.Credential, error) {
+	c, err := scanCredential(r.pool.QueryRow(ctx,
+		`SELECT `+credentialColumns+` FROM credentials WHERE id = $1`, id))
+	if err != nil {
+		return nil, fmt.Errorf("get credential: %w", err)
+	}
+	return c, nil
+}
+
+func (r *CredentialRepository) GetActiveBySubject(ctx context.Context, userID uuid.UUID, subjectType credential.SubjectType, subjectID uuid.UUID) (*credential.Credential, error) {
+	c, err := scanCredential(r.pool.QueryRow(ctx,
+		`SELECT `+credentialColumns+` FROM credentials
+		 WHERE user_id = $1 AND subject_type = $2 AND subject_id = $3 AND status = 'active'`,
+		userID, subjectType, subjectID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get credential by subject: %w", err)
+	}
+	return c, nil
+}
+
+func (r *CredentialRepository) List(ctx context.Context, params credential.ListParams) ([]credential.Credential, int, error) {
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM credentials WHERE user_id = $1`, params.UserID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count credentials: %w", err)
+	}
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+credentialColumns+` FROM credentials WHERE user_id = $1
+		 ORDER BY issued_at DESC LIMIT $2 OFFSET $3`,
+		params.UserID, params.Limit, params.Offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list credentials: %w", err)
+	}
+	defer rows.Close()
+
+	var creds []credential.Credential
+	for rows.Next() {
+		c, err := scanCredential(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan credential: %w", err)
+		}
+		creds = append(creds, *c)
+	}
+	return creds, total, rows.Err()
+}
+
+func (r *CredentialRepository) Revoke(ctx context.Context, id, userID uuid.UUID, reason string, at time.Time) error {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE credentials SET status = 'revoked', revoked_at = $3, revocation_reason = $4
+		 WHERE id = $1 AND user_id = $2 AND status = 'active'`,
+		id, userID, at, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("revoke credential: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("credential not found")
+	}
+	return nil
+}
@@ -3,9 +3,12 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"skillr-mvp-v1/backend/internal/domain/endorsement"
@@ -19,28 +22,99 @@ func NewEndorsementRepository(pool *pgxpool.Pool) *EndorsementRepository {
 	return &EndorsementRepository{pool: pool}
 }
 
-func (r *EndorsementRepository) Create(ctx context.Context, e *endorsement.Endorsement) error {
+// endorsementPublished restricts a query on endorsements to those shown on
+// the public profile: accepted by the learner, visible and not moderated.
+const endorsementPublished = `visible AND status = 'accepted' AND moderation_status = 'clear'`
+
+func (r *EndorsementRepository) Create(ctx context.Context, e *endorsement.Endorsement, audit *endorsement.ModerationEntry) error {
 	dimJSON, _ := json.Marshal(e.SkillDimensions)
-	_, err := r.pool.Exec(ctx,
-		`INSERT INTO endorsements (id, learner_id, endorser_id, endorser_name, endorser_role, endorser_verified, skill_dimensions, statement, context, artifact_refs, visible, created_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
-		e.ID, e.LearnerID, e.EndorserID, e.EndorserName, e.EndorserRole, e.EndorserVerified, dimJSON, e.Statement, e.Context, e.ArtifactRefs, e.Visible, e.CreatedAt,
+	var ratingsJSON, answersJSON []byte
+	if e.Ratings != nil {
+		ratingsJSON, _ = json.Marshal(e.Ratings)
+	}
+	if e.Answers != nil {
+		answersJSON, _ = json.Marshal(e.Answers)
+	}
+	flags := e.ScreeningFlags
+	if flags == nil {
+		flags = []string{}
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO endorsements (id, learner_id, endorser_id, endorser_name, endorser_role, endorser_verified, verification_method, endorser_organization, skill_dimensions, rubric_id, ratings, answers, statement, context, artifact_refs, visible,
+		                           endorser_email, status, moderation_status, screening_flags, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, $9, $10, $11, $12, $13, $14, $15, $16, NULLIF($17, ''), $18, $19, $20, $21)`,
+		e.ID, e.LearnerID, e.EndorserID, e.EndorserName, e.EndorserRole, e.EndorserVerified, e.VerificationMethod, e.EndorserOrganization, dimJSON,
+		e.RubricID, ratingsJSON, answersJSON, e.Statement, e.Context, e.ArtifactRefs, e.Visible,
+		e.EndorserEmail, e.Status, e.ModerationStatus, flags, e.CreatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("insert endorsement: %w", err)
 	}
+	if err := insertModerationEntry(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func insertModerationEntry(ctx context.Context, tx pgx.Tx, a *endorsement.ModerationEntry) error {
+	detailsJSON, _ := json.Marshal(a.Details)
+	if a.Details == nil {
+		detailsJSON = []byte("{}")
+	}
+	_, err := tx.Exec(ctx,
+		`INSERT INTO endorsement_moderation_log (id, endorsement_id, email_hash, actor_id, actor_role, action, details, created_at)
+		 VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8)`,
+		a.ID, a.EndorsementID, a.EmailHash, a.ActorID, a.ActorRole, a.Action, detailsJSON, a.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert moderation log: %w", err)
+	}
 	return nil
 }
 
+// endorsementColumns are scanned by scanEndorsement.
+const endorsementColumns = `id, learner_id, endorser_id, endorser_name, endorser_role, endorser_verified, COALESCE(verification_method, ''), endorser_organization,
+	skill_dimensions, rubric_id, ratings, answers, statement, context, artifact_refs, visible,
+	COALESCE(endorser_email, ''), status, moderation_status, screening_flags, decided_at, created_at`
+
+func scanEndorsement(row pgx.Row) (*endorsement.Endorsement, error) {
+	e := &endorsement.Endorsement{}
+	var dimJSON, ratingsJSON, answersJSON []byte
+	if err := row.Scan(&e.ID, &e.LearnerID, &e.EndorserID, &e.EndorserName, &e.EndorserRole, &e.EndorserVerified, &e.VerificationMethod, &e.EndorserOrganization,
+		&dimJSON, &e.RubricID, &ratingsJSON, &answersJSON, &e.Statement, &e.Context, &e.ArtifactRefs, &e.Visible,
+		&e.EndorserEmail, &e.Status, &e.ModerationStatus, &e.ScreeningFlags, &e.DecidedAt, &e.CreatedAt); err != nil {
+		return nil, err
+	}
+	_ = json.Unmarshal(dimJSON, &e.SkillDimensions)
+	if ratingsJSON != nil {
+		_ = json.Unmarshal(ratingsJSON, &e.Ratings)
+	}
+	if answersJSON != nil {
+		_ = json.Unmarshal(answersJSON, &e.Answers)
+	}
+	return e, nil
+}
+
+// learnerVisible excludes endorsements the learner does not see: flagged
+// ones await review, deleted ones were removed by an admin.
+const learnerVisible = `moderation_status NOT IN ('flagged', 'deleted')`
+
 func (r *EndorsementRepository) List(ctx context.Context, learnerID uuid.UUID, limit, offset int) ([]endorsement.Endorsement, int, error) {
 	var total int
-	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM endorsements WHERE learner_id = $1`, learnerID).Scan(&total); err != nil {
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM endorsements WHERE learner_id = $1 AND `+learnerVisible, learnerID).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("count endorsements: %w", err)
 	}
 
 	rows, err := r.pool.Query(ctx,
-		`SELECT id, learner_id, endorser_id, endorser_name, endorser_role, endorser_verified, skill_dimensions, statement, context, artifact_refs, visible, created_at
-		 FROM endorsements WHERE learner_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`,
+		`SELECT `+endorsementColumns+`
+		 FROM endorsements WHERE learner_id = $1 AND `+learnerVisible+` ORDER BY created_at DESC LIMIT $2 OFFSET $3`,
 		learnerID, limit, offset,
 	)
 	if err != nil {
@@ -50,32 +124,284 @@ func (r *EndorsementRepository) List(ctx context.Context, learnerID uuid.UUID, l
 
 	var endorsements []endorsement.Endorsement
 	for rows.Next() {
-		var e endorsement.Endorsement
-		var dimJSON []byte
-		if err := rows.Scan(&e.ID, &e.LearnerID, &e.EndorserID, &e.EndorserName, &e.EndorserRole, &e.EndorserVerified, &dimJSON, &e.Statement, &e.Context, &e.ArtifactRefs, &e.Visible, &e.CreatedAt); err != nil {
+		e, err := scanEndorsement(rows)
+		if err != nil {
 			return nil, 0, fmt.Errorf("scan endorsement: %w", err)
 		}
-		_ = json.Unmarshal(dimJSON, &e.SkillDimensions)
-		endorsements = append(endorsements, e)
+		endorsements = append(endorsements, *e)
 	}
 	return endorsements, total, nil
 }
 
 func (r *EndorsementRepository) GetByID(ctx context.Context, id uuid.UUID, learnerID uuid.UUID) (*endorsement.Endorsement, error) {
-	e := &endorsement.Endorsement{}
-	var dimJSON []byte
-	err := r.pool.QueryRow(ctx,
-		`SELECT id, learner_id, endorser_id, endorser_name, endorser_role, endorser_verified, skill_dimensions, statement, context, artifact_refs, visible, created_at
+	e, err := scanEndorsement(r.pool.QueryRow(ctx,
+		`SELECT `+endorsementColumns+`
 		 FROM endorsements WHERE id = $1 AND learner_id = $2`,
 		id, learnerID,
-	).Scan(&e.ID, &e.LearnerID, &e.EndorserID, &e.EndorserName, &e.EndorserRole, &e.EndorserVerified, &dimJSON, &e.Statement, &e.Context, &e.ArtifactRefs, &e.Visible, &e.CreatedAt)
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get endorsement: %w", err)
+	}
+	return e, nil
+}
+
+func (r *EndorsementRepository) GetEndorsement(ctx context.Context, id uuid.UUID) (*endorsement.Endorsement, error) {
+	e, err := scanEndorsement(r.pool.QueryRow(ctx,
+		`SELECT `+endorsementColumns+` FROM endorsements WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("get endorsement: %w", err)
 	}
-	_ = json.Unmarshal(dimJSON, &e.SkillDimensions)
 	return e, nil
 }
 
+// revokeEndorsementCredentials revokes active credentials issued for an
+// endorsement.
+func revokeEndorsementCredentials(ctx context.Context, tx pgx.Tx, id uuid.UUID, reason string, at time.Time) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE credentials SET status = 'revoked', revoked_at = $2, revocation_reason = $3
+		 WHERE subject_type = 'endorsement' AND subject_id = $1 AND status = 'active'`,
+		id, at, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("revoke endorsement credentials: %w", err)
+	}
+	return nil
+}
+
+func (r *EndorsementRepository) Decide(ctx context.Context, id, learnerID uuid.UUID, status string, visible bool, at time.Time, revoke string, audit *endorsement.ModerationEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx,
+		`UPDATE endorsements SET status = $3, visible = $4, decided_at = $5 WHERE id = $1 AND learner_id = $2`,
+		id, learnerID, status, visible, at)
+	if err != nil {
+		return fmt.Errorf("update endorsement status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("endorsement %s not found", id)
+	}
+	if revoke != "" {
+		if err := revokeEndorsementCredentials(ctx, tx, id, revoke, at); err != nil {
+			return err
+		}
+	}
+	if err := insertModerationEntry(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *EndorsementRepository) CreateReport(ctx context.Context, rep *endorsement.Report, audit *endorsement.ModerationEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO endorsement_reports (id, endorsement_id, reporter_id, reason, details, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		rep.ID, rep.EndorsementID, rep.ReporterID, rep.Reason, rep.Details, rep.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert report: %w", err)
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE endorsements SET moderation_status = 'flagged' WHERE id = $1 AND moderation_status = 'clear'`, rep.EndorsementID); err != nil {
+		return fmt.Errorf("flag endorsement: %w", err)
+	}
+	if err := insertModerationEntry(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *EndorsementRepository) ListModeration(ctx context.Context, status string, limit, offset int) ([]endorsement.ModerationCase, int, error) {
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM endorsements WHERE moderation_status = $1`, status).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count moderation queue: %w", err)
+	}
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+endorsementColumns+` FROM endorsements WHERE moderation_status = $1
+		 ORDER BY created_at, id LIMIT $2 OFFSET $3`,
+		status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list moderation queue: %w", err)
+	}
+	defer rows.Close()
+
+	var cases []endorsement.ModerationCase
+	index := map[uuid.UUID]int{}
+	var ids []uuid.UUID
+	for rows.Next() {
+		e, err := scanEndorsement(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan endorsement: %w", err)
+		}
+		index[e.ID] = len(cases)
+		ids = append(ids, e.ID)
+		cases = append(cases, endorsement.ModerationCase{Endorsement: *e, Reports: []endorsement.Report{}})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if len(ids) == 0 {
+		return cases, total, nil
+	}
+
+	reports, err := r.pool.Query(ctx,
+		`SELECT id, endorsement_id, reporter_id, reason, details, created_at, resolved_at
+		 FROM endorsement_reports WHERE endorsement_id = ANY($1) ORDER BY created_at`, ids)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list reports: %w", err)
+	}
+	defer reports.Close()
+	for reports.Next() {
+		var rep endorsement.Report
+		if err := reports.Scan(&rep.ID, &rep.EndorsementID, &rep.ReporterID, &rep.Reason, &rep.Details, &rep.CreatedAt, &rep.ResolvedAt); err != nil {
+			return nil, 0, fmt.Errorf("scan report: %w", err)
+		}
+		i := index[rep.EndorsementID]
+		cases[i].Reports = append(cases[i].Reports, rep)
+	}
+	return cases, total, reports.Err()
+}
+
+func (r *EndorsementRepository) SetModeration(ctx context.Context, id uuid.UUID, status string, at time.Time, revoke string, audit *endorsement.ModerationEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `UPDATE endorsements SET moderation_status = $2 WHERE id = $1`, id, status); err != nil {
+		return fmt.Errorf("update moderation status: %w", err)
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE endorsement_reports SET resolved_at = $2 WHERE endorsement_id = $1 AND resolved_at IS NULL`, id, at); err != nil {
+		return fmt.Errorf("resolve reports: %w", err)
+	}
+	if revoke != "" {
+		if err := revokeEndorsementCredentials(ctx, tx, id, revoke, at); err != nil {
+			return err
+		}
+	}
+	if err := insertModerationEntry(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *EndorsementRepository) ListModerationLog(ctx context.Context, endorsementID uuid.UUID) ([]endorsement.ModerationEntry, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, endorsement_id, COALESCE(email_hash, ''), actor_id, actor_role, action, details, created_at
+		 FROM endorsement_moderation_log WHERE endorsement_id = $1 ORDER BY created_at, id`, endorsementID)
+	if err != nil {
+		return nil, fmt.Errorf("list moderation log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []endorsement.ModerationEntry
+	for rows.Next() {
+		var a endorsement.ModerationEntry
+		var detailsJSON []byte
+		if err := rows.Scan(&a.ID, &a.EndorsementID, &a.EmailHash, &a.ActorID, &a.ActorRole, &a.Action, &detailsJSON, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan moderation log: %w", err)
+		}
+		_ = json.Unmarshal(detailsJSON, &a.Details)
+		entries = append(entries, a)
+	}
+	return entries, rows.Err()
+}
+
+func (r *EndorsementRepository) BlockEmail(ctx context.Context, b *endorsement.BlockedEmail, audit *endorsement.ModerationEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO blocked_endorser_emails (email, reason, blocked_by, created_at) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (email) DO UPDATE SET reason = EXCLUDED.reason, blocked_by = EXCLUDED.blocked_by`,
+		b.Email, b.Reason, b.BlockedBy, b.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert blocked email: %w", err)
+	}
+	_, err = tx.Exec(ctx,
+		`WITH revoked AS (
+		     UPDATE endorsement_invites SET status = 'revoked'
+		     WHERE lower(endorser_email) = $1 AND status = 'pending'
+		     RETURNING id
+		 )
+		 INSERT INTO endorsement_invite_events (invite_id, kind, created_at)
+		 SELECT id, 'revoked', $2 FROM revoked`,
+		b.Email, b.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("revoke invites of blocked email: %w", err)
+	}
+	if err := insertModerationEntry(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *EndorsementRepository) UnblockEmail(ctx context.Context, email string, audit *endorsement.ModerationEntry) (bool, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, `DELETE FROM blocked_endorser_emails WHERE email = $1`, email)
+	if err != nil {
+		return false, fmt.Errorf("delete blocked email: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+	if err := insertModerationEntry(ctx, tx, audit); err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+func (r *EndorsementRepository) ListBlockedEmails(ctx context.Context) ([]endorsement.BlockedEmail, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT email, reason, blocked_by, created_at FROM blocked_endorser_emails ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("list blocked emails: %w", err)
+	}
+	defer rows.Close()
+
+	var blocked []endorsement.BlockedEmail
+	for rows.Next() {
+		var b endorsement.BlockedEmail
+		if err := rows.Scan(&b.Email, &b.Reason, &b.BlockedBy, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan blocked email: %w", err)
+		}
+		blocked = append(blocked, b)
+	}
+	return blocked, rows.Err()
+}
+
+func (r *EndorsementRepository) IsEmailBlocked(ctx context.Context, email string) (bool, error) {
+	var blocked bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM blocked_endorser_emails WHERE email = $1)`, email).Scan(&blocked)
+	if err != nil {
+		return false, fmt.Errorf("check blocked email: %w", err)
+	}
+	return blocked, nil
+}
+
 func (r *EndorsementRepository) UpdateVisibility(ctx context.Context, id uuid.UUID, learnerID uuid.UUID, visible bool) (*endorsement.Endorsement, error) {
 	_, err := r.pool.Exec(ctx,
 		`UPDATE endorsements SET visible = $1 WHERE id = $2 AND learner_id = $3`,
@@ -89,9 +415,9 @@ func (r *EndorsementRepository) UpdateVisibility(ctx context.Context, id uuid.UU
 
 func (r *EndorsementRepository) CreateInvite(ctx context.Context, inv *endorsement.EndorsementInvite) error {
 	_, err := r.pool.Exec(ctx,
-		`INSERT INTO endorsement_invites (id, learner_id, endorser_email, endorser_role, invitation_token, status, created_at, expires_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
-		inv.ID, inv.LearnerID, inv.EndorserEmail, inv.EndorserRole, inv.InviteURL, inv.Status, inv.CreatedAt, inv.ExpiresAt,
+		`INSERT INTO endorsement_invites (id, learner_id, endorser_email, endorser_role, invitation_token, message, locale, status, created_at, expires_at, last_sent_at, rubric_id)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		inv.ID, inv.LearnerID, inv.EndorserEmail, inv.EndorserRole, inv.Token, inv.Message, inv.Locale, inv.Status, inv.CreatedAt, inv.ExpiresAt, inv.LastSentAt, inv.RubricID,
 	)
 	if err != nil {
 		return fmt.Errorf("insert invite: %w", err)
@@ -100,21 +426,31 @@ func (r *EndorsementRepository) CreateInvite(ctx context.Context, inv *endorseme
 }
 
 func (r *EndorsementRepository) GetInviteByToken(ctx context.Context, token string) (*endorsement.EndorsementInvite, error) {
-	inv := &endorsement.EndorsementInvite{}
-	err := r.pool.QueryRow(ctx,
-		`SELECT id, learner_id, endorser_email, endorser_role, status, created_at, expires_at
-		 FROM endorsement_invites WHERE invitation_token = $1`,
-		token,
-	).Scan(&inv.ID, &inv.LearnerID, &inv.EndorserEmail, &inv.EndorserRole, &inv.Status, &inv.CreatedAt, &inv.ExpiresAt)
+	inv, err := scanInvite(r.pool.QueryRow(ctx,
+		`SELECT `+inviteColumns+` FROM endorsement_invites WHERE invitation_token = $1`, token))
 	if err != nil {
 		return nil, fmt.Errorf("get invite: %w", err)
 	}
 	return inv, nil
 }
 
+// inviteColumns are scanned by scanInvite.
+const inviteColumns = `id, learner_id, endorser_email, endorser_role, status, message, locale, invitation_token,
+	created_at, expires_at, email_verified_at, last_sent_at, reminder_count, rubric_id`
+
+func scanInvite(row pgx.Row) (*endorsement.EndorsementInvite, error) {
+	inv := &endorsement.EndorsementInvite{}
+	err := row.Scan(&inv.ID, &inv.LearnerID, &inv.EndorserEmail, &inv.EndorserRole, &inv.Status, &inv.Message, &inv.Locale, &inv.Token,
+		&inv.CreatedAt, &inv.ExpiresAt, &inv.EmailVerifiedAt, &inv.LastSentAt, &inv.ReminderCount, &inv.RubricID)
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
 func (r *EndorsementRepository) ListPendingInvites(ctx context.Context, learnerID uuid.UUID) ([]endorsement.EndorsementInvite, int, error) {
 	rows, err := r.pool.Query(ctx,
-		`SELECT id, learner_id, endorser_email, endorser_role, status, created_at, expires_at
+		`SELECT `+inviteColumns+`
 		 FROM endorsement_invites WHERE learner_id = $1 AND status = 'pending' ORDER BY created_at DESC`,
 		learnerID,
 	)
@@ -124,14 +460,44 @@ func (r *EndorsementRepository) ListPendingInvites(ctx context.Context, learnerI
 	defer rows.Close()
 
 	var invites []endorsement.EndorsementInvite
+	index := map[uuid.UUID]int{}
+	var ids []uuid.UUID
 	for rows.Next() {
-		var inv endorsement.EndorsementInvite
-		if err := rows.Scan(&inv.ID, &inv.LearnerID, &inv.EndorserEmail, &inv.EndorserRole, &inv.Status, &inv.CreatedAt, &inv.ExpiresAt); err != nil {
+		inv, err := scanInvite(rows)
+		if err != nil {
 			return nil, 0, fmt.Errorf("scan invite: %w", err)
 		}
-		invites = append(invites, inv)
+		// The learner gets the link when creating the invite, not from the list.
+		inv.Token = ""
+		index[inv.ID] = len(invites)
+		ids = append(ids, inv.ID)
+		invites = append(invites, *inv)
 	}
-	return invites, len(invites), nil
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("list pending: %w", err)
+	}
+	if len(ids) == 0 {
+		return invites, 0, nil
+	}
+
+	events, err := r.pool.Query(ctx,
+		`SELECT ev.invite_id, ev.kind, ev.mail_id, COALESCE(m.status, ''), ev.created_at
+		 FROM endorsement_invite_events ev LEFT JOIN mail_outbox m ON m.id = ev.mail_id
+		 WHERE ev.invite_id = ANY($1) ORDER BY ev.created_at, ev.id`, ids)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list invite events: %w", err)
+	}
+	defer events.Close()
+	for events.Next() {
+		var inviteID uuid.UUID
+		var ev endorsement.InviteEvent
+		if err := events.Scan(&inviteID, &ev.Kind, &ev.MailID, &ev.MailStatus, &ev.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("scan invite event: %w", err)
+		}
+		i := index[inviteID]
+		invites[i].History = append(invites[i].History, ev)
+	}
+	return invites, len(invites), events.Err()
 }
 
 func (r *EndorsementRepository) MarkInviteCompleted(ctx context.Context, token string) error {
@@ -141,3 +507,259 @@ func (r *EndorsementRepository) MarkInviteCompleted(ctx context.Context, token s
 	)
 	return err
 }
+
+func (r *EndorsementRepository) GetInvite(ctx context.Context, id, learnerID uuid.UUID) (*endorsement.EndorsementInvite, error) {
+	inv, err := scanInvite(r.pool.QueryRow(ctx,
+		`SELECT `+inviteColumns+` FROM endorsement_invites WHERE id = $1 AND learner_id = $2`, id, learnerID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get invite: %w", err)
+	}
+	return inv, nil
+}
+
+func (r *EndorsementRepository) RevokeInvite(ctx context.Context, id, learnerID uuid.UUID) (bool, error) {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE endorsement_invites SET status = 'revoked' WHERE id = $1 AND learner_id = $2 AND status = 'pending'`,
+		id, learnerID)
+	if err != nil {
+		return false, fmt.Errorf("revoke invite: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *EndorsementRepository) RenewInvite(ctx context.Context, id uuid.UUID, expiresAt, sentAt time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE endorsement_invites SET status = 'pending', expires_at = $2, last_sent_at = $3, reminder_count = 0
+		 WHERE id = $1 AND status IN ('pending', 'expired')`,
+		id, expiresAt, sentAt)
+	if err != nil {
+		return fmt.Errorf("renew invite: %w", err)
+	}
+	return nil
+}
+
+func (r *EndorsementRepository) ExpireInvites(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx,
+		`UPDATE endorsement_invites SET status = 'expired' WHERE status = 'pending' AND expires_at <= $1 RETURNING id`, now)
+	if err != nil {
+		return nil, fmt.Errorf("expire invites: %w", err)
+	}
+	defer rows.Close()
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan expired invite: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *EndorsementRepository) ClaimReminders(ctx context.Context, sentBefore, at time.Time, maxReminders, limit int) ([]endorsement.EndorsementInvite, error) {
+	rows, err := r.pool.Query(ctx,
+		`UPDATE endorsement_invites SET reminder_count = reminder_count + 1, last_sent_at = $4
+		 WHERE id IN (
+			SELECT id FROM endorsement_invites
+			WHERE status = 'pending' AND expires_at > NOW() AND last_sent_at < $1 AND reminder_count < $2
+			ORDER BY last_sent_at LIMIT $3
+			FOR UPDATE SKIP LOCKED)
+		 RETURNING `+inviteColumns,
+		sentBefore, maxReminders, limit, at)
+	if err != nil {
+		return nil, fmt.Errorf("claim due reminders: %w", err)
+	}
+	defer rows.Close()
+	var invites []endorsement.EndorsementInvite
+	for rows.Next() {
+		inv, err := scanInvite(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan invite: %w", err)
+		}
+		invites = append(invites, *inv)
+	}
+	return invites, rows.Err()
+}
+
+func (r *EndorsementRepository) AddInviteEvent(ctx context.Context, inviteID uuid.UUID, kind string, mailID *uuid.UUID, at time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO endorsement_invite_events (invite_id, kind, mail_id, created_at) VALUES ($1, $2, $3, $4)`,
+		inviteID, kind, mailID, at)
+	if err != nil {
+		return fmt.Errorf("insert invite event: %w", err)
+	}
+	return nil
+}
+
+func (r *EndorsementRepository) SetVerificationCode(ctx context.Context, inviteID uuid.UUID, codeHash string, expiresAt time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE endorsement_invites SET verify_code_hash = $2, verify_code_expires_at = $3, verify_attempts = 0 WHERE id = $1`,
+		inviteID, codeHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("set verification code: %w", err)
+	}
+	return nil
+}
+
+func (r *EndorsementRepository) ConfirmVerificationCode(ctx context.Context, inviteID uuid.UUID, codeHash string, maxAttempts int) (bool, error) {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE endorsement_invites SET email_verified_at = NOW(), verify_code_hash = NULL
+		 WHERE id = $1 AND verify_code_hash = $2 AND verify_code_expires_at > NOW() AND verify_attempts < $3`,
+		inviteID, codeHash, maxAttempts)
+	if err != nil {
+		return false, fmt.Errorf("confirm verification code: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return true, nil
+	}
+	if _, err := r.pool.Exec(ctx,
+		`UPDATE endorsement_invites SET verify_attempts = verify_attempts + 1 WHERE id = $1`, inviteID); err != nil {
+		return false, fmt.Errorf("count verification attempt: %w", err)
+	}
+	return false, nil
+}
+
+// OrganizationForDomain looks domains up in the "verifiedDomains" list of
+// active brand configs, e.g. [{"domain": "schule.de", "organization": "Gymnasium X"}].
+// Without an organisation name the brand name is used.
+func (r *EndorsementRepository) OrganizationForDomain(ctx context.Context, domains []string) (string, error) {
+	if len(domains) == 0 {
+		return "", nil
+	}
+	var org string
+	err := r.pool.QueryRow(ctx,
+		`SELECT COALESCE(NULLIF(d->>'organization', ''), config->>'brandName', slug)
+		 FROM brand_configs, jsonb_array_elements(
+		        CASE WHEN jsonb_typeof(config->'verifiedDomains') = 'array' THEN config->'verifiedDomains' ELSE '[]'::jsonb END) AS d
+		 WHERE is_active = true AND LOWER(d->>'domain') = ANY($1)
+		 ORDER BY LENGTH(d->>'domain') DESC, slug
+		 LIMIT 1`, domains).Scan(&org)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("lookup organization domain: %w", err)
+	}
+	return org, nil
+}
+
+func (r *EndorsementRepository) LearnerName(ctx context.Context, learnerID uuid.UUID) (string, error) {
+	var name string
+	err := r.pool.QueryRow(ctx, `SELECT COALESCE(display_name, '') FROM users WHERE id = $1`, learnerID).Scan(&name)
+	if err != nil {
+		return "", fmt.Errorf("get learner name: %w", err)
+	}
+	return name, nil
+}
+
+// rubricDefinition is the JSONB definition column of endorsement_rubrics.
+type rubricDefinition struct {
+	Name       map[string]string             `json:"name"`
+	Scale      endorsement.RatingScale       `json:"scale"`
+	Dimensions []endorsement.RubricDimension `json:"dimensions"`
+	Prompts    []endorsement.RubricPrompt    `json:"prompts,omitempty"`
+}
+
+const rubricColumns = `id, role, version, definition, active, created_at`
+
+func scanRubric(row pgx.Row) (*endorsement.Rubric, error) {
+	rb := &endorsement.Rubric{}
+	var defJSON []byte
+	if err := row.Scan(&rb.ID, &rb.Role, &rb.Version, &defJSON, &rb.Active, &rb.CreatedAt); err != nil {
+		return nil, err
+	}
+	var def rubricDefinition
+	if err := json.Unmarshal(defJSON, &def); err != nil {
+		return nil, fmt.Errorf("decode rubric %s: %w", rb.ID, err)
+	}
+	rb.Name, rb.Scale, rb.Dimensions, rb.Prompts = def.Name, def.Scale, def.Dimensions, def.Prompts
+	return rb, nil
+}
+
+func (r *EndorsementRepository) ActiveRubric(ctx context.Context, role string) (*endorsement.Rubric, error) {
+	rb, err := scanRubric(r.pool.QueryRow(ctx,
+		`SELECT `+rubricColumns+` FROM endorsement_rubrics WHERE role = $1 AND active`, role))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get active rubric: %w", err)
+	}
+	return rb, nil
+}
+
+func (r *EndorsementRepository) GetRubric(ctx context.Context, id uuid.UUID) (*endorsement.Rubric, error) {
+	rb, err := scanRubric(r.pool.QueryRow(ctx,
+		`SELECT `+rubricColumns+` FROM endorsement_rubrics WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get rubric: %w", err)
+	}
+	return rb, nil
+}
+
+func (r *EndorsementRepository) ListRubrics(ctx context.Context, role string, activeOnly bool) ([]endorsement.Rubric, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+rubricColumns+` FROM endorsement_rubrics
+		 WHERE ($1 = '' OR role = $1) AND (NOT $2 OR active)
+		 ORDER BY role, version DESC`,
+		role, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("list rubrics: %w", err)
+	}
+	defer rows.Close()
+	var rubrics []endorsement.Rubric
+	for rows.Next() {
+		rb, err := scanRubric(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan rubric: %w", err)
+		}
+		rubrics = append(rubrics, *rb)
+	}
+	return rubrics, rows.Err()
+}
+
+func (r *EndorsementRepository) CreateRubric(ctx context.Context, rb *endorsement.Rubric) error {
+	defJSON, err := json.Marshal(rubricDefinition{Name: rb.Name, Scale: rb.Scale, Dimensions: rb.Dimensions, Prompts: rb.Prompts})
+	if err != nil {
+		return fmt.Errorf("encode rubric: %w", err)
+	}
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialize versions per role.
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('endorsement_rubric:' || $1))`, rb.Role); err != nil {
+		return fmt.Errorf("lock rubric role: %w", err)
+	}
+	if err := tx.QueryRow(ctx,
+		`SELECT COALESCE(MAX(version), 0) + 1 FROM endorsement_rubrics WHERE role = $1`, rb.Role,
+	).Scan(&rb.Version); err != nil {
+		return fmt.Errorf("next rubric version: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE endorsement_rubrics SET active = false WHERE role = $1 AND active`, rb.Role); err != nil {
+		return fmt.Errorf("deactivate previous rubric: %w", err)
+	}
+	if err := tx.QueryRow(ctx,
+		`INSERT INTO endorsement_rubrics (id, role, version, definition, active) VALUES ($1, $2, $3, $4, true) RETURNING created_at`,
+		rb.ID, rb.Role, rb.Version, defJSON,
+	).Scan(&rb.CreatedAt); err != nil {
+		return fmt.Errorf("insert rubric: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *EndorsementRepository) DeactivateRubric(ctx context.Context, id uuid.UUID) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `UPDATE endorsement_rubrics SET active = false WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("deactivate rubric: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
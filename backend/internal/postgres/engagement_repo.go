@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -22,10 +23,11 @@ func NewEngagementRepository(pool *pgxpool.Pool) *EngagementRepository {
 func (r *EngagementRepository) GetState(ctx context.Context, userID uuid.UUID) (*engagement.EngagementState, error) {
 	s := &engagement.EngagementState{}
 	err := r.pool.QueryRow(ctx,
-		`SELECT current_streak, longest_streak, total_xp, weekly_xp, level, level_title, last_active_date, streak_freeze_available
-		 FROM engagement_state WHERE user_id = $1`,
+		`SELECT current_streak, longest_streak, total_xp, `+weeklyXPSQL+`, level, level_title,
+		        to_char(last_active_date, 'YYYY-MM-DD'), streak_freeze_available, ledger_count
+		 FROM engagement_state e WHERE user_id = $1`,
 		userID,
-	).Scan(&s.CurrentStreak, &s.LongestStreak, &s.TotalXP, &s.WeeklyXP, &s.Level, &s.LevelTitle, &s.LastActiveDate, &s.StreakFreezeAvailable)
+	).Scan(&s.CurrentStreak, &s.LongestStreak, &s.TotalXP, &s.WeeklyXP, &s.Level, &s.LevelTitle, &s.LastActiveDate, &s.StreakFreezeAvailable, &s.LedgerCount)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("no engagement state found")
@@ -37,13 +39,15 @@ func (r *EngagementRepository) GetState(ctx context.Context, userID uuid.UUID) (
 
 func (r *EngagementRepository) UpsertState(ctx context.Context, userID uuid.UUID, state *engagement.EngagementState) error {
 	_, err := r.pool.Exec(ctx,
-		`INSERT INTO engagement_state (user_id, current_streak, longest_streak, total_xp, weekly_xp, level, level_title, last_active_date, streak_freeze_available)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`INSERT INTO engagement_state (user_id, current_streak, longest_streak, total_xp, weekly_xp, level, level_title, last_active_date, streak_freeze_available, ledger_count)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		 ON CONFLICT (user_id) DO UPDATE SET
 			current_streak = $2, longest_streak = $3, total_xp = $4, weekly_xp = $5,
-			level = $6, level_title = $7, last_active_date = $8, streak_freeze_available = $9, updated_at = NOW()`,
+			level = $6, level_title = $7, last_active_date = $8, streak_freeze_available = $9,
+			ledger_count = $10, updated_at = NOW()
+		 WHERE engagement_state.ledger_count <= EXCLUDED.ledger_count`,
 		userID, state.CurrentStreak, state.LongestStreak, state.TotalXP, state.WeeklyXP,
-		state.Level, state.LevelTitle, state.LastActiveDate, state.StreakFreezeAvailable,
+		state.Level, state.LevelTitle, state.LastActiveDate, state.StreakFreezeAvailable, state.LedgerCount,
 	)
 	if err != nil {
 		return fmt.Errorf("upsert engagement: %w", err)
@@ -57,9 +61,11 @@ func (r *EngagementRepository) GetLeaderboard(ctx context.Context, period string
 		 ORDER BY e.total_xp DESC LIMIT $1`
 
 	if period == "weekly" {
-		query = `SELECT u.id, u.display_name, e.weekly_xp AS total_xp, e.level, e.level_title
-			 FROM engagement_state e JOIN users u ON e.user_id = u.id
-			 ORDER BY e.weekly_xp DESC LIMIT $1`
+		query = `SELECT u.id, u.display_name, w.xp AS total_xp, e.level, e.level_title
+			 FROM (` + weeklyTotalsSQL + `) w
+			 JOIN engagement_state e ON e.user_id = w.user_id
+			 JOIN users u ON e.user_id = u.id
+			 ORDER BY w.xp DESC LIMIT $1`
 	}
 
 	rows, err := r.pool.Query(ctx, query, limit)
@@ -89,7 +95,9 @@ func (r *EngagementRepository) GetLeaderboard(ctx context.Context, period string
 func (r *EngagementRepository) GetUserRank(ctx context.Context, userID uuid.UUID, period string) (int, error) {
 	query := `SELECT COUNT(*) + 1 FROM engagement_state WHERE total_xp > (SELECT COALESCE(total_xp, 0) FROM engagement_state WHERE user_id = $1)`
 	if period == "weekly" {
-		query = `SELECT COUNT(*) + 1 FROM engagement_state WHERE weekly_xp > (SELECT COALESCE(weekly_xp, 0) FROM engagement_state WHERE user_id = $1)`
+		query = `SELECT COUNT(*) + 1 FROM (` + weeklyTotalsSQL + `) w
+			 WHERE w.xp > (SELECT COALESCE(SUM(xp), 0) FROM xp_transactions
+			               WHERE user_id = $1 AND action <> 'legacy_balance' AND awarded_at >= ` + weekStartSQL + `)`
 	}
 	var rank int
 	if err := r.pool.QueryRow(ctx, query, userID).Scan(&rank); err != nil {
@@ -97,3 +105,118 @@ func (r *EngagementRepository) GetUserRank(ctx context.Context, userID uuid.UUID
 	}
 	return rank, nil
 }
+
+// Weekly XP is summed from the ledger so that it resets on Monday (UTC)
+// without a write to the projection.
+const (
+	weekStartSQL = `date_trunc('week', NOW() AT TIME ZONE 'UTC') AT TIME ZONE 'UTC'`
+	weeklyXPSQL  = `(SELECT COALESCE(SUM(xp), 0) FROM xp_transactions t
+		WHERE t.user_id = e.user_id AND t.action <> 'legacy_balance' AND t.awarded_at >= ` + weekStartSQL + `)`
+	weeklyTotalsSQL = `SELECT user_id, SUM(xp) AS xp FROM xp_transactions
+		WHERE action <> 'legacy_balance' AND awarded_at >= ` + weekStartSQL + `
+		GROUP BY user_id`
+)
+
+func (r *EngagementRepository) AppendTransaction(ctx context.Context, t *engagement.Transaction) (bool, error) {
+	contextJSON := []byte("{}")
+	if len(t.Context) > 0 {
+		var err error
+		if contextJSON, err = json.Marshal(t.Context); err != nil {
+			return false, fmt.Errorf("encode XP context: %w", err)
+		}
+	}
+	tag, err := r.pool.Exec(ctx,
+		`INSERT INTO xp_transactions (id, user_id, action, xp, idempotency_key, context, awarded_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)
+		 ON CONFLICT (user_id, idempotency_key) DO NOTHING`,
+		t.ID, t.UserID, t.Action, t.XP, t.IdempotencyKey, contextJSON, t.AwardedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("insert XP transaction: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *EngagementRepository) ListTransactions(ctx context.Context, userID uuid.UUID) ([]engagement.Transaction, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, user_id, action, xp, idempotency_key, context, awarded_at
+		 FROM xp_transactions WHERE user_id = $1
+		 ORDER BY awarded_at, id`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list XP transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var txns []engagement.Transaction
+	for rows.Next() {
+		var t engagement.Transaction
+		var contextJSON []byte
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Action, &t.XP, &t.IdempotencyKey, &contextJSON, &t.AwardedAt); err != nil {
+			return nil, fmt.Errorf("scan XP transaction: %w", err)
+		}
+		if err := json.Unmarshal(contextJSON, &t.Context); err != nil {
+			return nil, fmt.Errorf("decode XP context: %w", err)
+		}
+		txns = append(txns, t)
+	}
+	return txns, rows.Err()
+}
+
+func (r *EngagementRepository) ListUsers(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT user_id FROM xp_transactions UNION SELECT user_id FROM engagement_state`)
+	if err != nil {
+		return nil, fmt.Errorf("list engagement users: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan engagement user: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *EngagementRepository) SessionStation(ctx context.Context, userID, sessionID uuid.UUID) (string, bool, error) {
+	var station string
+	err := r.pool.QueryRow(ctx,
+		`SELECT COALESCE(station_id, '') FROM sessions WHERE id = $1 AND user_id = $2`,
+		sessionID, userID,
+	).Scan(&station)
+	if err == pgx.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("get session station: %w", err)
+	}
+	return station, true, nil
+}
+
+func (r *EngagementRepository) QuestionExists(ctx context.Context, questionID string) (bool, error) {
+	var ok bool
+	err := r.pool.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM reflection_questions WHERE id = $1)`, questionID).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("check question: %w", err)
+	}
+	return ok, nil
+}
+
+func (r *EngagementRepository) HasModule(ctx context.Context, userID uuid.UUID, moduleID string) (bool, error) {
+	var ok bool
+	err := r.pool.QueryRow(ctx,
+		`SELECT EXISTS (
+		     SELECT 1 FROM lernreise_progress p JOIN lernreise_instances i ON i.id = p.instance_id
+		     WHERE i.user_id = $1 AND p.module_id = $2)`,
+		userID, moduleID).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("check module: %w", err)
+	}
+	return ok, nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"skillr-mvp-v1/backend/internal/domain/evidence"
@@ -19,34 +21,115 @@ func NewEvidenceRepository(pool *pgxpool.Pool) *EvidenceRepository {
 	return &EvidenceRepository{pool: pool}
 }
 
-func (r *EvidenceRepository) Create(ctx context.Context, e *evidence.PortfolioEntry) error {
+// evidenceColumns selects an entry with its revocation; use with evidenceFrom.
+const evidenceColumns = `pe.id, pe.user_id, pe.source_interaction_ids, pe.skill_dimensions, pe.evidence_type, pe.summary, pe.confidence, pe.context,
+	pe.signature, pe.signing_key_id, pe.signed_at, pe.version, pe.retracted_at, pe.retraction_reason, pe.created_at, pe.updated_at,
+	pe.verification_token, r.revoked_by, r.reason, r.revoked_at`
+
+const evidenceFrom = `portfolio_entries pe LEFT JOIN evidence_revocations r ON r.evidence_id = pe.id`
+
+func scanEvidence(row pgx.Row) (*evidence.PortfolioEntry, error) {
+	e := &evidence.PortfolioEntry{}
+	var dimJSON, ctxJSON []byte
+	var revokedBy, reason *string
+	var revokedAt *time.Time
+	if err := row.Scan(&e.ID, &e.UserID, &e.SourceInteractionIDs, &dimJSON, &e.EvidenceType, &e.Summary, &e.Confidence, &ctxJSON,
+		&e.Signature, &e.SigningKeyID, &e.SignedAt, &e.Version, &e.RetractedAt, &e.RetractionReason, &e.CreatedAt, &e.UpdatedAt,
+		&e.VerificationToken, &revokedBy, &reason, &revokedAt); err != nil {
+		return nil, err
+	}
+	_ = json.Unmarshal(dimJSON, &e.SkillDimensions)
+	_ = json.Unmarshal(ctxJSON, &e.Context)
+	if revokedAt != nil {
+		e.Revocation = &evidence.Revocation{EvidenceID: e.ID, RevokedAt: *revokedAt}
+		if revokedBy != nil {
+			e.Revocation.RevokedBy = *revokedBy
+		}
+		if reason != nil {
+			e.Revocation.Reason = *reason
+		}
+	}
+	return e, nil
+}
+
+func scanEvidenceRows(rows pgx.Rows) ([]evidence.PortfolioEntry, error) {
+	defer rows.Close()
+	var entries []evidence.PortfolioEntry
+	for rows.Next() {
+		e, err := scanEvidence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan evidence: %w", err)
+		}
+		entries = append(entries, *e)
+	}
+	return entries, rows.Err()
+}
+
+const insertEvidence = `INSERT INTO portfolio_entries (id, user_id, source_interaction_ids, skill_dimensions, evidence_type, summary, confidence, context, verification_token, signature, signing_key_id, signed_at, created_at)
+	 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+func insertEvidenceArgs(e *evidence.PortfolioEntry) []interface{} {
 	dimJSON, _ := json.Marshal(e.SkillDimensions)
 	ctxJSON, _ := json.Marshal(e.Context)
+	return []interface{}{e.ID, e.UserID, e.SourceInteractionIDs, dimJSON, e.EvidenceType, e.Summary, e.Confidence, ctxJSON, e.VerificationToken, e.Signature, e.SigningKeyID, e.SignedAt, e.CreatedAt}
+}
 
-	_, err := r.pool.Exec(ctx,
-		`INSERT INTO portfolio_entries (id, user_id, source_interaction_ids, skill_dimensions, evidence_type, summary, confidence, context, verification_token, created_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
-		e.ID, e.UserID, e.SourceInteractionIDs, dimJSON, e.EvidenceType, e.Summary, e.Confidence, ctxJSON, e.VerificationToken, e.CreatedAt,
-	)
+func (r *EvidenceRepository) Create(ctx context.Context, e *evidence.PortfolioEntry, audit *evidence.AuditEntry) error {
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, insertEvidence, insertEvidenceArgs(e)...); err != nil {
 		return fmt.Errorf("insert evidence: %w", err)
 	}
+	if err := insertEvidenceAudit(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// insertEvidenceAudit appends to the audit log inside the caller's
+// transaction. The row belongs to the entry's owner, so deleting the account
+// erases it.
+func insertEvidenceAudit(ctx context.Context, tx pgx.Tx, a *evidence.AuditEntry) error {
+	changesJSON, _ := json.Marshal(a.Changes)
+	tag, err := tx.Exec(ctx,
+		`INSERT INTO evidence_audit_log (id, evidence_id, user_id, actor_id, actor_role, action, changes, created_at)
+		 SELECT $1, $2, pe.user_id, $3, $4, $5, $6, $7 FROM portfolio_entries pe WHERE pe.id = $2`,
+		a.ID, a.EvidenceID, a.ActorID, a.ActorRole, a.Action, changesJSON, a.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert evidence audit: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return evidence.ErrNotFound
+	}
+	return nil
+}
+
+// revokeEvidenceCredentials revokes active credentials issued for an entry.
+func revokeEvidenceCredentials(ctx context.Context, tx pgx.Tx, id uuid.UUID, reason string, at time.Time) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE credentials SET status = 'revoked', revoked_at = $2, revocation_reason = $3
+		 WHERE subject_type = 'evidence' AND subject_id = $1 AND status = 'active'`,
+		id, at, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("revoke evidence credentials: %w", err)
+	}
 	return nil
 }
 
 func (r *EvidenceRepository) GetByID(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*evidence.PortfolioEntry, error) {
-	e := &evidence.PortfolioEntry{}
-	var dimJSON, ctxJSON []byte
-	err := r.pool.QueryRow(ctx,
-		`SELECT id, user_id, source_interaction_ids, skill_dimensions, evidence_type, summary, confidence, context, created_at
-		 FROM portfolio_entries WHERE id = $1 AND user_id = $2`,
+	e, err := scanEvidence(r.pool.QueryRow(ctx,
+		`SELECT `+evidenceColumns+` FROM `+evidenceFrom+` WHERE pe.id = $1 AND pe.user_id = $2`,
 		id, userID,
-	).Scan(&e.ID, &e.UserID, &e.SourceInteractionIDs, &dimJSON, &e.EvidenceType, &e.Summary, &e.Confidence, &ctxJSON, &e.CreatedAt)
+	))
 	if err != nil {
 		return nil, fmt.Errorf("get evidence: %w", err)
 	}
-	_ = json.Unmarshal(dimJSON, &e.SkillDimensions)
-	_ = json.Unmarshal(ctxJSON, &e.Context)
 	return e, nil
 }
 
@@ -59,14 +142,19 @@ func (r *EvidenceRepository) GetDetailedByID(ctx context.Context, id uuid.UUID,
 }
 
 func (r *EvidenceRepository) List(ctx context.Context, params evidence.ListParams) ([]evidence.PortfolioEntry, int, error) {
-	query := `SELECT id, user_id, source_interaction_ids, skill_dimensions, evidence_type, summary, confidence, context, created_at FROM portfolio_entries WHERE user_id = $1`
-	countQuery := `SELECT COUNT(*) FROM portfolio_entries WHERE user_id = $1`
+	query := `SELECT ` + evidenceColumns + ` FROM ` + evidenceFrom + ` WHERE pe.user_id = $1`
+	countQuery := `SELECT COUNT(*) FROM portfolio_entries pe WHERE pe.user_id = $1`
 	args := []interface{}{params.UserID}
 	argIdx := 2
 
+	if !params.IncludeRetracted {
+		query += " AND pe.retracted_at IS NULL"
+		countQuery += " AND pe.retracted_at IS NULL"
+	}
+
 	if params.EvidenceType != nil {
-		query += fmt.Sprintf(" AND evidence_type = $%d", argIdx)
-		countQuery += fmt.Sprintf(" AND evidence_type = $%d", argIdx)
+		query += fmt.Sprintf(" AND pe.evidence_type = $%d", argIdx)
+		countQuery += fmt.Sprintf(" AND pe.evidence_type = $%d", argIdx)
 		args = append(args, *params.EvidenceType)
 		argIdx++
 	}
@@ -76,66 +164,385 @@ func (r *EvidenceRepository) List(ctx context.Context, params evidence.ListParam
 		return nil, 0, fmt.Errorf("count evidence: %w", err)
 	}
 
-	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
+	query += fmt.Sprintf(" ORDER BY pe.created_at DESC LIMIT $%d OFFSET $%d", argIdx, argIdx+1)
 	args = append(args, params.Limit, params.Offset)
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, 0, fmt.Errorf("list evidence: %w", err)
 	}
-	defer rows.Close()
-
-	var entries []evidence.PortfolioEntry
-	for rows.Next() {
-		var e evidence.PortfolioEntry
-		var dimJSON, ctxJSON []byte
-		if err := rows.Scan(&e.ID, &e.UserID, &e.SourceInteractionIDs, &dimJSON, &e.EvidenceType, &e.Summary, &e.Confidence, &ctxJSON, &e.CreatedAt); err != nil {
-			return nil, 0, fmt.Errorf("scan evidence: %w", err)
-		}
-		_ = json.Unmarshal(dimJSON, &e.SkillDimensions)
-		_ = json.Unmarshal(ctxJSON, &e.Context)
-		entries = append(entries, e)
+	entries, err := scanEvidenceRows(rows)
+	if err != nil {
+		return nil, 0, err
 	}
 	return entries, total, nil
 }
 
 func (r *EvidenceRepository) ListByDimension(ctx context.Context, userID uuid.UUID, dimension string) ([]evidence.PortfolioEntry, int, error) {
 	rows, err := r.pool.Query(ctx,
-		`SELECT id, user_id, source_interaction_ids, skill_dimensions, evidence_type, summary, confidence, context, created_at
-		 FROM portfolio_entries WHERE user_id = $1 AND skill_dimensions ? $2 ORDER BY created_at DESC`,
+		`SELECT `+evidenceColumns+` FROM `+evidenceFrom+`
+		 WHERE pe.user_id = $1 AND pe.skill_dimensions ? $2 AND pe.retracted_at IS NULL ORDER BY pe.created_at DESC`,
 		userID, dimension,
 	)
 	if err != nil {
 		return nil, 0, fmt.Errorf("list by dimension: %w", err)
 	}
-	defer rows.Close()
-
-	var entries []evidence.PortfolioEntry
-	for rows.Next() {
-		var e evidence.PortfolioEntry
-		var dimJSON, ctxJSON []byte
-		if err := rows.Scan(&e.ID, &e.UserID, &e.SourceInteractionIDs, &dimJSON, &e.EvidenceType, &e.Summary, &e.Confidence, &ctxJSON, &e.CreatedAt); err != nil {
-			return nil, 0, fmt.Errorf("scan evidence: %w", err)
-		}
-		_ = json.Unmarshal(dimJSON, &e.SkillDimensions)
-		_ = json.Unmarshal(ctxJSON, &e.Context)
-		entries = append(entries, e)
+	entries, err := scanEvidenceRows(rows)
+	if err != nil {
+		return nil, 0, err
 	}
 	return entries, len(entries), nil
 }
 
 func (r *EvidenceRepository) GetByVerificationToken(ctx context.Context, id uuid.UUID, token string) (*evidence.PortfolioEntry, error) {
-	e := &evidence.PortfolioEntry{}
-	var dimJSON, ctxJSON []byte
-	err := r.pool.QueryRow(ctx,
-		`SELECT id, user_id, source_interaction_ids, skill_dimensions, evidence_type, summary, confidence, context, created_at
-		 FROM portfolio_entries WHERE id = $1 AND verification_token = $2`,
+	e, err := scanEvidence(r.pool.QueryRow(ctx,
+		`SELECT `+evidenceColumns+` FROM `+evidenceFrom+` WHERE pe.id = $1 AND pe.verification_token = $2`,
 		id, token,
-	).Scan(&e.ID, &e.UserID, &e.SourceInteractionIDs, &dimJSON, &e.EvidenceType, &e.Summary, &e.Confidence, &ctxJSON, &e.CreatedAt)
+	))
 	if err != nil {
 		return nil, fmt.Errorf("verify evidence: %w", err)
 	}
-	_ = json.Unmarshal(dimJSON, &e.SkillDimensions)
-	_ = json.Unmarshal(ctxJSON, &e.Context)
 	return e, nil
 }
+
+func (r *EvidenceRepository) ListUnsigned(ctx context.Context, activeKeyID string, limit int) ([]evidence.PortfolioEntry, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+evidenceColumns+` FROM `+evidenceFrom+`
+		 WHERE pe.signing_key_id IS DISTINCT FROM $1 AND pe.retracted_at IS NULL ORDER BY pe.created_at LIMIT $2`,
+		activeKeyID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list unsigned evidence: %w", err)
+	}
+	return scanEvidenceRows(rows)
+}
+
+func (r *EvidenceRepository) UpdateSignature(ctx context.Context, id uuid.UUID, signature, keyID string, signedAt time.Time, audit *evidence.AuditEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`UPDATE portfolio_entries SET signature = $2, signing_key_id = $3, signed_at = $4 WHERE id = $1`,
+		id, signature, keyID, signedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update evidence signature: %w", err)
+	}
+	if err := insertEvidenceAudit(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Update snapshots the stored version into evidence_versions before
+// overwriting it. The version check guards against concurrent edits.
+func (r *EvidenceRepository) Update(ctx context.Context, e *evidence.PortfolioEntry, audit *evidence.AuditEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx,
+		`INSERT INTO evidence_versions (evidence_id, version, summary, skill_dimensions, confidence, context, signature, signing_key_id, superseded_at)
+		 SELECT id, version, summary, skill_dimensions, confidence, context, signature, signing_key_id, $3
+		 FROM portfolio_entries WHERE id = $1 AND version = $2 AND retracted_at IS NULL`,
+		e.ID, e.Version-1, *e.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert evidence version: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return evidence.ErrVersionConflict
+	}
+
+	dimJSON, _ := json.Marshal(e.SkillDimensions)
+	ctxJSON, _ := json.Marshal(e.Context)
+	_, err = tx.Exec(ctx,
+		`UPDATE portfolio_entries SET summary = $2, skill_dimensions = $3, context = $4, version = $5, updated_at = $6,
+		        signature = $7, signing_key_id = $8, signed_at = $9
+		 WHERE id = $1`,
+		e.ID, e.Summary, dimJSON, ctxJSON, e.Version, e.UpdatedAt, e.Signature, e.SigningKeyID, e.SignedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update evidence: %w", err)
+	}
+	if err := revokeEvidenceCredentials(ctx, tx, e.ID, "evidence_updated", *e.UpdatedAt); err != nil {
+		return err
+	}
+	if err := insertEvidenceAudit(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *EvidenceRepository) Retract(ctx context.Context, id uuid.UUID, reason string, at time.Time, audit *evidence.AuditEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx,
+		`UPDATE portfolio_entries SET retracted_at = $2, retraction_reason = $3 WHERE id = $1 AND retracted_at IS NULL`,
+		id, at, reason,
+	)
+	if err != nil {
+		return fmt.Errorf("retract evidence: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+	if err := revokeEvidenceCredentials(ctx, tx, id, "evidence_retracted", at); err != nil {
+		return err
+	}
+	if err := insertEvidenceAudit(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *EvidenceRepository) ListVersions(ctx context.Context, id uuid.UUID) ([]evidence.Version, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT evidence_id, version, summary, skill_dimensions, confidence, context, signature, signing_key_id, superseded_at
+		 FROM evidence_versions WHERE evidence_id = $1 ORDER BY version DESC`, id)
+	if err != nil {
+		return nil, fmt.Errorf("list evidence versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []evidence.Version
+	for rows.Next() {
+		var v evidence.Version
+		var dimJSON, ctxJSON []byte
+		if err := rows.Scan(&v.EvidenceID, &v.Version, &v.Summary, &dimJSON, &v.Confidence, &ctxJSON, &v.Signature, &v.SigningKeyID, &v.SupersededAt); err != nil {
+			return nil, fmt.Errorf("scan evidence version: %w", err)
+		}
+		_ = json.Unmarshal(dimJSON, &v.SkillDimensions)
+		_ = json.Unmarshal(ctxJSON, &v.Context)
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+func (r *EvidenceRepository) ListAudit(ctx context.Context, id uuid.UUID) ([]evidence.AuditEntry, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, evidence_id, actor_id, actor_role, action, changes, created_at
+		 FROM evidence_audit_log WHERE evidence_id = $1 ORDER BY created_at, id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("list evidence audit: %w", err)
+	}
+	defer rows.Close()
+
+	var audit []evidence.AuditEntry
+	for rows.Next() {
+		var a evidence.AuditEntry
+		var changesJSON []byte
+		if err := rows.Scan(&a.ID, &a.EvidenceID, &a.ActorID, &a.ActorRole, &a.Action, &changesJSON, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan evidence audit: %w", err)
+		}
+		_ = json.Unmarshal(changesJSON, &a.Changes)
+		audit = append(audit, a)
+	}
+	return audit, rows.Err()
+}
+
+// Revoke records the revocation and revokes active credentials issued for
+// the entry in one transaction.
+func (r *EvidenceRepository) Revoke(ctx context.Context, rev *evidence.Revocation, audit *evidence.AuditEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO evidence_revocations (evidence_id, revoked_by, reason, revoked_at)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (evidence_id) DO UPDATE SET revoked_by = EXCLUDED.revoked_by, reason = EXCLUDED.reason, revoked_at = EXCLUDED.revoked_at`,
+		rev.EvidenceID, rev.RevokedBy, rev.Reason, rev.RevokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert revocation: %w", err)
+	}
+	if err := revokeEvidenceCredentials(ctx, tx, rev.EvidenceID, "evidence_revoked", rev.RevokedAt); err != nil {
+		return err
+	}
+	if err := insertEvidenceAudit(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *EvidenceRepository) Unrevoke(ctx context.Context, id uuid.UUID, audit *evidence.AuditEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, `DELETE FROM evidence_revocations WHERE evidence_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete revocation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+	if err := insertEvidenceAudit(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *EvidenceRepository) ListRevocations(ctx context.Context, limit, offset int) ([]evidence.Revocation, int, error) {
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM evidence_revocations`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count revocations: %w", err)
+	}
+	rows, err := r.pool.Query(ctx,
+		`SELECT evidence_id, revoked_by, reason, revoked_at FROM evidence_revocations
+		 ORDER BY revoked_at DESC LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list revocations: %w", err)
+	}
+	defer rows.Close()
+	var list []evidence.Revocation
+	for rows.Next() {
+		var rev evidence.Revocation
+		if err := rows.Scan(&rev.EvidenceID, &rev.RevokedBy, &rev.Reason, &rev.RevokedAt); err != nil {
+			return nil, 0, fmt.Errorf("scan revocation: %w", err)
+		}
+		list = append(list, rev)
+	}
+	return list, total, rows.Err()
+}
+
+const suggestionColumns = `id, user_id, session_id, dimension, score, confidence, summary, source_interaction_ids, context, status, evidence_id, prompt_id, prompt_version, created_at, reviewed_at`
+
+func scanSuggestion(row pgx.Row) (*evidence.Suggestion, error) {
+	s := &evidence.Suggestion{}
+	var ctxJSON []byte
+	if err := row.Scan(&s.ID, &s.UserID, &s.SessionID, &s.Dimension, &s.Score, &s.Confidence, &s.Summary, &s.SourceInteractionIDs,
+		&ctxJSON, &s.Status, &s.EvidenceID, &s.PromptID, &s.PromptVersion, &s.CreatedAt, &s.ReviewedAt); err != nil {
+		return nil, err
+	}
+	_ = json.Unmarshal(ctxJSON, &s.Context)
+	return s, nil
+}
+
+func (r *EvidenceRepository) CreateSuggestions(ctx context.Context, suggestions []evidence.Suggestion) (int, error) {
+	batch := &pgx.Batch{}
+	for _, s := range suggestions {
+		ctxJSON, _ := json.Marshal(s.Context)
+		batch.Queue(
+			`INSERT INTO evidence_suggestions (id, user_id, session_id, dimension, score, confidence, summary, source_interaction_ids, context, status, prompt_id, prompt_version, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			 ON CONFLICT (session_id, dimension) DO NOTHING`,
+			s.ID, s.UserID, s.SessionID, s.Dimension, s.Score, s.Confidence, s.Summary, s.SourceInteractionIDs, ctxJSON, s.Status, s.PromptID, s.PromptVersion, s.CreatedAt,
+		)
+	}
+	br := r.pool.SendBatch(ctx, batch)
+	defer br.Close()
+
+	stored := 0
+	for range suggestions {
+		tag, err := br.Exec()
+		if err != nil {
+			return stored, fmt.Errorf("insert suggestion: %w", err)
+		}
+		stored += int(tag.RowsAffected())
+	}
+	return stored, nil
+}
+
+func (r *EvidenceRepository) GetSuggestion(ctx context.Context, id, userID uuid.UUID) (*evidence.Suggestion, error) {
+	s, err := scanSuggestion(r.pool.QueryRow(ctx,
+		`SELECT `+suggestionColumns+` FROM evidence_suggestions WHERE id = $1 AND user_id = $2`, id, userID))
+	if err != nil {
+		return nil, fmt.Errorf("get suggestion: %w", err)
+	}
+	return s, nil
+}
+
+func (r *EvidenceRepository) ListSuggestions(ctx context.Context, params evidence.SuggestionListParams) ([]evidence.Suggestion, int, error) {
+	where := ` WHERE user_id = $1`
+	args := []interface{}{params.UserID}
+	if params.Status != "" {
+		args = append(args, params.Status)
+		where += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if params.SessionID != nil {
+		args = append(args, *params.SessionID)
+		where += fmt.Sprintf(" AND session_id = $%d", len(args))
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM evidence_suggestions`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count suggestions: %w", err)
+	}
+
+	query := `SELECT ` + suggestionColumns + ` FROM evidence_suggestions` + where +
+		fmt.Sprintf(" ORDER BY created_at DESC, dimension LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	rows, err := r.pool.Query(ctx, query, append(args, params.Limit, params.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	var list []evidence.Suggestion
+	for rows.Next() {
+		s, err := scanSuggestion(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan suggestion: %w", err)
+		}
+		list = append(list, *s)
+	}
+	return list, total, rows.Err()
+}
+
+func (r *EvidenceRepository) AcceptSuggestion(ctx context.Context, id uuid.UUID, e *evidence.PortfolioEntry, at time.Time, audit *evidence.AuditEntry) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Insert first so the suggestion can reference the entry; a suggestion
+	// that is no longer pending rolls the insert back.
+	if _, err := tx.Exec(ctx, insertEvidence, insertEvidenceArgs(e)...); err != nil {
+		return fmt.Errorf("insert evidence: %w", err)
+	}
+	tag, err := tx.Exec(ctx,
+		`UPDATE evidence_suggestions SET status = 'accepted', evidence_id = $2, reviewed_at = $3
+		 WHERE id = $1 AND status = 'pending'`,
+		id, e.ID, at,
+	)
+	if err != nil {
+		return fmt.Errorf("accept suggestion: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return evidence.ErrSuggestionReviewed
+	}
+	if err := insertEvidenceAudit(ctx, tx, audit); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *EvidenceRepository) DiscardSuggestion(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE evidence_suggestions SET status = 'discarded', reviewed_at = $2
+		 WHERE id = $1 AND status = 'pending'`,
+		id, at,
+	)
+	if err != nil {
+		return fmt.Errorf("discard suggestion: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return evidence.ErrSuggestionReviewed
+	}
+	return nil
+}
@@ -0,0 +1,326 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"skillr-mvp-v1/backend/internal/domain/job"
+)
+
+type JobRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewJobRepository(pool *pgxpool.Pool) *JobRepository {
+	return &JobRepository{pool: pool}
+}
+
+const jobColumns = `id, title, company, description, required_dimensions, location, source, external_url, is_active, created_at, updated_at`
+
+func scanJob(row pgx.Row) (*job.Posting, error) {
+	p := &job.Posting{}
+	var dims []byte
+	if err := row.Scan(&p.ID, &p.Title, &p.Company, &p.Description, &dims, &p.Location, &p.Source, &p.ExternalURL, &p.IsActive, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if len(dims) > 0 {
+		_ = json.Unmarshal(dims, &p.RequiredDimensions)
+	}
+	if p.RequiredDimensions == nil {
+		p.RequiredDimensions = map[string]float64{}
+	}
+	return p, nil
+}
+
+func (r *JobRepository) ListActive(ctx context.Context, filter job.PostingFilter, limit int) ([]job.Posting, error) {
+	where := []string{"is_active = true"}
+	var args []interface{}
+	if filter.Location != "" {
+		args = append(args, filter.Location)
+		where = append(where, fmt.Sprintf("position(lower($%d) in lower(location)) > 0", len(args)))
+	}
+	if filter.Source != "" {
+		args = append(args, filter.Source)
+		where = append(where, fmt.Sprintf("source = $%d", len(args)))
+	}
+	if filter.Title != "" {
+		args = append(args, filter.Title)
+		where = append(where, fmt.Sprintf("position(lower($%d) in lower(title)) > 0", len(args)))
+	}
+	args = append(args, limit)
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+jobColumns+` FROM job_postings WHERE `+strings.Join(where, " AND ")+
+			fmt.Sprintf(` ORDER BY created_at DESC LIMIT $%d`, len(args)),
+		args...)
+	if err != nil {
+		return nil, fmt.Errorf("list job postings: %w", err)
+	}
+	defer rows.Close()
+
+	var postings []job.Posting
+	for rows.Next() {
+		p, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		postings = append(postings, *p)
+	}
+	return postings, rows.Err()
+}
+
+// LoadSkills reads the dimension scores of the latest profile and the
+// numeric dimension values of the learner's current evidence.
+func (r *JobRepository) LoadSkills(ctx context.Context, userID uuid.UUID) (*job.Skills, error) {
+	skills := &job.Skills{ProfileScores: map[string]float64{}, Evidence: map[string]job.EvidenceStat{}}
+
+	var scores []byte
+	err := r.pool.QueryRow(ctx,
+		`SELECT dimension_scores, last_computed_at FROM skill_profiles
+		 WHERE user_id = $1 ORDER BY last_computed_at DESC LIMIT 1`,
+		userID,
+	).Scan(&scores, &skills.ProfileComputedAt)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("load profile scores: %w", err)
+	}
+	if len(scores) > 0 {
+		_ = json.Unmarshal(scores, &skills.ProfileScores)
+	}
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT d.key, (d.value)::text::float8
+		 FROM portfolio_entries pe, jsonb_each(pe.skill_dimensions) d
+		 WHERE pe.user_id = $1 AND pe.retracted_at IS NULL AND jsonb_typeof(d.value) = 'number'
+		   AND NOT EXISTS (SELECT 1 FROM evidence_revocations rv WHERE rv.evidence_id = pe.id)`,
+		userID)
+	if err != nil {
+		return nil, fmt.Errorf("load evidence dimensions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		var value float64
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		st := skills.Evidence[key]
+		st.Count++
+		if value > st.MaxScore {
+			st.MaxScore = value
+		}
+		skills.Evidence[key] = st
+	}
+	return skills, rows.Err()
+}
+
+// ListLernreisen returns the Lernreisen of the default content packs plus,
+// for a brand, those of its activated packs.
+func (r *JobRepository) ListLernreisen(ctx context.Context, brand string) ([]job.Lernreise, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT DISTINCT l.id, l.title, l.subtitle, l.icon, l.dimensions, l.sort_order
+		 FROM content_pack_lernreisen l
+		 JOIN content_packs p ON p.id = l.pack_id
+		 WHERE p.default_enabled = TRUE
+		    OR ($1 <> '' AND l.pack_id IN (SELECT pack_id FROM brand_content_packs WHERE brand_slug = $1 AND is_active = TRUE))
+		 ORDER BY l.sort_order`,
+		brand)
+	if err != nil {
+		return nil, fmt.Errorf("list lernreisen: %w", err)
+	}
+	defer rows.Close()
+
+	var result []job.Lernreise
+	for rows.Next() {
+		var lr job.Lernreise
+		var dims string
+		if err := rows.Scan(&lr.ID, &lr.Title, &lr.Subtitle, &lr.Icon, &dims, &lr.SortOrder); err != nil {
+			return nil, fmt.Errorf("scan lernreise row: %w", err)
+		}
+		_ = json.Unmarshal([]byte(dims), &lr.Dimensions)
+		result = append(result, lr)
+	}
+	return result, rows.Err()
+}
+
+func (r *JobRepository) HoneycombCtxID(ctx context.Context, userID uuid.UUID) (string, error) {
+	var ctxID *string
+	err := r.pool.QueryRow(ctx, `SELECT honeycomb_ctx_id FROM users WHERE id = $1`, userID).Scan(&ctxID)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get honeycomb ctx_id: %w", err)
+	}
+	if ctxID == nil {
+		return "", nil
+	}
+	return *ctxID, nil
+}
+
+func (r *JobRepository) List(ctx context.Context, params job.AdminListParams) ([]job.Posting, int, error) {
+	var where []string
+	var args []interface{}
+	if params.Source != "" {
+		args = append(args, params.Source)
+		where = append(where, fmt.Sprintf("source = $%d", len(args)))
+	}
+	if params.Active != nil {
+		args = append(args, *params.Active)
+		where = append(where, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+	if params.Query != "" {
+		args = append(args, params.Query)
+		where = append(where, fmt.Sprintf("(position(lower($%d) in lower(title)) > 0 OR position(lower($%d) in lower(coalesce(company, ''))) > 0)", len(args), len(args)))
+	}
+	cond := ""
+	if len(where) > 0 {
+		cond = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT count(*) FROM job_postings`+cond, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count job postings: %w", err)
+	}
+
+	args = append(args, params.Limit, params.Offset)
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+jobColumns+` FROM job_postings`+cond+
+			fmt.Sprintf(` ORDER BY updated_at DESC LIMIT $%d OFFSET $%d`, len(args)-1, len(args)),
+		args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list job postings: %w", err)
+	}
+	defer rows.Close()
+
+	var postings []job.Posting
+	for rows.Next() {
+		p, err := scanJob(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		postings = append(postings, *p)
+	}
+	return postings, total, rows.Err()
+}
+
+func (r *JobRepository) GetByID(ctx context.Context, id uuid.UUID) (*job.Posting, error) {
+	p, err := scanJob(r.pool.QueryRow(ctx, `SELECT `+jobColumns+` FROM job_postings WHERE id = $1`, id))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get job posting: %w", err)
+	}
+	return p, nil
+}
+
+func (r *JobRepository) Create(ctx context.Context, p *job.Posting) error {
+	return insertJob(ctx, r.pool, p)
+}
+
+func (r *JobRepository) Update(ctx context.Context, p *job.Posting) error {
+	return updateJob(ctx, r.pool, p)
+}
+
+func (r *JobRepository) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM job_postings WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("delete job posting: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ApplyFeed upserts the feed postings by external_url and deactivates the
+// source's postings that are no longer in the feed, all in one transaction.
+func (r *JobRepository) ApplyFeed(ctx context.Context, source string, postings []job.Posting, keepMissing bool, stats *job.FeedStats) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	urls := make([]string, 0, len(postings))
+	for i := range postings {
+		p := postings[i]
+		urls = append(urls, *p.ExternalURL)
+
+		existing, err := scanJob(tx.QueryRow(ctx,
+			`SELECT `+jobColumns+` FROM job_postings WHERE external_url = $1
+			 ORDER BY source IS NOT DISTINCT FROM $2 DESC, created_at LIMIT 1 FOR UPDATE`,
+			*p.ExternalURL, source))
+		switch {
+		case err == pgx.ErrNoRows:
+			p.ID = uuid.New()
+			p.CreatedAt = time.Now().UTC()
+			p.UpdatedAt = p.CreatedAt
+			if err := insertJob(ctx, tx, &p); err != nil {
+				return err
+			}
+			stats.Created++
+		case err != nil:
+			return fmt.Errorf("find job posting: %w", err)
+		case existing.Source == nil || *existing.Source != source:
+			stats.Skip("posting %s belongs to another source", *p.ExternalURL)
+		case existing.IsActive && existing.SameContent(p):
+			stats.Unchanged++
+		default:
+			p.ID = existing.ID
+			p.UpdatedAt = time.Now().UTC()
+			if err := updateJob(ctx, tx, &p); err != nil {
+				return err
+			}
+			stats.Updated++
+		}
+	}
+
+	if !keepMissing {
+		tag, err := tx.Exec(ctx,
+			`UPDATE job_postings SET is_active = false, updated_at = NOW()
+			 WHERE source = $1 AND is_active = true AND external_url IS NOT NULL
+			   AND NOT (external_url = ANY($2))`,
+			source, urls)
+		if err != nil {
+			return fmt.Errorf("deactivate job postings: %w", err)
+		}
+		stats.Deactivated = int(tag.RowsAffected())
+	}
+	return tx.Commit(ctx)
+}
+
+// jobExecer is satisfied by *pgxpool.Pool and pgx.Tx.
+type jobExecer interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+}
+
+func insertJob(ctx context.Context, db jobExecer, p *job.Posting) error {
+	dims, _ := json.Marshal(p.RequiredDimensions)
+	_, err := db.Exec(ctx,
+		`INSERT INTO job_postings (`+jobColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		p.ID, p.Title, p.Company, p.Description, dims, p.Location, p.Source, p.ExternalURL, p.IsActive, p.CreatedAt, p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert job posting: %w", err)
+	}
+	return nil
+}
+
+func updateJob(ctx context.Context, db jobExecer, p *job.Posting) error {
+	dims, _ := json.Marshal(p.RequiredDimensions)
+	_, err := db.Exec(ctx,
+		`UPDATE job_postings SET title = $2, company = $3, description = $4, required_dimensions = $5,
+		 location = $6, source = $7, external_url = $8, is_active = $9, updated_at = $10
+		 WHERE id = $1`,
+		p.ID, p.Title, p.Company, p.Description, dims, p.Location, p.Source, p.ExternalURL, p.IsActive, p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("update job posting: %w", err)
+	}
+	return nil
+}
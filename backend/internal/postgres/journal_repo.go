@@ -23,14 +23,18 @@ func (r *JournalRepository) Create(ctx context.Context, i *journal.Interaction)
 	timingJSON, _ := json.Marshal(i.Timing)
 	ctxJSON, _ := json.Marshal(i.Context)
 
-	_, err := r.pool.Exec(ctx,
+	tag, err := r.pool.Exec(ctx,
 		`INSERT INTO interactions (id, user_id, session_id, modality, user_input, assistant_response, timing, context, created_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		 SELECT $1, s.user_id, s.id, $4, $5, $6, $7, $8, $9
+		 FROM sessions s WHERE s.id = $3 AND s.user_id = $2`,
 		i.ID, i.UserID, i.SessionID, i.Modality, i.UserInput, i.AssistantResponse, timingJSON, ctxJSON, i.Timestamp,
 	)
 	if err != nil {
 		return fmt.Errorf("insert interaction: %w", err)
 	}
+	if tag.RowsAffected() == 0 {
+		return journal.ErrSessionNotFound
+	}
 	return nil
 }
 
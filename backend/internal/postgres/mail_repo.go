@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"skillr-mvp-v1/backend/internal/mail"
+)
+
+type MailRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewMailRepository(pool *pgxpool.Pool) *MailRepository {
+	return &MailRepository{pool: pool}
+}
+
+const mailColumns = `id, recipient, template, locale, subject, body_text, body_html, status, attempts, next_attempt_at, last_error, sent_at, created_at`
+
+func scanMails(rows pgx.Rows) ([]mail.OutboxMessage, error) {
+	var messages []mail.OutboxMessage
+	for rows.Next() {
+		var m mail.OutboxMessage
+		if err := rows.Scan(&m.ID, &m.To, &m.Template, &m.Locale, &m.Subject, &m.Text, &m.HTML, &m.Status, &m.Attempts,
+			&m.NextAttemptAt, &m.LastError, &m.SentAt, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan mail: %w", err)
+		}
+		messages = append(messages, m)
+	}
+	return messages, rows.Err()
+}
+
+func (r *MailRepository) Insert(ctx context.Context, m *mail.OutboxMessage) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO mail_outbox (`+mailColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		m.ID, m.To, m.Template, m.Locale, m.Subject, m.Text, m.HTML, m.Status, m.Attempts, m.NextAttemptAt, m.LastError, m.SentAt, m.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert mail: %w", err)
+	}
+	return nil
+}
+
+func (r *MailRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]mail.OutboxMessage, error) {
+	rows, err := r.pool.Query(ctx,
+		`UPDATE mail_outbox SET attempts = attempts + 1, next_attempt_at = $2
+		 WHERE id IN (
+		     SELECT id FROM mail_outbox
+		     WHERE status = 'pending' AND next_attempt_at <= $1
+		     ORDER BY next_attempt_at
+		     LIMIT $3
+		     FOR UPDATE SKIP LOCKED)
+		 RETURNING `+mailColumns,
+		now, now.Add(lease), limit)
+	if err != nil {
+		return nil, fmt.Errorf("claim mails: %w", err)
+	}
+	defer rows.Close()
+	return scanMails(rows)
+}
+
+func (r *MailRepository) MarkSent(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE mail_outbox SET status = 'sent', sent_at = $2, last_error = '', body_text = '', body_html = '' WHERE id = $1`, id, at)
+	if err != nil {
+		return fmt.Errorf("mark mail sent: %w", err)
+	}
+	return nil
+}
+
+func (r *MailRepository) MarkRetry(ctx context.Context, id uuid.UUID, next time.Time, lastErr string) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE mail_outbox SET next_attempt_at = $2, last_error = $3 WHERE id = $1`, id, next, lastErr)
+	if err != nil {
+		return fmt.Errorf("reschedule mail: %w", err)
+	}
+	return nil
+}
+
+func (r *MailRepository) MarkFinal(ctx context.Context, id uuid.UUID, status, lastErr string) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE mail_outbox SET status = $2, last_error = $3 WHERE id = $1`, id, status, lastErr)
+	if err != nil {
+		return fmt.Errorf("update mail status: %w", err)
+	}
+	return nil
+}
+
+func (r *MailRepository) List(ctx context.Context, status string, limit, offset int) ([]mail.OutboxMessage, int, error) {
+	where := ""
+	args := []interface{}{}
+	if status != "" {
+		where = " WHERE status = $1"
+		args = append(args, status)
+	}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM mail_outbox`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count mails: %w", err)
+	}
+
+	args = append(args, limit, offset)
+	rows, err := r.pool.Query(ctx,
+		fmt.Sprintf(`SELECT %s FROM mail_outbox%s ORDER BY created_at DESC LIMIT $%d OFFSET $%d`, mailColumns, where, len(args)-1, len(args)),
+		args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list mails: %w", err)
+	}
+	defer rows.Close()
+	messages, err := scanMails(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return messages, total, nil
+}
+
+func (r *MailRepository) Requeue(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE mail_outbox SET status = 'pending', attempts = 0, next_attempt_at = $2
+		 WHERE id = $1 AND status IN ('failed', 'bounced') AND body_text <> ''`, id, now)
+	if err != nil {
+		return false, fmt.Errorf("requeue mail: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *MailRepository) IsSuppressed(ctx context.Context, email string, softLimit int) (bool, error) {
+	var suppressed bool
+	err := r.pool.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM mail_bounces WHERE email = $1 AND (kind = 'hard' OR count >= $2))`,
+		strings.ToLower(email), softLimit).Scan(&suppressed)
+	if err != nil {
+		return false, fmt.Errorf("check bounce: %w", err)
+	}
+	return suppressed, nil
+}
+
+// RecordBounce counts a bounce; a hard bounce makes the entry hard for good.
+func (r *MailRepository) RecordBounce(ctx context.Context, email, kind, reason string, at time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO mail_bounces (email, kind, reason, count, first_at, last_at)
+		 VALUES ($1, $2, $3, 1, $4, $4)
+		 ON CONFLICT (email) DO UPDATE SET
+		     kind = CASE WHEN mail_bounces.kind = 'hard' THEN 'hard' ELSE EXCLUDED.kind END,
+		     reason = EXCLUDED.reason,
+		     count = mail_bounces.count + 1,
+		     last_at = EXCLUDED.last_at`,
+		strings.ToLower(email), kind, reason, at)
+	if err != nil {
+		return fmt.Errorf("record bounce: %w", err)
+	}
+	return nil
+}
+
+func (r *MailRepository) ListBounces(ctx context.Context, limit, offset int) ([]mail.Bounce, int, error) {
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM mail_bounces`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count bounces: %w", err)
+	}
+	rows, err := r.pool.Query(ctx,
+		`SELECT email, kind, reason, count, first_at, last_at FROM mail_bounces ORDER BY last_at DESC LIMIT $1 OFFSET $2`,
+		limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list bounces: %w", err)
+	}
+	defer rows.Close()
+
+	var bounces []mail.Bounce
+	for rows.Next() {
+		var b mail.Bounce
+		if err := rows.Scan(&b.Email, &b.Kind, &b.Reason, &b.Count, &b.FirstAt, &b.LastAt); err != nil {
+			return nil, 0, fmt.Errorf("scan bounce: %w", err)
+		}
+		bounces = append(bounces, b)
+	}
+	return bounces, total, rows.Err()
+}
+
+func (r *MailRepository) DeleteBounce(ctx context.Context, email string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM mail_bounces WHERE email = $1`, strings.ToLower(email))
+	if err != nil {
+		return false, fmt.Errorf("delete bounce: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
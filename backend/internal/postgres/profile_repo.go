@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -21,36 +23,31 @@ func NewProfileRepository(pool *pgxpool.Pool) *ProfileRepository {
 }
 
 func (r *ProfileRepository) GetLatest(ctx context.Context, userID uuid.UUID) (*profile.SkillProfile, error) {
-	p := &profile.SkillProfile{}
-	var categoriesJSON, summaryJSON []byte
-	err := r.pool.QueryRow(ctx,
-		`SELECT id, user_id, skill_categories, top_interests, top_strengths, completeness, evidence_summary, last_computed_at, created_at
-		 FROM skill_profiles WHERE user_id = $1 ORDER BY last_computed_at DESC LIMIT 1`,
+	p, err := scanProfile(r.pool.QueryRow(ctx,
+		`SELECT `+profileColumns+` FROM skill_profiles WHERE user_id = $1 ORDER BY last_computed_at DESC LIMIT 1`,
 		userID,
-	).Scan(&p.ID, &p.UserID, &categoriesJSON, &p.TopInterests, &p.TopStrengths, &p.Completeness, &summaryJSON, &p.LastComputedAt, &p.CreatedAt)
+	))
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("no profile found")
+			return nil, profile.ErrNoProfile
 		}
 		return nil, fmt.Errorf("get profile: %w", err)
 	}
-	_ = json.Unmarshal(categoriesJSON, &p.SkillCategories)
-	if summaryJSON != nil {
-		var s profile.EvidenceSummary
-		_ = json.Unmarshal(summaryJSON, &s)
-		p.EvidenceSummary = &s
-	}
 	return p, nil
 }
 
 func (r *ProfileRepository) Create(ctx context.Context, p *profile.SkillProfile) error {
 	categoriesJSON, _ := json.Marshal(p.SkillCategories)
 	summaryJSON, _ := json.Marshal(p.EvidenceSummary)
+	dimensionsJSON, _ := json.Marshal(p.DimensionScores)
+	if p.DimensionScores == nil {
+		dimensionsJSON = []byte("{}")
+	}
 
 	_, err := r.pool.Exec(ctx,
-		`INSERT INTO skill_profiles (id, user_id, skill_categories, top_interests, top_strengths, completeness, evidence_summary, last_computed_at, created_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
-		p.ID, p.UserID, categoriesJSON, p.TopInterests, p.TopStrengths, p.Completeness, summaryJSON, p.LastComputedAt, p.CreatedAt,
+		`INSERT INTO skill_profiles (id, user_id, skill_categories, top_interests, top_strengths, completeness, evidence_summary, dimension_scores, last_computed_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		p.ID, p.UserID, categoriesJSON, p.TopInterests, p.TopStrengths, p.Completeness, summaryJSON, dimensionsJSON, p.LastComputedAt, p.CreatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("insert profile: %w", err)
@@ -66,8 +63,7 @@ func (r *ProfileRepository) GetHistory(ctx context.Context, userID uuid.UUID, li
 	}
 
 	rows, err := r.pool.Query(ctx,
-		`SELECT id, user_id, skill_categories, top_interests, top_strengths, completeness, evidence_summary, last_computed_at, created_at
-		 FROM skill_profiles WHERE user_id = $1 ORDER BY last_computed_at DESC LIMIT $2 OFFSET $3`,
+		`SELECT `+profileColumns+` FROM skill_profiles WHERE user_id = $1 ORDER BY last_computed_at DESC LIMIT $2 OFFSET $3`,
 		userID, limit, offset,
 	)
 	if err != nil {
@@ -77,18 +73,11 @@ func (r *ProfileRepository) GetHistory(ctx context.Context, userID uuid.UUID, li
 
 	var profiles []profile.SkillProfile
 	for rows.Next() {
-		var p profile.SkillProfile
-		var categoriesJSON, summaryJSON []byte
-		if err := rows.Scan(&p.ID, &p.UserID, &categoriesJSON, &p.TopInterests, &p.TopStrengths, &p.Completeness, &summaryJSON, &p.LastComputedAt, &p.CreatedAt); err != nil {
+		p, err := scanProfile(rows)
+		if err != nil {
 			return nil, 0, fmt.Errorf("scan profile: %w", err)
 		}
-		_ = json.Unmarshal(categoriesJSON, &p.SkillCategories)
-		if summaryJSON != nil {
-			var s profile.EvidenceSummary
-			_ = json.Unmarshal(summaryJSON, &s)
-			p.EvidenceSummary = &s
-		}
-		profiles = append(profiles, p)
+		profiles = append(profiles, *p)
 	}
 	return profiles, total, nil
 }
@@ -102,16 +91,358 @@ func (r *ProfileRepository) GetPublic(ctx context.Context, userID uuid.UUID) (*p
 	var displayName string
 	_ = r.pool.QueryRow(ctx, `SELECT COALESCE(display_name, '') FROM users WHERE id = $1`, userID).Scan(&displayName)
 
-	var endorsementCount int
-	_ = r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM endorsements WHERE learner_id = $1 AND visible = true`, userID).Scan(&endorsementCount)
+	var endorsementCount, verifiedCount int
+	_ = r.pool.QueryRow(ctx,
+		`SELECT COUNT(*), COUNT(*) FILTER (WHERE endorser_verified) FROM endorsements WHERE learner_id = $1 AND `+endorsementPublished,
+		userID).Scan(&endorsementCount, &verifiedCount)
+
+	endorsements, err := r.publicEndorsements(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := r.publicCredentials(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 
 	return &profile.PublicProfile{
-		UserID:           p.UserID,
-		DisplayName:      displayName,
-		SkillCategories:  p.SkillCategories,
-		TopInterests:     p.TopInterests,
-		TopStrengths:     p.TopStrengths,
-		Completeness:     p.Completeness,
-		EndorsementCount: endorsementCount,
+		UserID:                   p.UserID,
+		DisplayName:              displayName,
+		SkillCategories:          p.SkillCategories,
+		TopInterests:             p.TopInterests,
+		TopStrengths:             p.TopStrengths,
+		Completeness:             p.Completeness,
+		EndorsementCount:         endorsementCount,
+		VerifiedEndorsementCount: verifiedCount,
+		VisibleEndorsements:      endorsements,
+		Credentials:              credentials,
 	}, nil
 }
+
+// publicEndorsements lists the most recent visible endorsements, verified
+// endorsers first.
+func (r *ProfileRepository) publicEndorsements(ctx context.Context, userID uuid.UUID) ([]profile.PublicEndorsement, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT endorser_name, endorser_role::text, endorser_verified, COALESCE(endorser_organization, ''), statement, created_at
+		 FROM endorsements WHERE learner_id = $1 AND `+endorsementPublished+`
+		 ORDER BY endorser_verified DESC, created_at DESC LIMIT 10`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("load public endorsements: %w", err)
+	}
+	defer rows.Close()
+
+	var out []profile.PublicEndorsement
+	for rows.Next() {
+		var e profile.PublicEndorsement
+		if err := rows.Scan(&e.EndorserName, &e.EndorserRole, &e.Verified, &e.Organization, &e.Statement, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan public endorsement: %w", err)
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// publicCredentials lists the user's active, unexpired credentials.
+func (r *ProfileRepository) publicCredentials(ctx context.Context, userID uuid.UUID) ([]profile.PublicCredential, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT id, credential_type, COALESCE(document->>'name', ''),
+		        COALESCE(document->'issuer'->>'name', ''), COALESCE(document->'issuer'->>'url', ''),
+		        issued_at, expires_at
+		 FROM credentials
+		 WHERE user_id = $1 AND status = 'active' AND (expires_at IS NULL OR expires_at > NOW())
+		 ORDER BY issued_at DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("load credentials: %w", err)
+	}
+	defer rows.Close()
+
+	var creds []profile.PublicCredential
+	for rows.Next() {
+		var c profile.PublicCredential
+		if err := rows.Scan(&c.ID, &c.Type, &c.Name, &c.IssuerName, &c.IssuerURL, &c.IssuedAt, &c.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("scan credential: %w", err)
+		}
+		creds = append(creds, c)
+	}
+	return creds, rows.Err()
+}
+
+// LoadSignals collects everything the profile engine aggregates for a user.
+func (r *ProfileRepository) LoadSignals(ctx context.Context, userID uuid.UUID) (*profile.Signals, error) {
+	s := &profile.Signals{}
+
+	err := r.eachRow(ctx, "reflections",
+		`SELECT capability_scores FROM reflections WHERE user_id = $1 AND scoring_status = 'scored'`, userID,
+		func(rows pgx.Rows) error {
+			var raw []byte
+			if err := rows.Scan(&raw); err != nil {
+				return err
+			}
+			var sig profile.ReflectionSignal
+			_ = json.Unmarshal(raw, &sig.Scores)
+			s.Reflections = append(s.Reflections, sig)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.eachRow(ctx, "evidence",
+		`SELECT skill_dimensions, confidence::float8, context FROM portfolio_entries WHERE user_id = $1 AND retracted_at IS NULL`, userID,
+		func(rows pgx.Rows) error {
+			var dimsJSON, ctxJSON []byte
+			var sig profile.EvidenceSignal
+			if err := rows.Scan(&dimsJSON, &sig.Confidence, &ctxJSON); err != nil {
+				return err
+			}
+			_ = json.Unmarshal(dimsJSON, &sig.Dimensions)
+			var evCtx struct {
+				Interests []string `json:"interests"`
+			}
+			_ = json.Unmarshal(ctxJSON, &evCtx)
+			sig.Interests = evCtx.Interests
+			s.Evidence = append(s.Evidence, sig)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.eachRow(ctx, "endorsements",
+		`SELECT skill_dimensions, endorser_verified FROM endorsements WHERE learner_id = $1 AND `+endorsementPublished, userID,
+		func(rows pgx.Rows) error {
+			var raw []byte
+			var sig profile.EndorsementSignal
+			if err := rows.Scan(&raw, &sig.Verified); err != nil {
+				return err
+			}
+			_ = json.Unmarshal(raw, &sig.Dimensions)
+			s.Endorsements = append(s.Endorsements, sig)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// Files count once the scanner reported them clean; links have no
+	// scan status.
+	err = r.eachRow(ctx, "artifacts",
+		`SELECT skill_dimensions FROM external_artifacts
+		 WHERE learner_id = $1 AND (scan_status IS NULL OR scan_status = 'clean')`, userID,
+		func(rows pgx.Rows) error {
+			var raw []byte
+			if err := rows.Scan(&raw); err != nil {
+				return err
+			}
+			var sig profile.ArtifactSignal
+			_ = json.Unmarshal(raw, &sig.Dimensions)
+			s.Artifacts = append(s.Artifacts, sig)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.eachRow(ctx, "interactions",
+		`SELECT profile_impact FROM interactions WHERE user_id = $1`, userID,
+		func(rows pgx.Rows) error {
+			var raw []byte
+			if err := rows.Scan(&raw); err != nil {
+				return err
+			}
+			var sig profile.InteractionSignal
+			_ = json.Unmarshal(raw, &sig.ProfileImpact)
+			s.Interactions = append(s.Interactions, sig)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// eachRow runs query and calls fn for every row. Query, scan and iteration
+// errors are all reported, so a signal source is never silently cut short.
+func (r *ProfileRepository) eachRow(ctx context.Context, what, query string, userID uuid.UUID, fn func(pgx.Rows) error) error {
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", what, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return fmt.Errorf("scan %s: %w", what, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("read %s: %w", what, err)
+	}
+	return nil
+}
+
+const profileColumns = `id, user_id, skill_categories, top_interests, top_strengths, completeness, evidence_summary, dimension_scores, last_computed_at, created_at`
+
+// scanProfile scans one skill_profiles row selected with profileColumns.
+func scanProfile(row pgx.Row) (*profile.SkillProfile, error) {
+	p := &profile.SkillProfile{}
+	var categoriesJSON, summaryJSON, dimensionsJSON []byte
+	if err := row.Scan(&p.ID, &p.UserID, &categoriesJSON, &p.TopInterests, &p.TopStrengths, &p.Completeness, &summaryJSON, &dimensionsJSON, &p.LastComputedAt, &p.CreatedAt); err != nil {
+		return nil, err
+	}
+	_ = json.Unmarshal(categoriesJSON, &p.SkillCategories)
+	_ = json.Unmarshal(dimensionsJSON, &p.DimensionScores)
+	if summaryJSON != nil {
+		var s profile.EvidenceSummary
+		_ = json.Unmarshal(summaryJSON, &s)
+		p.EvidenceSummary = &s
+	}
+	return p, nil
+}
+
+func (r *ProfileRepository) GetRange(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]profile.SkillProfile, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+profileColumns+` FROM skill_profiles
+		 WHERE user_id = $1 AND last_computed_at >= $2 AND last_computed_at <= $3
+		 ORDER BY last_computed_at DESC LIMIT $4`,
+		userID, from, to, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list profile range: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []profile.SkillProfile
+	for rows.Next() {
+		p, err := scanProfile(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan profile: %w", err)
+		}
+		profiles = append(profiles, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read profile range: %w", err)
+	}
+	slices.Reverse(profiles)
+	return profiles, nil
+}
+
+func (r *ProfileRepository) GetByID(ctx context.Context, userID, id uuid.UUID) (*profile.SkillProfile, error) {
+	p, err := scanProfile(r.pool.QueryRow(ctx,
+		`SELECT `+profileColumns+` FROM skill_profiles WHERE id = $1 AND user_id = $2`, id, userID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("profile snapshot not found")
+		}
+		return nil, fmt.Errorf("get profile snapshot: %w", err)
+	}
+	return p, nil
+}
+
+// ListContributions returns the evidence, scored reflections and visible
+// endorsements created in (since, until], oldest first.
+func (r *ProfileRepository) ListContributions(ctx context.Context, userID uuid.UUID, since, until time.Time) ([]profile.Contribution, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT 'evidence', id, summary, skill_dimensions, created_at
+		   FROM portfolio_entries WHERE user_id = $1 AND retracted_at IS NULL AND created_at > $2 AND created_at <= $3
+		 UNION ALL
+		 SELECT 'reflection', id, question_id, capability_scores, created_at
+		   FROM reflections WHERE user_id = $1 AND scoring_status = 'scored' AND created_at > $2 AND created_at <= $3
+		 UNION ALL
+		 SELECT 'endorsement', id, endorser_name || ': ' || LEFT(statement, 140), skill_dimensions, created_at
+		   FROM endorsements WHERE learner_id = $1 AND `+endorsementPublished+` AND created_at > $2 AND created_at <= $3
+		 ORDER BY 5`,
+		userID, since, until,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list contributions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []profile.Contribution
+	for rows.Next() {
+		var c profile.Contribution
+		var kind string
+		var dimsJSON []byte
+		if err := rows.Scan(&kind, &c.ID, &c.Summary, &dimsJSON, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan contribution: %w", err)
+		}
+		c.Type = profile.ContributionType(kind)
+		_ = json.Unmarshal(dimsJSON, &c.Dimensions)
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+// LoadExportData collects the data rendered into the PDF export: display
+// name, the most recent visible endorsements, verifiable evidence and the
+// partner brand the learner signed up through (if any).
+func (r *ProfileRepository) LoadExportData(ctx context.Context, userID uuid.UUID) (*profile.ExportData, error) {
+	data := &profile.ExportData{}
+
+	var brandSlug *string
+	var brandJSON []byte
+	err := r.pool.QueryRow(ctx,
+		`SELECT COALESCE(u.display_name, ''), u.brand_slug, b.config
+		 FROM users u LEFT JOIN brand_configs b ON b.slug = u.brand_slug AND b.is_active = true
+		 WHERE u.id = $1`, userID,
+	).Scan(&data.DisplayName, &brandSlug, &brandJSON)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("load export user: %w", err)
+	}
+	if brandSlug != nil && brandJSON != nil {
+		var cfg struct {
+			BrandName    string `json:"brandName"`
+			SponsorLabel string `json:"sponsorLabel"`
+			Theme        struct {
+				PrimaryColor string `json:"primaryColor"`
+				AccentColor  string `json:"accentColor"`
+			} `json:"theme"`
+		}
+		if json.Unmarshal(brandJSON, &cfg) == nil {
+			data.Brand = &profile.Brand{
+				Slug:         *brandSlug,
+				Name:         cfg.BrandName,
+				PrimaryColor: cfg.Theme.PrimaryColor,
+				AccentColor:  cfg.Theme.AccentColor,
+				SponsorLabel: cfg.SponsorLabel,
+			}
+		}
+	}
+
+	rows, err := r.pool.Query(ctx,
+		`SELECT endorser_name, endorser_role::text, endorser_verified, COALESCE(endorser_organization, ''), statement, created_at
+		 FROM endorsements WHERE learner_id = $1 AND `+endorsementPublished+`
+		 ORDER BY endorser_verified DESC, created_at DESC LIMIT 5`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("load export endorsements: %w", err)
+	}
+	for rows.Next() {
+		var e profile.ExportEndorsement
+		if err := rows.Scan(&e.EndorserName, &e.EndorserRole, &e.Verified, &e.Organization, &e.Statement, &e.CreatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan export endorsement: %w", err)
+		}
+		data.Endorsements = append(data.Endorsements, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("load export endorsements: %w", err)
+	}
+
+	rows, err = r.pool.Query(ctx,
+		`SELECT id, summary, verification_token, created_at
+		 FROM portfolio_entries WHERE user_id = $1 AND verification_token IS NOT NULL AND retracted_at IS NULL
+		 ORDER BY confidence DESC, created_at DESC LIMIT 8`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("load export evidence: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var e profile.ExportEvidence
+		if err := rows.Scan(&e.ID, &e.Summary, &e.VerificationToken, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan export evidence: %w", err)
+		}
+		data.Evidence = append(data.Evidence, e)
+	}
+	return data, rows.Err()
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"skillr-mvp-v1/backend/internal/domain/qr"
+)
+
+type QRRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewQRRepository(pool *pgxpool.Pool) *QRRepository {
+	return &QRRepository{pool: pool}
+}
+
+func (r *QRRepository) CreateLink(ctx context.Context, l *qr.Link) error {
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO qr_links (code_hash, owner_id, target, created_at, expires_at) VALUES ($1, $2, $3, $4, $5)`,
+		l.CodeHash, l.OwnerID, l.Target, l.CreatedAt, l.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("insert qr link: %w", err)
+	}
+	return nil
+}
+
+func (r *QRRepository) GetLink(ctx context.Context, codeHash string) (*qr.Link, error) {
+	l := &qr.Link{}
+	err := r.pool.QueryRow(ctx,
+		`SELECT code_hash, owner_id, target, created_at, expires_at FROM qr_links WHERE code_hash = $1`, codeHash,
+	).Scan(&l.CodeHash, &l.OwnerID, &l.Target, &l.CreatedAt, &l.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get qr link: %w", err)
+	}
+	return l, nil
+}
+
+func (r *QRRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM qr_links WHERE expires_at < $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired qr links: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *QRRepository) BrandLogoURL(ctx context.Context, userID uuid.UUID) (string, error) {
+	var cfg []byte
+	err := r.pool.QueryRow(ctx,
+		`SELECT b.config FROM users u JOIN brand_configs b ON b.slug = u.brand_slug AND b.is_active = true
+		 WHERE u.id = $1`, userID,
+	).Scan(&cfg)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("load brand config: %w", err)
+	}
+	var brand struct {
+		LogoURL string `json:"logoUrl"`
+	}
+	if err := json.Unmarshal(cfg, &brand); err != nil {
+		return "", fmt.Errorf("decode brand config: %w", err)
+	}
+	return brand.LogoURL, nil
+}
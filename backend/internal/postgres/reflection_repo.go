@@ -3,9 +3,12 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"skillr-mvp-v1/backend/internal/domain/reflection"
@@ -26,9 +29,9 @@ func (r *ReflectionRepository) Create(ctx context.Context, ref *reflection.Refle
 	}
 
 	_, err = r.pool.Exec(ctx,
-		`INSERT INTO reflections (id, user_id, station_id, question_id, response, response_time_ms, capability_scores, created_at)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
-		ref.ID, ref.UserID, ref.StationID, ref.QuestionID, ref.Response, ref.ResponseTimeMs, scoresJSON, ref.CreatedAt,
+		`INSERT INTO reflections (id, user_id, station_id, question_id, response, response_time_ms, capability_scores, scoring_status, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		ref.ID, ref.UserID, ref.StationID, ref.QuestionID, ref.Response, ref.ResponseTimeMs, scoresJSON, ref.ScoringStatus, ref.CreatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("insert reflection: %w", err)
@@ -37,7 +40,7 @@ func (r *ReflectionRepository) Create(ctx context.Context, ref *reflection.Refle
 }
 
 func (r *ReflectionRepository) List(ctx context.Context, params reflection.ListParams) ([]reflection.ReflectionResult, int, error) {
-	query := `SELECT id, user_id, station_id, question_id, response, response_time_ms, capability_scores, created_at FROM reflections WHERE user_id = $1`
+	query := `SELECT ` + reflectionColumns + ` FROM reflections WHERE user_id = $1`
 	countQuery := `SELECT COUNT(*) FROM reflections WHERE user_id = $1`
 	args := []interface{}{params.UserID}
 	argIdx := 2
@@ -63,18 +66,75 @@ func (r *ReflectionRepository) List(ctx context.Context, params reflection.ListP
 	}
 	defer rows.Close()
 
+	results, err := scanReflections(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+const reflectionColumns = `id, user_id, station_id, question_id, response, response_time_ms, capability_scores,
+	scoring_status, score_rationale, prompt_id, prompt_version, scored_by, scored_at, created_at, scoring_attempts`
+
+func scanReflections(rows pgx.Rows) ([]reflection.ReflectionResult, error) {
 	var results []reflection.ReflectionResult
 	for rows.Next() {
 		var ref reflection.ReflectionResult
 		var scoresJSON []byte
-		if err := rows.Scan(&ref.ID, &ref.UserID, &ref.StationID, &ref.QuestionID, &ref.Response, &ref.ResponseTimeMs, &scoresJSON, &ref.CreatedAt); err != nil {
-			return nil, 0, fmt.Errorf("scan reflection: %w", err)
+		if err := rows.Scan(&ref.ID, &ref.UserID, &ref.StationID, &ref.QuestionID, &ref.Response, &ref.ResponseTimeMs, &scoresJSON,
+			&ref.ScoringStatus, &ref.ScoreRationale, &ref.PromptID, &ref.PromptVersion, &ref.ScoredBy, &ref.ScoredAt, &ref.CreatedAt, &ref.ScoringAttempts); err != nil {
+			return nil, fmt.Errorf("scan reflection: %w", err)
 		}
 		_ = json.Unmarshal(scoresJSON, &ref.CapabilityScores)
 		results = append(results, ref)
 	}
+	return results, rows.Err()
+}
 
-	return results, total, nil
+// UpdateScores stores the outcome of an asynchronous scoring run. A nil
+// result only updates the status (used for failed runs).
+func (r *ReflectionRepository) UpdateScores(ctx context.Context, id uuid.UUID, status string, res *reflection.ScoreResult, scoredAt time.Time) error {
+	if res == nil {
+		_, err := r.pool.Exec(ctx, `UPDATE reflections SET scoring_status = $2 WHERE id = $1`, id, status)
+		if err != nil {
+			return fmt.Errorf("update reflection status: %w", err)
+		}
+		return nil
+	}
+
+	scoresJSON, err := json.Marshal(res.Scores)
+	if err != nil {
+		return fmt.Errorf("marshal capability scores: %w", err)
+	}
+	_, err = r.pool.Exec(ctx,
+		`UPDATE reflections SET capability_scores = $2, scoring_status = $3, score_rationale = $4, prompt_id = $5, prompt_version = $6,
+		     scored_by = $7, scored_at = $8
+		 WHERE id = $1`,
+		id, scoresJSON, status, res.Rationale, res.PromptID, res.PromptVersion, res.Scorer, scoredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update reflection scores: %w", err)
+	}
+	return nil
+}
+
+// ListPending returns the oldest reflections still awaiting scoring.
+func (r *ReflectionRepository) ListPending(ctx context.Context, limit int) ([]reflection.ReflectionResult, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+reflectionColumns+` FROM reflections WHERE scoring_status = 'pending' ORDER BY created_at LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list pending reflections: %w", err)
+	}
+	defer rows.Close()
+	return scanReflections(rows)
+}
+
+func (r *ReflectionRepository) RecordScoringAttempt(ctx context.Context, id uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `UPDATE reflections SET scoring_attempts = scoring_attempts + 1 WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("record scoring attempt: %w", err)
+	}
+	return nil
 }
 
 func (r *ReflectionRepository) GetAggregatedCapabilities(ctx context.Context, userID uuid.UUID) (*reflection.CapabilityScores, error) {
@@ -86,7 +146,7 @@ func (r *ReflectionRepository) GetAggregatedCapabilities(ctx context.Context, us
 			COALESCE(AVG((capability_scores->>'confidence')::numeric), 0),
 			COALESCE(AVG((capability_scores->>'resilience')::numeric), 0),
 			COALESCE(AVG((capability_scores->>'self_awareness')::numeric), 0)
-		 FROM reflections WHERE user_id = $1`,
+		 FROM reflections WHERE user_id = $1 AND scoring_status = 'scored'`,
 		userID,
 	).Scan(&scores.AnalyticalDepth, &scores.Creativity, &scores.Confidence, &scores.Resilience, &scores.SelfAwareness)
 	if err != nil {
@@ -94,3 +154,149 @@ func (r *ReflectionRepository) GetAggregatedCapabilities(ctx context.Context, us
 	}
 	return &scores, nil
 }
+
+const questionColumns = `id, locale, station_id, capability, difficulty, question, follow_up, active, created_at, updated_at`
+
+func scanQuestion(row pgx.Row) (*reflection.Question, error) {
+	var q reflection.Question
+	if err := row.Scan(&q.ID, &q.Locale, &q.StationID, &q.Capability, &q.Difficulty, &q.Text, &q.FollowUp, &q.Active, &q.CreatedAt, &q.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+func (r *ReflectionRepository) ListQuestions(ctx context.Context, filter reflection.QuestionFilter) ([]reflection.Question, error) {
+	query := `SELECT ` + questionColumns + ` FROM reflection_questions WHERE TRUE`
+	var args []interface{}
+	add := func(cond string, v interface{}) {
+		args = append(args, v)
+		query += fmt.Sprintf(" AND "+cond, len(args))
+	}
+	if filter.ID != "" {
+		add("id = $%d", filter.ID)
+	}
+	if filter.StationID != "" {
+		add("(station_id IS NULL OR station_id = $%d)", filter.StationID)
+	}
+	if filter.Capability != "" {
+		add("capability = $%d", filter.Capability)
+	}
+	if filter.Locale != "" {
+		add("locale = $%d", filter.Locale)
+	}
+	if filter.ActiveOnly {
+		query += " AND active"
+	}
+	query += " ORDER BY capability, difficulty, id, locale"
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list questions: %w", err)
+	}
+	defer rows.Close()
+
+	var questions []reflection.Question
+	for rows.Next() {
+		q, err := scanQuestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan question: %w", err)
+		}
+		questions = append(questions, *q)
+	}
+	return questions, rows.Err()
+}
+
+func (r *ReflectionRepository) GetQuestion(ctx context.Context, id, locale string) (*reflection.Question, error) {
+	q, err := scanQuestion(r.pool.QueryRow(ctx,
+		`SELECT `+questionColumns+` FROM reflection_questions WHERE id = $1 AND locale = $2`, id, locale))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get question: %w", err)
+	}
+	return q, nil
+}
+
+func (r *ReflectionRepository) CreateQuestion(ctx context.Context, q *reflection.Question) error {
+	tag, err := r.pool.Exec(ctx,
+		`INSERT INTO reflection_questions (`+questionColumns+`)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 ON CONFLICT (id, locale) DO NOTHING`,
+		q.ID, q.Locale, q.StationID, q.Capability, q.Difficulty, q.Text, q.FollowUp, q.Active, q.CreatedAt, q.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert question: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return reflection.ErrQuestionExists
+	}
+	return nil
+}
+
+func (r *ReflectionRepository) UpdateQuestion(ctx context.Context, q *reflection.Question) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE reflection_questions
+		 SET station_id = $3, capability = $4, difficulty = $5, question = $6, follow_up = $7, active = $8, updated_at = $9
+		 WHERE id = $1 AND locale = $2`,
+		q.ID, q.Locale, q.StationID, q.Capability, q.Difficulty, q.Text, q.FollowUp, q.Active, q.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update question: %w", err)
+	}
+	return nil
+}
+
+func (r *ReflectionRepository) DeactivateQuestion(ctx context.Context, id string) (bool, error) {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE reflection_questions SET active = FALSE, updated_at = NOW() WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("deactivate question: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// CountAnswered counts scored reflections per capability of the answered
+// question (translations share the capability, so one locale suffices).
+func (r *ReflectionRepository) CountAnswered(ctx context.Context, userID uuid.UUID) (map[string]int, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT q.capability, COUNT(*)
+		 FROM reflections r
+		 JOIN (SELECT DISTINCT id, capability FROM reflection_questions) q ON q.id = r.question_id
+		 WHERE r.user_id = $1 AND r.scoring_status = 'scored'
+		 GROUP BY q.capability`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("count answered questions: %w", err)
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var capability string
+		var n int
+		if err := rows.Scan(&capability, &n); err != nil {
+			return nil, fmt.Errorf("scan answered count: %w", err)
+		}
+		counts[capability] = n
+	}
+	return counts, rows.Err()
+}
+
+func (r *ReflectionRepository) RecentQuestionIDs(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT question_id FROM reflections WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("recent questions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan question id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -52,8 +52,8 @@ func (r *SessionRepository) GetDetailedByID(ctx context.Context, id uuid.UUID, u
 
 	rows, err := r.pool.Query(ctx,
 		`SELECT id, user_id, session_id, modality, user_input, assistant_response, timing, context, profile_impact, created_at
-		 FROM interactions WHERE session_id = $1 ORDER BY created_at ASC`,
-		id,
+		 FROM interactions WHERE session_id = $1 AND user_id = $2 ORDER BY created_at ASC`,
+		id, s.UserID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("query interactions: %w", err)
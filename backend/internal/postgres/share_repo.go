@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"skillr-mvp-v1/backend/internal/domain/share"
+)
+
+type ShareRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewShareRepository(pool *pgxpool.Pool) *ShareRepository {
+	return &ShareRepository{pool: pool}
+}
+
+const shareLinkColumns = `id, user_id, name, token_hash, password_hash, scope, expires_at, revoked_at, view_count, last_viewed_at, created_at`
+
+func scanShareLink(row pgx.Row) (*share.Link, error) {
+	l := &share.Link{}
+	var passwordHash *string
+	var scope []byte
+	if err := row.Scan(&l.ID, &l.UserID, &l.Name, &l.TokenHash, &passwordHash, &scope, &l.ExpiresAt, &l.RevokedAt, &l.ViewCount, &l.LastViewedAt, &l.CreatedAt); err != nil {
+		return nil, err
+	}
+	if passwordHash != nil {
+		l.PasswordHash = *passwordHash
+		l.HasPassword = true
+	}
+	_ = json.Unmarshal(scope, &l.Scope)
+	return l, nil
+}
+
+func (r *ShareRepository) Create(ctx context.Context, l *share.Link) error {
+	scope, _ := json.Marshal(l.Scope)
+	var passwordHash *string
+	if l.PasswordHash != "" {
+		passwordHash = &l.PasswordHash
+	}
+	_, err := r.pool.Exec(ctx,
+		`INSERT INTO share_links (id, user_id, name, token_hash, password_hash, scope, expires_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		l.ID, l.UserID, l.Name, l.TokenHash, passwordHash, scope, l.ExpiresAt, l.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert share link: %w", err)
+	}
+	return nil
+}
+
+func (r *ShareRepository) List(ctx context.Context, userID uuid.UUID) ([]share.Link, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT `+shareLinkColumns+` FROM share_links WHERE user_id = $1 ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list share links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []share.Link
+	for rows.Next() {
+		l, err := scanShareLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan share link: %w", err)
+		}
+		links = append(links, *l)
+	}
+	return links, rows.Err()
+}
+
+func (r *ShareRepository) GetByID(ctx context.Context, id, userID uuid.UUID) (*share.Link, error) {
+	l, err := scanShareLink(r.pool.QueryRow(ctx,
+		`SELECT `+shareLinkColumns+` FROM share_links WHERE id = $1 AND user_id = $2`, id, userID))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get share link: %w", err)
+	}
+	return l, nil
+}
+
+func (r *ShareRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*share.Link, error) {
+	l, err := scanShareLink(r.pool.QueryRow(ctx,
+		`SELECT `+shareLinkColumns+` FROM share_links WHERE token_hash = $1`, tokenHash))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get share link: %w", err)
+	}
+	return l, nil
+}
+
+func (r *ShareRepository) Revoke(ctx context.Context, id, userID uuid.UUID, at time.Time) (bool, error) {
+	tag, err := r.pool.Exec(ctx,
+		`UPDATE share_links SET revoked_at = $3 WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`,
+		id, userID, at)
+	if err != nil {
+		return false, fmt.Errorf("revoke share link: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *ShareRepository) RecordView(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE share_links SET view_count = view_count + 1, last_viewed_at = $2 WHERE id = $1`, id, at)
+	if err != nil {
+		return fmt.Errorf("record share link view: %w", err)
+	}
+	return nil
+}
+
+// shareEvidenceFilter limits evidence to the learner's ($1) current
+// entries among the IDs in parameter idsParam.
+func shareEvidenceFilter(idsParam int) string {
+	return fmt.Sprintf(`user_id = $1 AND id = ANY($%d) AND retracted_at IS NULL
+		AND NOT EXISTS (SELECT 1 FROM evidence_revocations rv WHERE rv.evidence_id = portfolio_entries.id)`, idsParam)
+}
+
+func (r *ShareRepository) CountScope(ctx context.Context, userID uuid.UUID, scope share.Scope) (*share.ScopeCount, error) {
+	c := &share.ScopeCount{}
+	err := r.pool.QueryRow(ctx,
+		`SELECT (SELECT COUNT(*) FROM endorsements WHERE learner_id = $1 AND id = ANY($2) AND `+endorsementPublished+`),
+		        (SELECT COUNT(*) FROM portfolio_entries WHERE `+shareEvidenceFilter(3)+`),
+		        (SELECT COUNT(*) FROM learner_portfolio_entries WHERE user_id = $1 AND id = ANY($4))`,
+		userID, scope.Endorsements, scope.Evidence, scope.PortfolioEntries,
+	).Scan(&c.Endorsements, &c.Evidence, &c.PortfolioEntries)
+	if err != nil {
+		return nil, fmt.Errorf("count scope: %w", err)
+	}
+	return c, nil
+}
+
+// LoadContent reads the scoped data. Items deleted or withdrawn since the
+// link was created are left out.
+func (r *ShareRepository) LoadContent(ctx context.Context, userID uuid.UUID, scope share.Scope) (*share.SharedProfile, error) {
+	p := &share.SharedProfile{
+		SkillCategories:  []share.Category{},
+		Endorsements:     []share.Endorsement{},
+		Evidence:         []share.Evidence{},
+		PortfolioEntries: []share.PortfolioEntry{},
+	}
+	_ = r.pool.QueryRow(ctx, `SELECT COALESCE(display_name, '') FROM users WHERE id = $1`, userID).Scan(&p.DisplayName)
+
+	if len(scope.Categories) > 0 {
+		var raw []byte
+		err := r.pool.QueryRow(ctx,
+			`SELECT skill_categories FROM skill_profiles WHERE user_id = $1 ORDER BY last_computed_at DESC LIMIT 1`,
+			userID).Scan(&raw)
+		if err != nil && err != pgx.ErrNoRows {
+			return nil, fmt.Errorf("load profile: %w", err)
+		}
+		var categories []share.Category
+		_ = json.Unmarshal(raw, &categories)
+		wanted := map[string]bool{}
+		for _, k := range scope.Categories {
+			wanted[k] = true
+		}
+		for _, c := range categories {
+			if wanted[c.Key] {
+				p.SkillCategories = append(p.SkillCategories, c)
+			}
+		}
+	}
+
+	if len(scope.Endorsements) > 0 {
+		rows, err := r.pool.Query(ctx,
+			`SELECT id, endorser_name, endorser_role, endorser_verified, COALESCE(endorser_organization, ''), skill_dimensions, statement, context, created_at
+			 FROM endorsements WHERE learner_id = $1 AND id = ANY($2) AND `+endorsementPublished+`
+			 ORDER BY created_at DESC`,
+			userID, scope.Endorsements)
+		if err != nil {
+			return nil, fmt.Errorf("load endorsements: %w", err)
+		}
+		for rows.Next() {
+			var e share.Endorsement
+			var dims []byte
+			if err := rows.Scan(&e.ID, &e.EndorserName, &e.EndorserRole, &e.EndorserVerified, &e.Organization, &dims, &e.Statement, &e.Context, &e.CreatedAt); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scan endorsement: %w", err)
+			}
+			_ = json.Unmarshal(dims, &e.SkillDimensions)
+			p.Endorsements = append(p.Endorsements, e)
+		}
+		rows.Close()
+	}
+
+	if len(scope.Evidence) > 0 {
+		rows, err := r.pool.Query(ctx,
+			`SELECT id, evidence_type, summary, skill_dimensions, confidence::float8, signature IS NOT NULL, created_at
+			 FROM portfolio_entries WHERE `+shareEvidenceFilter(2)+` ORDER BY created_at DESC`,
+			userID, scope.Evidence)
+		if err != nil {
+			return nil, fmt.Errorf("load evidence: %w", err)
+		}
+		for rows.Next() {
+			var e share.Evidence
+			var dims []byte
+			if err := rows.Scan(&e.ID, &e.EvidenceType, &e.Summary, &dims, &e.Confidence, &e.Signed, &e.CreatedAt); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scan evidence: %w", err)
+			}
+			_ = json.Unmarshal(dims, &e.SkillDimensions)
+			p.Evidence = append(p.Evidence, e)
+		}
+		rows.Close()
+	}
+
+	if len(scope.PortfolioEntries) > 0 {
+		rows, err := r.pool.Query(ctx,
+			`SELECT id, title, description, category, tags, created_at
+			 FROM learner_portfolio_entries WHERE user_id = $1 AND id = ANY($2) ORDER BY created_at DESC`,
+			userID, scope.PortfolioEntries)
+		if err != nil {
+			return nil, fmt.Errorf("load portfolio entries: %w", err)
+		}
+		for rows.Next() {
+			var e share.PortfolioEntry
+			if err := rows.Scan(&e.ID, &e.Title, &e.Description, &e.Category, &e.Tags, &e.CreatedAt); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("scan portfolio entry: %w", err)
+			}
+			if e.Tags == nil {
+				e.Tags = []string{}
+			}
+			p.PortfolioEntries = append(p.PortfolioEntries, e)
+		}
+		rows.Close()
+	}
+	return p, nil
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"skillr-mvp-v1/backend/internal/domain/taxonomy"
+)
+
+type TaxonomyRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewTaxonomyRepository(pool *pgxpool.Pool) *TaxonomyRepository {
+	return &TaxonomyRepository{pool: pool}
+}
+
+func scanRegistry(row pgx.Row) (*taxonomy.Registry, error) {
+	var data []byte
+	if err := row.Scan(&data); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var reg taxonomy.Registry
+	if err := json.Unmarshal(data, &reg); err != nil {
+		return nil, fmt.Errorf("decode taxonomy: %w", err)
+	}
+	return &reg, nil
+}
+
+func (r *TaxonomyRepository) Latest(ctx context.Context) (*taxonomy.Registry, error) {
+	return scanRegistry(r.pool.QueryRow(ctx,
+		`SELECT registry FROM taxonomy_versions ORDER BY version DESC LIMIT 1`))
+}
+
+func (r *TaxonomyRepository) Get(ctx context.Context, version int) (*taxonomy.Registry, error) {
+	return scanRegistry(r.pool.QueryRow(ctx,
+		`SELECT registry FROM taxonomy_versions WHERE version = $1`, version))
+}
+
+func (r *TaxonomyRepository) ListVersions(ctx context.Context) ([]taxonomy.VersionInfo, error) {
+	rows, err := r.pool.Query(ctx,
+		`SELECT version, source, jsonb_array_length(registry->'dimensions'), created_at
+		 FROM taxonomy_versions ORDER BY version DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("list taxonomy versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []taxonomy.VersionInfo
+	for rows.Next() {
+		var v taxonomy.VersionInfo
+		if err := rows.Scan(&v.Version, &v.Source, &v.Dimensions, &v.CreatedAt); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+func (r *TaxonomyRepository) Create(ctx context.Context, reg *taxonomy.Registry) error {
+	data, err := json.Marshal(reg)
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx,
+		`INSERT INTO taxonomy_versions (version, source, registry, created_at) VALUES ($1, $2, $3, $4)`,
+		reg.Version, reg.Source, data, reg.CreatedAt)
+	return err
+}
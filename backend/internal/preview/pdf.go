@@ -0,0 +1,138 @@
+package preview
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+var (
+	pdfObjRe    = regexp.MustCompile(`(?m)(?:^|[\s>])(\d+)\s+(\d+)\s+obj\b`)
+	pdfRootRe   = regexp.MustCompile(`/Root\s+(\d+)\s+\d+\s+R`)
+	pdfPagesRe  = regexp.MustCompile(`/Pages\s+(\d+)\s+\d+\s+R`)
+	pdfCountRe  = regexp.MustCompile(`/Count\s+(\d+)`)
+	pdfObjStmRe = regexp.MustCompile(`/Type\s*/ObjStm\b`)
+	pdfNRe      = regexp.MustCompile(`/N\s+(\d+)`)
+	pdfFirstRe  = regexp.MustCompile(`/First\s+(\d+)`)
+)
+
+// pdfPageCount returns the /Count of the page tree root: trailer /Root →
+// catalog /Pages → /Count. Objects may be stored plainly or in compressed
+// object streams (PDF 1.5+).
+func pdfPageCount(r io.ReaderAt, size int64) (int, error) {
+	data := make([]byte, size)
+	if _, err := r.ReadAt(data, 0); err != nil && err != io.EOF {
+		return 0, fmt.Errorf("read pdf: %w", err)
+	}
+	objs := pdfObjects(data)
+
+	roots := pdfRootRe.FindAllSubmatch(data, -1)
+	if len(roots) == 0 {
+		return 0, fmt.Errorf("pdf has no /Root")
+	}
+	// Incremental updates append newer trailers; the last one wins.
+	catalog := objs[atoi(roots[len(roots)-1][1])]
+	m := pdfPagesRe.FindSubmatch(catalog)
+	if m == nil {
+		return 0, fmt.Errorf("pdf catalog has no /Pages")
+	}
+	m = pdfCountRe.FindSubmatch(objs[atoi(m[1])])
+	if m == nil {
+		return 0, fmt.Errorf("pdf page tree has no /Count")
+	}
+	return atoi(m[1]), nil
+}
+
+// pdfObjects maps object numbers to their text. Later definitions replace
+// earlier ones, as with incremental updates.
+func pdfObjects(data []byte) map[int][]byte {
+	objs := map[int][]byte{}
+	var streams [][]byte
+	locs := pdfObjRe.FindAllSubmatchIndex(data, -1)
+	for i, loc := range locs {
+		end := len(data)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		body := data[loc[1]:end]
+		// Stream data may contain anything; look for endobj after it.
+		from := max(bytes.Index(body, []byte("endstream")), 0)
+		if j := bytes.Index(body[from:], []byte("endobj")); j >= 0 {
+			body = body[:from+j]
+		}
+		objs[atoi(data[loc[2]:loc[3]])] = body
+		if pdfObjStmRe.Match(body) {
+			streams = append(streams, body)
+		}
+	}
+	for _, body := range streams {
+		addObjectStream(objs, body)
+	}
+	return objs
+}
+
+// addObjectStream adds the objects of a FlateDecode object stream: a
+// header of "number offset" pairs followed by the objects from /First on.
+func addObjectStream(objs map[int][]byte, body []byte) {
+	n, first := pdfNRe.FindSubmatch(body), pdfFirstRe.FindSubmatch(body)
+	if n == nil || first == nil || !bytes.Contains(body, []byte("/FlateDecode")) {
+		return
+	}
+	raw := streamData(body)
+	if raw == nil {
+		return
+	}
+	content, err := io.ReadAll(io.LimitReader(zlibReader(raw), 64<<20))
+	if err != nil && len(content) == 0 {
+		return
+	}
+	firstOff := atoi(first[1])
+	if firstOff > len(content) {
+		return
+	}
+	header := bytes.Fields(content[:firstOff])
+	count := min(atoi(n[1]), len(header)/2)
+	for i := 0; i < count; i++ {
+		num, off := atoi(header[2*i]), firstOff+atoi(header[2*i+1])
+		end := len(content)
+		if i+1 < count {
+			end = firstOff + atoi(header[2*i+3])
+		}
+		if off <= end && end <= len(content) {
+			if _, ok := objs[num]; !ok {
+				objs[num] = content[off:end]
+			}
+		}
+	}
+}
+
+// streamData returns the bytes between "stream" and "endstream".
+func streamData(body []byte) []byte {
+	i := bytes.Index(body, []byte("stream"))
+	if i < 0 {
+		return nil
+	}
+	data := body[i+len("stream"):]
+	data = bytes.TrimPrefix(data, []byte("\r"))
+	data = bytes.TrimPrefix(data, []byte("\n"))
+	if j := bytes.LastIndex(data, []byte("endstream")); j >= 0 {
+		data = data[:j]
+	}
+	return data
+}
+
+func zlibReader(b []byte) io.Reader {
+	zr, err := zlib.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return bytes.NewReader(nil)
+	}
+	return zr
+}
+
+func atoi(b []byte) int {
+	n, _ := strconv.Atoi(string(b))
+	return n
+}
@@ -0,0 +1,222 @@
+// Package preview extracts basic metadata from uploaded files and makes
+// small JPEG thumbnails. Images are decoded with the standard library and
+// WebP, PDF, MP4 and WebM metadata is read from the file structure; only
+// rendering the first page of a PDF needs an external tool (pdftoppm from
+// poppler-utils), and is skipped without it.
+package preview
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register decoders for image.Decode
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// ThumbnailSize is the longest side of a thumbnail in pixels.
+const ThumbnailSize = 480
+
+// MaxPixels bounds the images that are decoded for a thumbnail; larger
+// images only get their dimensions recorded.
+const MaxPixels = 50_000_000
+
+// ErrTooManyPixels is returned by Thumbnail for images above MaxPixels.
+var ErrTooManyPixels = errors.New("image is too large to decode")
+
+// Info is the metadata of a file; fields that do not apply are zero.
+type Info struct {
+	Width    int
+	Height   int
+	Pages    int
+	Duration time.Duration
+}
+
+// Result is the metadata and, if one could be made, a JPEG thumbnail.
+type Result struct {
+	Info
+	Thumbnail []byte
+}
+
+// Generator makes previews. PDFRenderer is the path of pdftoppm; PDFs get
+// no thumbnail when it is empty.
+type Generator struct {
+	PDFRenderer string
+}
+
+// NewGenerator creates a generator that renders PDFs if pdftoppm is on the
+// PATH.
+func NewGenerator() *Generator {
+	path, _ := exec.LookPath("pdftoppm")
+	return &Generator{PDFRenderer: path}
+}
+
+// Generate reads the file at path with the given (sniffed) content type.
+// Types without a preview return an empty result.
+func (g *Generator) Generate(ctx context.Context, path, contentType string) (*Result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{}
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		cfg, _, err := image.DecodeConfig(f)
+		if err != nil {
+			return nil, fmt.Errorf("read image: %w", err)
+		}
+		res.Width, res.Height = cfg.Width, cfg.Height
+		if cfg.Width*cfg.Height > MaxPixels {
+			return res, nil
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		img, _, err := image.Decode(f)
+		if err != nil {
+			return nil, fmt.Errorf("decode image: %w", err)
+		}
+		res.Thumbnail, err = encodeThumbnail(img)
+		if err != nil {
+			return nil, err
+		}
+	case "image/webp":
+		res.Width, res.Height, err = webpSize(f)
+		if err != nil {
+			return nil, err
+		}
+	case "application/pdf":
+		res.Pages, err = pdfPageCount(f, st.Size())
+		if err != nil {
+			return nil, err
+		}
+		if g.PDFRenderer != "" {
+			res.Thumbnail, err = g.renderPDF(ctx, path)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case "video/mp4":
+		res.Info, err = mp4Info(f, st.Size())
+		if err != nil {
+			return nil, err
+		}
+	case "video/webm":
+		res.Info, err = webmInfo(f, st.Size())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// renderPDF renders the first page with pdftoppm.
+func (g *Generator) renderPDF(ctx context.Context, path string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "preview-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "page")
+	cmd := exec.CommandContext(ctx, g.PDFRenderer,
+		"-f", "1", "-l", "1", "-singlefile", "-jpeg", "-scale-to", strconv.Itoa(ThumbnailSize), path, out)
+	if msg, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("render pdf: %v: %s", err, bytes.TrimSpace(msg))
+	}
+	return os.ReadFile(out + ".jpg")
+}
+
+// encodeThumbnail scales img to fit ThumbnailSize and encodes it as JPEG.
+func encodeThumbnail(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, Thumbnail(img, ThumbnailSize), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Thumbnail scales img down to fit a size×size box (never up), averaging
+// the source pixels that fall into each target pixel. Transparent areas
+// become white.
+func Thumbnail(img image.Image, size int) *image.RGBA {
+	b := img.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dw, dh := sw, sh
+	if sw > size || sh > size {
+		if sw >= sh {
+			dw, dh = size, max(1, sh*size/sw)
+		} else {
+			dw, dh = max(1, sw*size/sh), size
+		}
+	}
+
+	type sum struct{ r, g, b, n uint64 }
+	sums := make([]sum, dw*dh)
+	for sy := 0; sy < sh; sy++ {
+		row := sy * dh / sh * dw
+		for sx := 0; sx < sw; sx++ {
+			r, g, bl, a := img.At(b.Min.X+sx, b.Min.Y+sy).RGBA()
+			// Colors are premultiplied; adding the missing coverage
+			// composites them over white.
+			s := &sums[row+sx*dw/sw]
+			s.r += uint64(r + 0xffff - a)
+			s.g += uint64(g + 0xffff - a)
+			s.b += uint64(bl + 0xffff - a)
+			s.n++
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for i, s := range sums {
+		if s.n == 0 {
+			continue
+		}
+		dst.SetRGBA(i%dw, i/dw, color.RGBA{
+			R: uint8(s.r / s.n >> 8),
+			G: uint8(s.g / s.n >> 8),
+			B: uint8(s.b / s.n >> 8),
+			A: 0xff,
+		})
+	}
+	return dst
+}
+
+// webpSize reads the canvas size from a WebP header (VP8, VP8L or VP8X).
+func webpSize(r io.Reader) (int, int, error) {
+	var h [30]byte
+	if _, err := io.ReadFull(r, h[:]); err != nil {
+		return 0, 0, fmt.Errorf("read webp: %w", err)
+	}
+	if string(h[0:4]) != "RIFF" || string(h[8:12]) != "WEBP" {
+		return 0, 0, fmt.Errorf("not a webp file")
+	}
+	switch string(h[12:16]) {
+	case "VP8 ":
+		return int(le16(h[26:]) & 0x3fff), int(le16(h[28:]) & 0x3fff), nil
+	case "VP8L":
+		bits := uint32(h[21]) | uint32(h[22])<<8 | uint32(h[23])<<16 | uint32(h[24])<<24
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1, nil
+	case "VP8X":
+		return le24(h[24:]) + 1, le24(h[27:]) + 1, nil
+	}
+	return 0, 0, fmt.Errorf("unknown webp chunk %q", h[12:16])
+}
+
+func le16(b []byte) uint16 { return uint16(b[0]) | uint16(b[1])<<8 }
+
+func le24(b []byte) int { return int(b[0]) | int(b[1])<<8 | int(b[2])<<16 }
@@ -0,0 +1,224 @@
+package preview
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+func writeTemp(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGenerate_ImageThumbnail(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 1200, 800))
+	for y := 0; y < 800; y++ {
+		for x := 0; x < 1200; x++ {
+			img.Set(x, y, color.NRGBA{R: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := (&Generator{}).Generate(context.Background(), writeTemp(t, "a.png", buf.Bytes()), "image/png")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if res.Width != 1200 || res.Height != 800 {
+		t.Errorf("size = %dx%d", res.Width, res.Height)
+	}
+	thumb, err := jpeg.Decode(bytes.NewReader(res.Thumbnail))
+	if err != nil {
+		t.Fatalf("thumbnail is not a JPEG: %v", err)
+	}
+	if b := thumb.Bounds(); b.Dx() != 480 || b.Dy() != 320 {
+		t.Errorf("thumbnail = %v, want 480x320", b)
+	}
+	if r, g, _, _ := thumb.At(240, 160).RGBA(); r>>8 < 180 || g>>8 > 30 {
+		t.Errorf("thumbnail color = %v", thumb.At(240, 160))
+	}
+}
+
+func TestThumbnail_TransparentBecomesWhiteAndSmallStaysSmall(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 50))
+	th := Thumbnail(img, ThumbnailSize)
+	if b := th.Bounds(); b.Dx() != 100 || b.Dy() != 50 {
+		t.Errorf("small image scaled to %v", b)
+	}
+	if c := th.RGBAAt(10, 10); c != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("transparent pixel = %v, want white", c)
+	}
+	if b := Thumbnail(image.NewGray(image.Rect(0, 0, 300, 3000)), 480).Bounds(); b.Dx() != 48 || b.Dy() != 480 {
+		t.Errorf("portrait thumbnail = %v", b)
+	}
+}
+
+func TestWebPSize(t *testing.T) {
+	vp8x := make([]byte, 30)
+	copy(vp8x, "RIFF\x00\x00\x00\x00WEBPVP8X")
+	vp8x[24], vp8x[25] = 0x7f, 0x07 // 1920-1
+	vp8x[27], vp8x[28] = 0x37, 0x04 // 1080-1
+
+	vp8l := make([]byte, 30)
+	copy(vp8l, "RIFF\x00\x00\x00\x00WEBPVP8L")
+	binary.LittleEndian.PutUint32(vp8l[21:], (640-1)|(480-1)<<14)
+
+	for name, data := range map[string][]byte{"1920x1080": vp8x, "640x480": vp8l} {
+		w, h, err := webpSize(bytes.NewReader(data))
+		if err != nil || fmt.Sprintf("%dx%d", w, h) != name {
+			t.Errorf("webpSize = %dx%d, %v, want %s", w, h, err, name)
+		}
+	}
+}
+
+func TestPDFPageCount(t *testing.T) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetFont("Helvetica", "", 12)
+	for i := 0; i < 3; i++ {
+		pdf.AddPage()
+		pdf.Cell(40, 10, fmt.Sprintf("Seite %d", i+1))
+	}
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := (&Generator{}).Generate(context.Background(), writeTemp(t, "a.pdf", buf.Bytes()), "application/pdf")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if res.Pages != 3 || res.Thumbnail != nil {
+		t.Errorf("pages = %d, thumbnail %d bytes", res.Pages, len(res.Thumbnail))
+	}
+}
+
+func TestPDFPageCount_ObjectStream(t *testing.T) {
+	objects := "<< /Type /Catalog /Pages 2 0 R >> << /Type /Pages /Kids [] /Count 7 >>"
+	header := "1 0 2 33 "
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	zw.Write([]byte(header + objects))
+	zw.Close()
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.5\n")
+	fmt.Fprintf(&pdf, "5 0 obj\n<< /Type /ObjStm /N 2 /First %d /Filter /FlateDecode /Length %d >>\nstream\n", len(header), z.Len())
+	pdf.Write(z.Bytes())
+	pdf.WriteString("\nendstream\nendobj\n")
+	pdf.WriteString("6 0 obj\n<< /Type /XRef /Root 1 0 R /Size 7 >>\nstream\n\nendstream\nendobj\n%%EOF\n")
+
+	n, err := pdfPageCount(bytes.NewReader(pdf.Bytes()), int64(pdf.Len()))
+	if err != nil || n != 7 {
+		t.Errorf("pdfPageCount = %d, %v, want 7", n, err)
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	g := NewGenerator()
+	if g.PDFRenderer == "" {
+		t.Skip("pdftoppm not installed")
+	}
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatal(err)
+	}
+	res, err := g.Generate(context.Background(), writeTemp(t, "a.pdf", buf.Bytes()), "application/pdf")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(res.Thumbnail))
+	if err != nil || max(cfg.Width, cfg.Height) != ThumbnailSize {
+		t.Errorf("page preview = %+v, %v", cfg, err)
+	}
+}
+
+func box(typ string, content ...[]byte) []byte {
+	body := bytes.Join(content, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	return append(append(b, typ...), body...)
+}
+
+func TestMP4Info(t *testing.T) {
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:], 1000)  // timescale
+	binary.BigEndian.PutUint32(mvhd[16:], 42500) // duration
+	audio := make([]byte, 84)
+	video := make([]byte, 84)
+	binary.BigEndian.PutUint32(video[76:], 1280<<16)
+	binary.BigEndian.PutUint32(video[80:], 720<<16)
+
+	file := bytes.Join([][]byte{
+		box("ftyp", []byte("isom")),
+		box("mdat", make([]byte, 1000)),
+		box("moov", box("mvhd", mvhd), box("trak", box("tkhd", audio)), box("trak", box("tkhd", video))),
+	}, nil)
+
+	res, err := (&Generator{}).Generate(context.Background(), writeTemp(t, "a.mp4", file), "video/mp4")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if res.Width != 1280 || res.Height != 720 || res.Duration != 42500*time.Millisecond {
+		t.Errorf("info = %+v", res.Info)
+	}
+}
+
+// ebml encodes an element with a one-byte size (enough for the test).
+func ebml(id uint32, content ...[]byte) []byte {
+	body := bytes.Join(content, nil)
+	var b []byte
+	for shift := 24; shift >= 0; shift -= 8 {
+		if id>>shift != 0 || shift == 0 {
+			b = append(b, byte(id>>shift))
+		}
+	}
+	return append(append(b, 0x80|byte(len(body))), body...)
+}
+
+func TestWebMInfo(t *testing.T) {
+	duration := binary.BigEndian.AppendUint64(nil, math.Float64bits(12345))
+	file := bytes.Join([][]byte{
+		ebml(0x1A45DFA3, ebml(0x4282, []byte("webm"))),
+		// Segment with unknown size, as written by live encoders.
+		{0x18, 0x53, 0x80, 0x67, 0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		ebml(ebmlInfo, ebml(ebmlTimecodeScale, []byte{0x0f, 0x42, 0x40}), ebml(ebmlDuration, duration)),
+		ebml(ebmlTracks, ebml(ebmlTrackEntry, ebml(ebmlVideo, ebml(ebmlPixelWidth, []byte{0x02, 0x80}), ebml(ebmlPixelHeight, []byte{0x01, 0x68})))),
+		ebml(ebmlCluster, []byte{0, 0, 0}),
+	}, nil)
+
+	res, err := (&Generator{}).Generate(context.Background(), writeTemp(t, "a.webm", file), "video/webm")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if res.Width != 640 || res.Height != 360 || res.Duration != 12345*time.Millisecond {
+		t.Errorf("info = %+v", res.Info)
+	}
+}
+
+func TestGenerate_OtherTypesHaveNoPreview(t *testing.T) {
+	res, err := (&Generator{}).Generate(context.Background(), writeTemp(t, "a.txt", []byte("hallo")), "text/plain")
+	if err != nil || res.Thumbnail != nil || res.Info != (Info{}) {
+		t.Errorf("text = %+v, %v", res, err)
+	}
+}
@@ -0,0 +1,232 @@
+package preview
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// mp4Info reads the duration (mvhd) and the size of the first visual track
+// (tkhd) from an MP4 file. The moov box may be anywhere in the file.
+func mp4Info(r io.ReaderAt, size int64) (Info, error) {
+	var info Info
+	moov, moovSize, err := findBox(r, 0, size, "moov")
+	if err != nil {
+		return info, err
+	}
+	if mvhd, n, err := findBox(r, moov, moov+moovSize, "mvhd"); err == nil {
+		buf := make([]byte, min(n, 32))
+		if _, err := r.ReadAt(buf, mvhd); err != nil {
+			return info, fmt.Errorf("read mvhd: %w", err)
+		}
+		var timescale uint32
+		var duration uint64
+		switch {
+		case buf[0] == 0 && len(buf) >= 20:
+			timescale, duration = binary.BigEndian.Uint32(buf[12:]), uint64(binary.BigEndian.Uint32(buf[16:]))
+		case buf[0] == 1 && len(buf) >= 32:
+			timescale, duration = binary.BigEndian.Uint32(buf[20:]), binary.BigEndian.Uint64(buf[24:])
+		}
+		if timescale > 0 {
+			info.Duration = time.Duration(float64(duration) / float64(timescale) * float64(time.Second))
+		}
+	}
+
+	// Audio tracks have a zero size; use the first track that has one.
+	for off := moov; off < moov+moovSize; {
+		trak, n, err := findBox(r, off, moov+moovSize, "trak")
+		if err != nil {
+			break
+		}
+		off = trak + n
+		tkhd, tn, err := findBox(r, trak, trak+n, "tkhd")
+		if err != nil || tn < 84 {
+			continue
+		}
+		buf := make([]byte, min(tn, 96))
+		if _, err := r.ReadAt(buf, tkhd); err != nil {
+			return info, fmt.Errorf("read tkhd: %w", err)
+		}
+		dims := buf[76:84]
+		if buf[0] == 1 && len(buf) >= 96 {
+			dims = buf[88:96]
+		}
+		if w, h := binary.BigEndian.Uint32(dims)>>16, binary.BigEndian.Uint32(dims[4:])>>16; w > 0 && h > 0 {
+			info.Width, info.Height = int(w), int(h)
+			break
+		}
+	}
+	return info, nil
+}
+
+// findBox returns the content offset and size of the first box of type
+// typ between start and end.
+func findBox(r io.ReaderAt, start, end int64, typ string) (int64, int64, error) {
+	var h [16]byte
+	for off := start; off+8 <= end; {
+		if _, err := r.ReadAt(h[:8], off); err != nil {
+			return 0, 0, fmt.Errorf("read box: %w", err)
+		}
+		size, header := int64(binary.BigEndian.Uint32(h[:4])), int64(8)
+		switch size {
+		case 0:
+			size = end - off
+		case 1:
+			if _, err := r.ReadAt(h[8:16], off+8); err != nil {
+				return 0, 0, fmt.Errorf("read box: %w", err)
+			}
+			size, header = int64(binary.BigEndian.Uint64(h[8:16])), 16
+		}
+		if size < header || off+size > end {
+			return 0, 0, fmt.Errorf("invalid %q box", h[4:8])
+		}
+		if string(h[4:8]) == typ {
+			return off + header, size - header, nil
+		}
+		off += size
+	}
+	return 0, 0, fmt.Errorf("no %s box", typ)
+}
+
+// Matroska/WebM element IDs.
+const (
+	ebmlSegment       = 0x18538067
+	ebmlInfo          = 0x1549A966
+	ebmlTimecodeScale = 0x2AD7B1
+	ebmlDuration      = 0x4489
+	ebmlTracks        = 0x1654AE6B
+	ebmlTrackEntry    = 0xAE
+	ebmlVideo         = 0xE0
+	ebmlPixelWidth    = 0xB0
+	ebmlPixelHeight   = 0xBA
+	ebmlCluster       = 0x1F43B675
+)
+
+// webmInfo reads the duration (Segment/Info) and the size of the first
+// video track (Segment/Tracks) from a WebM file.
+func webmInfo(r io.ReaderAt, size int64) (Info, error) {
+	w := &webmReader{r: r, timecodeScale: 1_000_000}
+	if err := w.walk(0, size); err != nil {
+		return Info{}, err
+	}
+	info := Info{Width: w.width, Height: w.height}
+	if w.duration > 0 {
+		info.Duration = time.Duration(w.duration * float64(w.timecodeScale))
+	}
+	return info, nil
+}
+
+type webmReader struct {
+	r             io.ReaderAt
+	timecodeScale uint64
+	duration      float64
+	width, height int
+}
+
+// walk visits the elements between start and end, descending into the
+// elements that contain what webmInfo needs. It stops at the first
+// cluster: Info and Tracks come before the media data.
+func (w *webmReader) walk(start, end int64) error {
+	for off := start; off < end; {
+		id, idLen, err := w.vint(off, true)
+		if err != nil {
+			return err
+		}
+		size, sizeLen, err := w.vint(off+int64(idLen), false)
+		if err != nil {
+			return err
+		}
+		data := off + int64(idLen+sizeLen)
+		if size < 0 || data+size > end {
+			size = end - data // unknown size: up to the end of the parent
+		}
+		switch id {
+		case ebmlCluster:
+			return nil
+		case ebmlSegment, ebmlInfo, ebmlTracks, ebmlVideo:
+			if err := w.walk(data, data+size); err != nil {
+				return err
+			}
+		case ebmlTrackEntry:
+			if w.width == 0 {
+				if err := w.walk(data, data+size); err != nil {
+					return err
+				}
+			}
+		case ebmlTimecodeScale:
+			if v := w.uint(data, size); v > 0 {
+				w.timecodeScale = v
+			}
+		case ebmlDuration:
+			w.duration = w.float(data, size)
+		case ebmlPixelWidth:
+			w.width = int(w.uint(data, size))
+		case ebmlPixelHeight:
+			w.height = int(w.uint(data, size))
+		}
+		off = data + size
+	}
+	return nil
+}
+
+// vint reads a variable-length integer. IDs keep their length marker;
+// sizes with all value bits set (unknown size) are returned as -1.
+func (w *webmReader) vint(off int64, keepMarker bool) (int64, int, error) {
+	var first [1]byte
+	if _, err := w.r.ReadAt(first[:], off); err != nil {
+		return 0, 0, fmt.Errorf("read webm element: %w", err)
+	}
+	n := 1
+	for mask := byte(0x80); n <= 8 && first[0]&mask == 0; mask >>= 1 {
+		n++
+	}
+	if n > 8 {
+		return 0, 0, fmt.Errorf("invalid webm element at %d", off)
+	}
+	buf := make([]byte, n)
+	if _, err := w.r.ReadAt(buf, off); err != nil {
+		return 0, 0, fmt.Errorf("read webm element: %w", err)
+	}
+	if !keepMarker {
+		buf[0] &= 0xff >> n
+	}
+	var v uint64
+	allOnes := buf[0] == 0xff>>n
+	for i, b := range buf {
+		v = v<<8 | uint64(b)
+		if i > 0 && b != 0xff {
+			allOnes = false
+		}
+	}
+	if !keepMarker && allOnes {
+		return -1, n, nil
+	}
+	return int64(v), n, nil
+}
+
+func (w *webmReader) uint(off, size int64) uint64 {
+	if size < 1 || size > 8 {
+		return 0
+	}
+	buf := make([]byte, size)
+	if _, err := w.r.ReadAt(buf, off); err != nil {
+		return 0
+	}
+	var v uint64
+	for _, b := range buf {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+func (w *webmReader) float(off, size int64) float64 {
+	switch size {
+	case 4:
+		return float64(math.Float32frombits(uint32(w.uint(off, size))))
+	case 8:
+		return math.Float64frombits(w.uint(off, size))
+	}
+	return 0
+}
@@ -0,0 +1,326 @@
+// Package resume renders public learner profiles as machine-readable
+// documents: schema.org Person JSON-LD and JSON Resume (jsonresume.org).
+// The public profile, portfolio page and share link endpoints select the
+// format by content negotiation.
+package resume
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Response formats.
+const (
+	FormatJSON       = "json"
+	FormatHTML       = "html"
+	FormatJSONLD     = "jsonld"
+	FormatJSONResume = "jsonresume"
+)
+
+// Media types of the machine-readable formats. JSON Resume has no
+// registered media type; the vendor type follows the project's name.
+const (
+	MediaJSONLD     = "application/ld+json"
+	MediaJSONResume = "application/vnd.jsonresume+json"
+)
+
+const (
+	schemaContext     = "https://schema.org"
+	jsonResumeSchema  = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+	jsonResumeVersion = "v1.0.0"
+)
+
+// Person is the public data of a learner, collected by each endpoint from
+// its own model.
+type Person struct {
+	ID   uuid.UUID
+	Name string
+	// URL is the canonical public URL of the document, if it has one.
+	URL         string
+	Skills      []Skill
+	Strengths   []string
+	Interests   []string
+	Credentials []Credential
+	Works       []Work
+	UpdatedAt   *time.Time
+}
+
+// Skill is an assessed skill category or dimension; Score is 0-100.
+type Skill struct {
+	Key      string
+	Label    string
+	Score    float64
+	Keywords []string
+}
+
+// Credential is an issued, verifiable credential (Open Badges 3.0).
+type Credential struct {
+	ID         uuid.UUID
+	Name       string
+	Type       string
+	IssuerName string
+	IssuerURL  string
+	IssuedAt   time.Time
+	ExpiresAt  *time.Time
+	VerifyURL  string
+}
+
+// Work is a portfolio entry or evidence item.
+type Work struct {
+	ID          uuid.UUID
+	Title       string
+	Description string
+	Category    string
+	Tags        []string
+	CreatedAt   time.Time
+}
+
+// Negotiate picks the response format. An explicit ?format= wins; otherwise
+// the Accept media type with the highest quality is used. html is only
+// chosen when the endpoint supports it.
+func Negotiate(r *http.Request, html bool) string {
+	switch strings.ToLower(r.URL.Query().Get("format")) {
+	case "jsonld", "json-ld":
+		return FormatJSONLD
+	case "jsonresume", "json-resume":
+		return FormatJSONResume
+	case "html":
+		if html {
+			return FormatHTML
+		}
+	case "json":
+		return FormatJSON
+	}
+
+	best, bestQ := FormatJSON, 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		var format string
+		switch mediaType {
+		case MediaJSONLD:
+			format = FormatJSONLD
+		case MediaJSONResume:
+			format = FormatJSONResume
+		case "text/html":
+			if html {
+				format = FormatHTML
+			}
+		case "application/json":
+			format = FormatJSON
+		}
+		if format != "" && q > bestQ {
+			best, bestQ = format, q
+		}
+	}
+	return best
+}
+
+// ContentType is the response content type of a machine-readable format.
+func ContentType(format string) string {
+	if format == FormatJSONResume {
+		return MediaJSONResume + "; charset=utf-8"
+	}
+	return MediaJSONLD + "; charset=utf-8"
+}
+
+// JSONLD renders p as a schema.org Person. Skills are DefinedTerms with the
+// score as description, credentials EducationalOccupationalCredentials
+// linking to their public verification, and works CreativeWorks attached
+// through their creator property.
+func JSONLD(p Person) map[string]interface{} {
+	doc := map[string]interface{}{
+		"@context":   schemaContext,
+		"@type":      "Person",
+		"identifier": "urn:uuid:" + p.ID.String(),
+		"name":       p.Name,
+	}
+	if p.URL != "" {
+		doc["@id"] = p.URL
+		doc["url"] = p.URL
+	}
+
+	if len(p.Skills) > 0 {
+		skills := make([]map[string]interface{}, 0, len(p.Skills))
+		for _, s := range p.Skills {
+			term := map[string]interface{}{
+				"@type":       "DefinedTerm",
+				"termCode":    s.Key,
+				"name":        s.Label,
+				"description": fmt.Sprintf("%s/100", formatScore(s.Score)),
+			}
+			if len(s.Keywords) > 0 {
+				term["alternateName"] = s.Keywords
+			}
+			skills = append(skills, term)
+		}
+		doc["skills"] = skills
+	}
+	if topics := append(append([]string{}, p.Strengths...), p.Interests...); len(topics) > 0 {
+		doc["knowsAbout"] = unique(topics)
+	}
+
+	if len(p.Credentials) > 0 {
+		creds := make([]map[string]interface{}, 0, len(p.Credentials))
+		for _, c := range p.Credentials {
+			cred := map[string]interface{}{
+				"@type":              "EducationalOccupationalCredential",
+				"identifier":         "urn:uuid:" + c.ID.String(),
+				"name":               c.Name,
+				"credentialCategory": c.Type,
+				"dateCreated":        c.IssuedAt.UTC().Format(time.RFC3339),
+			}
+			if c.VerifyURL != "" {
+				cred["url"] = c.VerifyURL
+			}
+			if c.ExpiresAt != nil {
+				cred["expires"] = c.ExpiresAt.UTC().Format(time.RFC3339)
+			}
+			if c.IssuerName != "" {
+				issuer := map[string]interface{}{"@type": "Organization", "name": c.IssuerName}
+				if c.IssuerURL != "" {
+					issuer["url"] = c.IssuerURL
+				}
+				cred["recognizedBy"] = issuer
+			}
+			creds = append(creds, cred)
+		}
+		doc["hasCredential"] = creds
+	}
+
+	if len(p.Works) > 0 {
+		works := make([]map[string]interface{}, 0, len(p.Works))
+		for _, w := range p.Works {
+			work := map[string]interface{}{
+				"@type":       "CreativeWork",
+				"identifier":  "urn:uuid:" + w.ID.String(),
+				"name":        w.Title,
+				"dateCreated": w.CreatedAt.UTC().Format(time.RFC3339),
+			}
+			if w.Description != "" {
+				work["description"] = w.Description
+			}
+			if w.Category != "" {
+				work["genre"] = w.Category
+			}
+			if len(w.Tags) > 0 {
+				work["keywords"] = strings.Join(w.Tags, ", ")
+			}
+			works = append(works, work)
+		}
+		doc["@reverse"] = map[string]interface{}{"creator": works}
+	}
+	return doc
+}
+
+// JSONResume renders p as a JSON Resume document: skills with their score
+// as level, strengths and interests as interests, credentials as
+// certificates and works as projects.
+func JSONResume(p Person) map[string]interface{} {
+	basics := map[string]interface{}{"name": p.Name}
+	if p.URL != "" {
+		basics["url"] = p.URL
+	}
+
+	skills := []map[string]interface{}{}
+	for _, s := range p.Skills {
+		skill := map[string]interface{}{"name": s.Label, "level": formatScore(s.Score) + "/100"}
+		if len(s.Keywords) > 0 {
+			skill["keywords"] = s.Keywords
+		}
+		skills = append(skills, skill)
+	}
+
+	interests := []map[string]interface{}{}
+	for _, name := range unique(append(append([]string{}, p.Strengths...), p.Interests...)) {
+		interests = append(interests, map[string]interface{}{"name": name})
+	}
+
+	certificates := []map[string]interface{}{}
+	for _, c := range p.Credentials {
+		cert := map[string]interface{}{"name": c.Name, "date": c.IssuedAt.UTC().Format("2006-01-02")}
+		if c.IssuerName != "" {
+			cert["issuer"] = c.IssuerName
+		}
+		if c.VerifyURL != "" {
+			cert["url"] = c.VerifyURL
+		}
+		certificates = append(certificates, cert)
+	}
+
+	projects := []map[string]interface{}{}
+	for _, w := range p.Works {
+		project := map[string]interface{}{"name": w.Title, "startDate": w.CreatedAt.UTC().Format("2006-01-02")}
+		if w.Description != "" {
+			project["description"] = w.Description
+		}
+		if w.Category != "" {
+			project["type"] = w.Category
+		}
+		if len(w.Tags) > 0 {
+			project["keywords"] = w.Tags
+		}
+		projects = append(projects, project)
+	}
+
+	meta := map[string]interface{}{"version": jsonResumeVersion}
+	if p.URL != "" {
+		meta["canonical"] = p.URL
+	}
+	if p.UpdatedAt != nil {
+		meta["lastModified"] = p.UpdatedAt.UTC().Format(time.RFC3339)
+	}
+	return map[string]interface{}{
+		"$schema":      jsonResumeSchema,
+		"basics":       basics,
+		"skills":       skills,
+		"interests":    interests,
+		"certificates": certificates,
+		"projects":     projects,
+		"meta":         meta,
+	}
+}
+
+// Render returns the document of a machine-readable format.
+func Render(p Person, format string) map[string]interface{} {
+	if format == FormatJSONResume {
+		return JSONResume(p)
+	}
+	return JSONLD(p)
+}
+
+// SortSkills orders skills by score, highest first.
+func SortSkills(skills []Skill) {
+	sort.SliceStable(skills, func(i, j int) bool { return skills[i].Score > skills[j].Score })
+}
+
+func formatScore(v float64) string {
+	return strconv.FormatFloat(v, 'f', 0, 64)
+}
+
+func unique(values []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package resume
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		url, accept string
+		html        bool
+		want        string
+	}{
+		{"/", "", true, FormatJSON},
+		{"/", "application/json", true, FormatJSON},
+		{"/", "text/html,application/xhtml+xml,*/*;q=0.8", true, FormatHTML},
+		{"/", "text/html", false, FormatJSON},
+		{"/", "application/ld+json", false, FormatJSONLD},
+		{"/", "application/json;q=0.9, application/vnd.jsonresume+json", false, FormatJSONResume},
+		{"/", "application/ld+json;q=0.2, application/json", false, FormatJSON},
+		{"/?format=jsonld", "text/html", true, FormatJSONLD},
+		{"/?format=json-resume", "", false, FormatJSONResume},
+		{"/?format=html", "", false, FormatJSON},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest("GET", tc.url, nil)
+		if tc.accept != "" {
+			req.Header.Set("Accept", tc.accept)
+		}
+		if got := Negotiate(req, tc.html); got != tc.want {
+			t.Errorf("Negotiate(%q, %q, %v) = %q, want %q", tc.url, tc.accept, tc.html, got, tc.want)
+		}
+	}
+}
+
+func TestDocuments(t *testing.T) {
+	issued := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	p := Person{
+		ID:        uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		Name:      "Ada",
+		URL:       "https://example.com/p/1",
+		Skills:    []Skill{{Key: "teamwork", Label: "Teamarbeit", Score: 64.4, Keywords: []string{"empathy"}}},
+		Strengths: []string{"Teamarbeit"},
+		Interests: []string{"Technik", "Teamarbeit"},
+		Credentials: []Credential{{
+			ID: uuid.New(), Name: "Badge", Type: "milestone", IssuerName: "SkillR",
+			IssuedAt: issued, VerifyURL: "https://example.com/verify/1",
+		}},
+	}
+
+	ld := JSONLD(p)
+	if ld["@type"] != "Person" || ld["@id"] != p.URL {
+		t.Errorf("unexpected JSON-LD person: %v", ld)
+	}
+	skills := ld["skills"].([]map[string]interface{})
+	if skills[0]["@type"] != "DefinedTerm" || skills[0]["description"] != "64/100" {
+		t.Errorf("unexpected skill: %v", skills[0])
+	}
+	if topics := ld["knowsAbout"].([]string); len(topics) != 2 {
+		t.Errorf("expected deduplicated topics, got %v", topics)
+	}
+	cred := ld["hasCredential"].([]map[string]interface{})[0]
+	if cred["url"] != "https://example.com/verify/1" || cred["recognizedBy"].(map[string]interface{})["name"] != "SkillR" {
+		t.Errorf("unexpected credential: %v", cred)
+	}
+	if _, ok := ld["@reverse"]; ok {
+		t.Error("expected no works")
+	}
+
+	jr := JSONResume(p)
+	if jr["basics"].(map[string]interface{})["url"] != p.URL {
+		t.Errorf("unexpected basics: %v", jr["basics"])
+	}
+	if s := jr["skills"].([]map[string]interface{})[0]; s["level"] != "64/100" || s["name"] != "Teamarbeit" {
+		t.Errorf("unexpected skill: %v", s)
+	}
+	if c := jr["certificates"].([]map[string]interface{})[0]; c["date"] != "2026-03-01" || c["issuer"] != "SkillR" {
+		t.Errorf("unexpected certificate: %v", c)
+	}
+}
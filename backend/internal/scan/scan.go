@@ -0,0 +1,141 @@
+// Package scan checks uploaded files for malware. ClamdScanner talks to a
+// ClamAV daemon over its TCP protocol (clamav/clamav in docker-compose).
+package scan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// Result is the verdict on one file.
+type Result struct {
+	Infected bool
+	// Signature names the malware found (e.g. "Eicar-Test-Signature").
+	Signature string
+}
+
+// ErrTooLarge is returned when the file exceeds the daemon's StreamMaxLength.
+var ErrTooLarge = errors.New("file exceeds the scanner's size limit")
+
+// chunkSize is the INSTREAM chunk size; clamd accepts up to its
+// StreamMaxLength in total, in chunks of any size.
+const chunkSize = 64 << 10
+
+// ClamdScanner scans files with clamd's INSTREAM command.
+type ClamdScanner struct {
+	Addr    string // host:port of clamd's TCP socket
+	Timeout time.Duration
+}
+
+// NewClamdScanner creates a scanner for clamd at addr (host:port).
+func NewClamdScanner(addr string) *ClamdScanner {
+	return &ClamdScanner{Addr: addr, Timeout: 2 * time.Minute}
+}
+
+// Scan streams r to clamd and returns its verdict. Errors mean no verdict
+// was reached; the file must not be treated as clean.
+func (s *ClamdScanner) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	defer conn.Close()
+
+	if _, err := io.WriteString(conn, "zINSTREAM\x00"); err != nil {
+		return Result{}, fmt.Errorf("clamd: %w", err)
+	}
+	buf := make([]byte, 4+chunkSize)
+	for {
+		n, rerr := io.ReadFull(r, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				// clamd closes the connection once the stream exceeds its
+				// limit; its reply says why.
+				if reply, rerr := readReply(conn); rerr == nil && reply != "" {
+					return parseReply(reply)
+				}
+				return Result{}, fmt.Errorf("clamd: %w", err)
+			}
+		}
+		if errors.Is(rerr, io.EOF) || errors.Is(rerr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if rerr != nil {
+			return Result{}, fmt.Errorf("read file: %w", rerr)
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return Result{}, fmt.Errorf("clamd: %w", err)
+	}
+	reply, err := readReply(conn)
+	if err != nil {
+		return Result{}, fmt.Errorf("clamd: %w", err)
+	}
+	return parseReply(reply)
+}
+
+// Ping checks that clamd is reachable.
+func (s *ClamdScanner) Ping(ctx context.Context) error {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := io.WriteString(conn, "zPING\x00"); err != nil {
+		return fmt.Errorf("clamd: %w", err)
+	}
+	reply, err := readReply(conn)
+	if err != nil {
+		return fmt.Errorf("clamd: %w", err)
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("clamd: unexpected reply %q", reply)
+	}
+	return nil
+}
+
+func (s *ClamdScanner) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("connect clamd: %w", err)
+	}
+	deadline := time.Now().Add(s.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+	return conn, nil
+}
+
+// readReply reads one NUL-terminated reply (z-prefixed commands).
+func readReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(errors.Is(err, io.EOF) && reply != "") {
+		return "", err
+	}
+	return strings.TrimSpace(strings.TrimRight(reply, "\x00")), nil
+}
+
+// parseReply interprets "stream: OK", "stream: <name> FOUND" and
+// "<message> ERROR".
+func parseReply(reply string) (Result, error) {
+	reply = strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case reply == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return Result{Infected: true, Signature: strings.TrimSuffix(reply, " FOUND")}, nil
+	case strings.Contains(reply, "size limit exceeded"):
+		return Result{}, ErrTooLarge
+	}
+	return Result{}, fmt.Errorf("clamd: %s", reply)
+}
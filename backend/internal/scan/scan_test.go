@@ -0,0 +1,107 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+)
+
+// eicar is the standard antivirus test file.
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// fakeClamd answers INSTREAM like clamd: content containing the EICAR
+// string is reported infected, streams over maxLength exceed the limit.
+func fakeClamd(t *testing.T, maxLength int) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveClamd(conn, maxLength)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func serveClamd(conn net.Conn, maxLength int) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	cmd, err := r.ReadString(0)
+	if err != nil {
+		return
+	}
+	switch cmd {
+	case "zPING\x00":
+		_, _ = io.WriteString(conn, "PONG\x00")
+	case "zINSTREAM\x00":
+		var data bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&data, r, int64(size)); err != nil {
+				return
+			}
+			if data.Len() > maxLength {
+				_, _ = io.WriteString(conn, "INSTREAM size limit exceeded. ERROR\x00")
+				return
+			}
+		}
+		if strings.Contains(data.String(), eicar) {
+			_, _ = io.WriteString(conn, "stream: Eicar-Test-Signature FOUND\x00")
+			return
+		}
+		_, _ = io.WriteString(conn, "stream: OK\x00")
+	}
+}
+
+func TestClamdScanner(t *testing.T) {
+	s := NewClamdScanner(fakeClamd(t, 1<<20))
+	ctx := context.Background()
+
+	if err := s.Ping(ctx); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+	res, err := s.Scan(ctx, strings.NewReader(strings.Repeat("harmlos ", 20000)))
+	if err != nil || res.Infected {
+		t.Errorf("clean file = %+v, %v", res, err)
+	}
+	res, err = s.Scan(ctx, strings.NewReader("prefix "+eicar))
+	if err != nil || !res.Infected || res.Signature != "Eicar-Test-Signature" {
+		t.Errorf("eicar = %+v, %v", res, err)
+	}
+}
+
+func TestClamdScanner_SizeLimit(t *testing.T) {
+	s := NewClamdScanner(fakeClamd(t, 100<<10))
+	_, err := s.Scan(context.Background(), bytes.NewReader(make([]byte, 1<<20)))
+	if !errors.Is(err, ErrTooLarge) {
+		t.Errorf("err = %v, want ErrTooLarge", err)
+	}
+}
+
+func TestClamdScanner_Unreachable(t *testing.T) {
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	ln.Close()
+	if _, err := NewClamdScanner(addr).Scan(context.Background(), strings.NewReader("x")); err == nil {
+		t.Error("expected error without clamd")
+	}
+}
@@ -2,12 +2,16 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 	"unicode"
@@ -18,6 +22,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"golang.org/x/crypto/bcrypt"
 
+	"skillr-mvp-v1/backend/internal/mail"
 	"skillr-mvp-v1/backend/internal/middleware"
 )
 
@@ -91,9 +96,29 @@ func validatePasswordStrength(password string) error {
 // AuthHandler provides local (non-Firebase) email/password authentication.
 // This is used when Firebase is not configured (staging / dev).
 type AuthHandler struct {
-	db *pgxpool.Pool
+	db      *pgxpool.Pool
+	mailer  Mailer
+	baseURL string
+	files   FileEraser
 }
 
+// Mailer queues templated mail (satisfied by *mail.Outbox).
+type Mailer interface {
+	Enqueue(ctx context.Context, to, template, locale string, data map[string]interface{}) (uuid.UUID, error)
+}
+
+// FileEraser removes the files a user uploaded (satisfied by
+// *artifact.Service).
+type FileEraser interface {
+	DeleteLearnerFiles(ctx context.Context, learnerID uuid.UUID) error
+}
+
+// resetTokenTTL is how long a password reset link stays valid.
+const resetTokenTTL = 60 * time.Minute
+
+// resetMailInterval is the least time between two reset mails to a user.
+const resetMailInterval = 5 * time.Minute
+
 func NewAuthHandler(db *pgxpool.Pool) *AuthHandler {
 	return &AuthHandler{db: db}
 }
@@ -103,6 +128,18 @@ func (h *AuthHandler) SetDB(db *pgxpool.Pool) {
 	h.db = db
 }
 
+// SetMailer enables password reset mails; baseURL is the public frontend
+// URL the reset links point to.
+func (h *AuthHandler) SetMailer(m Mailer, baseURL string) {
+	h.mailer = m
+	h.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// SetFileEraser makes account deletion remove the user's stored files.
+func (h *AuthHandler) SetFileEraser(f FileEraser) {
+	h.files = f
+}
+
 type loginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
@@ -112,6 +149,8 @@ type registerRequest struct {
 	Email       string `json:"email"`
 	DisplayName string `json:"displayName"`
 	Password    string `json:"password"`
+	// Brand is the partner brand slug the learner signed up through (optional).
+	Brand string `json:"brand,omitempty"`
 }
 
 type providerRequest struct {
@@ -251,11 +290,13 @@ func (h *AuthHandler) Register(c echo.Context) error {
 	id := uuid.New()
 	now := time.Now()
 
-	// H10: Atomic first-user-gets-admin — use INSERT with subquery to prevent race
+	// H10: Atomic first-user-gets-admin — use INSERT with subquery to prevent race.
+	// Partner attribution is only stored for active brands.
 	_, err = h.db.Exec(ctx,
-		`INSERT INTO users (id, email, display_name, role, auth_provider, password_hash, created_at, updated_at)
-		 VALUES ($1, $2, $3, (CASE WHEN (SELECT COUNT(*) FROM users) = 0 THEN 'admin' ELSE 'user' END)::user_role, $4::auth_provider, $5, $6, $6)`,
-		id, req.Email, req.DisplayName, "email", string(hash), now)
+		`INSERT INTO users (id, email, display_name, role, auth_provider, password_hash, brand_slug, created_at, updated_at)
+		 VALUES ($1, $2, $3, (CASE WHEN (SELECT COUNT(*) FROM users) = 0 THEN 'admin' ELSE 'user' END)::user_role, $4::auth_provider, $5,
+		         (SELECT slug FROM brand_configs WHERE slug = $7 AND is_active = true), $6, $6)`,
+		id, req.Email, req.DisplayName, "email", string(hash), now, req.Brand)
 	if err != nil {
 		log.Printf("auth register insert error: %v", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "internal error")
@@ -338,14 +379,16 @@ func (h *AuthHandler) LoginProvider(c echo.Context) error {
 }
 
 // ResetPassword handles POST /api/auth/reset-password
-// L7: In local auth mode, generates a reset token and logs it (email sending is not yet implemented).
+// Sends a single-use reset link to the address if an account exists. Only the
+// SHA-256 hash of the token is stored.
 func (h *AuthHandler) ResetPassword(c echo.Context) error {
 	if h.db == nil {
 		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not available")
 	}
 
 	var req struct {
-		Email string `json:"email"`
+		Email  string `json:"email"`
+		Locale string `json:"locale,omitempty"`
 	}
 	if err := c.Bind(&req); err != nil || req.Email == "" {
 		// Always return the same response to prevent email enumeration
@@ -355,15 +398,17 @@ func (h *AuthHandler) ResetPassword(c echo.Context) error {
 		})
 	}
 
-	// Check if user exists (don't reveal to client)
-	var exists bool
+	// Look up the user (don't reveal to client)
+	var userID uuid.UUID
 	ctx := c.Request().Context()
-	if err := h.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`, req.Email).Scan(&exists); err != nil {
+	err := h.db.QueryRow(ctx, `SELECT id FROM users WHERE email = $1`, req.Email).Scan(&userID)
+	if err != nil && err != pgx.ErrNoRows {
 		log.Printf("auth reset-password check error: %v", err)
 	}
-	if exists {
-		resetToken := uuid.New().String()
-		log.Printf("password reset requested for %s — token: %s (email sending not yet implemented)", req.Email, resetToken)
+	if err == nil {
+		if err := h.sendResetMail(ctx, userID, req.Email, req.Locale); err != nil {
+			log.Printf("auth reset-password for user %s: %v", userID, err)
+		}
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -372,6 +417,116 @@ func (h *AuthHandler) ResetPassword(c echo.Context) error {
 	})
 }
 
+func (h *AuthHandler) sendResetMail(ctx context.Context, userID uuid.UUID, email, locale string) error {
+	if h.mailer == nil {
+		return fmt.Errorf("mail not configured")
+	}
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return fmt.Errorf("generate token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	// No new token (and mail) while the last one is recent, so the
+	// endpoint cannot be used to flood an inbox.
+	tag, err := h.db.Exec(ctx,
+		`INSERT INTO password_reset_tokens (token_hash, user_id, expires_at)
+		 SELECT $1, $2, $3
+		 WHERE NOT EXISTS (SELECT 1 FROM password_reset_tokens WHERE user_id = $2 AND created_at > $4)`,
+		hashResetToken(token), userID, time.Now().Add(resetTokenTTL).UTC(), time.Now().Add(-resetMailInterval).UTC())
+	if err != nil {
+		return fmt.Errorf("store token: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("a reset mail was sent less than %s ago", resetMailInterval)
+	}
+
+	if locale == "" {
+		locale = mail.DefaultLocale
+	}
+	_, err = h.mailer.Enqueue(ctx, email, mail.TemplatePasswordReset, locale, map[string]interface{}{
+		"ResetURL":  h.baseURL + "/reset-password?token=" + token,
+		"ExpiresIn": int(resetTokenTTL.Minutes()),
+	})
+	return err
+}
+
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ResetPasswordConfirm handles POST /api/auth/reset-password/confirm
+// Sets a new password with a token from the reset mail. The token is consumed
+// atomically, so it cannot be used twice.
+func (h *AuthHandler) ResetPasswordConfirm(c echo.Context) error {
+	if h.db == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "database not available")
+	}
+
+	var req struct {
+		Token    string `json:"token"`
+		Password string `json:"password"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	if req.Token == "" || req.Password == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "token and password are required")
+	}
+	if err := validatePasswordStrength(req.Password); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal error")
+	}
+
+	// Consuming the token, setting the password and voiding the user's
+	// other links happen together, so a failure leaves the token usable.
+	ctx := c.Request().Context()
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		log.Printf("auth reset-password confirm error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal error")
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var userID uuid.UUID
+	err = tx.QueryRow(ctx,
+		`UPDATE password_reset_tokens SET used_at = NOW()
+		 WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		 RETURNING user_id`, hashResetToken(req.Token)).Scan(&userID)
+	if err == pgx.ErrNoRows {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid or expired token")
+	}
+	if err != nil {
+		log.Printf("auth reset-password confirm error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal error")
+	}
+
+	if _, err := tx.Exec(ctx,
+		`UPDATE users SET password_hash = $1, updated_at = NOW() WHERE id = $2`, string(hash), userID); err != nil {
+		log.Printf("auth reset-password update error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal error")
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE password_reset_tokens SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`, userID); err != nil {
+		log.Printf("auth reset-password invalidate error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal error")
+	}
+	if err := tx.Commit(ctx); err != nil {
+		log.Printf("auth reset-password commit error: %v", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal error")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"ok":      true,
+		"message": "Dein Passwort wurde geaendert.",
+	})
+}
+
 // DeleteAccount handles DELETE /api/auth/account — DSGVO Art. 17 (Right to Erasure)
 // Requires the user to authenticate with their password to confirm deletion.
 func (h *AuthHandler) DeleteAccount(c echo.Context) error {
@@ -410,6 +565,15 @@ func (h *AuthHandler) DeleteAccount(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusUnauthorized, "invalid credentials")
 	}
 
+	// Stored files are not covered by the cascade below; remove them first
+	// so that a failure leaves the account in place to retry.
+	if h.files != nil {
+		if err := h.files.DeleteLearnerFiles(ctx, id); err != nil {
+			log.Printf("account deletion error for %s: %v", id, err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "deletion failed")
+		}
+	}
+
 	// Delete all user data (cascade if FK constraints allow, otherwise delete related tables)
 	_, err = h.db.Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
 	if err != nil {
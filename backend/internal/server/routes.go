@@ -19,7 +19,12 @@ func RegisterRoutes(e *echo.Echo, deps *Dependencies) {
 		e.POST("/api/auth/login", deps.Auth.Login)
 		e.POST("/api/auth/register", deps.Auth.Register)
 		e.POST("/api/auth/login-provider", deps.Auth.LoginProvider)
-		e.POST("/api/auth/reset-password", deps.Auth.ResetPassword)
+		var resetMws []echo.MiddlewareFunc
+		if deps.PasswordResetRateLimit != nil {
+			resetMws = append(resetMws, deps.PasswordResetRateLimit)
+		}
+		e.POST("/api/auth/reset-password", deps.Auth.ResetPassword, resetMws...)
+		e.POST("/api/auth/reset-password/confirm", deps.Auth.ResetPasswordConfirm)
 		// User deletion endpoint — DSGVO Art. 17
 		e.DELETE("/api/auth/account", deps.Auth.DeleteAccount)
 	}
@@ -52,6 +57,19 @@ func RegisterRoutes(e *echo.Echo, deps *Dependencies) {
 		v1.GET("/portfolio/reflections", deps.Reflection.List)
 		v1.POST("/portfolio/reflections", deps.Reflection.Submit)
 		v1.GET("/portfolio/reflections/capabilities", deps.Reflection.Capabilities)
+		v1.GET("/portfolio/reflections/next", deps.Reflection.Next)
+
+		// Admin: question bank
+		var reflectionAdminMws []echo.MiddlewareFunc
+		if deps.FirebaseAuthMiddleware != nil {
+			reflectionAdminMws = append(reflectionAdminMws, deps.FirebaseAuthMiddleware)
+		}
+		reflectionAdminMws = append(reflectionAdminMws, middleware.RequireAdmin())
+		questionAdmin := e.Group("/api/admin/reflection-questions", reflectionAdminMws...)
+		questionAdmin.GET("", deps.Reflection.AdminListQuestions)
+		questionAdmin.POST("", deps.Reflection.AdminCreateQuestion)
+		questionAdmin.PUT("/:id/:locale", deps.Reflection.AdminUpdateQuestion)
+		questionAdmin.DELETE("/:id", deps.Reflection.AdminDeactivateQuestion)
 	}
 
 	// Profile
@@ -59,6 +77,8 @@ func RegisterRoutes(e *echo.Echo, deps *Dependencies) {
 		v1.GET("/portfolio/profile", deps.Profile.Get)
 		v1.POST("/portfolio/profile/compute", deps.Profile.Compute)
 		v1.GET("/portfolio/profile/history", deps.Profile.History)
+		v1.GET("/portfolio/profile/trend", deps.Profile.Trend)
+		v1.GET("/portfolio/profile/diff", deps.Profile.Diff)
 		v1.GET("/portfolio/profile/export", deps.Profile.Export)
 	}
 	// Public profile (no auth)
@@ -71,12 +91,97 @@ func RegisterRoutes(e *echo.Echo, deps *Dependencies) {
 		v1.GET("/portfolio/evidence", deps.Evidence.List)
 		v1.POST("/portfolio/evidence", deps.Evidence.Create)
 		v1.GET("/portfolio/evidence/:id", deps.Evidence.Get)
+		v1.PUT("/portfolio/evidence/:id", deps.Evidence.Update)
+		v1.POST("/portfolio/evidence/:id/retract", deps.Evidence.Retract)
+		v1.GET("/portfolio/evidence/:id/history", deps.Evidence.History)
 		v1.GET("/portfolio/evidence/by-dimension/:dim", deps.Evidence.ByDimension)
+		v1.POST("/portfolio/evidence/:id/revoke", deps.Evidence.Revoke)
+		v1.DELETE("/portfolio/evidence/:id/revoke", deps.Evidence.Unrevoke)
+		v1.GET("/portfolio/evidence/suggestions", deps.Evidence.Suggestions)
+		v1.POST("/portfolio/evidence/suggestions/:id/accept", deps.Evidence.AcceptSuggestion)
+		v1.POST("/portfolio/evidence/suggestions/:id/discard", deps.Evidence.DiscardSuggestion)
 	}
 	// Public verification (no auth) — L12: POST preferred, GET kept for email links
 	if deps.Evidence != nil {
 		e.GET("/api/v1/portfolio/evidence/verify/:id", deps.Evidence.Verify)
 		e.POST("/api/v1/portfolio/evidence/verify/:id", deps.Evidence.Verify)
+		e.GET("/api/v1/portfolio/evidence/revocations", deps.Evidence.Revocations)
+
+		// Admin: revocation list and re-signing after key rotation
+		var evidenceAdminMws []echo.MiddlewareFunc
+		if deps.FirebaseAuthMiddleware != nil {
+			evidenceAdminMws = append(evidenceAdminMws, deps.FirebaseAuthMiddleware)
+		}
+		evidenceAdminMws = append(evidenceAdminMws, middleware.RequireAdmin())
+		evidenceAdmin := e.Group("/api/admin/evidence", evidenceAdminMws...)
+		evidenceAdmin.GET("/revocations", deps.Evidence.AdminRevocations)
+		evidenceAdmin.POST("/:id/revoke", deps.Evidence.AdminRevoke)
+		evidenceAdmin.DELETE("/:id/revoke", deps.Evidence.AdminUnrevoke)
+		evidenceAdmin.GET("/:id/audit", deps.Evidence.AdminAudit)
+		evidenceAdmin.POST("/resign", deps.Evidence.AdminResign)
+	}
+
+	// Job matching
+	if deps.Job != nil {
+		v1.GET("/jobs/matches", deps.Job.Matches)
+		v1.GET("/jobs/gaps", deps.Job.Gaps)
+
+		// Admin: posting maintenance and partner feed import
+		var jobAdminMws []echo.MiddlewareFunc
+		if deps.FirebaseAuthMiddleware != nil {
+			jobAdminMws = append(jobAdminMws, deps.FirebaseAuthMiddleware)
+		}
+		jobAdminMws = append(jobAdminMws, middleware.RequireAdmin())
+		jobAdmin := e.Group("/api/admin/jobs", jobAdminMws...)
+		jobAdmin.GET("", deps.Job.AdminList)
+		jobAdmin.POST("", deps.Job.AdminCreate)
+		jobAdmin.POST("/import", deps.Job.AdminImport)
+		jobAdmin.GET("/:id", deps.Job.AdminGet)
+		jobAdmin.PUT("/:id", deps.Job.AdminUpdate)
+		jobAdmin.DELETE("/:id", deps.Job.AdminDelete)
+	}
+
+	// Skill taxonomy — public read API, admin ESCO import
+	if deps.Taxonomy != nil {
+		e.GET("/api/v1/taxonomy", deps.Taxonomy.Get)
+		e.GET("/api/v1/taxonomy/versions", deps.Taxonomy.Versions)
+		e.GET("/api/v1/taxonomy/resolve", deps.Taxonomy.Resolve)
+
+		var taxonomyAdminMws []echo.MiddlewareFunc
+		if deps.FirebaseAuthMiddleware != nil {
+			taxonomyAdminMws = append(taxonomyAdminMws, deps.FirebaseAuthMiddleware)
+		}
+		taxonomyAdminMws = append(taxonomyAdminMws, middleware.RequireAdmin())
+		e.POST("/api/admin/taxonomy/import/esco", deps.Taxonomy.AdminImportESCO, taxonomyAdminMws...)
+	}
+
+	// Share links — learner management, public access (rate limited)
+	if deps.Share != nil {
+		v1.GET("/portfolio/share-links", deps.Share.List)
+		v1.POST("/portfolio/share-links", deps.Share.Create)
+		v1.GET("/portfolio/share-links/:id", deps.Share.Get)
+		v1.DELETE("/portfolio/share-links/:id", deps.Share.Revoke)
+
+		shareGroup := e.Group("/api/v1/share")
+		if deps.ShareRateLimit != nil {
+			shareGroup.Use(deps.ShareRateLimit)
+		}
+		shareGroup.GET("/:token", deps.Share.Open)
+		shareGroup.POST("/:token", deps.Share.Open)
+	}
+
+	// Verifiable Credentials (Open Badges 3.0)
+	if deps.Credential != nil {
+		v1.GET("/portfolio/credentials", deps.Credential.List)
+		v1.POST("/portfolio/credentials", deps.Credential.Issue)
+		v1.GET("/portfolio/credentials/:id", deps.Credential.Get)
+		v1.GET("/portfolio/credentials/:id/download", deps.Credential.Download)
+		v1.POST("/portfolio/credentials/:id/revoke", deps.Credential.Revoke)
+		// Public: issuer DID document and verification (no auth)
+		e.GET("/.well-known/did.json", deps.Credential.DIDDocument)
+		e.GET("/.well-known/jwks.json", deps.Credential.JWKS)
+		e.POST("/api/v1/credentials/verify", deps.Credential.Verify)
+		e.GET("/api/v1/credentials/verify/:id", deps.Credential.VerifyByID)
 	}
 
 	// Endorsements
@@ -84,7 +189,12 @@ func RegisterRoutes(e *echo.Echo, deps *Dependencies) {
 		v1.GET("/portfolio/endorsements", deps.Endorsement.List)
 		v1.POST("/portfolio/endorsements/invite", deps.Endorsement.Invite)
 		v1.GET("/portfolio/endorsements/pending", deps.Endorsement.Pending)
+		v1.POST("/portfolio/endorsements/invites/:id/resend", deps.Endorsement.Resend)
+		v1.POST("/portfolio/endorsements/invites/:id/revoke", deps.Endorsement.Revoke)
 		v1.PUT("/portfolio/endorsements/:id/visibility", deps.Endorsement.Visibility)
+		v1.POST("/portfolio/endorsements/:id/accept", deps.Endorsement.Accept)
+		v1.POST("/portfolio/endorsements/:id/decline", deps.Endorsement.Decline)
+		v1.POST("/portfolio/endorsements/:id/report", deps.Endorsement.Report)
 	}
 	// Public submit (no auth, but rate limited — H9)
 	if deps.Endorsement != nil {
@@ -93,8 +203,45 @@ func RegisterRoutes(e *echo.Echo, deps *Dependencies) {
 			endorseGroup.Use(deps.EndorsementRateLimit)
 		}
 		endorseGroup.POST("", deps.Endorsement.Submit)
+		endorseGroup.POST("/verify/request", deps.Endorsement.RequestVerification)
+		endorseGroup.POST("/verify", deps.Endorsement.Verify)
+		endorseGroup.POST("/rubric", deps.Endorsement.Rubric)
 		// Keep legacy path for backwards compat but rate limited
 		e.POST("/api/v1/portfolio/endorsements", deps.Endorsement.Submit)
+
+		// Admin: rubric templates per endorser role and moderation
+		var endorsementAdminMws []echo.MiddlewareFunc
+		if deps.FirebaseAuthMiddleware != nil {
+			endorsementAdminMws = append(endorsementAdminMws, deps.FirebaseAuthMiddleware)
+		}
+		endorsementAdminMws = append(endorsementAdminMws, middleware.RequireAdmin())
+		rubricAdmin := e.Group("/api/admin/endorsement-rubrics", endorsementAdminMws...)
+		rubricAdmin.GET("", deps.Endorsement.AdminListRubrics)
+		rubricAdmin.POST("", deps.Endorsement.AdminCreateRubric)
+		rubricAdmin.DELETE("/:id", deps.Endorsement.AdminDeactivateRubric)
+
+		moderation := e.Group("/api/admin/endorsements", endorsementAdminMws...)
+		moderation.GET("/moderation", deps.Endorsement.AdminModerationQueue)
+		moderation.POST("/:id/hide", deps.Endorsement.AdminHide)
+		moderation.POST("/:id/delete", deps.Endorsement.AdminDelete)
+		moderation.POST("/:id/restore", deps.Endorsement.AdminRestore)
+		moderation.GET("/:id/audit", deps.Endorsement.AdminModerationLog)
+		moderation.GET("/blocked-emails", deps.Endorsement.AdminListBlockedEmails)
+		moderation.POST("/blocked-emails", deps.Endorsement.AdminBlockEmail)
+		moderation.DELETE("/blocked-emails/:email", deps.Endorsement.AdminUnblockEmail)
+	}
+
+	// QR codes for invites and evidence — the image encodes a short-lived
+	// redirect, so tokens stay out of images and request logs
+	if deps.QR != nil {
+		v1.GET("/portfolio/endorsements/invites/:id/qr", deps.QR.Invite)
+		v1.GET("/portfolio/evidence/:id/qr", deps.QR.Evidence)
+
+		qrGroup := e.Group("/api/v1/q")
+		if deps.QRRateLimit != nil {
+			qrGroup.Use(deps.QRRateLimit)
+		}
+		qrGroup.GET("/:code", deps.QR.Open)
 	}
 
 	// Artifacts
@@ -102,9 +249,31 @@ func RegisterRoutes(e *echo.Echo, deps *Dependencies) {
 		v1.GET("/portfolio/artifacts", deps.Artifact.List)
 		v1.POST("/portfolio/artifacts", deps.Artifact.Upload)
 		v1.GET("/portfolio/artifacts/:id", deps.Artifact.Get)
+		v1.GET("/portfolio/artifacts/:id/download", deps.Artifact.Download)
+		v1.GET("/portfolio/artifacts/:id/preview", deps.Artifact.Preview)
 		v1.DELETE("/portfolio/artifacts/:id", deps.Artifact.Delete)
 		v1.POST("/portfolio/artifacts/:id/link-endorsement", deps.Artifact.LinkEndorsement)
 	}
+	// Downloads of the local file storage — public, the signed URL is the
+	// authorization
+	if deps.Files != nil {
+		e.GET("/api/v1/files/*", deps.Files.Download)
+	}
+
+	// Admin: mail outbox and bounces
+	if deps.Mail != nil {
+		var mailAdminMws []echo.MiddlewareFunc
+		if deps.FirebaseAuthMiddleware != nil {
+			mailAdminMws = append(mailAdminMws, deps.FirebaseAuthMiddleware)
+		}
+		mailAdminMws = append(mailAdminMws, middleware.RequireAdmin())
+		mailAdmin := e.Group("/api/admin/mail", mailAdminMws...)
+		mailAdmin.GET("/outbox", deps.Mail.ListOutbox)
+		mailAdmin.POST("/outbox/:id/retry", deps.Mail.Retry)
+		mailAdmin.GET("/bounces", deps.Mail.ListBounces)
+		mailAdmin.POST("/bounces", deps.Mail.RecordBounce)
+		mailAdmin.DELETE("/bounces/:email", deps.Mail.DeleteBounce)
+	}
 
 	// Portfolio Entries
 	if deps.PortfolioEntries != nil {
@@ -132,6 +301,16 @@ func RegisterRoutes(e *echo.Echo, deps *Dependencies) {
 		v1.GET("/portfolio/engagement", deps.Engagement.Get)
 		v1.POST("/portfolio/engagement/award", deps.Engagement.Award)
 		v1.GET("/portfolio/engagement/leaderboard", deps.Engagement.Leaderboard)
+
+		// Admin: derive engagement state from the XP ledger again
+		var engagementAdminMws []echo.MiddlewareFunc
+		if deps.FirebaseAuthMiddleware != nil {
+			engagementAdminMws = append(engagementAdminMws, deps.FirebaseAuthMiddleware)
+		}
+		engagementAdminMws = append(engagementAdminMws, middleware.RequireAdmin())
+		engagementAdmin := e.Group("/api/admin/engagement", engagementAdminMws...)
+		engagementAdmin.POST("/rebuild", deps.Engagement.AdminRebuild)
+		engagementAdmin.POST("/users/:userId/rebuild", deps.Engagement.AdminRebuildUser)
 	}
 
 	// Lernreise (FR-074, FR-075)
@@ -426,8 +605,15 @@ type Dependencies struct {
 	Reflection             ReflectionHandler
 	Profile                ProfileHandler
 	Evidence               EvidenceHandler
+	Credential             CredentialHandler
+	Taxonomy               TaxonomyHandler
+	Job                    JobHandler
+	Share                  ShareHandler
 	Endorsement            EndorsementHandler
+	QR                     QRHandler
 	Artifact               ArtifactHandler
+	Files                  FileHandler
+	Mail                   MailHandler
 	Journal                JournalHandler
 	Engagement             EngagementHandler
 	Lernreise              LernreiseHandler
@@ -440,6 +626,9 @@ type Dependencies struct {
 	OptionalFirebaseAuth   echo.MiddlewareFunc // optional auth for AI routes (intro flow)
 	EndorsementRateLimit   echo.MiddlewareFunc // H9: rate limit for public endorsement submit
 	AIRateLimit            echo.MiddlewareFunc // rate limit for public AI endpoints
+	ShareRateLimit         echo.MiddlewareFunc // rate limit for public share links (password guessing)
+	QRRateLimit            echo.MiddlewareFunc // rate limit for QR code redirects
+	PasswordResetRateLimit echo.MiddlewareFunc // rate limit for password reset mails
 	// Gateway handlers (ported from Express gateway)
 	GatewayAnalytics   GatewayAnalyticsHandler
 	GatewayLegal       GatewayLegalHandler
@@ -465,12 +654,19 @@ type ReflectionHandler interface {
 	List(c echo.Context) error
 	Submit(c echo.Context) error
 	Capabilities(c echo.Context) error
+	Next(c echo.Context) error
+	AdminListQuestions(c echo.Context) error
+	AdminCreateQuestion(c echo.Context) error
+	AdminUpdateQuestion(c echo.Context) error
+	AdminDeactivateQuestion(c echo.Context) error
 }
 
 type ProfileHandler interface {
 	Get(c echo.Context) error
 	Compute(c echo.Context) error
 	History(c echo.Context) error
+	Trend(c echo.Context) error
+	Diff(c echo.Context) error
 	Public(c echo.Context) error
 	Export(c echo.Context) error
 }
@@ -480,7 +676,67 @@ type EvidenceHandler interface {
 	Create(c echo.Context) error
 	Get(c echo.Context) error
 	ByDimension(c echo.Context) error
+	Update(c echo.Context) error
+	Retract(c echo.Context) error
+	History(c echo.Context) error
 	Verify(c echo.Context) error
+	Revoke(c echo.Context) error
+	Unrevoke(c echo.Context) error
+	Suggestions(c echo.Context) error
+	AcceptSuggestion(c echo.Context) error
+	DiscardSuggestion(c echo.Context) error
+	Revocations(c echo.Context) error
+	AdminRevocations(c echo.Context) error
+	AdminRevoke(c echo.Context) error
+	AdminUnrevoke(c echo.Context) error
+	AdminAudit(c echo.Context) error
+	AdminResign(c echo.Context) error
+}
+
+type TaxonomyHandler interface {
+	Get(c echo.Context) error
+	Versions(c echo.Context) error
+	Resolve(c echo.Context) error
+	AdminImportESCO(c echo.Context) error
+}
+
+type JobHandler interface {
+	Matches(c echo.Context) error
+	Gaps(c echo.Context) error
+	AdminList(c echo.Context) error
+	AdminGet(c echo.Context) error
+	AdminCreate(c echo.Context) error
+	AdminUpdate(c echo.Context) error
+	AdminDelete(c echo.Context) error
+	AdminImport(c echo.Context) error
+}
+
+type ShareHandler interface {
+	List(c echo.Context) error
+	Create(c echo.Context) error
+	Get(c echo.Context) error
+	Revoke(c echo.Context) error
+	Open(c echo.Context) error
+}
+
+type MailHandler interface {
+	ListOutbox(c echo.Context) error
+	Retry(c echo.Context) error
+	ListBounces(c echo.Context) error
+	RecordBounce(c echo.Context) error
+	DeleteBounce(c echo.Context) error
+}
+
+type CredentialHandler interface {
+	List(c echo.Context) error
+	Issue(c echo.Context) error
+	Get(c echo.Context) error
+	Download(c echo.Context) error
+	Revoke(c echo.Context) error
+	DIDDocument(c echo.Context) error
+	JWKS(c echo.Context) error
+	Verify(c echo.Context) error
+	VerifyByID(c echo.Context) error
 }
 
 type EndorsementHandler interface {
@@ -489,6 +745,31 @@ type EndorsementHandler interface {
 	Invite(c echo.Context) error
 	Pending(c echo.Context) error
 	Visibility(c echo.Context) error
+	RequestVerification(c echo.Context) error
+	Verify(c echo.Context) error
+	Resend(c echo.Context) error
+	Revoke(c echo.Context) error
+	Rubric(c echo.Context) error
+	AdminListRubrics(c echo.Context) error
+	AdminCreateRubric(c echo.Context) error
+	AdminDeactivateRubric(c echo.Context) error
+	Accept(c echo.Context) error
+	Decline(c echo.Context) error
+	Report(c echo.Context) error
+	AdminModerationQueue(c echo.Context) error
+	AdminHide(c echo.Context) error
+	AdminDelete(c echo.Context) error
+	AdminRestore(c echo.Context) error
+	AdminModerationLog(c echo.Context) error
+	AdminListBlockedEmails(c echo.Context) error
+	AdminBlockEmail(c echo.Context) error
+	AdminUnblockEmail(c echo.Context) error
+}
+
+type QRHandler interface {
+	Invite(c echo.Context) error
+	Evidence(c echo.Context) error
+	Open(c echo.Context) error
 }
 
 type ArtifactHandler interface {
@@ -497,6 +778,12 @@ type ArtifactHandler interface {
 	Get(c echo.Context) error
 	Delete(c echo.Context) error
 	LinkEndorsement(c echo.Context) error
+	Download(c echo.Context) error
+	Preview(c echo.Context) error
+}
+
+type FileHandler interface {
+	Download(c echo.Context) error
 }
 
 type JournalHandler interface {
@@ -510,6 +797,8 @@ type EngagementHandler interface {
 	Get(c echo.Context) error
 	Award(c echo.Context) error
 	Leaderboard(c echo.Context) error
+	AdminRebuild(c echo.Context) error
+	AdminRebuildUser(c echo.Context) error
 }
 
 type AIHandler interface {
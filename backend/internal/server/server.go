@@ -45,13 +45,18 @@ func New(cfg *config.Config) *Server {
 		AllowCredentials: allowCreds,
 		MaxAge:           3600,
 	}))
-	// Conditional body limit: 500M for LFS uploads, 10M for everything else (FR-131)
+	// Conditional body limit: 500M for LFS uploads, 201M for artifact uploads
+	// (per-type limits are checked while streaming), 10M for everything else (FR-131)
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		lfsLimit := echomw.BodyLimit("500M")
+		artifactLimit := echomw.BodyLimit("201M")
 		defaultLimit := echomw.BodyLimit("10M")
 		return func(c echo.Context) error {
-			if c.Request().URL.Path == "/api/lfs/produce" {
+			switch {
+			case c.Request().URL.Path == "/api/lfs/produce":
 				return lfsLimit(next)(c)
+			case c.Request().Method == http.MethodPost && c.Request().URL.Path == "/api/v1/portfolio/artifacts":
+				return artifactLimit(next)(c)
 			}
 			return defaultLimit(next)(c)
 		}
@@ -0,0 +1,67 @@
+package signing
+
+import (
+	"fmt"
+	"math/big"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// EncodeMultibase encodes b as a base58-btc multibase string ("z" prefix).
+func EncodeMultibase(b []byte) string {
+	return "z" + encodeBase58(b)
+}
+
+// DecodeMultibase decodes a base58-btc multibase string.
+func DecodeMultibase(s string) ([]byte, error) {
+	if len(s) == 0 || s[0] != 'z' {
+		return nil, fmt.Errorf("unsupported multibase encoding")
+	}
+	return decodeBase58(s[1:])
+}
+
+func encodeBase58(b []byte) string {
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+func decodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, r := range s {
+		idx := -1
+		for i := 0; i < len(base58Alphabet); i++ {
+			if rune(base58Alphabet[i]) == r {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", r)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+	out := n.Bytes()
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), out...), nil
+}
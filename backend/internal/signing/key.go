@@ -0,0 +1,76 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// multicodec prefix for an Ed25519 public key (0xed01).
+var ed25519MulticodecPrefix = []byte{0xed, 0x01}
+
+// Key is an Ed25519 issuer key.
+type Key struct {
+	ID      string
+	private ed25519.PrivateKey
+	public  ed25519.PublicKey
+}
+
+// NewKey builds a key from a 32-byte Ed25519 seed. The key ID is derived from
+// the public key so the same seed always yields the same ID.
+func NewKey(seed []byte) (*Key, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("ed25519 seed must be %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+	priv := ed25519.NewKeyFromSeed(seed)
+	pub := priv.Public().(ed25519.PublicKey)
+	sum := sha256.Sum256(pub)
+	return &Key{ID: "key-" + hex.EncodeToString(sum[:4]), private: priv, public: pub}, nil
+}
+
+// ParseKey decodes a base64 (standard or URL) encoded seed.
+func ParseKey(encoded string) (*Key, error) {
+	encoded = strings.TrimSpace(encoded)
+	seed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		seed, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+		if err != nil {
+			return nil, fmt.Errorf("decode signing key: %w", err)
+		}
+	}
+	return NewKey(seed)
+}
+
+// GenerateKey creates a random key. Used in development when no key is
+// configured; signatures do not survive a restart.
+func GenerateKey() (*Key, error) {
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, fmt.Errorf("generate signing key: %w", err)
+	}
+	return NewKey(seed)
+}
+
+// Sign signs msg with the private key.
+func (k *Key) Sign(msg []byte) []byte {
+	return ed25519.Sign(k.private, msg)
+}
+
+// Verify checks sig over msg against the public key.
+func (k *Key) Verify(msg, sig []byte) bool {
+	return ed25519.Verify(k.public, msg, sig)
+}
+
+// PublicKey returns the raw public key.
+func (k *Key) PublicKey() ed25519.PublicKey {
+	return k.public
+}
+
+// PublicKeyMultibase returns the public key in Multikey form.
+func (k *Key) PublicKeyMultibase() string {
+	return EncodeMultibase(append(append([]byte{}, ed25519MulticodecPrefix...), k.public...))
+}
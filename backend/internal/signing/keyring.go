@@ -0,0 +1,107 @@
+package signing
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// KeyRing holds the active signing key and retired keys that are still
+// published for verification.
+//
+// Rotation: generate a new seed, move the current CREDENTIAL_SIGNING_KEY into
+// CREDENTIAL_RETIRED_KEYS and set the new seed as CREDENTIAL_SIGNING_KEY.
+// Signatures made with retired keys keep verifying; evidence is re-signed
+// with the active key in the background, after which the retired key can be
+// removed once no issued credential references it.
+type KeyRing struct {
+	active  *Key
+	retired []*Key
+}
+
+// NewKeyRing creates a ring from the active key and any retired keys.
+func NewKeyRing(active *Key, retired ...*Key) *KeyRing {
+	r := &KeyRing{active: active}
+	for _, k := range retired {
+		if k != nil && k.ID != active.ID {
+			r.retired = append(r.retired, k)
+		}
+	}
+	return r
+}
+
+// ParseKeyRing builds a ring from a base64 seed and a comma-separated list of
+// retired base64 seeds.
+func ParseKeyRing(active, retired string) (*KeyRing, error) {
+	key, err := ParseKey(active)
+	if err != nil {
+		return nil, err
+	}
+	var old []*Key
+	for _, s := range strings.Split(retired, ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		k, err := ParseKey(s)
+		if err != nil {
+			return nil, fmt.Errorf("retired key: %w", err)
+		}
+		old = append(old, k)
+	}
+	return NewKeyRing(key, old...), nil
+}
+
+// Active returns the key used for new signatures.
+func (r *KeyRing) Active() *Key {
+	return r.active
+}
+
+// Lookup finds a key by ID.
+func (r *KeyRing) Lookup(kid string) (*Key, bool) {
+	if r.active.ID == kid {
+		return r.active, true
+	}
+	for _, k := range r.retired {
+		if k.ID == kid {
+			return k, true
+		}
+	}
+	return nil, false
+}
+
+// Keys returns the active key followed by the retired keys.
+func (r *KeyRing) Keys() []*Key {
+	return append([]*Key{r.active}, r.retired...)
+}
+
+// Verify checks sig over msg with the key identified by kid.
+func (r *KeyRing) Verify(kid string, msg, sig []byte) bool {
+	k, ok := r.Lookup(kid)
+	return ok && k.Verify(msg, sig)
+}
+
+// JWK is an Ed25519 public key in JSON Web Key form (RFC 8037).
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+// JWKS returns the public keys of the ring as a JSON Web Key Set.
+func (r *KeyRing) JWKS() map[string][]JWK {
+	keys := make([]JWK, 0, len(r.retired)+1)
+	for _, k := range r.Keys() {
+		keys = append(keys, JWK{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(k.PublicKey()),
+			Kid: k.ID,
+			Use: "sig",
+			Alg: "EdDSA",
+		})
+	}
+	return map[string][]JWK{"keys": keys}
+}
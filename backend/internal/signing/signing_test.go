@@ -0,0 +1,113 @@
+package signing
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	in := map[string]interface{}{
+		"b":       []interface{}{1.0, "x", true, nil},
+		"a":       map[string]interface{}{"z": 0.5, "y": "<tag>"},
+		"numbers": []interface{}{1e21, 1e-7, 100.0, -0.25},
+		"€":       1,
+		"\r":      "line\nbreak",
+	}
+	got, err := Canonicalize(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"\r":"line\nbreak","a":{"y":"<tag>","z":0.5},"b":[1,"x",true,null],"numbers":[1e+21,1e-7,100,-0.25],"€":1}`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestMultibaseRoundTrip(t *testing.T) {
+	in := []byte{0, 0, 1, 2, 255, 42}
+	enc := EncodeMultibase(in)
+	if enc[0] != 'z' {
+		t.Fatalf("expected base58btc prefix, got %s", enc)
+	}
+	out, err := DecodeMultibase(enc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(in, out) {
+		t.Errorf("round trip mismatch: %v != %v", in, out)
+	}
+	if _, err := DecodeMultibase("z0OIl"); err == nil {
+		t.Error("expected error for invalid base58")
+	}
+}
+
+func TestKeySignVerify(t *testing.T) {
+	seed := bytes.Repeat([]byte{7}, 32)
+	k1, err := NewKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, _ := NewKey(seed)
+	if k1.ID != k2.ID {
+		t.Errorf("expected stable key ID, got %s and %s", k1.ID, k2.ID)
+	}
+	sig := k1.Sign([]byte("hello"))
+	if !k2.Verify([]byte("hello"), sig) {
+		t.Error("expected signature to verify")
+	}
+	if k2.Verify([]byte("hellO"), sig) {
+		t.Error("expected tampered message to fail")
+	}
+	if _, err := NewKey([]byte("short")); err == nil {
+		t.Error("expected error for short seed")
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	if _, err := ParseKey("BwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwcHBwc="); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ParseKey("not base64!"); err == nil {
+		t.Error("expected decode error")
+	}
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	oldKey, _ := NewKey(bytes.Repeat([]byte{1}, 32))
+	newKey, _ := NewKey(bytes.Repeat([]byte{2}, 32))
+	sig := oldKey.Sign([]byte("payload"))
+
+	ring := NewKeyRing(newKey, oldKey, newKey)
+	if ring.Active().ID != newKey.ID {
+		t.Errorf("expected new key active")
+	}
+	if len(ring.Keys()) != 2 {
+		t.Errorf("expected duplicate active key to be dropped, got %d keys", len(ring.Keys()))
+	}
+	if !ring.Verify(oldKey.ID, []byte("payload"), sig) {
+		t.Error("expected retired key to verify old signatures")
+	}
+	if ring.Verify("key-unknown", []byte("payload"), sig) {
+		t.Error("expected unknown key to fail")
+	}
+
+	jwks := ring.JWKS()["keys"]
+	if len(jwks) != 2 || jwks[0].Kid != newKey.ID || jwks[0].Kty != "OKP" || jwks[0].Crv != "Ed25519" {
+		t.Errorf("unexpected JWKS %+v", jwks)
+	}
+}
+
+func TestParseKeyRing(t *testing.T) {
+	a := "AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="
+	b := "AgICAgICAgICAgICAgICAgICAgICAgICAgICAgICAgI="
+	ring, err := ParseKeyRing(b, " "+a+" ,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ring.Keys()) != 2 {
+		t.Errorf("expected 2 keys, got %d", len(ring.Keys()))
+	}
+	if _, err := ParseKeyRing(b, "bogus"); err == nil {
+		t.Error("expected error for invalid retired key")
+	}
+}
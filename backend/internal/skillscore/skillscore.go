@@ -0,0 +1,22 @@
+// Package skillscore defines the scale of skill dimension scores. Scores
+// are 0-100 wherever they are stored, compared or published; writes with
+// values outside the scale are rejected rather than reinterpreted.
+package skillscore
+
+import "math"
+
+// Max is the highest score.
+const Max = 100.0
+
+// Valid reports whether v is on the 0-100 scale.
+func Valid(v float64) bool {
+	return !math.IsNaN(v) && v >= 0 && v <= Max
+}
+
+// Clamp bounds v to 0-100 when reading stored scores; NaN becomes 0.
+func Clamp(v float64) float64 {
+	if math.IsNaN(v) || v < 0 {
+		return 0
+	}
+	return math.Min(v, Max)
+}
@@ -0,0 +1,25 @@
+package skillscore
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClamp_KeepsScaleOfSmallScores(t *testing.T) {
+	for in, want := range map[float64]float64{0: 0, 0.5: 0.5, 1: 1, 42: 42, 100: 100, 140: 100, -3: 0} {
+		if got := Clamp(in); got != want {
+			t.Errorf("Clamp(%v) = %v, want %v", in, got, want)
+		}
+	}
+	if Clamp(math.NaN()) != 0 {
+		t.Error("Clamp(NaN) != 0")
+	}
+}
+
+func TestValid(t *testing.T) {
+	for v, want := range map[float64]bool{0: true, 1: true, 100: true, 100.5: false, -0.1: false, math.NaN(): false} {
+		if Valid(v) != want {
+			t.Errorf("Valid(%v) = %v", v, !want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Handler serves downloads of the local backend. The signature in the URL
+// is the authorization, so the route is public.
+type Handler struct {
+	store *LocalStore
+}
+
+func NewHandler(store *LocalStore) *Handler {
+	return &Handler{store: store}
+}
+
+// Download serves FilePath + key as an attachment if the URL's signature
+// is valid and not expired.
+func (h *Handler) Download(c echo.Context) error {
+	key := strings.TrimPrefix(c.Request().URL.Path, FilePath)
+	name := c.QueryParam("name")
+	switch err := h.store.Verify(key, name, c.QueryParam("expires"), c.QueryParam("sig")); {
+	case errors.Is(err, ErrURLExpired):
+		return echo.NewHTTPError(http.StatusGone, err.Error())
+	case err != nil:
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	}
+
+	f, info, err := h.store.file(key)
+	if errors.Is(err, ErrNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, "file not found")
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to open file")
+	}
+	defer f.Close()
+
+	w := c.Response()
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": SafeFilename(name)}))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, c.Request(), SafeFilename(name), info.ModTime(), f)
+	return nil
+}
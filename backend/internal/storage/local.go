@@ -0,0 +1,167 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilePath is the public download path of the local backend; the object key
+// is appended.
+const FilePath = "/api/v1/files/"
+
+var (
+	// ErrInvalidSignature is returned for download URLs that were not
+	// issued by this store.
+	ErrInvalidSignature = errors.New("invalid download signature")
+	// ErrURLExpired is returned for download URLs past their expiry.
+	ErrURLExpired = errors.New("download link has expired")
+)
+
+// LocalStore keeps objects as files below a directory. Signed URLs point
+// at the server itself (FilePath) and carry an HMAC of key, filename and
+// expiry.
+type LocalStore struct {
+	dir     string
+	baseURL string
+	secret  []byte
+	now     func() time.Time
+}
+
+// NewLocalStore creates the directory if needed. baseURL is the public URL
+// of this server; secret signs download URLs.
+func NewLocalStore(dir, baseURL string, secret []byte) (*LocalStore, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("local storage needs a signing secret")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("create storage dir: %w", err)
+	}
+	return &LocalStore{dir: dir, baseURL: strings.TrimRight(baseURL, "/"), secret: secret, now: time.Now}, nil
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put writes to a temporary file next to the target and renames it, so
+// readers never see partial objects.
+func (s *LocalStore) Put(_ context.Context, key string, r io.Reader, size int64, _ string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return fmt.Errorf("create object dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("create object: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	n, err := io.Copy(tmp, io.LimitReader(r, size))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("write object: %w", err)
+	}
+	if n != size {
+		return fmt.Errorf("write object: got %d of %d bytes", n, size)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return fmt.Errorf("store object: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("delete object: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) SignedURL(_ context.Context, key, filename string, ttl time.Duration) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	expires := s.now().Add(ttl).Unix()
+	q := url.Values{}
+	q.Set("expires", strconv.FormatInt(expires, 10))
+	q.Set("name", filename)
+	q.Set("sig", s.sign(key, filename, expires))
+	return s.baseURL + FilePath + key + "?" + q.Encode(), nil
+}
+
+// Verify checks a download URL's signature and expiry.
+func (s *LocalStore) Verify(key, filename, expires, sig string) error {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || validKey(key) != nil {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(sig), []byte(s.sign(key, filename, exp))) {
+		return ErrInvalidSignature
+	}
+	if s.now().Unix() > exp {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+func (s *LocalStore) sign(key, filename string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d", key, filename, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// file returns the object's file for serving downloads.
+func (s *LocalStore) file(key string) (*os.File, fs.FileInfo, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, info, nil
+}
@@ -0,0 +1,292 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	amzDateFormat    = "20060102T150405Z"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	maxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// S3Store keeps objects in a bucket of an S3-compatible service, signing
+// requests with AWS Signature Version 4. For Google Cloud Storage use the
+// endpoint https://storage.googleapis.com with HMAC keys of a service
+// account and region "auto".
+type S3Store struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	// pathStyle puts the bucket into the path (endpoint/bucket/key) instead
+	// of the host name (bucket.endpoint/key), as MinIO needs.
+	pathStyle bool
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewS3Store creates a store for bucket at endpoint (e.g.
+// "https://s3.eu-central-1.amazonaws.com").
+func NewS3Store(endpoint, region, bucket, accessKey, secretKey string, pathStyle bool) (*S3Store, error) {
+	u, err := url.Parse(strings.TrimRight(endpoint, "/"))
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, fmt.Errorf("invalid storage endpoint %q", endpoint)
+	}
+	if bucket == "" || accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("storage bucket and credentials are required")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &S3Store{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		pathStyle: pathStyle,
+		// No overall timeout: uploads and downloads of large files are
+		// bounded by the request context.
+		client: &http.Client{},
+		now:    time.Now,
+	}, nil
+}
+
+// objectURL returns the URL of key with the path escaped as SigV4 expects.
+func (s *S3Store) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	p := "/" + key
+	if s.pathStyle {
+		p = "/" + s.bucket + p
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	u.Path = p
+	u.RawPath = escapePath(p)
+	return &u
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), io.NopCloser(r))
+	if err != nil {
+		return err
+	}
+	// The payload is streamed, so it is not part of the signature; TLS
+	// protects it in transit.
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req, unsignedPayload)
+	if err != nil {
+		return fmt.Errorf("put object: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Store) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, fmt.Errorf("get object: %w", err)
+	}
+	return resp.Body, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, emptyPayloadHash)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("delete object: %w", err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// SignedURL returns a presigned GET URL (at most 7 days, the SigV4 limit).
+func (s *S3Store) SignedURL(_ context.Context, key, filename string, ttl time.Duration) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+	if ttl > maxPresignExpiry {
+		ttl = maxPresignExpiry
+	}
+	q := url.Values{}
+	if filename != "" {
+		q.Set("response-content-disposition", mime.FormatMediaType("attachment", map[string]string{"filename": SafeFilename(filename)}))
+	}
+	return s.presign(http.MethodGet, key, q, ttl, s.now().UTC()), nil
+}
+
+// do signs and sends req. Non-2xx responses become errors; 404 is
+// ErrNotFound.
+func (s *S3Store) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	now := s.now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("Authorization", s.authorization(req, payloadHash, now))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 == 2 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return nil, fmt.Errorf("storage returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// authorization returns the Authorization header for req, signing the
+// host and x-amz-* headers.
+func (s *S3Store) authorization(req *http.Request, payloadHash string, now time.Time) string {
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           now.Format(amzDateFormat),
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := s.scope(now)
+	return fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, s.signature(canonical, scope, now))
+}
+
+// presign returns a query-signed URL for method on key; only the host
+// header is signed.
+func (s *S3Store) presign(method, key string, q url.Values, ttl time.Duration, now time.Time) string {
+	u := s.objectURL(key)
+	scope := s.scope(now)
+	q.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	q.Set("X-Amz-Credential", s.accessKey+"/"+scope)
+	q.Set("X-Amz-Date", now.Format(amzDateFormat))
+	q.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	q.Set("X-Amz-SignedHeaders", "host")
+
+	canonical := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		canonicalQuery(q),
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+	u.RawQuery = canonicalQuery(q) + "&X-Amz-Signature=" + s.signature(canonical, scope, now)
+	return u.String()
+}
+
+func (s *S3Store) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+func (s *S3Store) signature(canonical, scope string, now time.Time) string {
+	sum := sha256.Sum256([]byte(canonical))
+	toSign := "AWS4-HMAC-SHA256\n" + now.Format(amzDateFormat) + "\n" + scope + "\n" + hex.EncodeToString(sum[:])
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, toSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQuery sorts parameters and encodes them as SigV4 requires.
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		vals := append([]string(nil), q[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func escapePath(p string) string {
+	return uriEncode(p, false)
+}
+
+// uriEncode percent-encodes everything but RFC 3986 unreserved characters
+// (and "/" unless encodeSlash).
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+// Package storage keeps uploaded files. Store has a local filesystem backend
+// for development and single-instance deployments and an S3-compatible
+// backend (AWS S3, Google Cloud Storage via its XML API with HMAC keys,
+// MinIO). Objects are never served directly: downloads go through signed,
+// time-limited URLs.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrNotFound is returned when an object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Store keeps objects under slash-separated keys such as
+// "artifacts/<learner>/<id>".
+type Store interface {
+	// Put stores size bytes read from r under key, replacing an existing
+	// object.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open returns the object's content (ErrNotFound if it does not exist).
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object; deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL that downloads the object as an attachment
+	// named filename until ttl has passed.
+	SignedURL(ctx context.Context, key, filename string, ttl time.Duration) (string, error)
+}
+
+// validKey rejects keys that could escape the store's root.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key || strings.HasPrefix(key, "../") || key == ".." {
+		return fmt.Errorf("invalid object key %q", key)
+	}
+	return nil
+}
+
+// SafeFilename reduces a client-supplied file name to its base name without
+// control characters, at most 200 characters long ("file" if nothing is
+// left).
+func SafeFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if r := []rune(name); len(r) > 200 {
+		name = string(r[len(r)-200:])
+	}
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return "file"
+	}
+	return name
+}
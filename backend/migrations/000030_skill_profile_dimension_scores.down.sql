@@ -0,0 +1 @@
+ALTER TABLE skill_profiles DROP COLUMN IF EXISTS dimension_scores;
@@ -0,0 +1,4 @@
+-- Persist per-dimension scores alongside the category roll-up so trends
+-- and diffs can be computed at dimension granularity.
+
+ALTER TABLE skill_profiles ADD COLUMN IF NOT EXISTS dimension_scores JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_reflections_pending;
+ALTER TABLE reflections DROP COLUMN IF EXISTS scored_at;
+ALTER TABLE reflections DROP COLUMN IF EXISTS prompt_version;
+ALTER TABLE reflections DROP COLUMN IF EXISTS prompt_id;
+ALTER TABLE reflections DROP COLUMN IF EXISTS score_rationale;
+ALTER TABLE reflections DROP COLUMN IF EXISTS scoring_status;
@@ -0,0 +1,10 @@
+-- Asynchronous AI scoring of reflections.
+-- Existing rows carry placeholder scores (all 50) and are re-scored as pending.
+
+ALTER TABLE reflections ADD COLUMN IF NOT EXISTS scoring_status TEXT NOT NULL DEFAULT 'pending';
+ALTER TABLE reflections ADD COLUMN IF NOT EXISTS score_rationale TEXT NOT NULL DEFAULT '';
+ALTER TABLE reflections ADD COLUMN IF NOT EXISTS prompt_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE reflections ADD COLUMN IF NOT EXISTS prompt_version INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reflections ADD COLUMN IF NOT EXISTS scored_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_reflections_pending ON reflections(created_at) WHERE scoring_status = 'pending';
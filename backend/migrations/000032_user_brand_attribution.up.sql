@@ -0,0 +1,4 @@
+-- Partner attribution: the brand a learner signed up through.
+-- Used to apply partner styling to exports.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS brand_slug TEXT REFERENCES brand_configs(slug) ON DELETE SET NULL;
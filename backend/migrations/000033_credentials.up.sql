@@ -0,0 +1,21 @@
+-- Open Badges 3.0 / W3C Verifiable Credentials issued for evidence entries,
+-- completed Lernreisen and visible endorsements.
+CREATE TABLE IF NOT EXISTS credentials (
+    id                UUID PRIMARY KEY,
+    user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    subject_type      TEXT NOT NULL CHECK (subject_type IN ('evidence', 'lernreise', 'endorsement')),
+    subject_id        UUID NOT NULL,
+    credential_type   TEXT NOT NULL CHECK (credential_type IN ('journey_completion', 'skill_attestation', 'endorsement_badge', 'milestone')),
+    key_id            TEXT NOT NULL,
+    status            TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'revoked')),
+    document          JSONB NOT NULL,
+    issued_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at        TIMESTAMPTZ,
+    revoked_at        TIMESTAMPTZ,
+    revocation_reason TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_credentials_user_id ON credentials (user_id, issued_at DESC);
+-- At most one active credential per record
+CREATE UNIQUE INDEX IF NOT EXISTS idx_credentials_active_subject
+    ON credentials (user_id, subject_type, subject_id) WHERE status = 'active';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS evidence_revocations;
+ALTER TABLE portfolio_entries DROP COLUMN IF EXISTS signed_at;
+ALTER TABLE portfolio_entries DROP COLUMN IF EXISTS signing_key_id;
+ALTER TABLE portfolio_entries DROP COLUMN IF EXISTS signature;
@@ -0,0 +1,15 @@
+-- Ed25519 signatures over the canonical JSON form of evidence entries and
+-- the evidence revocation list maintained by learners and admins.
+
+ALTER TABLE portfolio_entries ADD COLUMN IF NOT EXISTS signature TEXT;
+ALTER TABLE portfolio_entries ADD COLUMN IF NOT EXISTS signing_key_id TEXT;
+ALTER TABLE portfolio_entries ADD COLUMN IF NOT EXISTS signed_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS evidence_revocations (
+    evidence_id UUID PRIMARY KEY REFERENCES portfolio_entries(id) ON DELETE CASCADE,
+    revoked_by  TEXT NOT NULL CHECK (revoked_by IN ('learner', 'admin')),
+    reason      TEXT NOT NULL DEFAULT '',
+    revoked_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_revocations_revoked_at ON evidence_revocations (revoked_at DESC);
@@ -0,0 +1,23 @@
+-- Evidence suggested by the station-result extraction when a session ends.
+-- Suggestions become portfolio entries only after the learner accepts them.
+
+CREATE TABLE IF NOT EXISTS evidence_suggestions (
+    id                     UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id                UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id             UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    dimension              TEXT NOT NULL,
+    score                  NUMERIC(5,2) NOT NULL,
+    confidence             NUMERIC(3,2) NOT NULL,
+    summary                TEXT NOT NULL,
+    source_interaction_ids UUID[] NOT NULL DEFAULT '{}',
+    context                JSONB NOT NULL DEFAULT '{}',
+    status                 TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'discarded')),
+    evidence_id            UUID REFERENCES portfolio_entries(id) ON DELETE SET NULL,
+    prompt_id              TEXT NOT NULL DEFAULT '',
+    prompt_version         INTEGER NOT NULL DEFAULT 0,
+    created_at             TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reviewed_at            TIMESTAMPTZ,
+    UNIQUE (session_id, dimension)
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_suggestions_user ON evidence_suggestions(user_id, status, created_at DESC);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS evidence_audit_log;
+DROP FUNCTION IF EXISTS evidence_audit_log_append_only();
+DROP TABLE IF EXISTS evidence_versions;
+ALTER TABLE portfolio_entries DROP COLUMN IF EXISTS retraction_reason;
+ALTER TABLE portfolio_entries DROP COLUMN IF EXISTS retracted_at;
+ALTER TABLE portfolio_entries DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE portfolio_entries DROP COLUMN IF EXISTS version;
@@ -0,0 +1,44 @@
+-- Evidence lifecycle: edits keep earlier versions, learners can retract
+-- entries, and every change is recorded in an append-only audit log.
+
+ALTER TABLE portfolio_entries ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE portfolio_entries ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+ALTER TABLE portfolio_entries ADD COLUMN IF NOT EXISTS retracted_at TIMESTAMPTZ;
+ALTER TABLE portfolio_entries ADD COLUMN IF NOT EXISTS retraction_reason TEXT;
+
+CREATE TABLE IF NOT EXISTS evidence_versions (
+    evidence_id      UUID NOT NULL REFERENCES portfolio_entries(id) ON DELETE CASCADE,
+    version          INTEGER NOT NULL,
+    summary          TEXT NOT NULL,
+    skill_dimensions JSONB DEFAULT '{}',
+    confidence       NUMERIC(3,2) NOT NULL,
+    context          JSONB DEFAULT '{}',
+    signature        TEXT,
+    signing_key_id   TEXT,
+    superseded_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (evidence_id, version)
+);
+
+-- No foreign key: the audit trail outlives the entry it describes.
+CREATE TABLE IF NOT EXISTS evidence_audit_log (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    evidence_id UUID NOT NULL,
+    actor_id    UUID,
+    actor_role  TEXT NOT NULL CHECK (actor_role IN ('learner', 'admin', 'system')),
+    action      TEXT NOT NULL,
+    changes     JSONB NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_evidence_audit_log_evidence ON evidence_audit_log(evidence_id, created_at);
+
+CREATE OR REPLACE FUNCTION evidence_audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'evidence_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS evidence_audit_log_no_change ON evidence_audit_log;
+CREATE TRIGGER evidence_audit_log_no_change
+    BEFORE UPDATE OR DELETE ON evidence_audit_log
+    FOR EACH ROW EXECUTE FUNCTION evidence_audit_log_append_only();
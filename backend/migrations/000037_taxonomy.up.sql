@@ -0,0 +1,10 @@
+-- Published versions of the skill taxonomy registry (dimensions, categories,
+-- locale labels, aliases and ESCO URIs). Versions are never changed; the
+-- highest version is the current registry.
+
+CREATE TABLE IF NOT EXISTS taxonomy_versions (
+    version    INTEGER PRIMARY KEY CHECK (version > 0),
+    source     TEXT NOT NULL DEFAULT '',
+    registry   JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_jobs_source;
+DROP INDEX IF EXISTS idx_jobs_external_url;
@@ -0,0 +1,3 @@
+-- Feed imports look postings up by external_url and deactivate per source.
+CREATE INDEX idx_jobs_external_url ON job_postings(external_url) WHERE external_url IS NOT NULL;
+CREATE INDEX idx_jobs_source ON job_postings(source);
@@ -0,0 +1,19 @@
+-- Named, expiring share links to a tailored public profile. Only the
+-- SHA-256 hash of the link token is stored; scope selects the shared
+-- categories, endorsements, evidence and portfolio entries.
+
+CREATE TABLE IF NOT EXISTS share_links (
+    id             UUID PRIMARY KEY,
+    user_id        UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name           TEXT NOT NULL,
+    token_hash     TEXT NOT NULL UNIQUE,
+    password_hash  TEXT,
+    scope          JSONB NOT NULL DEFAULT '{}',
+    expires_at     TIMESTAMPTZ NOT NULL,
+    revoked_at     TIMESTAMPTZ,
+    view_count     INTEGER NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_share_links_user ON share_links (user_id, created_at DESC);
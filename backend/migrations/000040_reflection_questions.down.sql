@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reflections_user_question;
+DROP TABLE IF EXISTS reflection_questions;
@@ -0,0 +1,56 @@
+-- Managed reflection question bank. A question probes one capability at a
+-- difficulty level (1 easy - 3 hard); station_id limits it to one station
+-- (NULL: any station). Translations share the id. Questions are
+-- deactivated, never deleted, since reflections refer to them.
+
+CREATE TABLE IF NOT EXISTS reflection_questions (
+    id          TEXT NOT NULL,
+    locale      TEXT NOT NULL DEFAULT 'de',
+    station_id  TEXT,
+    capability  TEXT NOT NULL CHECK (capability IN ('analytical_depth', 'creativity', 'confidence', 'resilience', 'self_awareness')),
+    difficulty  SMALLINT NOT NULL DEFAULT 1 CHECK (difficulty BETWEEN 1 AND 3),
+    question    TEXT NOT NULL,
+    follow_up   TEXT NOT NULL DEFAULT '',
+    active      BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, locale)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reflection_questions_capability ON reflection_questions (capability) WHERE active;
+CREATE INDEX IF NOT EXISTS idx_reflections_user_question ON reflections (user_id, question_id);
+
+-- Seed: the questions the frontend has asked so far (same ids) plus harder
+-- variants, in German and English.
+INSERT INTO reflection_questions (id, locale, capability, difficulty, question, follow_up) VALUES
+    ('r-analytical-1', 'de', 'analytical_depth', 1, 'Was war das Schwierigste an dieser Station? Warum war es schwierig?', 'Wie hast du das Problem am Ende geloest?'),
+    ('r-analytical-1', 'en', 'analytical_depth', 1, 'What was the hardest part of this station? Why was it hard?', 'How did you solve the problem in the end?'),
+    ('r-analytical-2', 'de', 'analytical_depth', 2, 'Welche Zusammenhaenge hast du zwischen verschiedenen Teilen der Station erkannt?', ''),
+    ('r-analytical-2', 'en', 'analytical_depth', 2, 'Which connections did you notice between different parts of the station?', ''),
+    ('r-analytical-3', 'de', 'analytical_depth', 3, 'Welche Annahme hast du zu Beginn getroffen, die sich spaeter als falsch herausgestellt hat? Woran hast du das gemerkt?', 'Wie wuerdest du so eine Annahme beim naechsten Mal pruefen?'),
+    ('r-analytical-3', 'en', 'analytical_depth', 3, 'Which assumption did you make at the start that later turned out to be wrong? How did you notice?', 'How would you test such an assumption next time?'),
+    ('r-creativity-1', 'de', 'creativity', 1, 'Wenn du diese Situation nochmal erleben wuerdest — was wuerdest du anders machen?', 'Was waere das ueberraschendste Ergebnis?'),
+    ('r-creativity-1', 'en', 'creativity', 1, 'If you went through this situation again, what would you do differently?', 'What would be the most surprising outcome?'),
+    ('r-creativity-2', 'de', 'creativity', 2, 'Was wuerdest du an dieser Station veraendern, damit sie noch spannender wird?', ''),
+    ('r-creativity-2', 'en', 'creativity', 2, 'What would you change about this station to make it even more exciting?', ''),
+    ('r-creativity-3', 'de', 'creativity', 3, 'Welche voellig andere Loesung haette auch funktionieren koennen? Was spricht dafuer, was dagegen?', ''),
+    ('r-creativity-3', 'en', 'creativity', 3, 'Which completely different solution could also have worked? What speaks for it, what against it?', ''),
+    ('r-confidence-1', 'de', 'confidence', 1, 'In welchem Moment warst du dir am sichersten? Was hat dir Sicherheit gegeben?', ''),
+    ('r-confidence-1', 'en', 'confidence', 1, 'At which moment did you feel most sure of yourself? What gave you that confidence?', ''),
+    ('r-confidence-2', 'de', 'confidence', 2, 'Welche Entscheidung hast du ganz allein getroffen? Wie hast du dich dabei gefuehlt?', ''),
+    ('r-confidence-2', 'en', 'confidence', 2, 'Which decision did you make entirely on your own? How did that feel?', ''),
+    ('r-confidence-3', 'de', 'confidence', 3, 'Wo hast du deine Meinung vertreten, obwohl du nicht sicher warst, ob sie richtig ist?', 'Was wuerdest du jemandem raten, der sich das nicht traut?'),
+    ('r-confidence-3', 'en', 'confidence', 3, 'Where did you stand up for your opinion even though you were not sure it was right?', 'What would you tell someone who does not dare to do that?'),
+    ('r-resilience-1', 'de', 'resilience', 1, 'Gab es einen Moment, in dem du unsicher warst? Wie bist du damit umgegangen?', 'Was hat dir geholfen, weiterzumachen?'),
+    ('r-resilience-1', 'en', 'resilience', 1, 'Was there a moment when you felt unsure? How did you deal with it?', 'What helped you keep going?'),
+    ('r-resilience-2', 'de', 'resilience', 2, 'Stell dir vor, du erzaehlst einem Freund von dieser Erfahrung. Was wuerdest du sagen?', ''),
+    ('r-resilience-2', 'en', 'resilience', 2, 'Imagine telling a friend about this experience. What would you say?', ''),
+    ('r-resilience-3', 'de', 'resilience', 3, 'Was hat an dieser Station nicht geklappt, und was nimmst du trotzdem daraus mit?', ''),
+    ('r-resilience-3', 'en', 'resilience', 3, 'What did not work out at this station, and what do you take away from it anyway?', ''),
+    ('r-selfawareness-1', 'de', 'self_awareness', 1, 'Was hast du ueber dich selbst gelernt, das du vorher nicht wusstest?', ''),
+    ('r-selfawareness-1', 'en', 'self_awareness', 1, 'What did you learn about yourself that you did not know before?', ''),
+    ('r-selfawareness-2', 'de', 'self_awareness', 2, 'Welche deiner Staerken hat dir an dieser Station am meisten geholfen?', ''),
+    ('r-selfawareness-2', 'en', 'self_awareness', 2, 'Which of your strengths helped you most at this station?', ''),
+    ('r-selfawareness-3', 'de', 'self_awareness', 3, 'Woran merkst du, dass dir eine Aufgabe wirklich liegt — und woran, dass sie dich nur anstrengt?', ''),
+    ('r-selfawareness-3', 'en', 'self_awareness', 3, 'How can you tell that a task really suits you, and how that it only wears you out?', '')
+ON CONFLICT (id, locale) DO NOTHING;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS password_reset_tokens;
+DROP TABLE IF EXISTS mail_bounces;
+DROP TABLE IF EXISTS mail_outbox;
@@ -0,0 +1,44 @@
+-- Outbound mail: rendered messages wait in the outbox until a worker
+-- delivers them; temporary failures are retried with backoff. Bodies are
+-- cleared once a message is sent.
+
+CREATE TABLE IF NOT EXISTS mail_outbox (
+    id              UUID PRIMARY KEY,
+    recipient       TEXT NOT NULL,
+    template        TEXT NOT NULL,
+    locale          TEXT NOT NULL,
+    subject         TEXT NOT NULL,
+    body_text       TEXT NOT NULL DEFAULT '',
+    body_html       TEXT NOT NULL DEFAULT '',
+    status          TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'bounced', 'suppressed')),
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error      TEXT NOT NULL DEFAULT '',
+    sent_at         TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_mail_outbox_due ON mail_outbox (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_mail_outbox_created ON mail_outbox (created_at DESC);
+
+-- Delivery failures per address. A hard bounce, or three soft bounces,
+-- suppress further mail to the address.
+CREATE TABLE IF NOT EXISTS mail_bounces (
+    email     TEXT PRIMARY KEY,
+    kind      TEXT NOT NULL CHECK (kind IN ('hard', 'soft')),
+    reason    TEXT NOT NULL DEFAULT '',
+    count     INTEGER NOT NULL DEFAULT 1,
+    first_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Password reset links. Only the SHA-256 hash of the token is stored.
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    token_hash  TEXT PRIMARY KEY,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    used_at     TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user ON password_reset_tokens (user_id);
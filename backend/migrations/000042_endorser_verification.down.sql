@@ -0,0 +1,9 @@
+ALTER TABLE endorsements
+    DROP COLUMN IF EXISTS endorser_organization,
+    DROP COLUMN IF EXISTS verification_method;
+
+ALTER TABLE endorsement_invites
+    DROP COLUMN IF EXISTS email_verified_at,
+    DROP COLUMN IF EXISTS verify_attempts,
+    DROP COLUMN IF EXISTS verify_code_expires_at,
+    DROP COLUMN IF EXISTS verify_code_hash;
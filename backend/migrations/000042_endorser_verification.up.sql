@@ -0,0 +1,13 @@
+-- Endorser verification: a one-time code mailed to the invited address
+-- proves the endorser controls it. Only a hash of the code is stored.
+ALTER TABLE endorsement_invites
+    ADD COLUMN IF NOT EXISTS verify_code_hash       TEXT,
+    ADD COLUMN IF NOT EXISTS verify_code_expires_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS verify_attempts        INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS email_verified_at      TIMESTAMPTZ;
+
+-- How the endorser was verified ('email' or 'organization') and, for
+-- addresses on a verified organisation domain, the organisation name.
+ALTER TABLE endorsements
+    ADD COLUMN IF NOT EXISTS verification_method   TEXT,
+    ADD COLUMN IF NOT EXISTS endorser_organization TEXT;
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS endorsement_invite_events;
+DROP INDEX IF EXISTS idx_endorsement_invites_due;
+
+ALTER TABLE endorsement_invites
+    DROP COLUMN IF EXISTS reminder_count,
+    DROP COLUMN IF EXISTS last_sent_at,
+    DROP COLUMN IF EXISTS locale;
+
+-- Enum values cannot be dropped; revoked invites are kept as expired.
+UPDATE endorsement_invites SET status = 'expired' WHERE status = 'revoked';
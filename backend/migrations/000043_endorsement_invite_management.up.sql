@@ -0,0 +1,26 @@
+-- Invites can be revoked by the learner.
+ALTER TYPE endorsement_invite_status ADD VALUE IF NOT EXISTS 'revoked';
+
+-- Mail language, last delivery (initial, resend or reminder) and number of
+-- automatic reminders since then.
+ALTER TABLE endorsement_invites
+    ADD COLUMN IF NOT EXISTS locale         TEXT NOT NULL DEFAULT 'de',
+    ADD COLUMN IF NOT EXISTS last_sent_at   TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS reminder_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE endorsement_invites SET last_sent_at = created_at WHERE last_sent_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_endorsement_invites_due
+    ON endorsement_invites (last_sent_at) WHERE status = 'pending';
+
+-- Invite history shown to the learner. mail_id links the outbox message
+-- whose delivery status is reported.
+CREATE TABLE IF NOT EXISTS endorsement_invite_events (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    invite_id   UUID NOT NULL REFERENCES endorsement_invites(id) ON DELETE CASCADE,
+    kind        TEXT NOT NULL CHECK (kind IN ('created', 'resent', 'reminder', 'revoked', 'expired')),
+    mail_id     UUID REFERENCES mail_outbox(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_endorsement_invite_events_invite ON endorsement_invite_events (invite_id, created_at);
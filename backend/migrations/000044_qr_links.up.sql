@@ -0,0 +1,12 @@
+-- Short-lived redirects encoded in QR codes. The QR code carries only the
+-- random code, so invite and verification tokens stay out of images, scans
+-- and request logs; only its hash is stored.
+CREATE TABLE IF NOT EXISTS qr_links (
+    code_hash   TEXT PRIMARY KEY,
+    owner_id    UUID NOT NULL,
+    target      TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_qr_links_expires ON qr_links (expires_at);
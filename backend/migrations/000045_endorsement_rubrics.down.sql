@@ -0,0 +1,10 @@
+ALTER TABLE endorsements
+    DROP COLUMN IF EXISTS answers,
+    DROP COLUMN IF EXISTS ratings,
+    DROP COLUMN IF EXISTS rubric_id;
+
+ALTER TABLE endorsement_invites DROP COLUMN IF EXISTS rubric_id;
+
+DROP TABLE IF EXISTS endorsement_rubrics;
+
+-- Enum values cannot be dropped; 'trainer' stays.
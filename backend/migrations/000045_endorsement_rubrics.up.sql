@@ -0,0 +1,37 @@
+-- Trainers (Ausbilder:innen) endorse apprentices.
+ALTER TYPE endorser_role ADD VALUE IF NOT EXISTS 'trainer';
+
+-- Versioned rating templates per endorser role. definition holds the
+-- localized name, the rating scale with descriptors, the rated dimensions
+-- (taxonomy keys) and the free-text prompts. Rubrics are never changed:
+-- a new version replaces the active one, since invites refer to them.
+-- role is TEXT because the new enum value cannot be used in this migration.
+CREATE TABLE IF NOT EXISTS endorsement_rubrics (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    role        TEXT NOT NULL CHECK (role IN ('teacher', 'trainer', 'mentor', 'employer', 'peer', 'parent', 'other')),
+    version     INTEGER NOT NULL,
+    definition  JSONB NOT NULL,
+    active      BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (role, version)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_endorsement_rubrics_active ON endorsement_rubrics (role) WHERE active;
+
+-- The invite locks the rubric active at creation; the endorsement keeps the
+-- raw ratings and prompt answers next to the normalized skill_dimensions.
+ALTER TABLE endorsement_invites
+    ADD COLUMN IF NOT EXISTS rubric_id UUID REFERENCES endorsement_rubrics(id);
+
+ALTER TABLE endorsements
+    ADD COLUMN IF NOT EXISTS rubric_id UUID REFERENCES endorsement_rubrics(id),
+    ADD COLUMN IF NOT EXISTS ratings   JSONB,
+    ADD COLUMN IF NOT EXISTS answers   JSONB;
+
+-- Seed: one rubric for teachers, trainers, employers and peers on a shared
+-- four-level scale.
+INSERT INTO endorsement_rubrics (role, version, definition) VALUES
+    ('teacher', 1, '{"name": {"de": "Einschaetzung Lehrkraft", "en": "Teacher assessment"}, "scale": {"levels": [{"value": 1, "label": {"de": "Ansatzweise", "en": "Emerging"}, "descriptor": {"de": "Zeigt die Kompetenz selten und nur mit viel Unterstuetzung.", "en": "Rarely shows the skill, and only with a lot of support."}}, {"value": 2, "label": {"de": "Entwickelnd", "en": "Developing"}, "descriptor": {"de": "Zeigt die Kompetenz manchmal, braucht noch Anleitung.", "en": "Sometimes shows the skill, still needs guidance."}}, {"value": 3, "label": {"de": "Sicher", "en": "Proficient"}, "descriptor": {"de": "Zeigt die Kompetenz regelmaessig und selbststaendig.", "en": "Regularly shows the skill on their own."}}, {"value": 4, "label": {"de": "Herausragend", "en": "Outstanding"}, "descriptor": {"de": "Zeigt die Kompetenz auch in schwierigen Situationen und ist Vorbild fuer andere.", "en": "Shows the skill even in difficult situations and is a role model for others."}}]}, "dimensions": [{"key": "analytical-thinking", "required": true, "description": {"de": "Durchdringt Aufgaben, erkennt Zusammenhaenge und begruendet Loesungen.", "en": "Works through tasks, sees connections and explains solutions."}}, {"key": "communication", "required": true, "description": {"de": "Drueckt sich muendlich und schriftlich klar aus und hoert zu.", "en": "Expresses themselves clearly in speech and writing and listens."}}, {"key": "teamwork", "required": true, "description": {"de": "Arbeitet in Gruppen verlaesslich und konstruktiv mit.", "en": "Works reliably and constructively in groups."}}, {"key": "persistence", "required": false, "description": {"de": "Bleibt auch bei Rueckschlaegen dran.", "en": "Keeps going after setbacks."}}, {"key": "curiosity", "required": false, "description": {"de": "Stellt Fragen und vertieft Themen aus eigenem Antrieb.", "en": "Asks questions and explores topics on their own."}}], "prompts": [{"key": "example", "required": true, "max_length": 1000, "question": {"de": "Beschreibe eine konkrete Situation, in der du die Staerken der lernenden Person im Unterricht erlebt hast.", "en": "Describe a specific situation in which you saw the learner''s strengths."}}, {"key": "growth", "required": false, "max_length": 1000, "question": {"de": "Wo siehst du noch Entwicklungspotenzial?", "en": "Where do you see room for growth?"}}]}'),
+    ('trainer', 1, '{"name": {"de": "Einschaetzung Ausbilder:in", "en": "Trainer assessment"}, "scale": {"levels": [{"value": 1, "label": {"de": "Ansatzweise", "en": "Emerging"}, "descriptor": {"de": "Zeigt die Kompetenz selten und nur mit viel Unterstuetzung.", "en": "Rarely shows the skill, and only with a lot of support."}}, {"value": 2, "label": {"de": "Entwickelnd", "en": "Developing"}, "descriptor": {"de": "Zeigt die Kompetenz manchmal, braucht noch Anleitung.", "en": "Sometimes shows the skill, still needs guidance."}}, {"value": 3, "label": {"de": "Sicher", "en": "Proficient"}, "descriptor": {"de": "Zeigt die Kompetenz regelmaessig und selbststaendig.", "en": "Regularly shows the skill on their own."}}, {"value": 4, "label": {"de": "Herausragend", "en": "Outstanding"}, "descriptor": {"de": "Zeigt die Kompetenz auch in schwierigen Situationen und ist Vorbild fuer andere.", "en": "Shows the skill even in difficult situations and is a role model for others."}}]}, "dimensions": [{"key": "planning", "required": true, "description": {"de": "Plant Arbeitsschritte und haelt Absprachen und Fristen ein.", "en": "Plans work steps and keeps agreements and deadlines."}}, {"key": "problem-solving", "required": true, "description": {"de": "Findet bei praktischen Problemen eigenstaendig Loesungen.", "en": "Finds solutions to practical problems independently."}}, {"key": "teamwork", "required": true, "description": {"de": "Arbeitet im Team und mit Kolleg:innen zuverlaessig zusammen.", "en": "Works reliably with the team and colleagues."}}, {"key": "initiative", "required": false, "description": {"de": "Uebernimmt Aufgaben, ohne dazu aufgefordert zu werden.", "en": "Takes on tasks without being asked."}}, {"key": "persistence", "required": false, "description": {"de": "Fuehrt auch muehsame Aufgaben sorgfaeltig zu Ende.", "en": "Finishes tedious tasks carefully."}}], "prompts": [{"key": "example", "required": true, "max_length": 1000, "question": {"de": "Beschreibe eine konkrete Situation, in der du die Staerken der lernenden Person in der Ausbildung erlebt hast.", "en": "Describe a specific situation in which you saw the learner''s strengths."}}, {"key": "growth", "required": false, "max_length": 1000, "question": {"de": "Wo siehst du noch Entwicklungspotenzial?", "en": "Where do you see room for growth?"}}]}'),
+    ('employer', 1, '{"name": {"de": "Einschaetzung Arbeitgeber:in", "en": "Employer assessment"}, "scale": {"levels": [{"value": 1, "label": {"de": "Ansatzweise", "en": "Emerging"}, "descriptor": {"de": "Zeigt die Kompetenz selten und nur mit viel Unterstuetzung.", "en": "Rarely shows the skill, and only with a lot of support."}}, {"value": 2, "label": {"de": "Entwickelnd", "en": "Developing"}, "descriptor": {"de": "Zeigt die Kompetenz manchmal, braucht noch Anleitung.", "en": "Sometimes shows the skill, still needs guidance."}}, {"value": 3, "label": {"de": "Sicher", "en": "Proficient"}, "descriptor": {"de": "Zeigt die Kompetenz regelmaessig und selbststaendig.", "en": "Regularly shows the skill on their own."}}, {"value": 4, "label": {"de": "Herausragend", "en": "Outstanding"}, "descriptor": {"de": "Zeigt die Kompetenz auch in schwierigen Situationen und ist Vorbild fuer andere.", "en": "Shows the skill even in difficult situations and is a role model for others."}}]}, "dimensions": [{"key": "communication", "required": true, "description": {"de": "Kommuniziert klar mit Kolleg:innen und Kund:innen.", "en": "Communicates clearly with colleagues and customers."}}, {"key": "teamwork", "required": true, "description": {"de": "Bringt sich ins Team ein und unterstuetzt andere.", "en": "Contributes to the team and supports others."}}, {"key": "initiative", "required": true, "description": {"de": "Erkennt, was zu tun ist, und handelt selbststaendig.", "en": "Sees what needs doing and acts on their own."}}, {"key": "adaptability", "required": false, "description": {"de": "Stellt sich auf neue Aufgaben und Ablaeufe ein.", "en": "Adapts to new tasks and processes."}}, {"key": "problem-solving", "required": false, "description": {"de": "Loest Probleme im Arbeitsalltag pragmatisch.", "en": "Solves everyday work problems pragmatically."}}], "prompts": [{"key": "example", "required": true, "max_length": 1000, "question": {"de": "Beschreibe eine konkrete Situation, in der du die Staerken der Person bei der Arbeit erlebt hast.", "en": "Describe a specific situation in which you saw the person''s strengths."}}, {"key": "growth", "required": false, "max_length": 1000, "question": {"de": "Wo siehst du noch Entwicklungspotenzial?", "en": "Where do you see room for growth?"}}]}'),
+    ('peer', 1, '{"name": {"de": "Einschaetzung Mitlernende", "en": "Peer assessment"}, "scale": {"levels": [{"value": 1, "label": {"de": "Ansatzweise", "en": "Emerging"}, "descriptor": {"de": "Zeigt die Kompetenz selten und nur mit viel Unterstuetzung.", "en": "Rarely shows the skill, and only with a lot of support."}}, {"value": 2, "label": {"de": "Entwickelnd", "en": "Developing"}, "descriptor": {"de": "Zeigt die Kompetenz manchmal, braucht noch Anleitung.", "en": "Sometimes shows the skill, still needs guidance."}}, {"value": 3, "label": {"de": "Sicher", "en": "Proficient"}, "descriptor": {"de": "Zeigt die Kompetenz regelmaessig und selbststaendig.", "en": "Regularly shows the skill on their own."}}, {"value": 4, "label": {"de": "Herausragend", "en": "Outstanding"}, "descriptor": {"de": "Zeigt die Kompetenz auch in schwierigen Situationen und ist Vorbild fuer andere.", "en": "Shows the skill even in difficult situations and is a role model for others."}}]}, "dimensions": [{"key": "teamwork", "required": true, "description": {"de": "Ist in gemeinsamen Projekten verlaesslich.", "en": "Is reliable in joint projects."}}, {"key": "communication", "required": true, "description": {"de": "Erklaert Dinge verstaendlich und hoert zu.", "en": "Explains things clearly and listens."}}, {"key": "empathy", "required": false, "description": {"de": "Nimmt Ruecksicht und merkt, wie es anderen geht.", "en": "Is considerate and notices how others feel."}}, {"key": "creativity", "required": false, "description": {"de": "Bringt eigene, neue Ideen ein.", "en": "Contributes original ideas."}}], "prompts": [{"key": "example", "required": true, "max_length": 1000, "question": {"de": "Beschreibe eine konkrete Situation, in der du die Staerken deiner Mitschuelerin oder deines Mitschuelers erlebt hast.", "en": "Describe a specific situation in which you saw your peer''s strengths."}}, {"key": "growth", "required": false, "max_length": 1000, "question": {"de": "Wo siehst du noch Entwicklungspotenzial?", "en": "Where do you see room for growth?"}}]}');
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS endorsement_moderation_log;
+DROP FUNCTION IF EXISTS endorsement_moderation_log_append_only();
+DROP TABLE IF EXISTS blocked_endorser_emails;
+DROP TABLE IF EXISTS endorsement_reports;
+DROP INDEX IF EXISTS idx_endorsements_moderation;
+ALTER TABLE endorsements
+    DROP COLUMN IF EXISTS decided_at,
+    DROP COLUMN IF EXISTS screening_flags,
+    DROP COLUMN IF EXISTS moderation_status,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS endorser_email;
@@ -0,0 +1,66 @@
+-- New endorsements are held until the learner accepts them and are screened
+-- for abusive content and contact details first. status is the learner's
+-- decision, moderation_status the screening and admin state. Only accepted,
+-- clear and visible endorsements are public. Existing endorsements were
+-- already public and count as accepted.
+ALTER TABLE endorsements
+    ADD COLUMN IF NOT EXISTS endorser_email    TEXT,
+    ADD COLUMN IF NOT EXISTS status            TEXT NOT NULL DEFAULT 'accepted'
+        CHECK (status IN ('pending', 'accepted', 'declined')),
+    ADD COLUMN IF NOT EXISTS moderation_status TEXT NOT NULL DEFAULT 'clear'
+        CHECK (moderation_status IN ('clear', 'flagged', 'hidden', 'deleted')),
+    ADD COLUMN IF NOT EXISTS screening_flags   TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS decided_at        TIMESTAMPTZ;
+
+ALTER TABLE endorsements ALTER COLUMN status SET DEFAULT 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_endorsements_moderation ON endorsements(moderation_status, created_at)
+    WHERE moderation_status <> 'clear';
+
+-- Abuse reports filed by learners; resolved by an admin decision.
+CREATE TABLE IF NOT EXISTS endorsement_reports (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    endorsement_id UUID NOT NULL REFERENCES endorsements(id) ON DELETE CASCADE,
+    reporter_id    UUID NOT NULL,
+    reason         TEXT NOT NULL CHECK (reason IN ('harassment', 'inappropriate', 'false_information', 'spam', 'other')),
+    details        TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at    TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_endorsement_reports_endorsement ON endorsement_reports(endorsement_id, created_at);
+
+-- Endorser addresses that may no longer be invited or submit endorsements.
+-- Stored lower-case.
+CREATE TABLE IF NOT EXISTS blocked_endorser_emails (
+    email      TEXT PRIMARY KEY,
+    reason     TEXT NOT NULL DEFAULT '',
+    blocked_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Every moderation decision. No foreign key: the trail outlives the
+-- endorsement. Blocks have an email instead of an endorsement.
+CREATE TABLE IF NOT EXISTS endorsement_moderation_log (
+    id             UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    endorsement_id UUID,
+    email          TEXT,
+    actor_id       UUID,
+    actor_role     TEXT NOT NULL CHECK (actor_role IN ('learner', 'admin', 'system')),
+    action         TEXT NOT NULL,
+    details        JSONB NOT NULL DEFAULT '{}',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_endorsement_moderation_log_endorsement ON endorsement_moderation_log(endorsement_id, created_at);
+
+CREATE OR REPLACE FUNCTION endorsement_moderation_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'endorsement_moderation_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS endorsement_moderation_log_no_change ON endorsement_moderation_log;
+CREATE TRIGGER endorsement_moderation_log_no_change
+    BEFORE UPDATE OR DELETE ON endorsement_moderation_log
+    FOR EACH ROW EXECUTE FUNCTION endorsement_moderation_log_append_only();
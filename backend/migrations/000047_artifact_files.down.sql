@@ -0,0 +1,6 @@
+ALTER TABLE external_artifacts
+    DROP COLUMN IF EXISTS checksum_sha256,
+    DROP COLUMN IF EXISTS size_bytes,
+    DROP COLUMN IF EXISTS content_type,
+    DROP COLUMN IF EXISTS file_name,
+    DROP COLUMN IF EXISTS object_key;
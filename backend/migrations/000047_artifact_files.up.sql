@@ -0,0 +1,9 @@
+-- Uploaded artifact files. The object key points into the configured
+-- storage backend; storage_ref keeps the URL of link artifacts. The content
+-- type is sniffed from the file, the checksum is the SHA-256 of the content.
+ALTER TABLE external_artifacts
+    ADD COLUMN IF NOT EXISTS object_key      TEXT,
+    ADD COLUMN IF NOT EXISTS file_name       TEXT,
+    ADD COLUMN IF NOT EXISTS content_type    TEXT,
+    ADD COLUMN IF NOT EXISTS size_bytes      BIGINT,
+    ADD COLUMN IF NOT EXISTS checksum_sha256 TEXT;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_external_artifacts_scan_queue;
+ALTER TABLE external_artifacts
+    DROP COLUMN IF EXISTS scanned_at,
+    DROP COLUMN IF EXISTS scan_signature,
+    DROP COLUMN IF EXISTS scan_status;
@@ -0,0 +1,18 @@
+-- Malware scanning of uploaded artifact files. Files stay quarantined
+-- (pending) until the scanner reports them clean; infected files are removed
+-- from storage and keep the signature that was found. failed means the file
+-- could not be scanned (e.g. it exceeds the scanner's size limit), unscanned
+-- that no scanner was configured at upload time. Links have no status.
+ALTER TABLE external_artifacts
+    ADD COLUMN IF NOT EXISTS scan_status    TEXT
+        CHECK (scan_status IN ('pending', 'clean', 'infected', 'failed', 'unscanned')),
+    ADD COLUMN IF NOT EXISTS scan_signature TEXT,
+    ADD COLUMN IF NOT EXISTS scanned_at     TIMESTAMPTZ;
+
+-- Files uploaded before scanning existed are scanned on the next start.
+UPDATE external_artifacts SET scan_status = 'pending'
+ WHERE object_key IS NOT NULL AND scan_status IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_external_artifacts_scan_queue
+    ON external_artifacts (uploaded_at)
+    WHERE scan_status IN ('pending', 'unscanned');
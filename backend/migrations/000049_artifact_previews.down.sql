@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_external_artifacts_preview_queue;
+ALTER TABLE external_artifacts
+    DROP COLUMN IF EXISTS duration_ms,
+    DROP COLUMN IF EXISTS page_count,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS preview_key,
+    DROP COLUMN IF EXISTS preview_status;
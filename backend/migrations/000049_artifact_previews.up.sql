@@ -0,0 +1,19 @@
+-- Thumbnails and metadata of uploaded artifact files, made by the preview
+-- worker once a file has passed the malware scan. The thumbnail is a JPEG
+-- stored next to the original (preview_key); files that cannot have one
+-- (videos, Office documents) only get their metadata.
+ALTER TABLE external_artifacts
+    ADD COLUMN IF NOT EXISTS preview_status TEXT
+        CHECK (preview_status IN ('pending', 'done', 'failed')),
+    ADD COLUMN IF NOT EXISTS preview_key    TEXT,
+    ADD COLUMN IF NOT EXISTS width          INTEGER,
+    ADD COLUMN IF NOT EXISTS height         INTEGER,
+    ADD COLUMN IF NOT EXISTS page_count     INTEGER,
+    ADD COLUMN IF NOT EXISTS duration_ms    BIGINT;
+
+UPDATE external_artifacts SET preview_status = 'pending'
+ WHERE object_key IS NOT NULL AND preview_status IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_external_artifacts_preview_queue
+    ON external_artifacts (uploaded_at)
+    WHERE preview_status = 'pending';
@@ -0,0 +1,3 @@
+ALTER TABLE engagement_state DROP COLUMN IF EXISTS ledger_count;
+DROP TABLE IF EXISTS xp_transactions;
+DROP FUNCTION IF EXISTS xp_transactions_append_only();
@@ -0,0 +1,47 @@
+-- XP is recorded in an append-only ledger; engagement_state becomes a
+-- projection derived from it. Each award carries an idempotency key that
+-- identifies the action instance (e.g. station + session), so repeating a
+-- request never awards XP twice.
+CREATE TABLE IF NOT EXISTS xp_transactions (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action          TEXT NOT NULL,
+    xp              INTEGER NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    context         JSONB NOT NULL DEFAULT '{}',
+    awarded_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_xp_transactions_user ON xp_transactions(user_id, awarded_at);
+CREATE INDEX IF NOT EXISTS idx_xp_transactions_awarded ON xp_transactions(awarded_at);
+
+-- Rows are never changed. Deleting the user (account deletion) still
+-- removes them through the foreign key.
+CREATE OR REPLACE FUNCTION xp_transactions_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND pg_trigger_depth() > 1 THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'xp_transactions is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS xp_transactions_no_change ON xp_transactions;
+CREATE TRIGGER xp_transactions_no_change
+    BEFORE UPDATE OR DELETE ON xp_transactions
+    FOR EACH ROW EXECUTE FUNCTION xp_transactions_append_only();
+
+-- Number of ledger rows the projection was derived from; a projection is
+-- only replaced by one derived from at least as many rows.
+ALTER TABLE engagement_state
+    ADD COLUMN IF NOT EXISTS ledger_count INTEGER NOT NULL DEFAULT 0;
+
+-- Carry existing balances over as one opening transaction per user, dated
+-- on the last active day. Streaks were never counted up before, so there
+-- is no streak history to keep.
+INSERT INTO xp_transactions (user_id, action, xp, idempotency_key, awarded_at)
+SELECT user_id, 'legacy_balance', total_xp, 'legacy_balance', last_active_date::timestamptz
+FROM engagement_state
+WHERE total_xp > 0
+ON CONFLICT (user_id, idempotency_key) DO NOTHING;
@@ -0,0 +1,8 @@
+UPDATE endorsements e
+SET skill_dimensions = (
+    SELECT COALESCE(jsonb_object_agg(d.key, (d.value)::text::float8 / 100), '{}'::jsonb)
+    FROM jsonb_each(e.skill_dimensions) d
+)
+WHERE e.rubric_id IS NOT NULL
+  AND e.skill_dimensions IS NOT NULL
+  AND jsonb_typeof(e.skill_dimensions) = 'object';
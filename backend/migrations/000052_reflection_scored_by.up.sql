@@ -0,0 +1,9 @@
+-- Record which scorer produced a reflection's scores. The heuristic
+-- scorer, including the fallback for failed AI calls, always stored the
+-- prompt ID 'heuristic', so earlier rows can be attributed from it.
+
+ALTER TABLE reflections ADD COLUMN IF NOT EXISTS scored_by TEXT NOT NULL DEFAULT '';
+
+UPDATE reflections
+SET scored_by = CASE WHEN prompt_id = 'heuristic' THEN 'heuristic' ELSE 'ai' END
+WHERE scoring_status = 'scored';
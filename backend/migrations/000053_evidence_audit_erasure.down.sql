@@ -0,0 +1,16 @@
+-- Redacted text and the trail of deleted accounts cannot be restored.
+
+DROP TRIGGER IF EXISTS evidence_audit_log_no_change ON evidence_audit_log;
+
+DROP INDEX IF EXISTS idx_evidence_audit_log_user;
+ALTER TABLE evidence_audit_log DROP COLUMN IF EXISTS user_id;
+
+CREATE OR REPLACE FUNCTION evidence_audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'evidence_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER evidence_audit_log_no_change
+    BEFORE UPDATE OR DELETE ON evidence_audit_log
+    FOR EACH ROW EXECUTE FUNCTION evidence_audit_log_append_only();
@@ -0,0 +1,43 @@
+-- The evidence audit log is erased with the learner's account (DSGVO
+-- Art. 17) and keeps no free text: summary and context changes only name
+-- the field, and reasons stay on the retraction or revocation they belong
+-- to. Rows remain append-only otherwise.
+
+DROP TRIGGER IF EXISTS evidence_audit_log_no_change ON evidence_audit_log;
+
+ALTER TABLE evidence_audit_log ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+UPDATE evidence_audit_log a SET user_id = pe.user_id
+FROM portfolio_entries pe
+WHERE pe.id = a.evidence_id AND a.user_id IS NULL;
+
+-- Entries of deleted accounts are gone; so is their trail.
+DELETE FROM evidence_audit_log WHERE user_id IS NULL;
+
+ALTER TABLE evidence_audit_log ALTER COLUMN user_id SET NOT NULL;
+
+UPDATE evidence_audit_log
+SET changes = (changes - 'summary' - 'context' - 'reason')
+    || jsonb_build_object('fields', (
+        SELECT COALESCE(jsonb_agg(f), '[]'::jsonb)
+        FROM jsonb_object_keys(changes) f
+        WHERE f IN ('summary', 'skill_dimensions', 'context')))
+WHERE action = 'update';
+
+UPDATE evidence_audit_log SET changes = changes - 'reason'
+WHERE action IN ('retract', 'revoke');
+
+CREATE INDEX IF NOT EXISTS idx_evidence_audit_log_user ON evidence_audit_log(user_id);
+
+CREATE OR REPLACE FUNCTION evidence_audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND pg_trigger_depth() > 1 THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'evidence_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER evidence_audit_log_no_change
+    BEFORE UPDATE OR DELETE ON evidence_audit_log
+    FOR EACH ROW EXECUTE FUNCTION evidence_audit_log_append_only();
@@ -0,0 +1,17 @@
+-- Hashed addresses and the trail of deleted endorsements cannot be restored.
+
+DROP TRIGGER IF EXISTS endorsement_moderation_log_no_change ON endorsement_moderation_log;
+
+ALTER TABLE endorsement_moderation_log DROP CONSTRAINT IF EXISTS endorsement_moderation_log_endorsement_fk;
+ALTER TABLE endorsement_moderation_log ADD COLUMN IF NOT EXISTS email TEXT;
+ALTER TABLE endorsement_moderation_log DROP COLUMN IF EXISTS email_hash;
+
+CREATE OR REPLACE FUNCTION endorsement_moderation_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'endorsement_moderation_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER endorsement_moderation_log_no_change
+    BEFORE UPDATE OR DELETE ON endorsement_moderation_log
+    FOR EACH ROW EXECUTE FUNCTION endorsement_moderation_log_append_only();
@@ -0,0 +1,36 @@
+-- The endorsement moderation log no longer keeps endorser addresses: blocks
+-- record the SHA-256 of the normalized address instead. Entries of an
+-- endorsement are erased with it, and so with the learner's account (DSGVO
+-- Art. 17). Rows remain append-only otherwise.
+
+DROP TRIGGER IF EXISTS endorsement_moderation_log_no_change ON endorsement_moderation_log;
+
+ALTER TABLE endorsement_moderation_log ADD COLUMN IF NOT EXISTS email_hash TEXT;
+
+UPDATE endorsement_moderation_log
+SET email_hash = encode(sha256(convert_to(lower(btrim(email)), 'UTF8')), 'hex')
+WHERE email IS NOT NULL;
+
+ALTER TABLE endorsement_moderation_log DROP COLUMN IF EXISTS email;
+
+-- Endorsements of deleted accounts are gone; so is their trail.
+DELETE FROM endorsement_moderation_log l
+WHERE l.endorsement_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM endorsements e WHERE e.id = l.endorsement_id);
+
+ALTER TABLE endorsement_moderation_log
+    ADD CONSTRAINT endorsement_moderation_log_endorsement_fk
+    FOREIGN KEY (endorsement_id) REFERENCES endorsements(id) ON DELETE CASCADE;
+
+CREATE OR REPLACE FUNCTION endorsement_moderation_log_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND pg_trigger_depth() > 1 THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'endorsement_moderation_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER endorsement_moderation_log_no_change
+    BEFORE UPDATE OR DELETE ON endorsement_moderation_log
+    FOR EACH ROW EXECUTE FUNCTION endorsement_moderation_log_append_only();
@@ -0,0 +1,2 @@
+-- Rescaled fractions cannot be told apart from scores on the 0-100 scale;
+-- nothing is undone.
@@ -0,0 +1,52 @@
+-- Evidence, artifacts and endorsements from before the 0-100 scale stored
+-- dimension scores as 0-1 fractions, which reads used to scale up (every
+-- value up to 1 counted as a fraction). Move them onto the scale by the
+-- same rule.
+-- Rescaled evidence loses its signature and is signed again by the server.
+UPDATE portfolio_entries pe
+SET skill_dimensions = (
+        SELECT jsonb_object_agg(d.key,
+            CASE WHEN jsonb_typeof(d.value) = 'number' AND (d.value)::text::float8 BETWEEN 0 AND 1
+                 THEN to_jsonb((d.value)::text::float8 * 100)
+                 ELSE d.value END)
+        FROM jsonb_each(pe.skill_dimensions) d
+    ),
+    signature = NULL, signing_key_id = NULL, signed_at = NULL
+WHERE jsonb_typeof(pe.skill_dimensions) = 'object'
+  AND EXISTS (
+      SELECT 1 FROM jsonb_each(pe.skill_dimensions) d
+      WHERE jsonb_typeof(d.value) = 'number'
+        AND (d.value)::text::float8 > 0 AND (d.value)::text::float8 <= 1
+  );
+
+UPDATE external_artifacts a
+SET skill_dimensions = (
+        SELECT jsonb_object_agg(d.key,
+            CASE WHEN jsonb_typeof(d.value) = 'number' AND (d.value)::text::float8 BETWEEN 0 AND 1
+                 THEN to_jsonb((d.value)::text::float8 * 100)
+                 ELSE d.value END)
+        FROM jsonb_each(a.skill_dimensions) d
+    )
+WHERE jsonb_typeof(a.skill_dimensions) = 'object'
+  AND EXISTS (
+      SELECT 1 FROM jsonb_each(a.skill_dimensions) d
+      WHERE jsonb_typeof(d.value) = 'number'
+        AND (d.value)::text::float8 > 0 AND (d.value)::text::float8 <= 1
+  );
+
+-- Endorsements given on a rubric were moved by 000051.
+UPDATE endorsements e
+SET skill_dimensions = (
+        SELECT jsonb_object_agg(d.key,
+            CASE WHEN jsonb_typeof(d.value) = 'number' AND (d.value)::text::float8 BETWEEN 0 AND 1
+                 THEN to_jsonb((d.value)::text::float8 * 100)
+                 ELSE d.value END)
+        FROM jsonb_each(e.skill_dimensions) d
+    )
+WHERE e.rubric_id IS NULL
+  AND jsonb_typeof(e.skill_dimensions) = 'object'
+  AND EXISTS (
+      SELECT 1 FROM jsonb_each(e.skill_dimensions) d
+      WHERE jsonb_typeof(d.value) = 'number'
+        AND (d.value)::text::float8 > 0 AND (d.value)::text::float8 <= 1
+  );
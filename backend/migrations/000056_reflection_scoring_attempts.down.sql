@@ -0,0 +1 @@
+ALTER TABLE reflections DROP COLUMN IF EXISTS scoring_attempts;
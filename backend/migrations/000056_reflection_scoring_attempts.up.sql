@@ -0,0 +1,4 @@
+-- Count scoring runs that failed because the AI service was unavailable, so
+-- that a reflection is retried a few times before the heuristic scores it.
+
+ALTER TABLE reflections ADD COLUMN IF NOT EXISTS scoring_attempts INT NOT NULL DEFAULT 0;
@@ -138,6 +138,18 @@ else
   fi
   yaml_kv SOLID_POD_ADMIN_EMAIL "${SOLID_POD_ADMIN_EMAIL:-admin@skillr.local}"
   yaml_kv SOLID_POD_ADMIN_PASSWORD "${SOLID_POD_ADMIN_PASSWORD:-skillr}"
+  # The server refuses to start on Cloud Run without a credential signing key
+  [ -z "${CREDENTIAL_SIGNING_KEY:-}" ] && warn "CREDENTIAL_SIGNING_KEY not set — the service will not start."
+  yaml_kv CREDENTIAL_SIGNING_KEY "${CREDENTIAL_SIGNING_KEY:-}"
+  yaml_kv CREDENTIAL_RETIRED_KEYS "${CREDENTIAL_RETIRED_KEYS:-}"
+  # Uploads need a bucket; the server refuses local storage on Cloud Run
+  [ -z "${STORAGE_S3_BUCKET:-}" ] && warn "STORAGE_S3_BUCKET not set — the service will not start."
+  yaml_kv STORAGE_BACKEND "s3"
+  yaml_kv STORAGE_S3_ENDPOINT "${STORAGE_S3_ENDPOINT:-https://storage.googleapis.com}"
+  yaml_kv STORAGE_S3_REGION "${STORAGE_S3_REGION:-auto}"
+  yaml_kv STORAGE_S3_BUCKET "${STORAGE_S3_BUCKET:-}"
+  yaml_kv STORAGE_S3_ACCESS_KEY "${STORAGE_S3_ACCESS_KEY:-}"
+  yaml_kv STORAGE_S3_SECRET_KEY "${STORAGE_S3_SECRET_KEY:-}"
   yaml_kv RUN_MIGRATIONS "true"
   yaml_kv STATIC_DIR "/app/static"
   yaml_kv MIGRATIONS_PATH "/app/migrations"